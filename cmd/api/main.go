@@ -17,6 +17,9 @@ import (
 
 	"github.com/yourusername/datacollector/internal/api/handlers"
 	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/exchange"
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 	"github.com/yourusername/datacollector/internal/service"
 
@@ -48,7 +51,7 @@ func main() {
 	}
 
 	// Connect to MongoDB
-	db, err := repository.Connect(cfg.Database.URI, cfg.Database.Database)
+	db, err := repository.Connect(cfg.Database.URI, cfg.Database.Database, cfg.Database.WriteConcern, cfg.Database.WriteJournal)
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
@@ -75,12 +78,23 @@ func main() {
 
 	// Middleware
 	app.Use(recover.New())
+
+	// Tag each request with an ID, taken from the X-Request-ID header or
+	// generated, and carry it in the request context for service logs
+	app.Use(func(c *fiber.Ctx) error {
+		requestID := logging.RequestIDFromHeader(c.Get(logging.RequestIDHeader))
+		c.Locals("request_id", requestID)
+		c.Set(logging.RequestIDHeader, requestID)
+		c.SetUserContext(logging.WithRequestID(c.UserContext(), requestID))
+		return c.Next()
+	})
 	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
+		Format: "[${time}] ${status} - ${latency} ${method} ${path} req=${locals:request_id}\n",
 	}))
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, " + logging.RequestIDHeader,
+		ExposeHeaders: logging.RequestIDHeader,
 	}))
 
 	// Health check endpoint
@@ -110,23 +124,41 @@ func main() {
 	// Initialize repositories
 	connectorRepo := repository.NewConnectorRepository(db)
 	jobRepo := repository.NewJobRepository(db)
+	jobRunRepo := repository.NewJobRunRepository(db)
 	ohlcvRepo := repository.NewOHLCVRepository(db)
+	ohlcvRepo.SetFreshnessThresholds(models.FreshnessThresholds{
+		FreshMultiplier: cfg.Quality.FreshMultiplier,
+		StaleMultiplier: cfg.Quality.StaleMultiplier,
+	})
+	ohlcvRepo.SetChunkCompression(cfg.Storage.CompressChunks)
+	tradeRepo := repository.NewTradeRepository(db)
+	orderBookRepo := repository.NewOrderBookRepository(db)
+	derivativesRepo := repository.NewDerivativesRepository(db)
 	alertRepo := repository.NewAlertRepository(db)
 	retentionRepo := repository.NewRetentionRepository(db)
 	indicatorConfigRepo := repository.NewIndicatorConfigRepository(db)
 	qualityRepo := repository.NewQualityRepository(db)
 	mlExportRepo := repository.NewMLExportRepository(db)
+	mlFeatureCacheRepo := repository.NewMLFeatureCacheRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
 
 	// Initialize services
 	rateLimiter := service.NewRateLimiter(connectorRepo)
 	ccxtService := service.NewCCXTServiceWithRateLimiter(rateLimiter)
-	jobExecutor := service.NewJobExecutor(jobRepo, connectorRepo, ohlcvRepo, cfg)
+	jobExecutor := service.NewJobExecutor(jobRepo, jobRunRepo, connectorRepo, ohlcvRepo, tradeRepo, orderBookRepo, derivativesRepo, indicatorConfigRepo, cfg)
 	jobScheduler := service.NewJobScheduler(jobRepo, jobExecutor)
-	recalcService := service.NewRecalculatorService(jobRepo, connectorRepo, ohlcvRepo)
+	recalcService := service.NewRecalculatorService(jobRepo, connectorRepo, ohlcvRepo, indicatorConfigRepo)
 	alertService := service.NewAlertService(alertRepo, jobRepo, connectorRepo)
-	retentionService := service.NewRetentionService(retentionRepo)
+	retentionService := service.NewRetentionService(retentionRepo, ohlcvRepo)
 	qualityService := service.NewQualityService(qualityRepo, ohlcvRepo, jobRepo, ccxtService, connectorRepo, rateLimiter)
-	mlExportService := service.NewMLExportService(ohlcvRepo, jobRepo, mlExportRepo)
+	mlExportService := service.NewMLExportService(ohlcvRepo, derivativesRepo, jobRepo, mlExportRepo, indicatorConfigRepo, mlFeatureCacheRepo, idempotencyRepo, recalcService, cfg)
+
+	// Spread jobs that fell behind while the scheduler was down before it starts
+	reconcileCtx, reconcileCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	if _, err := jobScheduler.ReconcileSchedule(reconcileCtx, service.DefaultReconcileSpread); err != nil {
+		log.Printf("Warning: Failed to reconcile job schedule: %v", err)
+	}
+	reconcileCancel()
 
 	// Start automatic job scheduler
 	jobScheduler.Start()
@@ -137,16 +169,49 @@ func main() {
 	qualityScheduler.Start()
 	defer qualityScheduler.Stop()
 
+	// Refresh a job's quality right after it stores candles, on top of the
+	// hourly check
+	if cfg.Quality.RefreshOnWrite {
+		qualityRefresher := service.NewQualityRefresher(qualityService, time.Duration(cfg.Quality.RefreshDebounceSeconds)*time.Second)
+		jobExecutor.SetQualityRefresher(qualityRefresher)
+		defer qualityRefresher.Stop()
+	}
+
+	// Remove partial files of exports interrupted by the last shutdown
+	if _, err := mlExportService.RemoveStrayTempFiles(context.Background()); err != nil {
+		log.Printf("Warning: Failed to remove stray export temp files: %v", err)
+	}
+
+	// Start export cleanup scheduler (removes expired export files)
+	exportCleanupScheduler := service.NewExportCleanupScheduler(mlExportService, time.Duration(cfg.MLExport.CleanupIntervalMinutes)*time.Minute)
+	exportCleanupScheduler.Start()
+	defer exportCleanupScheduler.Stop()
+
+	// Start chunk consistency scheduler (repairs chunk counts and time ranges)
+	if cfg.Storage.ConsistencyCheckHours > 0 {
+		consistencyScheduler := service.NewChunkConsistencyScheduler(retentionService, time.Duration(cfg.Storage.ConsistencyCheckHours)*time.Hour)
+		consistencyScheduler.Start()
+		defer consistencyScheduler.Stop()
+	}
+
+	// Start exchange metadata scheduler (keeps cached metadata fresh)
+	exchange.SetMetadataCacheTTL(time.Duration(cfg.Exchange.MetadataCacheTTLMinutes) * time.Minute)
+	if cfg.Exchange.MetadataRefreshMinutes > 0 {
+		metadataScheduler := service.NewExchangeMetadataScheduler(time.Duration(cfg.Exchange.MetadataRefreshMinutes) * time.Minute)
+		metadataScheduler.Start()
+		defer metadataScheduler.Stop()
+	}
+
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler(db)
-	connectorHandler := handlers.NewConnectorHandlerWithOHLCV(connectorRepo, jobRepo, ohlcvRepo, cfg)
-	jobHandler := handlers.NewJobHandler(jobRepo, connectorRepo, ohlcvRepo, jobExecutor)
-	indicatorHandler := handlers.NewIndicatorHandler(ohlcvRepo, recalcService)
+	healthHandler := handlers.NewHealthHandler(db, mlExportService)
+	connectorHandler := handlers.NewConnectorHandlerWithOHLCV(connectorRepo, jobRepo, ohlcvRepo, ccxtService, indicatorConfigRepo, cfg)
+	jobHandler := handlers.NewJobHandler(jobRepo, jobRunRepo, connectorRepo, ohlcvRepo, indicatorConfigRepo, jobExecutor, jobScheduler, cfg)
+	indicatorHandler := handlers.NewIndicatorHandler(ohlcvRepo, indicatorConfigRepo, recalcService)
 	indicatorConfigHandler := handlers.NewIndicatorConfigHandler(indicatorConfigRepo)
 	alertHandler := handlers.NewAlertHandler(alertRepo, alertService)
 	retentionHandler := handlers.NewRetentionHandler(retentionRepo, retentionService)
 	qualityHandler := handlers.NewQualityHandler(qualityService, jobRepo)
-	mlExportHandler := handlers.NewMLExportHandler(mlExportService)
+	mlExportHandler := handlers.NewMLExportHandler(mlExportService, cfg)
 
 	// Health routes
 	api.Get("/health", healthHandler.GetHealth)
@@ -155,6 +220,7 @@ func main() {
 	api.Get("/exchanges/metadata", healthHandler.GetExchangesMetadata)
 	api.Post("/exchanges/refresh", healthHandler.RefreshExchangeCache)
 	api.Get("/exchanges/:id/metadata", healthHandler.GetExchangeMetadata)
+	api.Get("/exchanges/:id/capabilities", healthHandler.GetExchangeCapabilities)
 	api.Get("/exchanges/:id/debug", healthHandler.DebugExchange)
 	api.Get("/exchanges/:id/symbols", healthHandler.GetExchangeSymbols)
 	api.Get("/exchanges/:id/symbols/validate", healthHandler.ValidateSymbol)
@@ -170,10 +236,14 @@ func main() {
 	api.Delete("/connectors/:id", connectorHandler.DeleteConnector)
 	api.Post("/connectors/:id/suspend", connectorHandler.SuspendConnector)
 	api.Post("/connectors/:id/resume", connectorHandler.ResumeConnector)
+	api.Post("/connectors/:id/jobs/pause", connectorHandler.PauseConnectorJobs)
+	api.Post("/connectors/:id/jobs/resume", connectorHandler.ResumeConnectorJobs)
 	api.Get("/connectors/:id/rate-limit", connectorHandler.GetRateLimitStatus)
 	api.Post("/connectors/:id/rate-limit/reset", connectorHandler.ResetRateLimitUsage)
 	api.Get("/connectors/:id/stats", connectorHandler.GetConnectorStats)
 	api.Get("/connectors/:id/health", connectorHandler.GetConnectorHealth)
+	api.Get("/connectors/:id/health/history", connectorHandler.GetConnectorHealthHistory)
+	api.Post("/connectors/:id/health/probe", connectorHandler.ProbeConnectorHealth)
 
 	// Global stats
 	api.Get("/stats", connectorHandler.GetAllStats)
@@ -181,19 +251,28 @@ func main() {
 	// Job routes (queue and batch routes MUST come before :id routes)
 	api.Post("/jobs", jobHandler.CreateJob)
 	api.Post("/jobs/batch", jobHandler.CreateJobsBatch)
+	api.Post("/jobs/reconcile-schedule", jobHandler.ReconcileSchedule)
+	api.Post("/jobs/symbol/pause", jobHandler.PauseSymbolJobs)
+	api.Post("/jobs/symbol/resume", jobHandler.ResumeSymbolJobs)
+	api.Delete("/jobs/symbol", jobHandler.DeleteSymbolJobs)
 	api.Get("/jobs", jobHandler.GetJobs)
 	api.Get("/jobs/queue", jobHandler.GetQueue)
+	api.Get("/jobs/stale", qualityHandler.GetStaleJobs)
 	api.Get("/jobs/:id", jobHandler.GetJob)
 	api.Put("/jobs/:id", jobHandler.UpdateJob)
 	api.Delete("/jobs/:id", jobHandler.DeleteJob)
 	api.Post("/jobs/:id/pause", jobHandler.PauseJob)
 	api.Post("/jobs/:id/resume", jobHandler.ResumeJob)
 	api.Post("/jobs/:id/execute", jobHandler.ExecuteJob)
+	api.Get("/jobs/:id/runs", jobHandler.GetJobRuns)
 
 	// Job data export routes
 	api.Get("/jobs/:id/ohlcv", jobHandler.GetJobOHLCVData)
+	api.Delete("/jobs/:id/ohlcv", jobHandler.DeleteJobOHLCVRange)
+	api.Post("/jobs/:id/ohlcv/ingest", jobHandler.IngestJobOHLCV)
 	api.Get("/jobs/:id/export", jobHandler.ExportJobData)
 	api.Get("/jobs/:id/export/ml", jobHandler.ExportJobDataForML)
+	api.Get("/jobs/:id/features/latest", mlExportHandler.GetLatestFeatures)
 
 	// Job dependency routes
 	api.Get("/jobs/:id/dependencies", jobHandler.GetJobDependencies)
@@ -209,6 +288,8 @@ func main() {
 	api.Post("/jobs/:id/quality/backfill", qualityHandler.StartBackfill)
 	api.Get("/jobs/:id/quality/backfill/status", qualityHandler.GetBackfillStatus)
 	api.Get("/jobs/:id/quality/backfill/history", qualityHandler.GetBackfillHistory)
+	api.Post("/connectors/:id/backfill", qualityHandler.StartConnectorBackfill)
+	api.Get("/connectors/:id/backfill/status", qualityHandler.GetConnectorBackfillStatus)
 	api.Get("/quality", qualityHandler.GetCachedResults)
 	api.Get("/quality/summary", qualityHandler.GetCachedSummary)
 	api.Post("/quality/check", qualityHandler.StartQualityCheck)
@@ -219,6 +300,10 @@ func main() {
 	// Connector-specific job routes
 	api.Get("/connectors/:exchangeId/jobs", jobHandler.GetJobsByConnector)
 
+	// Indicator coverage across active jobs and the exportable indicator catalog
+	api.Get("/indicators/coverage", indicatorHandler.GetCoverage)
+	api.Get("/indicators/catalog", indicatorHandler.GetCatalog)
+
 	// Indicator data retrieval routes (using query parameter for symbol to handle slashes)
 	api.Get("/indicators/:exchange/:timeframe/latest", indicatorHandler.GetLatestIndicators)
 	api.Get("/indicators/:exchange/:timeframe/range", indicatorHandler.GetIndicatorRange)
@@ -226,7 +311,10 @@ func main() {
 
 	// Indicator recalculation routes
 	api.Post("/jobs/:id/indicators/recalculate", indicatorHandler.RecalculateJob)
+	api.Post("/jobs/:id/indicators/:indicator/recalculate", indicatorHandler.RecalculateJobIndicator)
+	api.Post("/jobs/:id/indicators/preview", indicatorHandler.PreviewJobIndicators)
 	api.Post("/connectors/:id/indicators/recalculate", indicatorHandler.RecalculateConnector)
+	api.Post("/indicators/:exchange/:timeframe/recalculate", indicatorHandler.RecalculateSeries)
 
 	// Indicator configuration routes (builtin-defaults, default, validation-rules, and validate MUST come before :id routes)
 	api.Get("/indicators/configs", indicatorConfigHandler.GetConfigs)
@@ -272,6 +360,7 @@ func main() {
 	api.Post("/retention/cleanup", retentionHandler.RunCleanup)
 	api.Post("/retention/cleanup/default", retentionHandler.RunDefaultCleanup)
 	api.Post("/retention/cleanup/empty", retentionHandler.DeleteEmptyChunks)
+	api.Post("/retention/chunks/repair", retentionHandler.RepairChunkBoundaries)
 	api.Post("/retention/cleanup/exchange/:exchangeId", retentionHandler.CleanupExchange)
 
 	// ML Export routes
@@ -279,10 +368,16 @@ func main() {
 
 	// ML Export job routes
 	ml.Post("/export/start", mlExportHandler.StartExport)
+	ml.Get("/export/preflight", mlExportHandler.PreflightExport)
 	ml.Get("/export/jobs", mlExportHandler.ListExportJobs)
+	ml.Delete("/export/jobs", mlExportHandler.DeleteExportsByStatus)
+	ml.Get("/export/usage", mlExportHandler.GetExportUsage)
 	ml.Get("/export/jobs/:id", mlExportHandler.GetExportJob)
 	ml.Get("/export/jobs/:id/download", mlExportHandler.DownloadExport)
 	ml.Get("/export/jobs/:id/metadata", mlExportHandler.GetExportMetadata)
+	ml.Get("/export/jobs/:id/model-card", mlExportHandler.GetModelCard)
+	ml.Get("/export/jobs/:id/verify", mlExportHandler.VerifyExport)
+	ml.Get("/export/jobs/:id/preview", mlExportHandler.PreviewExport)
 	ml.Post("/export/jobs/:id/cancel", mlExportHandler.CancelExport)
 	ml.Delete("/export/jobs/:id", mlExportHandler.DeleteExport)
 
@@ -290,8 +385,10 @@ func main() {
 	ml.Get("/profiles", mlExportHandler.ListProfiles)
 	ml.Get("/profiles/presets", mlExportHandler.GetPresets)
 	ml.Post("/profiles", mlExportHandler.CreateProfile)
+	ml.Post("/profiles/diff", mlExportHandler.DiffProfiles)
 	ml.Get("/profiles/:id", mlExportHandler.GetProfile)
 	ml.Put("/profiles/:id", mlExportHandler.UpdateProfile)
+	ml.Post("/profiles/:id/clone", mlExportHandler.CloneProfile)
 	ml.Delete("/profiles/:id", mlExportHandler.DeleteProfile)
 
 	// ML Export utility routes
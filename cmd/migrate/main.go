@@ -0,0 +1,90 @@
+// Command migrate moves OHLCV series from the legacy single-document "ohlcv"
+// collection into monthly chunks.
+//
+// Each legacy document is rewritten through the chunked upsert and deleted
+// only after all of its candles are verified in the chunks, so the command can
+// be stopped and re-run at any time: migrated series are gone from the legacy
+// collection and candles already in a chunk are skipped.
+//
+// Usage:
+//
+//	go run ./cmd/migrate [-exchange binance] [-dry-run]
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/repository"
+)
+
+func main() {
+	exchangeID := flag.String("exchange", "", "Only migrate series of this exchange")
+	dryRun := flag.Bool("dry-run", false, "List the legacy series without migrating them")
+	timeout := flag.Duration("series-timeout", 5*time.Minute, "Timeout for migrating a single series")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := repository.Connect(cfg.Database.URI, cfg.Database.Database, cfg.Database.WriteConcern, cfg.Database.WriteJournal)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer db.Close()
+
+	ohlcvRepo := repository.NewOHLCVRepository(db)
+	ohlcvRepo.SetChunkCompression(cfg.Storage.CompressChunks)
+
+	// Stop between series on Ctrl+C; the next run picks up where this one left off
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	series, err := ohlcvRepo.FindLegacySeries(ctx, *exchangeID)
+	if err != nil {
+		log.Fatalf("Failed to list legacy series: %v", err)
+	}
+
+	log.Printf("[MIGRATE] Found %d legacy series", len(series))
+	if *dryRun {
+		for _, doc := range series {
+			log.Printf("[MIGRATE] %s %s %s: %d candles", doc.ExchangeID, doc.Symbol, doc.Timeframe, doc.CandlesCount)
+		}
+		return
+	}
+
+	migrated, failed, candles := 0, 0, 0
+	for i, doc := range series {
+		if ctx.Err() != nil {
+			log.Printf("[MIGRATE] Interrupted after %d of %d series", i, len(series))
+			break
+		}
+
+		seriesCtx, cancel := context.WithTimeout(ctx, *timeout)
+		count, err := ohlcvRepo.MigrateLegacySeries(seriesCtx, doc.ExchangeID, doc.Symbol, doc.Timeframe)
+		cancel()
+
+		if err != nil {
+			failed++
+			log.Printf("[MIGRATE] %d/%d %s %s %s failed: %v", i+1, len(series), doc.ExchangeID, doc.Symbol, doc.Timeframe, err)
+			continue
+		}
+
+		migrated++
+		candles += count
+		log.Printf("[MIGRATE] %d/%d %s %s %s: %d candles", i+1, len(series), doc.ExchangeID, doc.Symbol, doc.Timeframe, count)
+	}
+
+	log.Printf("[MIGRATE] Done: %d series migrated (%d candles), %d failed", migrated, candles, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
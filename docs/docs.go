@@ -43,6 +43,20 @@ const docTemplate = `{
                         "description": "Filter by status (active, suspended)",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10000,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of connectors to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -120,8 +134,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Health status for all connectors",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_api_handlers.AllConnectorsHealthResponse"
                         }
                     }
                 }
@@ -152,10 +165,195 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "Health status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConnectorHealthResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors/{id}/health/history": {
+            "get": {
+                "description": "Returns one point per hour for the last 24 hours with calls, failures, error rate and average latency. Hours without calls are zero.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Connectors"
+                ],
+                "summary": "Get connector health history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Health history",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.HealthHistoryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors/{id}/health/probe": {
+            "post": {
+                "description": "Performs a lightweight call to the exchange (server time, or markets when unsupported) through the rate limiter and records the result and latency in the connector health. A successful probe also closes an open circuit breaker.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Connectors"
+                ],
+                "summary": "Probe connector health",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Probe result",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ProbeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors/{id}/jobs/pause": {
+            "post": {
+                "description": "Pauses all jobs of the connector without suspending it, so manual executions keep working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Connectors"
+                ],
+                "summary": "Pause a connector's jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jobs paused",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConnectorJobsStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors/{id}/jobs/resume": {
+            "post": {
+                "description": "Resumes all jobs of the connector without changing the connector's status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Connectors"
+                ],
+                "summary": "Resume a connector's jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jobs resumed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConnectorJobsStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/connectors/{id}/stats": {
+            "get": {
+                "description": "Returns job counts, rate limit usage and stored data volume of a connector",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Connectors"
+                ],
+                "summary": "Get connector statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Connector statistics",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConnectorStatsResponse"
+                        }
                     },
                     "404": {
                         "description": "Connector not found",
@@ -217,7 +415,7 @@ const docTemplate = `{
         },
         "/exchanges/refresh": {
             "post": {
-                "description": "Clears and refreshes the exchange metadata cache",
+                "description": "Rediscovers supported exchanges and refreshes cached metadata; exchanges whose refresh fails keep their previous metadata",
                 "consumes": [
                     "application/json"
                 ],
@@ -263,6 +461,42 @@ const docTemplate = `{
                 }
             }
         },
+        "/exchanges/{id}/capabilities": {
+            "get": {
+                "description": "Returns a normalized report of what an exchange supports, from CCXT's has and timeframes maps: market types, timeframes, and for each job type whether the CCXT method it collects with is native, emulated or unsupported",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "Get exchange capabilities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchange capabilities",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_exchange.ExchangeCapabilities"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/exchanges/{id}/debug": {
             "get": {
                 "description": "Returns detailed debug information for a specific exchange",
@@ -476,7 +710,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Validates multiple trading symbols at once on a specific exchange",
+                "description": "Validates multiple trading symbols at once on a specific exchange. Besides the valid/invalid map, details reports per symbol whether its market is active, why it can't be imported and the closest listed symbol for typos.",
                 "consumes": [
                     "application/json"
                 ],
@@ -540,7 +774,7 @@ const docTemplate = `{
         },
         "/health": {
             "get": {
-                "description": "Returns the health status of the API and connected services",
+                "description": "Returns the health status of the API and connected services. Status is degraded when ML exports can't be written and warning when the export disk is low; only an unhealthy database returns 503.",
                 "consumes": [
                     "application/json"
                 ],
@@ -606,6 +840,33 @@ const docTemplate = `{
                         "description": "Filter by timeframe",
                         "name": "timeframe",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort field (created_at, next_run_time, last_run_time, symbol, status)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc); defaults to asc when sort is given",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10000,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of jobs to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -619,7 +880,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new data collection job for a specific symbol and timeframe",
+                "description": "Creates a new data collection job for a specific symbol and timeframe. job_type selects OHLCV candles (default), public trades, order book snapshots, funding rates or open interest; for trades, orderbook and funding jobs the timeframe sets how often the job runs, and for open_interest jobs also the interval of the points. Jobs the exchange has no CCXT method for are rejected",
                 "consumes": [
                     "application/json"
                 ],
@@ -723,45 +984,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}": {
-            "get": {
-                "description": "Retrieves a specific data collection job by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/jobs/reconcile-schedule": {
+            "post": {
+                "description": "Reschedules active jobs whose next run time is more than one timeframe in the past, e.g. after the scheduler was down, spreading them evenly over spread_seconds so they don't all run at once",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Get a job by ID",
+                "summary": "Reconcile overdue job schedules",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 300,
+                        "description": "Window the overdue jobs are spread over (max 86400)",
+                        "name": "spread_seconds",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Job details",
+                        "description": "Reconciliation result",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_api_handlers.ScheduleReconcileResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid job ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Job not found",
+                        "description": "Invalid spread",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -770,52 +1020,124 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}/execute": {
-            "post": {
-                "description": "Triggers immediate execution of a data collection job",
-                "consumes": [
-                    "application/json"
-                ],
+        "/jobs/symbol": {
+            "delete": {
+                "description": "Deletes every job collecting the symbol, on all exchanges or only on exchange_id, along with their run history like DELETE /jobs/{id}. Collected data is kept.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Execute a job manually",
+                "summary": "Delete all jobs of a symbol",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Symbol (e.g., BTC/USDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only delete the symbol's jobs on this exchange",
+                        "name": "exchange_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Execution result",
+                        "description": "Summary of deleted jobs",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_api_handlers.SymbolJobsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Job not found",
+                    "400": {
+                        "description": "Missing symbol",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/jobs/symbol/pause": {
+            "post": {
+                "description": "Pauses every job collecting the symbol, on all exchanges or only on exchange_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Pause all jobs of a symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol (e.g., BTC/USDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
                     },
-                    "409": {
-                        "description": "Job is locked",
+                    {
+                        "type": "string",
+                        "description": "Only pause the symbol's jobs on this exchange",
+                        "name": "exchange_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Summary of affected jobs",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.SymbolJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing symbol",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/jobs/symbol/resume": {
+            "post": {
+                "description": "Resumes every job collecting the symbol, on all exchanges or only on exchange_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Resume all jobs of a symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol (e.g., BTC/USDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only resume the symbol's jobs on this exchange",
+                        "name": "exchange_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Summary of affected jobs",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.SymbolJobsResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Execution failed",
+                    "400": {
+                        "description": "Missing symbol",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -824,9 +1146,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}/quality": {
+        "/jobs/{id}": {
             "get": {
-                "description": "Analyzes data quality for a specific job including gaps, missing candles, and freshness",
+                "description": "Retrieves a specific data collection job by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -836,7 +1158,7 @@ const docTemplate = `{
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Get job data quality",
+                "summary": "Get a job by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -848,7 +1170,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data quality metrics",
+                        "description": "Job details",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -867,9 +1189,83 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/execute": {
+            "post": {
+                "description": "Triggers immediate execution of a data collection job. A run that fails calling the exchange returns an error whose code tells its cause, with the execution result as details: RATE_LIMITED (429, retry after the Retry-After seconds), SYMBOL_INVALID (400, fix the job), EXCHANGE_UNAVAILABLE (503, retry later) or EXCHANGE_ERROR (502).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Execute a job manually",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Execution result",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.JobExecutionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Symbol not found on the exchange",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Job is locked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limited by the exchange",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Execution failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Exchange call failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Exchange unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -878,43 +1274,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/quality": {
+        "/jobs/{id}/features/latest": {
             "get": {
-                "description": "Returns data quality metrics for all jobs with optional filtering",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Generates features for a job's most recent candles with a saved profile, without targets, splits or sequences. With export_id, the rows use that export's feature columns and normalization params, so they match what a model trained on it expects. The profile defaults to the export's config.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Quality"
+                    "ML Export"
                 ],
-                "summary": "Get all jobs data quality",
+                "summary": "Get latest features for inference",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by exchange ID",
-                        "name": "exchange_id",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export profile ID (required without export_id)",
+                        "name": "config_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by quality status (excellent, good, fair, poor)",
-                        "name": "quality_status",
+                        "description": "Export the model was trained on",
+                        "name": "export_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of newest rows (default: 1, max: 500)",
+                        "name": "rows",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data quality metrics for all jobs",
+                        "description": "Latest feature rows",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.LatestFeatures"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Job, profile or export not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -923,9 +1335,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/quality/summary": {
+        "/jobs/{id}/ohlcv/ingest": {
+            "post": {
+                "description": "Stores a JSON array of candles from an external feed under the job's exchange, symbol and timeframe, so they are checked and exported like fetched candles. Every candle must have finite, positive prices, a non-negative volume, a high and low that bound open and close, and a timestamp on a bar boundary of the job's timeframe; one invalid candle rejects the whole batch, with the invalid candles by index in the details. Indicators are calculated with the job's indicator config, using the stored candles before the batch as warm-up. Candles whose timestamp is already stored are not overwritten but counted in duplicates_skipped; delete the range first (DELETE /jobs/{id}/ohlcv) to replace them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Push candles for a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Candles, in any order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.Candle"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candles stored",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid candles or not an OHLCV job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/quality": {
             "get": {
-                "description": "Returns aggregated data quality metrics for all jobs or filtered by exchange",
+                "description": "Analyzes data quality for a specific job including gaps, missing candles, and freshness",
                 "consumes": [
                     "application/json"
                 ],
@@ -933,20 +1404,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Quality"
+                    "Jobs"
                 ],
-                "summary": "Get data quality summary",
+                "summary": "Get job data quality",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by exchange ID",
-                        "name": "exchange_id",
-                        "in": "query"
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data quality summary",
+                        "description": "Data quality metrics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -961,81 +1447,3952 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_yourusername_datacollector_internal_models.ConnectorCreateRequest": {
+        },
+        "/jobs/{id}/runs": {
+            "get": {
+                "description": "Returns the most recent executions of a job (newest first) with their outcome, duration and candles fetched",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job run history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of runs to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Run history",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.JobRunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/config/default": {
+            "get": {
+                "description": "Returns the default export configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get default configuration",
+                "responses": {
+                    "200": {
+                        "description": "Default configuration",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/datasets": {
+            "post": {
+                "description": "Creates a combined ML dataset from multiple data collection jobs. With config.separate_files set, each job is exported on its own and the files are bundled into one .zip with a manifest.json mapping them to their jobs. The response includes each source's time range and flags sources that do not overlap. Retrying with the same Idempotency-Key header and body returns the original job instead of starting another.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Create combined dataset",
+                "parameters": [
+                    {
+                        "description": "Dataset configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.CreateDatasetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-chosen key that makes retries safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dataset creation already started with this Idempotency-Key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ExportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Dataset creation started",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in progress or used with a different request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Export disk limit reached or exports unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs": {
+            "get": {
+                "description": "Returns a paginated list of export jobs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "List export jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of export jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes all export jobs with the given status and their output files. Statuses other than failed require confirm=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Bulk delete export jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status (failed, completed, cancelled, expired)",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Confirm deletion of non-failed jobs",
+                        "name": "confirm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deletion summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or missing confirmation",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}": {
+            "get": {
+                "description": "Returns the current status and progress of an export job. warnings lists non-fatal issues that can leave the dataset with fewer rows than expected, such as source jobs without data, skipped for too few bars or covering only part of the time range; errors lists the failures, prefixed with the ID of the request that started the export. The response carries an ETag of the job's last update and honors If-None-Match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ExportResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified (If-None-Match matched ETag)"
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an export job and its output files",
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Delete export job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/cancel": {
+            "post": {
+                "description": "Cancels a running export job",
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Cancel export job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/download": {
+            "get": {
+                "description": "Downloads the completed export file. Supports Range/If-Range requests (206 Partial Content) for resumable downloads and ETag/If-None-Match for conditional requests. Exports stored in S3 are redirected (307) to a presigned URL, or streamed from the bucket when presigning is disabled. Exports split by label download their manifest, which lists the split files with row counts, time ranges and checksums.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Download export file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte range to resume a partial download (e.g. bytes=1024-)",
+                        "name": "Range",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated subset of columns to return (CSV/JSONL only, e.g. close,rsi14)",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export file (X-Content-SHA256 header carries the file checksum)",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial export file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified (If-None-Match matched ETag)"
+                    },
+                    "404": {
+                        "description": "Job not found or not completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "416": {
+                        "description": "Requested range not satisfiable"
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/metadata": {
+            "get": {
+                "description": "Returns detailed metadata including feature schema and normalization params",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export metadata",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportMetadata"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/model-card": {
+            "get": {
+                "description": "Renders the export metadata (sources, date range, feature schema with NaN stats, normalization params, split and sequence info) and the config that produced it as a Markdown or JSON data card",
+                "produces": [
+                    "application/json",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export data card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format (json, markdown)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data card",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLModelCard"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or export not completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/preview": {
+            "get": {
+                "description": "Reads the columns and the first and last n rows of a completed export's output file without downloading it. Split outputs show the head of the first split file and the tail of the last.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Preview export rows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Rows from each end (max 100)",
+                        "name": "n",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export preview",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid n, job not completed or format without preview",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job or file not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/jobs/{id}/verify": {
+            "get": {
+                "description": "Re-computes the SHA-256 of the export file on disk and reports any checksum or size mismatch",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Verify export file integrity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification result",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Job not completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/preflight": {
+            "get": {
+                "description": "Checks, without starting an export, that each source job has enough bars for the largest window, lag, lookahead and sequence of a config. The config is a saved profile, a builtin preset, or the default config.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Preflight an export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated source job IDs",
+                        "name": "job_ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Saved export profile ID",
+                        "name": "profile_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Builtin preset name",
+                        "name": "preset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preflight result",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ExportPreflight"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Profile or preset not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/start": {
+            "post": {
+                "description": "Starts a background job to export data with feature engineering. Retrying with the same Idempotency-Key header and body returns the original job instead of starting another.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Start ML export",
+                "parameters": [
+                    {
+                        "description": "Export configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.StartExportRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-chosen key that makes retries safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job already started with this Idempotency-Key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ExportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Export job started",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in progress or used with a different request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Export disk limit reached or exports unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/export/usage": {
+            "get": {
+                "description": "Returns the space used by export files and the configured limit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export disk usage",
+                "responses": {
+                    "200": {
+                        "description": "Disk usage",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/features": {
+            "get": {
+                "description": "Returns a list of available features that can be exported",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get available features",
+                "responses": {
+                    "200": {
+                        "description": "List of features",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/formats": {
+            "get": {
+                "description": "Returns a list of supported export formats with details",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get supported formats",
+                "responses": {
+                    "200": {
+                        "description": "List of formats",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/profiles": {
+            "get": {
+                "description": "Returns a list of saved export configuration profiles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "List export profiles",
+                "responses": {
+                    "200": {
+                        "description": "List of profiles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new export configuration profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Create export profile",
+                "parameters": [
+                    {
+                        "description": "Profile configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Profile created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConfigResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/profiles/diff": {
+            "post": {
+                "description": "Compares two export configs without running an export: the columns only one of them produces, whether their shared columns come in the same order, and the preprocessing, split, target, sequence and resample settings they differ in. Each side is a profile ID or preset name (profile_a/profile_b) or an inline config (config_a/config_b). Auxiliary source columns aren't included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Diff export profiles",
+                "parameters": [
+                    {
+                        "description": "Configs to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.DiffProfilesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Config diff",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ConfigDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/profiles/presets": {
+            "get": {
+                "description": "Returns a list of built-in export configuration presets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export presets",
+                "responses": {
+                    "200": {
+                        "description": "List of presets",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/profiles/{id}": {
+            "get": {
+                "description": "Returns a specific export configuration profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Get export profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConfigResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing export configuration profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Update export profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConfigResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an export configuration profile",
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Delete export profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot delete preset",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ml/profiles/{id}/clone": {
+            "post": {
+                "description": "Copies an export profile, or a built-in preset by ID or name (e.g. full_features), into a new non-preset profile. Without a name the clone is named after the source with a \" (copy)\" suffix.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ML Export"
+                ],
+                "summary": "Clone export profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID or preset name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and description of the clone",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.CloneProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Profile cloned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.ConfigResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Name already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/quality": {
+            "get": {
+                "description": "Returns data quality metrics for all jobs with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quality"
+                ],
+                "summary": "Get all jobs data quality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by exchange ID",
+                        "name": "exchange_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by quality status (excellent, good, fair, poor)",
+                        "name": "quality_status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data quality metrics for all jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/quality/summary": {
+            "get": {
+                "description": "Returns aggregated data quality metrics for all jobs or filtered by exchange",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quality"
+                ],
+                "summary": "Get data quality summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by exchange ID",
+                        "name": "exchange_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data quality summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Returns connector and job counts and the volume of stored OHLCV data across all connectors",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get global statistics",
+                "responses": {
+                    "200": {
+                        "description": "Global statistics",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api_handlers.StatsResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_yourusername_datacollector_internal_exchange.ExchangeCapabilities": {
+            "type": "object",
+            "properties": {
+                "exchange_id": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "job_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_exchange.JobTypeSupport"
+                    }
+                },
+                "last_refreshed": {
+                    "type": "string"
+                },
+                "market_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ohlcv_limit": {
+                    "type": "integer"
+                },
+                "refresh_error": {
+                    "type": "string"
+                },
+                "timeframes": {
+                    "description": "Shortest first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_exchange.JobTypeSupport": {
+            "type": "object",
+            "properties": {
+                "job_type": {
+                    "type": "string"
+                },
+                "method": {
+                    "description": "CCXT method the job collects with",
+                    "type": "string"
+                },
+                "support": {
+                    "description": "\"native\", \"emulated\" or \"unsupported\"",
+                    "type": "string"
+                },
+                "supported": {
+                    "type": "boolean"
+                },
+                "timeframes": {
+                    "description": "Timeframes the exchange serves for job types whose data has a\ntimeframe; for other job types the timeframe only sets how often the\njob runs and any timeframe is accepted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.AlignmentConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "tolerance_ms": {
+                    "description": "Default 10% of a bar, at most half a bar",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.AuxSource": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "ohlcv jobs: open, high, low, close, volume, returns, log_returns or a stored indicator; funding jobs: funding_rate; open_interest jobs: open_interest, open_interest_value",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Defaults to the job's symbol in lower case, e.g. \"eth_usdt\"",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.AuxSourceInfo": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Joined column names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "end_time": {
+                    "description": "When the last point became known",
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "job_type": {
+                    "type": "string"
+                },
+                "point_count": {
+                    "description": "Points loaded from the source",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "When the first point became known (candles: at close)",
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.Candle": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "indicators": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.Indicators"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "quote_volume": {
+                    "description": "Optional, only set when the source provides it",
+                    "type": "number"
+                },
+                "timestamp": {
+                    "description": "Unix milliseconds",
+                    "type": "integer"
+                },
+                "trade_count": {
+                    "description": "Optional, only set when the source provides it",
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ClipBounds": {
+            "type": "object",
+            "properties": {
+                "clipped": {
+                    "description": "Values moved onto a bound",
+                    "type": "integer"
+                },
+                "fit_rows": {
+                    "description": "Leading rows the bounds were computed from",
+                    "type": "integer"
+                },
+                "lower": {
+                    "type": "number"
+                },
+                "method": {
+                    "description": "stddev or percentile",
+                    "type": "string"
+                },
+                "upper": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ConfigDiff": {
+            "type": "object",
+            "properties": {
+                "column_count_a": {
+                    "type": "integer"
+                },
+                "column_count_b": {
+                    "type": "integer"
+                },
+                "common_columns": {
+                    "type": "integer"
+                },
+                "only_in_a": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "only_in_b": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "same_column_order": {
+                    "type": "boolean"
+                },
+                "settings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SettingDiff"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ConnectorCreateRequest": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "exchange_id"
+            ],
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "indicator_config_id": {
+                    "description": "Indicator config for the connector's jobs (default: global default config)",
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "type": "object",
+                    "required": [
+                        "limit",
+                        "period_ms"
+                    ],
+                    "properties": {
+                        "limit": {
+                            "description": "Max requests per period",
+                            "type": "integer",
+                            "minimum": 1
+                        },
+                        "min_delay_ms": {
+                            "description": "Min delay between calls (default: calculated from limit/period)",
+                            "type": "integer",
+                            "minimum": 100
+                        },
+                        "period_ms": {
+                            "description": "Period in milliseconds",
+                            "type": "integer",
+                            "minimum": 1000
+                        }
+                    }
+                },
+                "request_timeout_ms": {
+                    "description": "Per-request timeout (default: EXCHANGE_REQUEST_TIMEOUT)",
+                    "type": "integer",
+                    "minimum": 1000
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ConnectorHealth": {
+            "type": "object",
+            "properties": {
+                "average_response_ms": {
+                    "description": "Average response time in ms",
+                    "type": "number"
+                },
+                "circuit_open_until": {
+                    "description": "When an open circuit lets a probe through",
+                    "type": "string"
+                },
+                "circuit_state": {
+                    "description": "\"closed\", \"open\", \"half_open\"",
+                    "type": "string"
+                },
+                "consecutive_failures": {
+                    "description": "Consecutive failure count",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "Last error message",
+                    "type": "string"
+                },
+                "last_failed_call": {
+                    "description": "Last failed API call",
+                    "type": "string"
+                },
+                "last_health_check": {
+                    "description": "Last health check timestamp",
+                    "type": "string"
+                },
+                "last_response_ms": {
+                    "description": "Last response time in ms",
+                    "type": "integer"
+                },
+                "last_successful_call": {
+                    "description": "Last successful API call",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"healthy\", \"degraded\", \"unhealthy\"",
+                    "type": "string"
+                },
+                "total_calls": {
+                    "description": "Total API calls made",
+                    "type": "integer"
+                },
+                "total_failures": {
+                    "description": "Total failed calls",
+                    "type": "integer"
+                },
+                "uptime_percentage": {
+                    "description": "Uptime percentage (0-100)",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.DataRange": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "exchanges": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timeframes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_bars": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.DatasetCoverage": {
+            "type": "object",
+            "properties": {
+                "overlap_end": {
+                    "type": "string"
+                },
+                "overlap_ratio": {
+                    "description": "Common range / combined range (0-1)",
+                    "type": "number"
+                },
+                "overlap_start": {
+                    "description": "Range covered by every source",
+                    "type": "string"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SourceCoverage"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.DroppedColumnInfo": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "nan_fraction": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.DroppedRowsInfo": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "first_timestamp": {
+                    "type": "integer"
+                },
+                "last_timestamp": {
+                    "type": "integer"
+                },
+                "max_nan_fraction": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ExportPreflight": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "feature_warmup": {
+                    "description": "Leading bars the largest window/lag needs",
+                    "type": "integer"
+                },
+                "lookahead": {
+                    "description": "Trailing bars the largest target lookahead needs",
+                    "type": "integer"
+                },
+                "ok": {
+                    "type": "boolean"
+                },
+                "required_bars": {
+                    "type": "integer"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.PreflightSource"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ExportWarning": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "description": "Source job the warning is about",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.FeatureConfig": {
+            "type": "object",
+            "properties": {
+                "auto_recalculate_missing": {
+                    "description": "Recalculate and store indicators missing from source jobs before the export",
+                    "type": "boolean"
+                },
+                "compute_missing_indicators": {
+                    "description": "Compute indicators missing from stored candles using the active indicator config",
+                    "type": "boolean"
+                },
+                "cross_features": {
+                    "description": "Cross-indicator features",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "derivatives_features": {
+                    "description": "Derivatives features, joined from the symbol's funding rate and open\ninterest jobs: the last value at or before each candle's open time",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_indicators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "frac_diff": {
+                    "description": "Order and weight threshold of the frac_diff price feature",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FracDiffConfig"
+                        }
+                    ]
+                },
+                "include_all_indicators": {
+                    "description": "Indicator selection",
+                    "type": "boolean"
+                },
+                "include_ohlcv": {
+                    "description": "Base data",
+                    "type": "boolean"
+                },
+                "include_timestamp": {
+                    "type": "boolean"
+                },
+                "include_volume": {
+                    "type": "boolean"
+                },
+                "indicator_categories": {
+                    "description": "trend, momentum, volatility, volume",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lagged_features": {
+                    "description": "Lagged features",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.LagConfig"
+                        }
+                    ]
+                },
+                "market_sessions": {
+                    "description": "Session hours and encoding of the market_session temporal feature",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MarketSessionConfig"
+                        }
+                    ]
+                },
+                "price_features": {
+                    "description": "Price-based features",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rolling_features": {
+                    "description": "Rolling statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.RollingConfig"
+                        }
+                    ]
+                },
+                "specific_indicators": {
+                    "description": "sma20, rsi14, etc.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temporal_features": {
+                    "description": "Temporal features",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.FeatureRow": {
+            "type": "object",
+            "properties": {
+                "timestamp": {
+                    "type": "string"
+                },
+                "values": {
+                    "description": "null where the feature has no value yet",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.FeatureSchema": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Categories names the values of a categorical column, indexed by value",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nan_count": {
+                    "type": "integer"
+                },
+                "nan_percent": {
+                    "type": "number"
+                },
+                "source": {
+                    "description": "ohlcv, indicator, price_feature, lagged, rolling, temporal, cross, target",
+                    "type": "string"
+                },
+                "std": {
+                    "type": "number"
+                },
+                "type": {
+                    "description": "float64, int64, bool, string",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.FloatFormat": {
+            "type": "string",
+            "enum": [
+                "fixed",
+                "general",
+                "scientific"
+            ],
+            "x-enum-comments": {
+                "FloatFormatFixed": "Fixed decimals, e.g. 0.00001234 (default)",
+                "FloatFormatGeneral": "Significant digits regardless of magnitude, e.g. 1.234e-05",
+                "FloatFormatScientific": "Always an exponent, e.g. 1.23400000e-05"
+            },
+            "x-enum-descriptions": [
+                "Fixed decimals, e.g. 0.00001234 (default)",
+                "Significant digits regardless of magnitude, e.g. 1.234e-05",
+                "Always an exponent, e.g. 1.23400000e-05"
+            ],
+            "x-enum-varnames": [
+                "FloatFormatFixed",
+                "FloatFormatGeneral",
+                "FloatFormatScientific"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.FracDiffConfig": {
+            "type": "object",
+            "properties": {
+                "d": {
+                    "description": "D is the order of differencing, between 0 and 1 exclusive. Lower values\nkeep more memory of the price level, higher values are closer to log\nreturns.",
+                    "type": "number"
+                },
+                "threshold": {
+                    "description": "Threshold drops the weights whose magnitude falls below it, which fixes\nthe window width",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.FreshnessThresholds": {
+            "type": "object",
+            "properties": {
+                "fresh_multiplier": {
+                    "description": "Up to this many bars old is \"fresh\"",
+                    "type": "number"
+                },
+                "stale_multiplier": {
+                    "description": "Up to this many bars old is \"stale\", beyond is \"very_stale\"",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.HealthHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "error_rate_percent": {
+                    "type": "number"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "hour": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.Indicators": {
+            "type": "object",
+            "properties": {
+                "adx": {
+                    "description": "ADX/DMI (Average Directional Index / Directional Movement Index)",
+                    "type": "number"
+                },
+                "atr": {
+                    "description": "ATR (Average True Range)",
+                    "type": "number"
+                },
+                "bb_bandwidth": {
+                    "description": "(Upper - Lower) / Middle * 100",
+                    "type": "number"
+                },
+                "bb_lower": {
+                    "type": "number"
+                },
+                "bb_middle": {
+                    "type": "number"
+                },
+                "bb_percent_b": {
+                    "description": "(Price - Lower) / (Upper - Lower)",
+                    "type": "number"
+                },
+                "bb_upper": {
+                    "description": "Bollinger Bands",
+                    "type": "number"
+                },
+                "cci": {
+                    "description": "CCI (Commodity Channel Index)",
+                    "type": "number"
+                },
+                "cmf": {
+                    "description": "CMF (Chaikin Money Flow)",
+                    "type": "number"
+                },
+                "dema": {
+                    "description": "Double Exponential Moving Average",
+                    "type": "number"
+                },
+                "donchian_lower": {
+                    "type": "number"
+                },
+                "donchian_middle": {
+                    "type": "number"
+                },
+                "donchian_upper": {
+                    "description": "Donchian Channels",
+                    "type": "number"
+                },
+                "ema12": {
+                    "description": "Exponential Moving Average",
+                    "type": "number"
+                },
+                "ema26": {
+                    "type": "number"
+                },
+                "ema50": {
+                    "type": "number"
+                },
+                "hma": {
+                    "description": "Hull Moving Average",
+                    "type": "number"
+                },
+                "ichimoku_chikou": {
+                    "description": "Lagging Span",
+                    "type": "number"
+                },
+                "ichimoku_kijun": {
+                    "description": "Base Line",
+                    "type": "number"
+                },
+                "ichimoku_senkou_a": {
+                    "description": "Leading Span A",
+                    "type": "number"
+                },
+                "ichimoku_senkou_b": {
+                    "description": "Leading Span B",
+                    "type": "number"
+                },
+                "ichimoku_tenkan": {
+                    "description": "Ichimoku Cloud",
+                    "type": "number"
+                },
+                "keltner_lower": {
+                    "type": "number"
+                },
+                "keltner_middle": {
+                    "type": "number"
+                },
+                "keltner_upper": {
+                    "description": "Keltner Channels",
+                    "type": "number"
+                },
+                "macd": {
+                    "description": "MACD (Moving Average Convergence Divergence)",
+                    "type": "number"
+                },
+                "macd_hist": {
+                    "type": "number"
+                },
+                "macd_signal": {
+                    "type": "number"
+                },
+                "mfi": {
+                    "description": "MFI (Money Flow Index)",
+                    "type": "number"
+                },
+                "minus_di": {
+                    "description": "-DI",
+                    "type": "number"
+                },
+                "momentum": {
+                    "description": "Momentum",
+                    "type": "number"
+                },
+                "obv": {
+                    "description": "OBV (On-Balance Volume)",
+                    "type": "number"
+                },
+                "plus_di": {
+                    "description": "+DI",
+                    "type": "number"
+                },
+                "roc": {
+                    "description": "ROC (Rate of Change)",
+                    "type": "number"
+                },
+                "rsi14": {
+                    "type": "number"
+                },
+                "rsi24": {
+                    "type": "number"
+                },
+                "rsi6": {
+                    "description": "RSI (Relative Strength Index)",
+                    "type": "number"
+                },
+                "sma20": {
+                    "description": "Simple Moving Average",
+                    "type": "number"
+                },
+                "sma200": {
+                    "type": "number"
+                },
+                "sma50": {
+                    "type": "number"
+                },
+                "stddev": {
+                    "description": "Standard Deviation",
+                    "type": "number"
+                },
+                "stoch_d": {
+                    "description": "%D",
+                    "type": "number"
+                },
+                "stoch_k": {
+                    "description": "Stochastic Oscillator",
+                    "type": "number"
+                },
+                "supertrend": {
+                    "description": "SuperTrend",
+                    "type": "number"
+                },
+                "supertrend_signal": {
+                    "description": "1=buy, -1=sell, 0=neutral",
+                    "type": "integer"
+                },
+                "tema": {
+                    "description": "Triple Exponential Moving Average",
+                    "type": "number"
+                },
+                "volume_sma": {
+                    "description": "Volume SMA",
+                    "type": "number"
+                },
+                "vwap": {
+                    "description": "VWAP (Volume Weighted Average Price)",
+                    "type": "number"
+                },
+                "vwma": {
+                    "description": "Volume Weighted Moving Average",
+                    "type": "number"
+                },
+                "williams_r": {
+                    "description": "Williams %R",
+                    "type": "number"
+                },
+                "wma": {
+                    "description": "Weighted Moving Average",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.JobCreateRequest": {
+            "type": "object",
+            "required": [
+                "connector_exchange_id",
+                "symbol",
+                "timeframe"
+            ],
+            "properties": {
+                "collect_historical": {
+                    "type": "boolean"
+                },
+                "compute_indicators_on_ingest": {
+                    "description": "Calculate indicators before storing candles (defaults to true)",
+                    "type": "boolean"
+                },
+                "connector_exchange_id": {
+                    "type": "string"
+                },
+                "depends_on": {
+                    "description": "Job IDs (as strings) that must complete first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_unclosed_bar": {
+                    "description": "Don't store the still-forming latest candle (defaults to true)",
+                    "type": "boolean"
+                },
+                "freshness": {
+                    "description": "Per-job freshness thresholds (defaults to global)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FreshnessThresholds"
+                        }
+                    ]
+                },
+                "freshness_sla_minutes": {
+                    "description": "Freshness SLA in minutes (defaults from the timeframe)",
+                    "type": "integer"
+                },
+                "indicator_config_id": {
+                    "description": "Indicator config override (defaults to the connector's)",
+                    "type": "string"
+                },
+                "job_type": {
+                    "description": "Defaults to \"ohlcv\"",
+                    "type": "string",
+                    "enum": [
+                        "ohlcv",
+                        "trades",
+                        "orderbook",
+                        "funding",
+                        "open_interest"
+                    ]
+                },
+                "orderbook_depth": {
+                    "description": "Orderbook jobs only (defaults to 50)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "paused"
+                    ]
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.JobExecutionResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "execution_time_ms": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_run_time": {
+                    "type": "string"
+                },
+                "records_fetched": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.JobRun": {
+            "type": "object",
+            "properties": {
+                "candles_fetched": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.LagConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "lag_features": {
+                    "description": "Which features to lag, empty = all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lag_periods": {
+                    "description": "e.g., [1, 5, 10, 20]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.LatestFeatures": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "export_job_id": {
+                    "description": "Export whose columns and normalization were applied",
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "normalized": {
+                    "type": "boolean"
+                },
+                "rows": {
+                    "description": "Oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FeatureRow"
+                    }
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportConfig": {
+            "type": "object",
+            "properties": {
+                "alignment": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.AlignmentConfig"
+                },
+                "auxiliary_sources": {
+                    "description": "AuxiliarySources joins columns of other collection jobs onto every row,\ne.g. the funding rate or a second symbol's returns as a market factor",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.AuxSource"
+                    }
+                },
+                "column_order": {
+                    "description": "ColumnOrder pins the listed columns to the front of the export, in this\norder, for pipelines that address columns by index. Other columns follow\nin the canonical order; listed columns the export doesn't produce are ignored.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exclude_unclosed_bar": {
+                    "description": "ExcludeUnclosedBar drops each source's latest bar while it is still\nforming (default true), as its values are provisional until it closes",
+                    "type": "boolean"
+                },
+                "features": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FeatureConfig"
+                },
+                "float_format": {
+                    "description": "FloatFormat and FloatPrecision control how CSV and JSONL exports write\nfloats: fixed (default) with FloatPrecision decimals, general with\nFloatPrecision significant digits, or scientific. FloatPrecision 0 uses\nthe writer default of 8. JSONL without a FloatFormat keeps full precision.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FloatFormat"
+                        }
+                    ]
+                },
+                "float_precision": {
+                    "type": "integer"
+                },
+                "format": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportFormat"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "is_preset": {
+                    "type": "boolean"
+                },
+                "min_bars": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MinBarsConfig"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "preprocessing": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.PreprocessConfig"
+                },
+                "resample": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ResampleConfig"
+                },
+                "retention_hours": {
+                    "description": "RetentionHours controls how long the export file is kept after completion.\nnil falls back to the server default, 0 means the file never expires.",
+                    "type": "integer"
+                },
+                "separate_files": {
+                    "description": "SeparateFiles exports each source job on its own instead of merging\nthem, bundling the per-job files into one .zip with a manifest.json\nmapping files to source jobs",
+                    "type": "boolean"
+                },
+                "sequence": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SequenceConfig"
+                },
+                "split": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SplitConfig"
+                },
+                "tail_bars": {
+                    "description": "TailBars limits the export to the most recent N bars of each source job\n(0 = full history). The bars before them that features need to warm up\nare still loaded, then dropped before preprocessing.",
+                    "type": "integer"
+                },
+                "target": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetConfig"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "parquet",
+                "numpy",
+                "jsonl",
+                "json"
+            ],
+            "x-enum-varnames": [
+                "MLExportFormatCSV",
+                "MLExportFormatParquet",
+                "MLExportFormatNumpy",
+                "MLExportFormatJSONL",
+                "MLExportFormatJSON"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportMetadata": {
+            "type": "object",
+            "properties": {
+                "auxiliary_sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.AuxSourceInfo"
+                    }
+                },
+                "clip_bounds": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ClipBounds"
+                    }
+                },
+                "coverage": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.DatasetCoverage"
+                },
+                "data_range": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.DataRange"
+                },
+                "dropped_columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.DroppedColumnInfo"
+                    }
+                },
+                "dropped_rows": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.DroppedRowsInfo"
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "feature_schema": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.FeatureSchema"
+                    }
+                },
+                "normalization_params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.NormParams"
+                    }
+                },
+                "recalculated_jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.RecalculatedJobInfo"
+                    }
+                },
+                "sequence_info": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SequenceInfo"
+                },
+                "skipped_sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SkippedSourceInfo"
+                    }
+                },
+                "source_jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SourceJobInfo"
+                    }
+                },
+                "split_info": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SplitInfo"
+                },
+                "tail": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TailInfo"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetInfo"
+                    }
+                },
+                "trimmed_warmup_rows": {
+                    "description": "Leading rows dropped by TrimWarmup",
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportPreview": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "export_job_id": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportFormat"
+                },
+                "head": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportPreviewRow"
+                    }
+                },
+                "row_count": {
+                    "type": "integer"
+                },
+                "tail": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportPreviewRow"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportPreviewRow": {
+            "type": "object",
+            "properties": {
+                "split": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "NumPy outputs carry no timestamps",
+                    "type": "string"
+                },
+                "values": {
+                    "description": "null for NaN and infinite values",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLExportVerification": {
+            "type": "object",
+            "properties": {
+                "actual_checksum": {
+                    "type": "string"
+                },
+                "actual_size": {
+                    "type": "integer"
+                },
+                "checksum_match": {
+                    "type": "boolean"
+                },
+                "expected_checksum": {
+                    "type": "string"
+                },
+                "expected_size": {
+                    "type": "integer"
+                },
+                "export_job_id": {
+                    "type": "string"
+                },
+                "file_exists": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "output_path": {
+                    "type": "string"
+                },
+                "size_match": {
+                    "type": "boolean"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MLModelCard": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "config": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "export_job_id": {
+                    "type": "string"
+                },
+                "feature_count": {
+                    "type": "integer"
+                },
+                "file_size_bytes": {
+                    "type": "integer"
+                },
+                "format": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportFormat"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportMetadata"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "row_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MarketSessionConfig": {
+            "type": "object",
+            "properties": {
+                "asian": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SessionHours"
+                },
+                "european": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SessionHours"
+                },
+                "one_hot": {
+                    "description": "OneHot emits one 0/1 column per session code, market_session_\u003cname\u003e,\ninstead of a single integer code column",
+                    "type": "boolean"
+                },
+                "us": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SessionHours"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.MinBarsAction": {
+            "type": "string",
+            "enum": [
+                "fail",
+                "skip"
+            ],
+            "x-enum-comments": {
+                "MinBarsActionFail": "Fail the export listing the short sources (default)",
+                "MinBarsActionSkip": "Leave short sources out and report them in the metadata"
+            },
+            "x-enum-descriptions": [
+                "Fail the export listing the short sources (default)",
+                "Leave short sources out and report them in the metadata"
+            ],
+            "x-enum-varnames": [
+                "MinBarsActionFail",
+                "MinBarsActionSkip"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.MinBarsConfig": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "fail (default), skip",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MinBarsAction"
+                        }
+                    ]
+                },
+                "min_bars": {
+                    "description": "0 = derive from features, target and sequence config",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.NaNHandlingType": {
+            "type": "string",
+            "enum": [
+                "drop",
+                "forward_fill",
+                "backward_fill",
+                "interpolate",
+                "zero"
+            ],
+            "x-enum-varnames": [
+                "NaNHandlingDrop",
+                "NaNHandlingForwardFill",
+                "NaNHandlingBackwardFill",
+                "NaNHandlingInterpolate",
+                "NaNHandlingZero"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.NormParams": {
+            "type": "object",
+            "properties": {
+                "iqr": {
+                    "description": "For robust scaling",
+                    "type": "number"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "std": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.NormalizationType": {
+            "type": "string",
+            "enum": [
+                "none",
+                "minmax",
+                "zscore",
+                "robust"
+            ],
+            "x-enum-varnames": [
+                "NormalizationNone",
+                "NormalizationMinMax",
+                "NormalizationZScore",
+                "NormalizationRobust"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.PreflightSource": {
+            "type": "object",
+            "properties": {
+                "bar_count": {
+                    "type": "integer"
+                },
+                "estimated": {
+                    "description": "Bar count estimated after resampling",
+                    "type": "boolean"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "shortfall": {
+                    "type": "integer"
+                },
+                "sufficient": {
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.PreprocessConfig": {
+            "type": "object",
+            "properties": {
+                "clip_method": {
+                    "description": "ClipMethod picks how ClipOutliers bounds each column: stddev (default)\nclips at OutlierStdDev standard deviations from the mean of all rows;\npercentile clips at the LowerPct and UpperPct percentiles (0-100, default\n1 and 99) of the training rows, which the outliers themselves barely\nmove. Percentile clipping leaves categorical columns alone.",
+                    "type": "string"
+                },
+                "clip_outliers": {
+                    "type": "boolean"
+                },
+                "forward_fill_limit": {
+                    "description": "Forward and backward fill carry a value across at most this many\nconsecutive NaN rows (0 = unlimited). The rest of a longer gap, and rows\nwith no value to carry, stay NaN, so with RemoveNaNRows they are dropped\n(subject to MaxNaNFraction) instead of holding a stale value.",
+                    "type": "integer"
+                },
+                "inf_handling": {
+                    "description": "drop, replace_nan, clip",
+                    "type": "string"
+                },
+                "lower_pct": {
+                    "type": "number"
+                },
+                "max_col_nan_fraction": {
+                    "description": "Drop feature columns whose fraction of NaN values is above this threshold\nbefore filling and row removal (0 = keep all columns). Targets are never dropped.",
+                    "type": "number"
+                },
+                "max_nan_fraction": {
+                    "description": "With RemoveNaNRows, keep rows whose fraction of NaN values is at or below\nthis threshold (0 = drop any row with a NaN)",
+                    "type": "number"
+                },
+                "nan_handling": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.NaNHandlingType"
+                },
+                "normalization": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.NormalizationType"
+                },
+                "outlier_stddev": {
+                    "description": "Clip at N std devs",
+                    "type": "number"
+                },
+                "protect_columns": {
+                    "description": "ProtectColumns names columns, such as split keys, that clipping,\nnormalization and NaN column dropping leave as they are. Target\ncolumns are always protected.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove_nan_rows": {
+                    "type": "boolean"
+                },
+                "trim_warmup": {
+                    "description": "TrimWarmup drops the first WarmupRows rows before preprocessing, where\nlong lookbacks leave features NaN, regardless of NaNs further on.\nWarmupRows 0 uses the feature config's warm-up (the largest indicator,\nrolling and lag period). Exports limited by TailBars already drop them.",
+                    "type": "boolean"
+                },
+                "upper_pct": {
+                    "type": "number"
+                },
+                "warmup_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.RecalculatedJobInfo": {
+            "type": "object",
+            "properties": {
+                "candles_updated": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped": {
+                    "description": "Over the per-export recalculation limit",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ResampleConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "timeframe": {
+                    "description": "Target timeframe, e.g. \"1h\"",
+                    "type": "string"
+                },
+                "volume_aggregation": {
+                    "description": "base (default), quote",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.VolumeAggregation"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.RescheduledJob": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string"
+                },
+                "next_run_time": {
+                    "type": "string"
+                },
+                "previous_next_run_time": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.RollingConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "rolling_features": {
+                    "description": "Which features, empty = close only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stats": {
+                    "description": "mean, std, min, max, median",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "windows": {
+                    "description": "e.g., [5, 10, 20]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.ScheduleReconcileResult": {
+            "type": "object",
+            "properties": {
+                "adjusted": {
+                    "description": "Jobs more than one timeframe overdue that were rescheduled",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "Active jobs whose next run time had passed",
+                    "type": "integer"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.RescheduledJob"
+                    }
+                },
+                "spread_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SequenceConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "include_target": {
+                    "description": "Include target in sequence output",
+                    "type": "boolean"
+                },
+                "length": {
+                    "description": "Sequence length (e.g., 60 candles)",
+                    "type": "integer"
+                },
+                "stride": {
+                    "description": "Step between sequences (1 = sliding window)",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SequenceInfo": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "type": "integer"
+                },
+                "stride": {
+                    "type": "integer"
+                },
+                "test_sequences": {
+                    "type": "integer"
+                },
+                "total_sequences": {
+                    "type": "integer"
+                },
+                "train_sequences": {
+                    "type": "integer"
+                },
+                "val_sequences": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SessionHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "description": "1-24",
+                    "type": "integer"
+                },
+                "start": {
+                    "description": "0-23",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SettingDiff": {
+            "type": "object",
+            "properties": {
+                "a": {},
+                "b": {},
+                "field": {
+                    "description": "Dotted path, e.g. \"split.train_ratio\"",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SkippedSourceInfo": {
+            "type": "object",
+            "properties": {
+                "bar_count": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "required_bars": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SourceCoverage": {
+            "type": "object",
+            "properties": {
+                "bar_count": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "overlap_ratio": {
+                    "description": "Common range / this source's range (0-1)",
+                    "type": "number"
+                },
+                "snapped_bars": {
+                    "description": "With alignment, the candles moved onto the bar grid and those dropped\nfor being too far off it",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "tail_start": {
+                    "description": "First bar of the TailBars window; bars from StartTime up to it were\nloaded as feature warm-up only",
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                },
+                "unaligned_bars": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SourceJobInfo": {
+            "type": "object",
+            "properties": {
+                "bar_count": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "snapped_bars": {
+                    "description": "With alignment, the candles moved onto the bar grid and those dropped\nfor being too far off it",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "tail_start": {
+                    "description": "First bar of the TailBars window; bars from StartTime up to it were\nloaded as feature warm-up only",
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                },
+                "unaligned_bars": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SplitConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "shuffle": {
+                    "description": "Only if TimeBased is false",
+                    "type": "boolean"
+                },
+                "shuffle_index": {
+                    "description": "ShuffleIndex keeps rows chronological but adds a shuffle_index column giving\na reproducible random order of the training rows (-1 for other splits).\nOnly used with TimeBased splits.",
+                    "type": "boolean"
+                },
+                "shuffle_seed": {
+                    "type": "integer"
+                },
+                "test_ratio": {
+                    "type": "number"
+                },
+                "time_based": {
+                    "description": "True = chronological split (no look-ahead bias)",
+                    "type": "boolean"
+                },
+                "train_ratio": {
+                    "type": "number"
+                },
+                "validation_ratio": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SplitInfo": {
+            "type": "object",
+            "properties": {
+                "shuffle_seed": {
+                    "description": "Set when a shuffle_index column was emitted for the training rows",
+                    "type": "integer"
+                },
+                "test_end": {
+                    "type": "string"
+                },
+                "test_rows": {
+                    "type": "integer"
+                },
+                "test_start": {
+                    "type": "string"
+                },
+                "train_end": {
+                    "type": "string"
+                },
+                "train_rows": {
+                    "type": "integer"
+                },
+                "train_start": {
+                    "type": "string"
+                },
+                "val_end": {
+                    "type": "string"
+                },
+                "val_rows": {
+                    "type": "integer"
+                },
+                "val_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SymbolJobSummary": {
+            "type": "object",
+            "properties": {
+                "connector_exchange_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_type": {
+                    "type": "string"
+                },
+                "previous_status": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.SymbolJobsResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"pause\", \"resume\" or \"delete\"",
+                    "type": "string"
+                },
+                "affected": {
+                    "description": "Jobs whose status changed, or that were deleted",
+                    "type": "integer"
+                },
+                "exchange_id": {
+                    "description": "Empty when the operation spanned all exchanges",
+                    "type": "string"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SymbolJobSummary"
+                    }
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.TailInfo": {
+            "type": "object",
+            "properties": {
+                "effective_bars": {
+                    "description": "Bars left for the export",
+                    "type": "integer"
+                },
+                "loaded_bars": {
+                    "description": "Bars features were generated from, warm-up included",
+                    "type": "integer"
+                },
+                "tail_bars": {
+                    "description": "Requested bars per source job",
+                    "type": "integer"
+                },
+                "warmup_rows": {
+                    "description": "Leading rows dropped after feature generation",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.TargetBinMode": {
+            "type": "string",
+            "enum": [
+                "fixed",
+                "quantile"
+            ],
+            "x-enum-comments": {
+                "TargetBinModeFixed": "Edges are the configured classification bins",
+                "TargetBinModeQuantile": "Edges split the future returns into equally populated classes"
+            },
+            "x-enum-descriptions": [
+                "Edges are the configured classification bins",
+                "Edges split the future returns into equally populated classes"
+            ],
+            "x-enum-varnames": [
+                "TargetBinModeFixed",
+                "TargetBinModeQuantile"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.TargetConfig": {
+            "type": "object",
+            "properties": {
+                "bin_mode": {
+                    "description": "fixed (default) or quantile",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetBinMode"
+                        }
+                    ]
+                },
+                "classification_bins": {
+                    "description": "For multi-class: [-0.02, -0.01, 0.01, 0.02]",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "lookahead_periods": {
+                    "description": "e.g., [1, 5, 10]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "num_classes": {
+                    "description": "Class count for quantile mode",
+                    "type": "integer"
+                },
+                "targets": {
+                    "description": "Additional targets, each with its own bins",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetSpec"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetType"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.TargetInfo": {
+            "type": "object",
+            "properties": {
+                "bin_mode": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetBinMode"
+                },
+                "classification_bins": {
+                    "description": "Edges the classes were assigned with",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "column": {
+                    "type": "string"
+                },
+                "lookahead_period": {
+                    "type": "integer"
+                },
+                "num_classes": {
+                    "type": "integer"
+                },
+                "quantile_edges": {
+                    "description": "Computed edges in quantile mode",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetType"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.TargetSpec": {
+            "type": "object",
+            "properties": {
+                "bin_mode": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetBinMode"
+                },
+                "classification_bins": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "lookahead_periods": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "description": "Column prefix, defaults to the type: target_\u003cname\u003e_\u003cperiod\u003e",
+                    "type": "string"
+                },
+                "num_classes": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.TargetType"
+                }
+            }
+        },
+        "github_com_yourusername_datacollector_internal_models.TargetType": {
+            "type": "string",
+            "enum": [
+                "future_returns",
+                "future_direction",
+                "future_class",
+                "future_volatility"
+            ],
+            "x-enum-varnames": [
+                "TargetTypeFutureReturns",
+                "TargetTypeFutureDirection",
+                "TargetTypeFutureClass",
+                "TargetTypeFutureVolatility"
+            ]
+        },
+        "github_com_yourusername_datacollector_internal_models.VolumeAggregation": {
+            "type": "string",
+            "enum": [
+                "base",
+                "quote"
+            ],
+            "x-enum-comments": {
+                "VolumeAggregationBase": "Volume is the summed base volume (default)",
+                "VolumeAggregationQuote": "Volume is the summed quote volume"
+            },
+            "x-enum-descriptions": [
+                "Volume is the summed base volume (default)",
+                "Volume is the summed quote volume"
+            ],
+            "x-enum-varnames": [
+                "VolumeAggregationBase",
+                "VolumeAggregationQuote"
+            ]
+        },
+        "internal_api_handlers.AllConnectorsHealthResponse": {
+            "type": "object",
+            "properties": {
+                "connectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_api_handlers.ConnectorHealthSummary"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "summary": {
+                    "$ref": "#/definitions/internal_api_handlers.HealthCounts"
+                }
+            }
+        },
+        "internal_api_handlers.CircuitBreakerStatus": {
+            "type": "object",
+            "properties": {
+                "cooldown_seconds": {
+                    "type": "integer"
+                },
+                "open_until": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "\"closed\", \"open\" or \"half_open\"",
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.CloneProfileRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api_handlers.ConfigResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_preset": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorHealthReport": {
+            "type": "object",
+            "properties": {
+                "active_job_count": {
+                    "type": "integer"
+                },
+                "circuit_breaker": {
+                    "$ref": "#/definitions/internal_api_handlers.CircuitBreakerStatus"
+                },
+                "connector_status": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "error_rate_percentage": {
+                    "type": "number"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "health": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ConnectorHealth"
+                },
+                "health_description": {
+                    "type": "string"
+                },
+                "job_count": {
+                    "type": "integer"
+                },
+                "request_timeout_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorHealthResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_api_handlers.ConnectorHealthReport"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorHealthSummary": {
+            "type": "object",
+            "properties": {
+                "active_job_count": {
+                    "type": "integer"
+                },
+                "average_response_ms": {
+                    "type": "number"
+                },
+                "connector_status": {
+                    "type": "string"
+                },
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "error_rate_percentage": {
+                    "type": "number"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "health_status": {
+                    "type": "string"
+                },
+                "job_count": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_failed_call": {
+                    "type": "string"
+                },
+                "last_successful_call": {
+                    "type": "string"
+                },
+                "total_calls": {
+                    "type": "integer"
+                },
+                "total_failures": {
+                    "type": "integer"
+                },
+                "uptime_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorJobsStatus": {
+            "type": "object",
+            "properties": {
+                "active_job_count": {
+                    "type": "integer"
+                },
+                "affected_jobs": {
+                    "type": "integer"
+                },
+                "connector_status": {
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "job_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorJobsStatusResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_api_handlers.ConnectorJobsStatus"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.ConnectorStatsResponse": {
             "type": "object",
-            "required": [
-                "display_name",
-                "exchange_id"
-            ],
             "properties": {
+                "active_job_count": {
+                    "type": "integer"
+                },
+                "connector_id": {
+                    "type": "string"
+                },
+                "data_stats": {
+                    "$ref": "#/definitions/internal_api_handlers.DataStats"
+                },
                 "display_name": {
                     "type": "string"
                 },
                 "exchange_id": {
                     "type": "string"
                 },
+                "failed_jobs": {
+                    "description": "Jobs whose last run recorded an error",
+                    "type": "integer"
+                },
+                "job_count": {
+                    "type": "integer"
+                },
+                "last_run_time": {
+                    "type": "string"
+                },
                 "rate_limit": {
-                    "type": "object",
-                    "required": [
-                        "limit",
-                        "period_ms"
-                    ],
-                    "properties": {
-                        "limit": {
-                            "description": "Max requests per period",
-                            "type": "integer",
-                            "minimum": 1
-                        },
-                        "min_delay_ms": {
-                            "description": "Min delay between calls (default: calculated from limit/period)",
-                            "type": "integer",
-                            "minimum": 100
-                        },
-                        "period_ms": {
-                            "description": "Period in milliseconds",
-                            "type": "integer",
-                            "minimum": 1000
-                        }
+                    "$ref": "#/definitions/internal_api_handlers.RateLimitStats"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api_handlers.CountSummary": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.CreateDatasetRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_yourusername_datacollector_internal_models.JobCreateRequest": {
+        "internal_api_handlers.DataStats": {
             "type": "object",
-            "required": [
-                "connector_exchange_id",
-                "symbol",
-                "timeframe"
-            ],
             "properties": {
-                "collect_historical": {
-                    "type": "boolean"
+                "newest_data": {
+                    "type": "string"
                 },
-                "connector_exchange_id": {
+                "oldest_data": {
                     "type": "string"
                 },
-                "depends_on": {
-                    "description": "Job IDs (as strings) that must complete first",
+                "total_candles": {
+                    "type": "integer"
+                },
+                "total_chunks": {
+                    "type": "integer"
+                },
+                "unique_exchanges": {
+                    "description": "1 for a single connector with data",
+                    "type": "integer"
+                },
+                "unique_symbols": {
+                    "type": "integer"
+                },
+                "unique_timeframes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.DiffProfilesRequest": {
+            "type": "object",
+            "properties": {
+                "config_a": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "config_b": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "profile_a": {
+                    "type": "string"
+                },
+                "profile_b": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api_handlers.ExportResponse": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "column_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "current_phase": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "errors": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "feature_count": {
+                    "type": "integer"
+                },
+                "file_size_bytes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "output_path": {
+                    "type": "string"
+                },
+                "processed_records": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "number"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "row_count": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
                 "status": {
-                    "type": "string",
-                    "enum": [
-                        "active",
-                        "paused"
-                    ]
+                    "type": "string"
                 },
-                "symbol": {
+                "total_records": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ExportWarning"
+                    }
+                }
+            }
+        },
+        "internal_api_handlers.HealthCounts": {
+            "type": "object",
+            "properties": {
+                "degraded": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unhealthy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.HealthHistory": {
+            "type": "object",
+            "properties": {
+                "bucket_seconds": {
+                    "type": "integer"
+                },
+                "exchange_id": {
                     "type": "string"
                 },
-                "timeframe": {
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.HealthHistoryPoint"
+                    }
+                }
+            }
+        },
+        "internal_api_handlers.HealthHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_api_handlers.HealthHistory"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.JobExecutionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.JobExecutionResult"
+                },
+                "success": {
+                    "description": "Whether the execution succeeded",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.JobRunsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.JobRun"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.ProbeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_api_handlers.ProbeResult"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.ProbeResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "exchange_id": {
+                    "type": "string"
+                },
+                "health": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ConnectorHealth"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "probed_at": {
+                    "type": "string"
+                },
+                "reachable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.RateLimitStats": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "min_delay_ms": {
+                    "type": "integer"
+                },
+                "period_ms": {
+                    "type": "integer"
+                },
+                "usage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api_handlers.ScheduleReconcileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.ScheduleReconcileResult"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_api_handlers.StartExportRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.MLExportConfig"
+                },
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_api_handlers.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "connectors": {
+                    "$ref": "#/definitions/internal_api_handlers.CountSummary"
+                },
+                "data": {
+                    "description": "Absent when no OHLCV data is stored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_api_handlers.DataStats"
+                        }
+                    ]
+                },
+                "jobs": {
+                    "$ref": "#/definitions/internal_api_handlers.CountSummary"
+                }
+            }
+        },
+        "internal_api_handlers.SymbolJobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_yourusername_datacollector_internal_models.SymbolJobsResult"
+                },
+                "message": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         }
@@ -1044,7 +5401,7 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "1.0.5",
+	Version:          "1.1.0",
 	Host:             "localhost:3000",
 	BasePath:         "/api/v1",
 	Schemes:          []string{"http", "https"},
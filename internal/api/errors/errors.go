@@ -23,12 +23,14 @@ const (
 	ErrCodeInternal         ErrorCode = "INTERNAL_ERROR"
 	ErrCodeDatabaseError    ErrorCode = "DATABASE_ERROR"
 	ErrCodeExchangeError    ErrorCode = "EXCHANGE_ERROR"
+	ErrCodeExchangeUnavailable ErrorCode = "EXCHANGE_UNAVAILABLE"
 	ErrCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 
 	// Business logic errors
 	ErrCodeJobLocked        ErrorCode = "JOB_LOCKED"
 	ErrCodeConnectorInactive ErrorCode = "CONNECTOR_INACTIVE"
 	ErrCodeSymbolInvalid    ErrorCode = "SYMBOL_INVALID"
+	ErrCodeTimeframeInvalid ErrorCode = "TIMEFRAME_INVALID"
 	ErrCodeNoData           ErrorCode = "NO_DATA"
 )
 
@@ -118,6 +120,11 @@ func ExchangeError(message string) *APIError {
 	return NewAPIError(ErrCodeExchangeError, message, fiber.StatusBadGateway)
 }
 
+// ExchangeUnavailable creates a 503 error for an exchange that can't be reached
+func ExchangeUnavailable(exchange string) *APIError {
+	return NewAPIError(ErrCodeExchangeUnavailable, fmt.Sprintf("Exchange %s is unavailable", exchange), fiber.StatusServiceUnavailable)
+}
+
 // ServiceUnavailable creates a 503 Service Unavailable error
 func ServiceUnavailable(message string) *APIError {
 	return NewAPIError(ErrCodeServiceUnavailable, message, fiber.StatusServiceUnavailable)
@@ -140,6 +147,15 @@ func SymbolInvalid(symbol, exchange string) *APIError {
 	return NewAPIError(ErrCodeSymbolInvalid, fmt.Sprintf("Symbol %s is not valid on exchange %s", symbol, exchange), fiber.StatusBadRequest)
 }
 
+// TimeframeInvalid creates an invalid timeframe error listing the supported timeframes
+func TimeframeInvalid(timeframe, exchange string, supported []string) *APIError {
+	return NewAPIError(ErrCodeTimeframeInvalid, fmt.Sprintf("Timeframe %s is not supported by exchange %s", timeframe, exchange), fiber.StatusBadRequest).
+		WithDetails(map[string]interface{}{
+			"timeframe":            timeframe,
+			"supported_timeframes": supported,
+		})
+}
+
 // NoData creates a no data error
 func NoData(resource string) *APIError {
 	return NewAPIError(ErrCodeNoData, fmt.Sprintf("No data available for %s", resource), fiber.StatusNotFound)
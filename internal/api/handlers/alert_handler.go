@@ -31,7 +31,7 @@ func NewAlertHandler(alertRepo *repository.AlertRepository, alertService *servic
 // GetAlerts retrieves all alerts with optional filters
 // GET /api/v1/alerts
 func (h *AlertHandler) GetAlerts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	filter := bson.M{}
@@ -77,7 +77,7 @@ func (h *AlertHandler) GetAlerts(c *fiber.Ctx) error {
 // GetActiveAlerts retrieves all active alerts
 // GET /api/v1/alerts/active
 func (h *AlertHandler) GetActiveAlerts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	alerts, err := h.alertRepo.FindActive(ctx)
@@ -95,7 +95,7 @@ func (h *AlertHandler) GetActiveAlerts(c *fiber.Ctx) error {
 // GetAlertSummary retrieves alert summary statistics
 // GET /api/v1/alerts/summary
 func (h *AlertHandler) GetAlertSummary(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	summary, err := h.alertRepo.GetSummary(ctx)
@@ -112,7 +112,7 @@ func (h *AlertHandler) GetAlertSummary(c *fiber.Ctx) error {
 // GetAlert retrieves a single alert by ID
 // GET /api/v1/alerts/:id
 func (h *AlertHandler) GetAlert(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -134,7 +134,7 @@ func (h *AlertHandler) GetAlert(c *fiber.Ctx) error {
 // GetAlertsByJob retrieves all alerts for a specific job
 // GET /api/v1/jobs/:id/alerts
 func (h *AlertHandler) GetAlertsByJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -154,7 +154,7 @@ func (h *AlertHandler) GetAlertsByJob(c *fiber.Ctx) error {
 // GetAlertsByConnector retrieves all alerts for a specific connector/exchange
 // GET /api/v1/connectors/:exchangeId/alerts
 func (h *AlertHandler) GetAlertsByConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	exchangeID := c.Params("exchangeId")
@@ -175,7 +175,7 @@ func (h *AlertHandler) GetAlertsByConnector(c *fiber.Ctx) error {
 // AcknowledgeAlert acknowledges an alert
 // POST /api/v1/alerts/:id/acknowledge
 func (h *AlertHandler) AcknowledgeAlert(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -214,7 +214,7 @@ func (h *AlertHandler) AcknowledgeAlert(c *fiber.Ctx) error {
 // ResolveAlert resolves an alert
 // POST /api/v1/alerts/:id/resolve
 func (h *AlertHandler) ResolveAlert(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -241,7 +241,7 @@ func (h *AlertHandler) ResolveAlert(c *fiber.Ctx) error {
 // DeleteAlert deletes an alert
 // DELETE /api/v1/alerts/:id
 func (h *AlertHandler) DeleteAlert(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -262,7 +262,7 @@ func (h *AlertHandler) DeleteAlert(c *fiber.Ctx) error {
 // AcknowledgeAllAlerts acknowledges all active alerts
 // POST /api/v1/alerts/acknowledge-all
 func (h *AlertHandler) AcknowledgeAllAlerts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	var req struct {
@@ -301,7 +301,7 @@ func (h *AlertHandler) AcknowledgeAllAlerts(c *fiber.Ctx) error {
 // GetAlertConfig retrieves the alert configuration
 // GET /api/v1/alerts/config
 func (h *AlertHandler) GetAlertConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	config, err := h.alertRepo.GetConfig(ctx)
@@ -318,7 +318,7 @@ func (h *AlertHandler) GetAlertConfig(c *fiber.Ctx) error {
 // UpdateAlertConfig updates the alert configuration
 // PUT /api/v1/alerts/config
 func (h *AlertHandler) UpdateAlertConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var config models.AlertConfig
@@ -347,7 +347,7 @@ func (h *AlertHandler) UpdateAlertConfig(c *fiber.Ctx) error {
 // TriggerAlertCheck manually triggers an alert check
 // POST /api/v1/alerts/check
 func (h *AlertHandler) TriggerAlertCheck(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	if err := h.alertService.CheckJobsForAlerts(ctx); err != nil {
@@ -363,7 +363,7 @@ func (h *AlertHandler) TriggerAlertCheck(c *fiber.Ctx) error {
 // CleanupAlerts removes old resolved alerts
 // POST /api/v1/alerts/cleanup
 func (h *AlertHandler) CleanupAlerts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	// Default to 7 days
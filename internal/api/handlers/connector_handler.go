@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -9,9 +10,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/yourusername/datacollector/internal/api/errors"
+	"github.com/yourusername/datacollector/internal/api/pagination"
 	"github.com/yourusername/datacollector/internal/config"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
+	"github.com/yourusername/datacollector/internal/service"
 )
 
 // ConnectorHandler handles connector-related endpoints
@@ -19,6 +22,8 @@ type ConnectorHandler struct {
 	repo     *repository.ConnectorRepository
 	jobRepo  *repository.JobRepository
 	ohlcvRepo *repository.OHLCVRepository
+	ccxtService *service.CCXTService
+	configRepo  *repository.IndicatorConfigRepository
 	config   *config.Config
 }
 
@@ -31,13 +36,17 @@ func NewConnectorHandler(repo *repository.ConnectorRepository, jobRepo *reposito
 	}
 }
 
-// NewConnectorHandlerWithOHLCV creates a new connector handler with OHLCV repository
-func NewConnectorHandlerWithOHLCV(repo *repository.ConnectorRepository, jobRepo *repository.JobRepository, ohlcvRepo *repository.OHLCVRepository, cfg *config.Config) *ConnectorHandler {
+// NewConnectorHandlerWithOHLCV creates a new connector handler with OHLCV
+// repository, the CCXT service used for on-demand health probes and the
+// indicator config repository used to validate connector configs
+func NewConnectorHandlerWithOHLCV(repo *repository.ConnectorRepository, jobRepo *repository.JobRepository, ohlcvRepo *repository.OHLCVRepository, ccxtService *service.CCXTService, configRepo *repository.IndicatorConfigRepository, cfg *config.Config) *ConnectorHandler {
 	return &ConnectorHandler{
-		repo:      repo,
-		jobRepo:   jobRepo,
-		ohlcvRepo: ohlcvRepo,
-		config:    cfg,
+		repo:        repo,
+		jobRepo:     jobRepo,
+		ohlcvRepo:   ohlcvRepo,
+		ccxtService: ccxtService,
+		configRepo:  configRepo,
+		config:      cfg,
 	}
 }
 
@@ -53,7 +62,7 @@ func NewConnectorHandlerWithOHLCV(repo *repository.ConnectorRepository, jobRepo
 // @Failure 409 {object} map[string]interface{} "Connector already exists"
 // @Router /connectors [post]
 func (h *ConnectorHandler) CreateConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var req models.ConnectorCreateRequest
@@ -76,6 +85,20 @@ func (h *ConnectorHandler) CreateConnector(c *fiber.Ctx) error {
 		}))
 	}
 
+	if req.RequestTimeoutMs != 0 && req.RequestTimeoutMs < 1000 {
+		return errors.SendError(c, errors.ValidationError("Invalid request timeout", map[string]interface{}{
+			"request_timeout_ms": "must be >= 1000",
+		}))
+	}
+
+	if req.IndicatorConfigID != "" {
+		if _, err := h.configRepo.FindByID(ctx, req.IndicatorConfigID); err != nil {
+			return errors.SendError(c, errors.ValidationError("Indicator config not found", map[string]interface{}{
+				"indicator_config_id": req.IndicatorConfigID,
+			}))
+		}
+	}
+
 	// Calculate MinDelayMs if not provided
 	minDelayMs := req.RateLimit.MinDelayMs
 	if minDelayMs == 0 {
@@ -103,6 +126,8 @@ func (h *ConnectorHandler) CreateConnector(c *fiber.Ctx) error {
 			Usage:       0,
 			PeriodStart: time.Now(),
 		},
+		RequestTimeoutMs:  req.RequestTimeoutMs,
+		IndicatorConfigID: req.IndicatorConfigID,
 	}
 
 	// Create in database
@@ -126,10 +151,12 @@ func (h *ConnectorHandler) CreateConnector(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param status query string false "Filter by status (active, suspended)"
+// @Param limit query int false "Page size" default(10000)
+// @Param offset query int false "Number of connectors to skip" default(0)
 // @Success 200 {object} map[string]interface{} "List of connectors"
 // @Router /connectors [get]
 func (h *ConnectorHandler) GetConnectors(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	// Optional filter by status
@@ -138,7 +165,9 @@ func (h *ConnectorHandler) GetConnectors(c *fiber.Ctx) error {
 		filter["status"] = status
 	}
 
-	connectors, err := h.repo.FindAll(ctx, filter)
+	page := pagination.FromQuery(c)
+
+	connectors, total, err := h.repo.FindAllPaginated(ctx, filter, page.Offset, page.Limit)
 	if err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to retrieve connectors"))
 	}
@@ -158,16 +187,17 @@ func (h *ConnectorHandler) GetConnectors(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    responses,
-		"count":   len(responses),
+		"success":    true,
+		"data":       responses,
+		"count":      len(responses),
+		"pagination": pagination.NewMeta(page, total),
 	})
 }
 
 // GetConnector retrieves a connector by ID
 // GET /api/v1/connectors/:id
 func (h *ConnectorHandler) GetConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -198,7 +228,7 @@ func (h *ConnectorHandler) GetConnector(c *fiber.Ctx) error {
 // UpdateConnector updates a connector
 // PUT /api/v1/connectors/:id
 func (h *ConnectorHandler) UpdateConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -237,6 +267,26 @@ func (h *ConnectorHandler) UpdateConnector(c *fiber.Ctx) error {
 		}
 	}
 
+	if req.RequestTimeoutMs != nil {
+		if *req.RequestTimeoutMs != 0 && *req.RequestTimeoutMs < 1000 {
+			return errors.SendError(c, errors.ValidationError("Invalid request timeout", map[string]interface{}{
+				"request_timeout_ms": "must be 0 (default) or >= 1000",
+			}))
+		}
+		update["request_timeout_ms"] = *req.RequestTimeoutMs
+	}
+
+	if req.IndicatorConfigID != nil {
+		if *req.IndicatorConfigID != "" {
+			if _, err := h.configRepo.FindByID(ctx, *req.IndicatorConfigID); err != nil {
+				return errors.SendError(c, errors.ValidationError("Indicator config not found", map[string]interface{}{
+					"indicator_config_id": *req.IndicatorConfigID,
+				}))
+			}
+		}
+		update["indicator_config_id"] = *req.IndicatorConfigID
+	}
+
 	if len(update) == 0 {
 		return errors.SendError(c, errors.BadRequest("No fields to update"))
 	}
@@ -264,7 +314,7 @@ func (h *ConnectorHandler) UpdateConnector(c *fiber.Ctx) error {
 // DeleteConnector deletes a connector
 // DELETE /api/v1/connectors/:id
 func (h *ConnectorHandler) DeleteConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -285,7 +335,7 @@ func (h *ConnectorHandler) DeleteConnector(c *fiber.Ctx) error {
 // SuspendConnector suspends a connector and all its jobs
 // POST /api/v1/connectors/:id/suspend
 func (h *ConnectorHandler) SuspendConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -306,7 +356,7 @@ func (h *ConnectorHandler) SuspendConnector(c *fiber.Ctx) error {
 	}
 
 	// Suspend all jobs attached to this connector
-	if err := h.jobRepo.UpdateStatusByConnector(ctx, connector.ExchangeID, "paused"); err != nil {
+	if _, err := h.jobRepo.UpdateStatusByConnector(ctx, connector.ExchangeID, "paused"); err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to suspend attached jobs"))
 	}
 
@@ -331,7 +381,7 @@ func (h *ConnectorHandler) SuspendConnector(c *fiber.Ctx) error {
 // ResumeConnector resumes a suspended connector and all its jobs
 // POST /api/v1/connectors/:id/resume
 func (h *ConnectorHandler) ResumeConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -352,7 +402,7 @@ func (h *ConnectorHandler) ResumeConnector(c *fiber.Ctx) error {
 	}
 
 	// Resume all jobs attached to this connector
-	if err := h.jobRepo.UpdateStatusByConnector(ctx, connector.ExchangeID, "active"); err != nil {
+	if _, err := h.jobRepo.UpdateStatusByConnector(ctx, connector.ExchangeID, "active"); err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to resume attached jobs"))
 	}
 
@@ -377,7 +427,7 @@ func (h *ConnectorHandler) ResumeConnector(c *fiber.Ctx) error {
 // GetRateLimitStatus returns the current rate limit status for a connector
 // GET /api/v1/connectors/:id/rate-limit
 func (h *ConnectorHandler) GetRateLimitStatus(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -435,7 +485,7 @@ func (h *ConnectorHandler) GetRateLimitStatus(c *fiber.Ctx) error {
 // ResetRateLimitUsage resets the rate limit usage counter for a connector
 // POST /api/v1/connectors/:id/rate-limit/reset
 func (h *ConnectorHandler) ResetRateLimitUsage(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -460,10 +510,87 @@ func (h *ConnectorHandler) ResetRateLimitUsage(c *fiber.Ctx) error {
 	})
 }
 
+// PauseConnectorJobs pauses all jobs of a connector while leaving the connector
+// active, so manual executions keep working
+// @Summary Pause a connector's jobs
+// @Description Pauses all jobs of the connector without suspending it, so manual executions keep working
+// @Tags Connectors
+// @Produce json
+// @Param id path string true "Connector ID"
+// @Success 200 {object} ConnectorJobsStatusResponse "Jobs paused"
+// @Failure 404 {object} map[string]interface{} "Connector not found"
+// @Router /connectors/{id}/jobs/pause [post]
+func (h *ConnectorHandler) PauseConnectorJobs(c *fiber.Ctx) error {
+	return h.setConnectorJobsStatus(c, "paused")
+}
+
+// ResumeConnectorJobs resumes all jobs of a connector without changing the
+// connector's status
+// @Summary Resume a connector's jobs
+// @Description Resumes all jobs of the connector without changing the connector's status
+// @Tags Connectors
+// @Produce json
+// @Param id path string true "Connector ID"
+// @Success 200 {object} ConnectorJobsStatusResponse "Jobs resumed"
+// @Failure 404 {object} map[string]interface{} "Connector not found"
+// @Router /connectors/{id}/jobs/resume [post]
+func (h *ConnectorHandler) ResumeConnectorJobs(c *fiber.Ctx) error {
+	return h.setConnectorJobsStatus(c, "active")
+}
+
+// setConnectorJobsStatus sets the status of all jobs of the connector and
+// reports how many changed
+func (h *ConnectorHandler) setConnectorJobsStatus(c *fiber.Ctx, status string) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	connector, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid connector ID format"))
+		}
+		return errors.SendError(c, errors.NotFound("Connector"))
+	}
+
+	affected, err := h.jobRepo.UpdateStatusByConnector(ctx, connector.ExchangeID, status)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to update attached jobs"))
+	}
+
+	jobCount, _ := h.jobRepo.CountByConnector(ctx, connector.ExchangeID)
+	activeJobCount, _ := h.jobRepo.CountActiveByConnector(ctx, connector.ExchangeID)
+
+	action := "paused"
+	if status == "active" {
+		action = "resumed"
+	}
+
+	return c.JSON(ConnectorJobsStatusResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d job(s) %s, connector status unchanged", affected, action),
+		Data: ConnectorJobsStatus{
+			ExchangeID:      connector.ExchangeID,
+			ConnectorStatus: connector.Status,
+			AffectedJobs:    affected,
+			JobCount:        jobCount,
+			ActiveJobCount:  activeJobCount,
+		},
+	})
+}
+
 // GetConnectorStats returns statistics for a connector including data volume
-// GET /api/v1/connectors/:id/stats
+// @Summary Get connector statistics
+// @Description Returns job counts, rate limit usage and stored data volume of a connector
+// @Tags Connectors
+// @Produce json
+// @Param id path string true "Connector ID"
+// @Success 200 {object} ConnectorStatsResponse "Connector statistics"
+// @Failure 404 {object} map[string]interface{} "Connector not found"
+// @Router /connectors/{id}/stats [get]
 func (h *ConnectorHandler) GetConnectorStats(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -501,41 +628,34 @@ func (h *ConnectorHandler) GetConnectorStats(c *fiber.Ctx) error {
 		}
 	}
 
-	response := fiber.Map{
-		"connector_id":     connector.ID.Hex(),
-		"exchange_id":      connector.ExchangeID,
-		"display_name":     connector.DisplayName,
-		"status":           connector.Status,
-		"job_count":        jobCount,
-		"active_job_count": activeJobCount,
-		"failed_jobs":      failedJobs,
-		"last_run_time":    lastRunTime,
-		"rate_limit": fiber.Map{
-			"limit":        connector.RateLimit.Limit,
-			"usage":        connector.RateLimit.Usage,
-			"period_ms":    connector.RateLimit.PeriodMs,
-			"min_delay_ms": connector.RateLimit.MinDelayMs,
+	return c.JSON(ConnectorStatsResponse{
+		ConnectorID:    connector.ID.Hex(),
+		ExchangeID:     connector.ExchangeID,
+		DisplayName:    connector.DisplayName,
+		Status:         connector.Status,
+		JobCount:       jobCount,
+		ActiveJobCount: activeJobCount,
+		FailedJobs:     failedJobs,
+		LastRunTime:    lastRunTime,
+		RateLimit: RateLimitStats{
+			Limit:      connector.RateLimit.Limit,
+			Usage:      connector.RateLimit.Usage,
+			PeriodMs:   connector.RateLimit.PeriodMs,
+			MinDelayMs: connector.RateLimit.MinDelayMs,
 		},
-	}
-
-	if ohlcvStats != nil {
-		response["data_stats"] = fiber.Map{
-			"total_candles":     ohlcvStats.TotalCandles,
-			"total_chunks":      ohlcvStats.TotalChunks,
-			"unique_symbols":    ohlcvStats.UniqueSymbols,
-			"unique_timeframes": ohlcvStats.UniqueTimeframes,
-			"oldest_data":       ohlcvStats.OldestData,
-			"newest_data":       ohlcvStats.NewestData,
-		}
-	}
-
-	return c.JSON(response)
+		DataStats: newDataStats(ohlcvStats),
+	})
 }
 
 // GetAllStats returns aggregate statistics across all connectors
-// GET /api/v1/stats
+// @Summary Get global statistics
+// @Description Returns connector and job counts and the volume of stored OHLCV data across all connectors
+// @Tags Stats
+// @Produce json
+// @Success 200 {object} StatsResponse "Global statistics"
+// @Router /stats [get]
 func (h *ConnectorHandler) GetAllStats(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	// Get connector stats
@@ -544,7 +664,7 @@ func (h *ConnectorHandler) GetAllStats(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.DatabaseError("Failed to retrieve connector statistics"))
 	}
 
-	activeConnectors := 0
+	activeConnectors := int64(0)
 	for _, conn := range connectors {
 		if conn.Status == "active" {
 			activeConnectors++
@@ -567,30 +687,11 @@ func (h *ConnectorHandler) GetAllStats(c *fiber.Ctx) error {
 		ohlcvStats, _ = h.ohlcvRepo.GetAllStats(ctx)
 	}
 
-	response := fiber.Map{
-		"connectors": fiber.Map{
-			"total":  len(connectors),
-			"active": activeConnectors,
-		},
-		"jobs": fiber.Map{
-			"total":  totalJobs,
-			"active": activeJobs,
-		},
-	}
-
-	if ohlcvStats != nil {
-		response["data"] = fiber.Map{
-			"total_candles":     ohlcvStats.TotalCandles,
-			"total_chunks":      ohlcvStats.TotalChunks,
-			"unique_exchanges":  ohlcvStats.UniqueExchanges,
-			"unique_symbols":    ohlcvStats.UniqueSymbols,
-			"unique_timeframes": ohlcvStats.UniqueTimeframes,
-			"oldest_data":       ohlcvStats.OldestData,
-			"newest_data":       ohlcvStats.NewestData,
-		}
-	}
-
-	return c.JSON(response)
+	return c.JSON(StatsResponse{
+		Connectors: CountSummary{Total: int64(len(connectors)), Active: activeConnectors},
+		Jobs:       CountSummary{Total: totalJobs, Active: activeJobs},
+		Data:       newDataStats(ohlcvStats),
+	})
 }
 
 // GetConnectorHealth returns health status for a specific connector
@@ -600,11 +701,11 @@ func (h *ConnectorHandler) GetAllStats(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Connector ID"
-// @Success 200 {object} map[string]interface{} "Health status"
+// @Success 200 {object} ConnectorHealthResponse "Health status"
 // @Failure 404 {object} map[string]interface{} "Connector not found"
 // @Router /connectors/{id}/health [get]
 func (h *ConnectorHandler) GetConnectorHealth(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -629,37 +730,167 @@ func (h *ConnectorHandler) GetConnectorHealth(c *fiber.Ctx) error {
 		healthDescription = "Multiple consecutive failures detected"
 	}
 
+	// Circuit breaker state (closed unless failures have opened it)
+	circuitState := connector.Health.CircuitState
+	if circuitState == "" {
+		circuitState = models.CircuitClosed
+	}
+	switch circuitState {
+	case models.CircuitOpen:
+		healthDescription = "Circuit breaker open, job executions paused until cooldown ends"
+	case models.CircuitHalfOpen:
+		healthDescription = "Circuit breaker half-open, probing exchange"
+	}
+
 	// Calculate error rate
 	errorRate := float64(0)
 	if connector.Health.TotalCalls > 0 {
 		errorRate = (float64(connector.Health.TotalFailures) / float64(connector.Health.TotalCalls)) * 100
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data": fiber.Map{
-			"exchange_id":           connector.ExchangeID,
-			"display_name":          connector.DisplayName,
-			"connector_status":      connector.Status,
-			"health":                connector.Health,
-			"health_description":    healthDescription,
-			"error_rate_percentage": errorRate,
-			"job_count":             jobCount,
-			"active_job_count":      activeJobCount,
+	requestTimeoutMs := connector.RequestTimeoutMs
+	if requestTimeoutMs == 0 {
+		requestTimeoutMs = h.config.Exchange.RequestTimeout
+	}
+
+	return c.JSON(ConnectorHealthResponse{
+		Success: true,
+		Data: ConnectorHealthReport{
+			ExchangeID:          connector.ExchangeID,
+			DisplayName:         connector.DisplayName,
+			ConnectorStatus:     connector.Status,
+			Health:              connector.Health,
+			HealthDescription:   healthDescription,
+			ErrorRatePercentage: errorRate,
+			JobCount:            jobCount,
+			ActiveJobCount:      activeJobCount,
+			RequestTimeoutMs:    requestTimeoutMs,
+			CircuitBreaker: CircuitBreakerStatus{
+				State:           circuitState,
+				OpenUntil:       connector.Health.CircuitOpenUntil,
+				Threshold:       h.config.Exchange.CircuitBreakerThreshold,
+				CooldownSeconds: h.config.Exchange.CircuitBreakerCooldownSeconds,
+			},
 		},
 	})
 }
 
+// GetConnectorHealthHistory returns hourly call, error rate and latency history for a connector
+// @Summary Get connector health history
+// @Description Returns one point per hour for the last 24 hours with calls, failures, error rate and average latency. Hours without calls are zero.
+// @Tags Connectors
+// @Accept json
+// @Produce json
+// @Param id path string true "Connector ID"
+// @Success 200 {object} HealthHistoryResponse "Health history"
+// @Failure 404 {object} map[string]interface{} "Connector not found"
+// @Router /connectors/{id}/health/history [get]
+func (h *ConnectorHandler) GetConnectorHealthHistory(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	connector, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid connector ID format"))
+		}
+		return errors.SendError(c, errors.NotFound("Connector"))
+	}
+
+	return c.JSON(HealthHistoryResponse{
+		Success: true,
+		Data: HealthHistory{
+			ExchangeID:    connector.ExchangeID,
+			BucketSeconds: int(time.Hour.Seconds()),
+			Points:        models.HealthHistorySeries(connector.Health.History, time.Now(), models.HealthHistoryHours),
+		},
+	})
+}
+
+// ProbeConnectorHealth actively checks that a connector's exchange is reachable
+// @Summary Probe connector health
+// @Description Performs a lightweight call to the exchange (server time, or markets when unsupported) through the rate limiter and records the result and latency in the connector health. A successful probe also closes an open circuit breaker.
+// @Tags Connectors
+// @Accept json
+// @Produce json
+// @Param id path string true "Connector ID"
+// @Success 200 {object} ProbeResponse "Probe result"
+// @Failure 404 {object} map[string]interface{} "Connector not found"
+// @Router /connectors/{id}/health/probe [post]
+func (h *ConnectorHandler) ProbeConnectorHealth(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	connector, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid connector ID format"))
+		}
+		return errors.SendError(c, errors.NotFound("Connector"))
+	}
+
+	requestTimeoutMs := connector.RequestTimeoutMs
+	if requestTimeoutMs == 0 {
+		requestTimeoutMs = h.config.Exchange.RequestTimeout
+	}
+
+	latency, probeErr := h.ccxtService.ProbeExchange(ctx, connector.ExchangeID, requestTimeoutMs)
+	if probeErr != nil && ctx.Err() != nil {
+		// Timed out waiting for a rate limit slot, the exchange was never called
+		return errors.SendError(c, errors.InternalError("Probe timed out waiting for rate limit"))
+	}
+
+	if probeErr != nil {
+		if err := h.repo.RecordFailedCall(ctx, connector.ExchangeID, probeErr.Error()); err != nil {
+			return errors.SendError(c, errors.DatabaseError("Failed to record probe result"))
+		}
+	} else {
+		if err := h.repo.RecordSuccessfulCall(ctx, connector.ExchangeID, latency.Milliseconds()); err != nil {
+			return errors.SendError(c, errors.DatabaseError("Failed to record probe result"))
+		}
+		if connector.Health.IsCircuitOpen() {
+			if err := h.repo.CloseCircuit(ctx, connector.ExchangeID); err != nil {
+				return errors.SendError(c, errors.DatabaseError("Failed to close circuit breaker"))
+			}
+		}
+	}
+
+	health, err := h.repo.GetHealthStatus(ctx, connector.ExchangeID)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to fetch connector health"))
+	}
+
+	result := ProbeResult{
+		ExchangeID: connector.ExchangeID,
+		Reachable:  probeErr == nil,
+		LatencyMs:  latency.Milliseconds(),
+		ProbedAt:   time.Now(),
+		Health:     health,
+	}
+	if probeErr != nil {
+		result.Error = probeErr.Error()
+	}
+
+	return c.JSON(ProbeResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
 // GetAllConnectorsHealth returns health status for all connectors
 // @Summary Get all connectors health status
 // @Description Returns health metrics and status for all connectors
 // @Tags Connectors
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]interface{} "Health status for all connectors"
+// @Success 200 {object} AllConnectorsHealthResponse "Health status for all connectors"
 // @Router /connectors/health [get]
 func (h *ConnectorHandler) GetAllConnectorsHealth(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	connectors, err := h.repo.FindAll(ctx, bson.M{})
@@ -668,10 +899,8 @@ func (h *ConnectorHandler) GetAllConnectorsHealth(c *fiber.Ctx) error {
 	}
 
 	// Build health report for each connector
-	healthReports := make([]fiber.Map, 0, len(connectors))
-	healthySummary := 0
-	degradedSummary := 0
-	unhealthySummary := 0
+	healthReports := make([]ConnectorHealthSummary, 0, len(connectors))
+	summary := HealthCounts{Total: len(connectors)}
 
 	for _, conn := range connectors {
 		// Calculate error rate
@@ -688,45 +917,40 @@ func (h *ConnectorHandler) GetAllConnectorsHealth(c *fiber.Ctx) error {
 
 		switch healthStatus {
 		case "healthy":
-			healthySummary++
+			summary.Healthy++
 		case "degraded":
-			degradedSummary++
+			summary.Degraded++
 		case "unhealthy":
-			unhealthySummary++
+			summary.Unhealthy++
 		}
 
 		// Get job counts
 		jobCount, _ := h.jobRepo.CountByConnector(ctx, conn.ExchangeID)
 		activeJobCount, _ := h.jobRepo.CountActiveByConnector(ctx, conn.ExchangeID)
 
-		healthReports = append(healthReports, fiber.Map{
-			"exchange_id":           conn.ExchangeID,
-			"display_name":          conn.DisplayName,
-			"connector_status":      conn.Status,
-			"health_status":         healthStatus,
-			"total_calls":           conn.Health.TotalCalls,
-			"total_failures":        conn.Health.TotalFailures,
-			"consecutive_failures":  conn.Health.ConsecutiveFailures,
-			"error_rate_percentage": errorRate,
-			"uptime_percentage":     conn.Health.UptimePercentage,
-			"average_response_ms":   conn.Health.AverageResponseMs,
-			"last_successful_call":  conn.Health.LastSuccessfulCall,
-			"last_failed_call":      conn.Health.LastFailedCall,
-			"last_error":            conn.Health.LastError,
-			"job_count":             jobCount,
-			"active_job_count":      activeJobCount,
+		healthReports = append(healthReports, ConnectorHealthSummary{
+			ExchangeID:          conn.ExchangeID,
+			DisplayName:         conn.DisplayName,
+			ConnectorStatus:     conn.Status,
+			HealthStatus:        healthStatus,
+			TotalCalls:          conn.Health.TotalCalls,
+			TotalFailures:       conn.Health.TotalFailures,
+			ConsecutiveFailures: conn.Health.ConsecutiveFailures,
+			ErrorRatePercentage: errorRate,
+			UptimePercentage:    conn.Health.UptimePercentage,
+			AverageResponseMs:   conn.Health.AverageResponseMs,
+			LastSuccessfulCall:  conn.Health.LastSuccessfulCall,
+			LastFailedCall:      conn.Health.LastFailedCall,
+			LastError:           conn.Health.LastError,
+			JobCount:            jobCount,
+			ActiveJobCount:      activeJobCount,
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"summary": fiber.Map{
-			"total":     len(connectors),
-			"healthy":   healthySummary,
-			"degraded":  degradedSummary,
-			"unhealthy": unhealthySummary,
-		},
-		"connectors": healthReports,
+	return c.JSON(AllConnectorsHealthResponse{
+		Success:    true,
+		Summary:    summary,
+		Connectors: healthReports,
 	})
 }
 
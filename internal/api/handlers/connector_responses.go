@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// CountSummary counts all and active items of one kind
+type CountSummary struct {
+	Total  int64 `json:"total"`
+	Active int64 `json:"active"`
+}
+
+// DataStats summarizes the stored OHLCV data
+type DataStats struct {
+	TotalCandles     int64     `json:"total_candles"`
+	TotalChunks      int       `json:"total_chunks"`
+	UniqueExchanges  int       `json:"unique_exchanges"` // 1 for a single connector with data
+	UniqueSymbols    int       `json:"unique_symbols"`
+	UniqueTimeframes int       `json:"unique_timeframes"`
+	OldestData       time.Time `json:"oldest_data"`
+	NewestData       time.Time `json:"newest_data"`
+}
+
+// newDataStats converts OHLCV stats for a response, nil when there are none
+func newDataStats(stats *models.OHLCVStats) *DataStats {
+	if stats == nil {
+		return nil
+	}
+	return &DataStats{
+		TotalCandles:     stats.TotalCandles,
+		TotalChunks:      stats.TotalChunks,
+		UniqueExchanges:  stats.UniqueExchanges,
+		UniqueSymbols:    stats.UniqueSymbols,
+		UniqueTimeframes: stats.UniqueTimeframes,
+		OldestData:       stats.OldestData,
+		NewestData:       stats.NewestData,
+	}
+}
+
+// StatsResponse is the response of GET /stats
+type StatsResponse struct {
+	Connectors CountSummary `json:"connectors"`
+	Jobs       CountSummary `json:"jobs"`
+	Data       *DataStats   `json:"data,omitempty"` // Absent when no OHLCV data is stored
+}
+
+// RateLimitStats is the rate limit configuration and usage of a connector
+type RateLimitStats struct {
+	Limit      int `json:"limit"`
+	Usage      int `json:"usage"`
+	PeriodMs   int `json:"period_ms"`
+	MinDelayMs int `json:"min_delay_ms"`
+}
+
+// ConnectorStatsResponse is the response of GET /connectors/:id/stats
+type ConnectorStatsResponse struct {
+	ConnectorID    string         `json:"connector_id"`
+	ExchangeID     string         `json:"exchange_id"`
+	DisplayName    string         `json:"display_name"`
+	Status         string         `json:"status"`
+	JobCount       int64          `json:"job_count"`
+	ActiveJobCount int64          `json:"active_job_count"`
+	FailedJobs     int            `json:"failed_jobs"` // Jobs whose last run recorded an error
+	LastRunTime    *time.Time     `json:"last_run_time"`
+	RateLimit      RateLimitStats `json:"rate_limit"`
+	DataStats      *DataStats     `json:"data_stats,omitempty"`
+}
+
+// CircuitBreakerStatus is the circuit breaker state of a connector with the
+// configured thresholds
+type CircuitBreakerStatus struct {
+	State           string     `json:"state"` // "closed", "open" or "half_open"
+	OpenUntil       *time.Time `json:"open_until"`
+	Threshold       int        `json:"threshold"`
+	CooldownSeconds int        `json:"cooldown_seconds"`
+}
+
+// ConnectorHealthReport is the health of a single connector
+type ConnectorHealthReport struct {
+	ExchangeID          string                 `json:"exchange_id"`
+	DisplayName         string                 `json:"display_name"`
+	ConnectorStatus     string                 `json:"connector_status"`
+	Health              models.ConnectorHealth `json:"health"`
+	HealthDescription   string                 `json:"health_description"`
+	ErrorRatePercentage float64                `json:"error_rate_percentage"`
+	JobCount            int64                  `json:"job_count"`
+	ActiveJobCount      int64                  `json:"active_job_count"`
+	RequestTimeoutMs    int                    `json:"request_timeout_ms"`
+	CircuitBreaker      CircuitBreakerStatus   `json:"circuit_breaker"`
+}
+
+// ConnectorHealthResponse is the response of GET /connectors/:id/health
+type ConnectorHealthResponse struct {
+	Success bool                  `json:"success"`
+	Data    ConnectorHealthReport `json:"data"`
+}
+
+// HealthHistory is the hourly health history of a connector
+type HealthHistory struct {
+	ExchangeID    string                      `json:"exchange_id"`
+	BucketSeconds int                         `json:"bucket_seconds"`
+	Points        []models.HealthHistoryPoint `json:"points"`
+}
+
+// HealthHistoryResponse is the response of GET /connectors/:id/health/history
+type HealthHistoryResponse struct {
+	Success bool          `json:"success"`
+	Data    HealthHistory `json:"data"`
+}
+
+// ProbeResult is the outcome of probing a connector's exchange
+type ProbeResult struct {
+	ExchangeID string                  `json:"exchange_id"`
+	Reachable  bool                    `json:"reachable"`
+	LatencyMs  int64                   `json:"latency_ms"`
+	ProbedAt   time.Time               `json:"probed_at"`
+	Health     *models.ConnectorHealth `json:"health"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// ProbeResponse is the response of POST /connectors/:id/health/probe
+type ProbeResponse struct {
+	Success bool        `json:"success"`
+	Data    ProbeResult `json:"data"`
+}
+
+// ConnectorHealthSummary is one connector's entry in GET /connectors/health
+type ConnectorHealthSummary struct {
+	ExchangeID          string     `json:"exchange_id"`
+	DisplayName         string     `json:"display_name"`
+	ConnectorStatus     string     `json:"connector_status"`
+	HealthStatus        string     `json:"health_status"`
+	TotalCalls          int64      `json:"total_calls"`
+	TotalFailures       int64      `json:"total_failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	ErrorRatePercentage float64    `json:"error_rate_percentage"`
+	UptimePercentage    float64    `json:"uptime_percentage"`
+	AverageResponseMs   float64    `json:"average_response_ms"`
+	LastSuccessfulCall  *time.Time `json:"last_successful_call"`
+	LastFailedCall      *time.Time `json:"last_failed_call"`
+	LastError           string     `json:"last_error"`
+	JobCount            int64      `json:"job_count"`
+	ActiveJobCount      int64      `json:"active_job_count"`
+}
+
+// HealthCounts counts connectors by health status
+type HealthCounts struct {
+	Total     int `json:"total"`
+	Healthy   int `json:"healthy"`
+	Degraded  int `json:"degraded"`
+	Unhealthy int `json:"unhealthy"`
+}
+
+// AllConnectorsHealthResponse is the response of GET /connectors/health
+type AllConnectorsHealthResponse struct {
+	Success    bool                     `json:"success"`
+	Summary    HealthCounts             `json:"summary"`
+	Connectors []ConnectorHealthSummary `json:"connectors"`
+}
+
+// ConnectorJobsStatus is the outcome of pausing or resuming a connector's jobs
+type ConnectorJobsStatus struct {
+	ExchangeID      string `json:"exchange_id"`
+	ConnectorStatus string `json:"connector_status"`
+	AffectedJobs    int64  `json:"affected_jobs"`
+	JobCount        int64  `json:"job_count"`
+	ActiveJobCount  int64  `json:"active_job_count"`
+}
+
+// ConnectorJobsStatusResponse is the response of POST /connectors/:id/jobs/pause
+// and /connectors/:id/jobs/resume
+type ConnectorJobsStatusResponse struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Data    ConnectorJobsStatus `json:"data"`
+}
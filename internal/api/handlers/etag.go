@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// versionETag builds an ETag for a response whose content only changes when
+// its latest update time or its number of items changes
+func versionETag(updatedAt time.Time, count int) string {
+	return fmt.Sprintf("\"%x-%x\"", count, updatedAt.UnixNano())
+}
+
+// notModified sets the ETag header and reports whether the request's
+// If-None-Match already matches it, in which case the caller should reply 304
+func notModified(c *fiber.Ctx, etag string) bool {
+	c.Set(fiber.HeaderETag, etag)
+
+	match := c.Get(fiber.HeaderIfNoneMatch)
+	if match == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(match, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
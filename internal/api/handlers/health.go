@@ -2,26 +2,31 @@ package handlers
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/yourusername/datacollector/internal/exchange"
 	"github.com/yourusername/datacollector/internal/repository"
+	"github.com/yourusername/datacollector/internal/service"
 )
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	db *repository.Database
+	db            *repository.Database
+	exportService *service.MLExportService
 }
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler(db *repository.Database) *HealthHandler {
-	return &HealthHandler{db: db}
+func NewHealthHandler(db *repository.Database, exportService *service.MLExportService) *HealthHandler {
+	return &HealthHandler{db: db, exportService: exportService}
 }
 
 // GetHealth returns the health status of the application
 // @Summary Get API health status
-// @Description Returns the health status of the API and connected services
+// @Description Returns the health status of the API and connected services. Status is degraded when ML exports can't be written and warning when the export disk is low; only an unhealthy database returns 503.
 // @Tags Health
 // @Accept json
 // @Produce json
@@ -29,7 +34,7 @@ func NewHealthHandler(db *repository.Database) *HealthHandler {
 // @Failure 503 {object} map[string]interface{} "Service unavailable"
 // @Router /health [get]
 func (h *HealthHandler) GetHealth(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	// Check database health
@@ -40,14 +45,26 @@ func (h *HealthHandler) GetHealth(c *fiber.Ctx) error {
 		dbError = err.Error()
 	}
 
+	// Check that ML exports can be written (the API still works without them)
+	exportHealth := h.exportService.CheckHealth()
+
+	status := "ok"
+	switch exportHealth.Status {
+	case "unavailable":
+		status = "degraded"
+	case "warning":
+		status = "warning"
+	}
+
 	response := fiber.Map{
-		"status":    "ok",
+		"status":    status,
 		"timestamp": time.Now().Unix(),
 		"services": fiber.Map{
 			"database": fiber.Map{
 				"status": dbStatus,
 				"error":  dbError,
 			},
+			"ml_export": exportHealth,
 		},
 	}
 
@@ -146,25 +163,49 @@ func (h *HealthHandler) GetExchangeMetadata(c *fiber.Ctx) error {
 	return c.JSON(metadata)
 }
 
-// RefreshExchangeCache clears and refreshes the exchange metadata cache
+// GetExchangeCapabilities returns what a specific exchange supports
+// @Summary Get exchange capabilities
+// @Description Returns a normalized report of what an exchange supports, from CCXT's has and timeframes maps: market types, timeframes, and for each job type whether the CCXT method it collects with is native, emulated or unsupported
+// @Tags Exchanges
+// @Produce json
+// @Param id path string true "Exchange ID"
+// @Success 200 {object} exchange.ExchangeCapabilities "Exchange capabilities"
+// @Failure 404 {object} map[string]interface{} "Exchange not found"
+// @Router /exchanges/{id}/capabilities [get]
+func (h *HealthHandler) GetExchangeCapabilities(c *fiber.Ctx) error {
+	exchangeID := c.Params("id")
+
+	capabilities, err := exchange.GetExchangeCapabilities(exchangeID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(capabilities)
+}
+
+// RefreshExchangeCache rediscovers supported exchanges and refreshes the cached exchange metadata
 // @Summary Refresh exchange cache
-// @Description Clears and refreshes the exchange metadata cache
+// @Description Rediscovers supported exchanges and refreshes cached metadata; exchanges whose refresh fails keep their previous metadata
 // @Tags Exchanges
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Cache refreshed"
 // @Router /exchanges/refresh [post]
 func (h *HealthHandler) RefreshExchangeCache(c *fiber.Ctx) error {
-	// Clear the cache
-	exchange.ClearCache()
-
 	// Trigger rediscovery
+	exchange.ClearSupportedCache()
 	exchanges := exchange.GetSupportedExchanges()
 
+	refreshed, failed := exchange.RefreshCachedMetadata()
+
 	return c.JSON(fiber.Map{
-		"success":   true,
-		"message":   "Exchange cache refreshed",
-		"exchanges": len(exchanges),
+		"success":            true,
+		"message":            "Exchange cache refreshed",
+		"exchanges":          len(exchanges),
+		"metadata_refreshed": refreshed,
+		"metadata_failed":    failed,
 	})
 }
 
@@ -297,9 +338,18 @@ func (h *HealthHandler) ValidateSymbol(c *fiber.Ctx) error {
 	})
 }
 
+// symbolValidation is the validation result of one symbol
+type symbolValidation struct {
+	Symbol     string `json:"symbol"`
+	Valid      bool   `json:"valid"`  // The exchange lists the symbol
+	Active     bool   `json:"active"` // The symbol's market is trading
+	Reason     string `json:"reason,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"` // Closest listed symbol, for unknown symbols
+}
+
 // ValidateSymbols validates multiple symbols at once
 // @Summary Validate multiple symbols
-// @Description Validates multiple trading symbols at once on a specific exchange
+// @Description Validates multiple trading symbols at once on a specific exchange. Besides the valid/invalid map, details reports per symbol whether its market is active, why it can't be imported and the closest listed symbol for typos.
 // @Tags Exchanges
 // @Accept json
 // @Produce json
@@ -328,40 +378,54 @@ func (h *HealthHandler) ValidateSymbols(c *fiber.Ctx) error {
 		})
 	}
 
-	symbols, err := exchange.GetExchangeSymbols(exchangeID)
+	markets, err := exchange.GetExchangeMarketStatus(exchangeID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	// Create a set for faster lookup
-	symbolSet := make(map[string]bool, len(symbols))
-	for _, s := range symbols {
-		symbolSet[s] = true
+	symbols := make([]string, 0, len(markets))
+	for s := range markets {
+		symbols = append(symbols, s)
 	}
+	sort.Strings(symbols)
 
 	// Validate each symbol
 	results := make(map[string]bool)
+	details := make([]symbolValidation, 0, len(request.Symbols))
 	validCount := 0
 	invalidCount := 0
+	inactiveCount := 0
 
 	for _, sym := range request.Symbols {
-		isValid := symbolSet[sym]
+		active, isValid := markets[sym]
 		results[sym] = isValid
-		if isValid {
-			validCount++
-		} else {
+
+		detail := symbolValidation{Symbol: sym, Valid: isValid, Active: isValid && active}
+		switch {
+		case !isValid:
 			invalidCount++
+			detail.Reason = "unknown symbol"
+			detail.Suggestion = closestSymbol(sym, symbols)
+		case !active:
+			validCount++
+			inactiveCount++
+			detail.Reason = "market inactive"
+		default:
+			validCount++
 		}
+		details = append(details, detail)
 	}
 
 	return c.JSON(fiber.Map{
-		"exchange_id":   exchangeID,
-		"results":       results,
-		"valid_count":   validCount,
-		"invalid_count": invalidCount,
-		"total":         len(request.Symbols),
+		"exchange_id":    exchangeID,
+		"results":        results,
+		"details":        details,
+		"valid_count":    validCount,
+		"invalid_count":  invalidCount,
+		"inactive_count": inactiveCount,
+		"total":          len(request.Symbols),
 	})
 }
 
@@ -418,6 +482,61 @@ func (h *HealthHandler) GetPopularSymbols(c *fiber.Ctx) error {
 	})
 }
 
+// closestSymbol returns the listed symbol closest to symbol, ignoring case and
+// separators ("btcusdt" matches "BTC/USDT"), or "" when none is close enough
+// to be a likely typo
+func closestSymbol(symbol string, symbols []string) string {
+	target := normalizeSymbol(symbol)
+	if target == "" {
+		return ""
+	}
+
+	// Allow roughly one edit per three characters
+	best, bestDistance := "", len(target)/3+1
+	for _, s := range symbols {
+		distance := editDistance(target, normalizeSymbol(s))
+		if distance < bestDistance {
+			best, bestDistance = s, distance
+			if distance == 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// normalizeSymbol upper-cases a symbol and drops the base/quote separators
+func normalizeSymbol(symbol string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '-', '_', ' ':
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, symbol)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
 // splitSymbol splits a trading pair symbol into base and quote currencies
 func splitSymbol(symbol string) []string {
 	var parts []string
@@ -28,7 +28,7 @@ func NewIndicatorConfigHandler(configRepo *repository.IndicatorConfigRepository)
 // GetConfigs retrieves all indicator configurations
 // GET /api/v1/indicators/configs
 func (h *IndicatorConfigHandler) GetConfigs(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	configs, err := h.configRepo.FindAll(ctx)
@@ -46,7 +46,7 @@ func (h *IndicatorConfigHandler) GetConfigs(c *fiber.Ctx) error {
 // GetConfig retrieves a single indicator configuration by ID
 // GET /api/v1/indicators/configs/:id
 func (h *IndicatorConfigHandler) GetConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -68,7 +68,7 @@ func (h *IndicatorConfigHandler) GetConfig(c *fiber.Ctx) error {
 // GetDefaultConfig retrieves the default indicator configuration
 // GET /api/v1/indicators/configs/default
 func (h *IndicatorConfigHandler) GetDefaultConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	config, err := h.configRepo.FindDefault(ctx)
@@ -85,7 +85,7 @@ func (h *IndicatorConfigHandler) GetDefaultConfig(c *fiber.Ctx) error {
 // CreateConfig creates a new indicator configuration
 // POST /api/v1/indicators/configs
 func (h *IndicatorConfigHandler) CreateConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var req models.IndicatorConfigCreateRequest
@@ -147,7 +147,7 @@ func (h *IndicatorConfigHandler) CreateConfig(c *fiber.Ctx) error {
 // UpdateConfig updates an indicator configuration
 // PUT /api/v1/indicators/configs/:id
 func (h *IndicatorConfigHandler) UpdateConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -243,7 +243,7 @@ func (h *IndicatorConfigHandler) UpdateConfig(c *fiber.Ctx) error {
 // DeleteConfig deletes an indicator configuration
 // DELETE /api/v1/indicators/configs/:id
 func (h *IndicatorConfigHandler) DeleteConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -264,7 +264,7 @@ func (h *IndicatorConfigHandler) DeleteConfig(c *fiber.Ctx) error {
 // SetDefaultConfig sets a configuration as the default
 // POST /api/v1/indicators/configs/:id/default
 func (h *IndicatorConfigHandler) SetDefaultConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/yourusername/datacollector/internal/models"
@@ -12,23 +13,26 @@ import (
 
 // IndicatorHandler handles indicator-related HTTP requests
 type IndicatorHandler struct {
-	ohlcvRepo     *repository.OHLCVRepository
-	recalcService *service.RecalculatorService
+	ohlcvRepo           *repository.OHLCVRepository
+	indicatorConfigRepo *repository.IndicatorConfigRepository
+	recalcService       *service.RecalculatorService
 }
 
 // NewIndicatorHandler creates a new indicator handler
 func NewIndicatorHandler(
 	ohlcvRepo *repository.OHLCVRepository,
+	indicatorConfigRepo *repository.IndicatorConfigRepository,
 	recalcService *service.RecalculatorService,
 ) *IndicatorHandler {
 	return &IndicatorHandler{
-		ohlcvRepo:     ohlcvRepo,
-		recalcService: recalcService,
+		ohlcvRepo:           ohlcvRepo,
+		indicatorConfigRepo: indicatorConfigRepo,
+		recalcService:       recalcService,
 	}
 }
 
 // GetLatestIndicators retrieves the latest candle with indicators
-// GET /api/v1/indicators/:exchange/:timeframe/latest?symbol=ETH/USDT
+// GET /api/v1/indicators/:exchange/:timeframe/latest?symbol=ETH/USDT[&debug=true]
 func (h *IndicatorHandler) GetLatestIndicators(c *fiber.Ctx) error {
 	exchangeID := c.Params("exchange")
 	timeframe := c.Params("timeframe")
@@ -39,7 +43,7 @@ func (h *IndicatorHandler) GetLatestIndicators(c *fiber.Ctx) error {
 	}
 
 	// Fetch OHLCV document
-	doc, err := h.ohlcvRepo.FindByJob(c.Context(), exchangeID, symbol, timeframe)
+	doc, source, err := h.ohlcvRepo.FindByJobWithSource(c.Context(), exchangeID, symbol, timeframe)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -51,7 +55,7 @@ func (h *IndicatorHandler) GetLatestIndicators(c *fiber.Ctx) error {
 	// Return the latest candle (index 0)
 	latestCandle := doc.Candles[0]
 
-	return c.JSON(fiber.Map{
+	return c.JSON(withStorageDebug(c, fiber.Map{
 		"exchange":  exchangeID,
 		"symbol":    symbol,
 		"timeframe": timeframe,
@@ -64,11 +68,11 @@ func (h *IndicatorHandler) GetLatestIndicators(c *fiber.Ctx) error {
 			"volume": latestCandle.Volume,
 		},
 		"indicators": latestCandle.Indicators,
-	})
+	}, source))
 }
 
 // GetIndicatorRange retrieves indicators for a range of candles
-// GET /api/v1/indicators/:exchange/:timeframe/range?symbol=ETH/USDT&limit=100&offset=0
+// GET /api/v1/indicators/:exchange/:timeframe/range?symbol=ETH/USDT&limit=100&offset=0[&debug=true]
 func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 	exchangeID := c.Params("exchange")
 	timeframe := c.Params("timeframe")
@@ -95,7 +99,7 @@ func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 	}
 
 	// Fetch OHLCV document
-	doc, err := h.ohlcvRepo.FindByJob(c.Context(), exchangeID, symbol, timeframe)
+	doc, source, err := h.ohlcvRepo.FindByJobWithSource(c.Context(), exchangeID, symbol, timeframe)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -109,7 +113,7 @@ func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 	totalCandles := len(candles)
 
 	if offset >= totalCandles {
-		return c.JSON(fiber.Map{
+		return c.JSON(withStorageDebug(c, fiber.Map{
 			"exchange":      exchangeID,
 			"symbol":        symbol,
 			"timeframe":     timeframe,
@@ -117,7 +121,7 @@ func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 			"offset":        offset,
 			"limit":         limit,
 			"candles":       []models.Candle{},
-		})
+		}, source))
 	}
 
 	end := offset + limit
@@ -127,7 +131,7 @@ func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 
 	selectedCandles := candles[offset:end]
 
-	return c.JSON(fiber.Map{
+	return c.JSON(withStorageDebug(c, fiber.Map{
 		"exchange":      exchangeID,
 		"symbol":        symbol,
 		"timeframe":     timeframe,
@@ -136,11 +140,11 @@ func (h *IndicatorHandler) GetIndicatorRange(c *fiber.Ctx) error {
 		"limit":         limit,
 		"returned":      len(selectedCandles),
 		"candles":       selectedCandles,
-	})
+	}, source))
 }
 
 // GetSpecificIndicator retrieves history of a specific indicator
-// GET /api/v1/indicators/:exchange/:timeframe/:indicator?symbol=ETH/USDT&limit=100
+// GET /api/v1/indicators/:exchange/:timeframe/:indicator?symbol=ETH/USDT&limit=100[&debug=true]
 func (h *IndicatorHandler) GetSpecificIndicator(c *fiber.Ctx) error {
 	exchangeID := c.Params("exchange")
 	timeframe := c.Params("timeframe")
@@ -159,7 +163,7 @@ func (h *IndicatorHandler) GetSpecificIndicator(c *fiber.Ctx) error {
 	}
 
 	// Fetch OHLCV document
-	doc, err := h.ohlcvRepo.FindByJob(c.Context(), exchangeID, symbol, timeframe)
+	doc, source, err := h.ohlcvRepo.FindByJobWithSource(c.Context(), exchangeID, symbol, timeframe)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -187,14 +191,14 @@ func (h *IndicatorHandler) GetSpecificIndicator(c *fiber.Ctx) error {
 		}
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(withStorageDebug(c, fiber.Map{
 		"exchange":    exchangeID,
 		"symbol":      symbol,
 		"timeframe":   timeframe,
 		"indicator":   indicatorName,
 		"data_points": len(result),
 		"data":        result,
-	})
+	}, source))
 }
 
 // RecalculateJob triggers recalculation for a specific job
@@ -217,6 +221,153 @@ func (h *IndicatorHandler) RecalculateJob(c *fiber.Ctx) error {
 	})
 }
 
+// RecalculateJobIndicator recomputes a single indicator for a job, optionally with new parameters
+// POST /api/v1/jobs/:id/indicators/:indicator/recalculate
+// Body (optional): {"params": {"rsi_periods": [14]}}
+func (h *IndicatorHandler) RecalculateJobIndicator(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	name := c.Params("indicator")
+
+	spec, ok := models.FindIndicatorSpec(name)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Unknown indicator '%s'", name),
+			"valid": models.IndicatorNames(),
+		})
+	}
+
+	var req struct {
+		Params map[string]interface{} `json:"params"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	job, baseConfig, err := h.recalcService.IndicatorConfigForJob(c.Context(), jobID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Job not found",
+			"message": err.Error(),
+		})
+	}
+
+	config, err := models.SingleIndicatorConfig(baseConfig, spec, req.Params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid parameters",
+			"message": err.Error(),
+			"params":  spec.Params,
+		})
+	}
+
+	// Same limits as GET /indicators/configs/validation-rules
+	if result := config.Validate(); !result.Valid {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":  "Invalid parameters",
+			"errors": result.Errors,
+		})
+	}
+
+	updated, err := h.recalcService.RecalculateIndicator(c.Context(), job, spec, config)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Recalculation failed",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":         true,
+		"message":         fmt.Sprintf("%s recalculated successfully", spec.Name),
+		"job_id":          jobID,
+		"indicator":       spec.Name,
+		"fields":          spec.Fields,
+		"config":          config.Name,
+		"candles_updated": updated,
+	})
+}
+
+// PreviewJobIndicators computes indicators over a job's most recent candles
+// with a given config, without storing them
+// POST /api/v1/jobs/:id/indicators/preview
+// Body: {"config_id": "..."} or {"config": {...}}, plus optional "limit" (default 50, max 1000).
+// Without a config, the job's own indicator config is used.
+func (h *IndicatorHandler) PreviewJobIndicators(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+
+	var req struct {
+		ConfigID string                  `json:"config_id"`
+		Config   *models.IndicatorConfig `json:"config"`
+		Limit    int                     `json:"limit"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+	if req.Limit < 1 || req.Limit > 1000 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "limit must be between 1 and 1000"})
+	}
+	if req.ConfigID != "" && req.Config != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Provide either config_id or config, not both"})
+	}
+
+	job, config, err := h.recalcService.IndicatorConfigForJob(c.Context(), jobID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Job not found",
+			"message": err.Error(),
+		})
+	}
+
+	switch {
+	case req.ConfigID != "":
+		config, err = h.indicatorConfigRepo.FindByID(c.Context(), req.ConfigID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Indicator config not found",
+				"message": err.Error(),
+			})
+		}
+	case req.Config != nil:
+		config = req.Config
+		if config.Name == "" {
+			config.Name = "preview"
+		}
+		if result := config.Validate(); !result.Valid {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":  "Invalid indicator config",
+				"errors": result.Errors,
+			})
+		}
+	}
+
+	candles, err := h.recalcService.PreviewIndicators(c.Context(), job, config, req.Limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Preview failed",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"job_id":    jobID,
+		"symbol":    job.Symbol,
+		"timeframe": job.Timeframe,
+		"config":    config.Name,
+		"limit":     req.Limit,
+		"returned":  len(candles),
+		"candles":   candles,
+	})
+}
+
 // RecalculateConnector triggers recalculation for all jobs on a connector
 // POST /api/v1/connectors/:id/indicators/recalculate
 func (h *IndicatorHandler) RecalculateConnector(c *fiber.Ctx) error {
@@ -237,8 +388,94 @@ func (h *IndicatorHandler) RecalculateConnector(c *fiber.Ctx) error {
 	})
 }
 
+// RecalculateSeries recomputes indicators for a stored series, e.g. after importing raw OHLCV
+// POST /api/v1/indicators/:exchange/:timeframe/recalculate?symbol=BTC/USDT
+func (h *IndicatorHandler) RecalculateSeries(c *fiber.Ctx) error {
+	exchange := c.Params("exchange")
+	timeframe := c.Params("timeframe")
+	symbol := c.Query("symbol")
+
+	if symbol == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "symbol query parameter is required"})
+	}
+
+	candlesUpdated, err := h.recalcService.RecalculateSeries(c.Context(), exchange, symbol, timeframe)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Recalculation failed",
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":          true,
+		"message":          "Indicators recalculated successfully",
+		"exchange":         exchange,
+		"symbol":           symbol,
+		"timeframe":        timeframe,
+		"candles_updated":  candlesUpdated,
+	})
+}
+
+// GetCoverage reports which indicators are populated on each active job's latest
+// candle, flagging jobs that miss indicators enabled in their own config
+// GET /api/v1/indicators/coverage[?needs_recalculation=true]
+func (h *IndicatorHandler) GetCoverage(c *fiber.Ctx) error {
+	report, err := h.recalcService.CheckCoverage(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Coverage check failed",
+			"message": err.Error(),
+		})
+	}
+
+	if c.QueryBool("needs_recalculation", false) {
+		flagged := report.Jobs[:0]
+		for _, job := range report.Jobs {
+			if job.NeedsRecalculation {
+				flagged = append(flagged, job)
+			}
+		}
+		report.Jobs = flagged
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// GetCatalog lists the exportable indicators with their category, description,
+// period and the IndicatorConfig settings that control them
+// GET /api/v1/indicators/catalog[?category=trend][&search=average]
+func (h *IndicatorHandler) GetCatalog(c *fiber.Ctx) error {
+	category := strings.ToLower(c.Query("category"))
+	if category != "" && len(service.IndicatorCatalog(category, "")) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("category must be one of: %s", strings.Join(service.IndicatorCategories, ", ")),
+		})
+	}
+
+	entries := service.IndicatorCatalog(category, c.Query("search"))
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"count":      len(entries),
+		"categories": service.IndicatorCategories,
+		"data":       entries,
+	})
+}
+
 // Helper functions
 
+// withStorageDebug adds the storage backend that served the read when ?debug=true
+func withStorageDebug(c *fiber.Ctx, response fiber.Map, source *models.StorageSource) fiber.Map {
+	if c.QueryBool("debug", false) {
+		response["debug"] = fiber.Map{"storage": source}
+	}
+	return response
+}
+
 func extractIndicatorValue(indicators models.Indicators, name string) interface{} {
 	switch name {
 	// Trend indicators
@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"iter"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +18,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/yourusername/datacollector/internal/api/errors"
+	"github.com/yourusername/datacollector/internal/api/pagination"
+	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/exchange"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 	"github.com/yourusername/datacollector/internal/service"
@@ -20,24 +29,47 @@ import (
 // JobHandler handles job-related endpoints
 type JobHandler struct {
 	jobRepo       *repository.JobRepository
+	jobRunRepo    *repository.JobRunRepository
 	connectorRepo *repository.ConnectorRepository
 	ohlcvRepo     *repository.OHLCVRepository
+	configRepo    *repository.IndicatorConfigRepository
 	jobExecutor   *service.JobExecutor
+	jobScheduler  *service.JobScheduler
+	maxPageLimit  int
+	maxExportRows int
 }
 
+// Limits used when the server config leaves them unset
+const (
+	defaultMaxPageLimit  = 1000
+	defaultMaxExportRows = 1000000
+)
+
 // NewJobHandler creates a new job handler
-func NewJobHandler(jobRepo *repository.JobRepository, connectorRepo *repository.ConnectorRepository, ohlcvRepo *repository.OHLCVRepository, jobExecutor *service.JobExecutor) *JobHandler {
-	return &JobHandler{
+func NewJobHandler(jobRepo *repository.JobRepository, jobRunRepo *repository.JobRunRepository, connectorRepo *repository.ConnectorRepository, ohlcvRepo *repository.OHLCVRepository, configRepo *repository.IndicatorConfigRepository, jobExecutor *service.JobExecutor, jobScheduler *service.JobScheduler, cfg *config.Config) *JobHandler {
+	h := &JobHandler{
 		jobRepo:       jobRepo,
+		jobRunRepo:    jobRunRepo,
 		connectorRepo: connectorRepo,
 		ohlcvRepo:     ohlcvRepo,
+		configRepo:    configRepo,
 		jobExecutor:   jobExecutor,
+		jobScheduler:  jobScheduler,
+		maxPageLimit:  cfg.Server.MaxPageLimit,
+		maxExportRows: cfg.Server.MaxExportRows,
+	}
+	if h.maxPageLimit < 1 {
+		h.maxPageLimit = defaultMaxPageLimit
 	}
+	if h.maxExportRows < 1 {
+		h.maxExportRows = defaultMaxExportRows
+	}
+	return h
 }
 
 // CreateJob creates a new job
 // @Summary Create a new job
-// @Description Creates a new data collection job for a specific symbol and timeframe
+// @Description Creates a new data collection job for a specific symbol and timeframe. job_type selects OHLCV candles (default), public trades, order book snapshots, funding rates or open interest; for trades, orderbook and funding jobs the timeframe sets how often the job runs, and for open_interest jobs also the interval of the points. Jobs the exchange has no CCXT method for are rejected
 // @Tags Jobs
 // @Accept json
 // @Produce json
@@ -48,7 +80,7 @@ func NewJobHandler(jobRepo *repository.JobRepository, connectorRepo *repository.
 // @Failure 409 {object} map[string]interface{} "Job already exists"
 // @Router /jobs [post]
 func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var req models.JobCreateRequest
@@ -65,12 +97,47 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		}))
 	}
 
+	if apiErr := validateJobType(req.JobType, req.OrderBookDepth); apiErr != nil {
+		return errors.SendError(c, apiErr)
+	}
+
+	if req.Freshness != nil {
+		if err := req.Freshness.Validate(); err != nil {
+			return errors.SendError(c, errors.ValidationError("Invalid freshness thresholds", map[string]string{
+				"freshness": err.Error(),
+			}))
+		}
+	}
+
+	if req.FreshnessSLAMinutes < 0 {
+		return errors.SendError(c, errors.ValidationError("Invalid freshness SLA", map[string]string{
+			"freshness_sla_minutes": "must be >= 0",
+		}))
+	}
+
 	// Verify connector exists
 	_, err := h.connectorRepo.FindByExchangeID(ctx, req.ConnectorExchangeID)
 	if err != nil {
 		return errors.SendError(c, errors.NotFound("Connector"))
 	}
 
+	if apiErr := validateTimeframe(req.ConnectorExchangeID, req.Timeframe); apiErr != nil {
+		return errors.SendError(c, apiErr)
+	}
+
+	if apiErr := validateJobCapability(req.ConnectorExchangeID, req.JobType); apiErr != nil {
+		return errors.SendError(c, apiErr)
+	}
+
+	// Verify the indicator config override exists
+	if req.IndicatorConfigID != "" {
+		if _, err := h.configRepo.FindByID(ctx, req.IndicatorConfigID); err != nil {
+			return errors.SendError(c, errors.ValidationError("Indicator config not found", map[string]string{
+				"indicator_config_id": req.IndicatorConfigID,
+			}))
+		}
+	}
+
 	// Create job model
 	status := req.Status
 	if status == "" {
@@ -100,12 +167,19 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 	}
 
 	job := &models.Job{
-		ConnectorExchangeID: req.ConnectorExchangeID,
-		Symbol:              req.Symbol,
-		Timeframe:           req.Timeframe,
-		Status:              status,
-		CollectHistorical:   req.CollectHistorical,
-		DependsOn:           dependsOn,
+		ConnectorExchangeID:       req.ConnectorExchangeID,
+		Symbol:                    req.Symbol,
+		Timeframe:                 req.Timeframe,
+		JobType:                   req.JobType,
+		OrderBookDepth:            req.OrderBookDepth,
+		Status:                    status,
+		CollectHistorical:         req.CollectHistorical,
+		DependsOn:                 dependsOn,
+		Freshness:                 req.Freshness,
+		FreshnessSLAMinutes:       req.FreshnessSLAMinutes,
+		IndicatorConfigID:         req.IndicatorConfigID,
+		ExcludeUnclosedBar:        req.ExcludeUnclosedBar,
+		ComputeIndicatorsOnIngest: req.ComputeIndicatorsOnIngest,
 		Schedule: models.Schedule{
 			Mode: "timeframe",
 		},
@@ -117,7 +191,7 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 	// Create in database
 	if err := h.jobRepo.Create(ctx, job); err != nil {
 		if strings.Contains(err.Error(), "already exists") {
-			return errors.SendError(c, errors.Conflict("Job already exists for this exchange/symbol/timeframe combination"))
+			return errors.SendError(c, errors.Conflict("Job already exists for this exchange/symbol/timeframe/job type combination"))
 		}
 		return errors.SendError(c, errors.DatabaseError("Failed to create job"))
 	}
@@ -139,7 +213,7 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Router /jobs/batch [post]
 func (h *JobHandler) CreateJobsBatch(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	var req struct {
@@ -174,11 +248,26 @@ func (h *JobHandler) CreateJobsBatch(c *fiber.Ctx) error {
 			))
 		}
 
+		if apiErr := validateJobType(jobReq.JobType, jobReq.OrderBookDepth); apiErr != nil {
+			apiErr.Message = fmt.Sprintf("Job %d: %s", i+1, apiErr.Message)
+			return errors.SendError(c, apiErr)
+		}
+
 		// Verify connector exists
 		_, err := h.connectorRepo.FindByExchangeID(ctx, jobReq.ConnectorExchangeID)
 		if err != nil {
 			return errors.SendError(c, errors.NotFound(fmt.Sprintf("Job %d: Connector '%s'", i+1, jobReq.ConnectorExchangeID)))
 		}
+
+		if apiErr := validateTimeframe(jobReq.ConnectorExchangeID, jobReq.Timeframe); apiErr != nil {
+			apiErr.Message = fmt.Sprintf("Job %d: %s", i+1, apiErr.Message)
+			return errors.SendError(c, apiErr)
+		}
+
+		if apiErr := validateJobCapability(jobReq.ConnectorExchangeID, jobReq.JobType); apiErr != nil {
+			apiErr.Message = fmt.Sprintf("Job %d: %s", i+1, apiErr.Message)
+			return errors.SendError(c, apiErr)
+		}
 	}
 
 	// Create all jobs
@@ -198,6 +287,8 @@ func (h *JobHandler) CreateJobsBatch(c *fiber.Ctx) error {
 			ConnectorExchangeID: jobReq.ConnectorExchangeID,
 			Symbol:              jobReq.Symbol,
 			Timeframe:           jobReq.Timeframe,
+			JobType:             jobReq.JobType,
+			OrderBookDepth:      jobReq.OrderBookDepth,
 			Status:              status,
 			CollectHistorical:   jobReq.CollectHistorical,
 			Schedule: models.Schedule{
@@ -226,6 +317,60 @@ func (h *JobHandler) CreateJobsBatch(c *fiber.Ctx) error {
 	})
 }
 
+// validateTimeframe checks a job timeframe against the timeframes its exchange
+// supports. Exchanges whose metadata can't be loaded are not checked, so an
+// unknown exchange doesn't block job creation.
+func validateTimeframe(exchangeID, timeframe string) *errors.APIError {
+	supported, err := exchange.SupportedTimeframes(exchangeID)
+	if err != nil || len(supported) == 0 {
+		return nil
+	}
+	for _, tf := range supported {
+		if tf == timeframe {
+			return nil
+		}
+	}
+	return errors.TimeframeInvalid(timeframe, exchangeID, supported)
+}
+
+// validateJobCapability checks that an exchange supports the CCXT method a
+// non-OHLCV job type collects with. Like validateTimeframe, exchanges whose
+// capabilities can't be loaded are not checked.
+func validateJobCapability(exchangeID, jobType string) *errors.APIError {
+	if jobType == "" || jobType == models.JobTypeOHLCV {
+		return nil
+	}
+	supported, err := exchange.SupportsJobType(exchangeID, jobType)
+	if err != nil || supported {
+		return nil
+	}
+	return errors.ValidationError(fmt.Sprintf("Exchange %s does not support %s jobs", exchangeID, jobType), map[string]string{
+		"job_type": fmt.Sprintf("%s does not implement %s", exchangeID, exchange.JobCapability(jobType)),
+	})
+}
+
+// validateJobType checks a job type and that an orderbook depth is only set
+// on orderbook jobs. An empty job type creates an OHLCV job.
+func validateJobType(jobType string, orderBookDepth int) *errors.APIError {
+	if jobType != "" && !models.IsValidJobType(jobType) {
+		return errors.ValidationError("Invalid job type", map[string]string{
+			"job_type": fmt.Sprintf("must be one of %s, %s, %s, %s, %s", models.JobTypeOHLCV, models.JobTypeTrades,
+				models.JobTypeOrderBook, models.JobTypeFunding, models.JobTypeOpenInterest),
+		})
+	}
+	if orderBookDepth < 0 {
+		return errors.ValidationError("Invalid orderbook depth", map[string]string{
+			"orderbook_depth": "must be positive",
+		})
+	}
+	if orderBookDepth > 0 && jobType != models.JobTypeOrderBook {
+		return errors.ValidationError("Invalid orderbook depth", map[string]string{
+			"orderbook_depth": "only applies to orderbook jobs",
+		})
+	}
+	return nil
+}
+
 // GetJobs retrieves all jobs
 // @Summary Get all jobs
 // @Description Retrieves all data collection jobs with optional filtering
@@ -236,10 +381,14 @@ func (h *JobHandler) CreateJobsBatch(c *fiber.Ctx) error {
 // @Param exchange_id query string false "Filter by exchange ID"
 // @Param symbol query string false "Filter by symbol"
 // @Param timeframe query string false "Filter by timeframe"
+// @Param sort query string false "Sort field (created_at, next_run_time, last_run_time, symbol, status)" default(created_at)
+// @Param order query string false "Sort order (asc, desc); defaults to asc when sort is given"
+// @Param limit query int false "Page size" default(10000)
+// @Param offset query int false "Number of jobs to skip" default(0)
 // @Success 200 {object} map[string]interface{} "List of jobs"
 // @Router /jobs [get]
 func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	// Optional filters
@@ -261,15 +410,23 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 		filter["timeframe"] = timeframe
 	}
 
-	jobs, err := h.jobRepo.FindAll(ctx, filter)
+	sort, err := repository.JobSort(c.Query("sort"), strings.ToLower(c.Query("order")))
+	if err != nil {
+		return errors.SendError(c, errors.ValidationError(err.Error(), nil))
+	}
+
+	page := pagination.FromQuery(c)
+
+	jobs, total, err := h.jobRepo.FindAllPaginated(ctx, filter, sort, page.Offset, page.Limit)
 	if err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to retrieve jobs"))
 	}
 
 	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    jobs,
-		"count":   len(jobs),
+		"success":    true,
+		"data":       jobs,
+		"count":      len(jobs),
+		"pagination": pagination.NewMeta(page, total),
 	})
 }
 
@@ -285,7 +442,7 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Router /jobs/{id} [get]
 func (h *JobHandler) GetJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -306,7 +463,7 @@ func (h *JobHandler) GetJob(c *fiber.Ctx) error {
 // GetJobsByConnector retrieves all jobs for a connector
 // GET /api/v1/connectors/:exchangeId/jobs
 func (h *JobHandler) GetJobsByConnector(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	exchangeID := c.Params("exchangeId")
@@ -327,7 +484,7 @@ func (h *JobHandler) GetJobsByConnector(c *fiber.Ctx) error {
 // UpdateJob updates a job
 // PUT /api/v1/jobs/:id
 func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -390,6 +547,45 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 		update["depends_on"] = dependsOn
 	}
 
+	if req.Freshness != nil {
+		if err := req.Freshness.Validate(); err != nil {
+			return errors.SendError(c, errors.ValidationError("Invalid freshness thresholds", map[string]string{
+				"freshness": err.Error(),
+			}))
+		}
+		update["freshness"] = req.Freshness
+	} else if req.ClearFreshness {
+		update["freshness"] = nil
+	}
+
+	if req.FreshnessSLAMinutes != nil {
+		if *req.FreshnessSLAMinutes < 0 {
+			return errors.SendError(c, errors.ValidationError("Invalid freshness SLA", map[string]string{
+				"freshness_sla_minutes": "must be >= 0",
+			}))
+		}
+		update["freshness_sla_minutes"] = *req.FreshnessSLAMinutes
+	}
+
+	if req.IndicatorConfigID != nil {
+		if *req.IndicatorConfigID != "" {
+			if _, err := h.configRepo.FindByID(ctx, *req.IndicatorConfigID); err != nil {
+				return errors.SendError(c, errors.ValidationError("Indicator config not found", map[string]string{
+					"indicator_config_id": *req.IndicatorConfigID,
+				}))
+			}
+		}
+		update["indicator_config_id"] = *req.IndicatorConfigID
+	}
+
+	if req.ExcludeUnclosedBar != nil {
+		update["exclude_unclosed_bar"] = *req.ExcludeUnclosedBar
+	}
+
+	if req.ComputeIndicatorsOnIngest != nil {
+		update["compute_indicators_on_ingest"] = *req.ComputeIndicatorsOnIngest
+	}
+
 	if len(update) == 0 {
 		return errors.SendError(c, errors.BadRequest("No fields to update"))
 	}
@@ -417,7 +613,7 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 // PauseJob pauses a job
 // POST /api/v1/jobs/:id/pause
 func (h *JobHandler) PauseJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -444,7 +640,7 @@ func (h *JobHandler) PauseJob(c *fiber.Ctx) error {
 // ResumeJob resumes a paused job
 // POST /api/v1/jobs/:id/resume
 func (h *JobHandler) ResumeJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -490,7 +686,7 @@ func (h *JobHandler) ResumeJob(c *fiber.Ctx) error {
 // DeleteJob deletes a job
 // DELETE /api/v1/jobs/:id
 func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -505,23 +701,157 @@ func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.DatabaseError("Failed to delete job"))
 	}
 
+	// The job is gone, so its run history is no longer reachable
+	if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+		_ = h.jobRunRepo.DeleteByJob(ctx, objectID)
+	}
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
+// PauseSymbolJobs pauses all jobs of a symbol
+// @Summary Pause all jobs of a symbol
+// @Description Pauses every job collecting the symbol, on all exchanges or only on exchange_id
+// @Tags Jobs
+// @Produce json
+// @Param symbol query string true "Symbol (e.g., BTC/USDT)"
+// @Param exchange_id query string false "Only pause the symbol's jobs on this exchange"
+// @Success 200 {object} SymbolJobsResponse "Summary of affected jobs"
+// @Failure 400 {object} map[string]interface{} "Missing symbol"
+// @Router /jobs/symbol/pause [post]
+func (h *JobHandler) PauseSymbolJobs(c *fiber.Ctx) error {
+	return h.setSymbolJobsStatus(c, "pause", "paused")
+}
+
+// ResumeSymbolJobs resumes all jobs of a symbol
+// @Summary Resume all jobs of a symbol
+// @Description Resumes every job collecting the symbol, on all exchanges or only on exchange_id
+// @Tags Jobs
+// @Produce json
+// @Param symbol query string true "Symbol (e.g., BTC/USDT)"
+// @Param exchange_id query string false "Only resume the symbol's jobs on this exchange"
+// @Success 200 {object} SymbolJobsResponse "Summary of affected jobs"
+// @Failure 400 {object} map[string]interface{} "Missing symbol"
+// @Router /jobs/symbol/resume [post]
+func (h *JobHandler) ResumeSymbolJobs(c *fiber.Ctx) error {
+	return h.setSymbolJobsStatus(c, "resume", "active")
+}
+
+// DeleteSymbolJobs deletes all jobs of a symbol, e.g. once it is delisted
+// @Summary Delete all jobs of a symbol
+// @Description Deletes every job collecting the symbol, on all exchanges or only on exchange_id, along with their run history like DELETE /jobs/{id}. Collected data is kept.
+// @Tags Jobs
+// @Produce json
+// @Param symbol query string true "Symbol (e.g., BTC/USDT)"
+// @Param exchange_id query string false "Only delete the symbol's jobs on this exchange"
+// @Success 200 {object} SymbolJobsResponse "Summary of deleted jobs"
+// @Failure 400 {object} map[string]interface{} "Missing symbol"
+// @Router /jobs/symbol [delete]
+func (h *JobHandler) DeleteSymbolJobs(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	result, jobs, err := h.findSymbolJobs(ctx, c, "delete")
+	if err != nil {
+		return err
+	}
+
+	ids := make([]primitive.ObjectID, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+
+	result.Affected, err = h.jobRepo.DeleteByIDs(ctx, ids)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to delete jobs"))
+	}
+
+	// The jobs are gone, so their run history is no longer reachable
+	_ = h.jobRunRepo.DeleteByJobs(ctx, ids)
+
+	return c.JSON(SymbolJobsResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d job(s) deleted", result.Affected),
+		Data:    result,
+	})
+}
+
+// setSymbolJobsStatus sets the status of all jobs of the requested symbol
+func (h *JobHandler) setSymbolJobsStatus(c *fiber.Ctx, action, status string) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	result, _, err := h.findSymbolJobs(ctx, c, action)
+	if err != nil {
+		return err
+	}
+
+	result.Affected, err = h.jobRepo.UpdateStatusBySymbol(ctx, result.Symbol, result.ExchangeID, status)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to update jobs"))
+	}
+
+	return c.JSON(SymbolJobsResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d job(s) set to %s", result.Affected, status),
+		Data:    result,
+	})
+}
+
+// findSymbolJobs validates the symbol and exchange_id query parameters and
+// returns the matching jobs with a result summarizing them. On failure the
+// error response has already been sent and is returned.
+func (h *JobHandler) findSymbolJobs(ctx context.Context, c *fiber.Ctx, action string) (*models.SymbolJobsResult, []*models.Job, error) {
+	symbol := strings.TrimSpace(c.Query("symbol"))
+	if symbol == "" {
+		return nil, nil, errors.SendError(c, errors.ValidationError("Missing required fields", map[string]string{
+			"symbol": "required",
+		}))
+	}
+	exchangeID := strings.TrimSpace(c.Query("exchange_id"))
+
+	jobs, err := h.jobRepo.FindBySymbol(ctx, symbol, exchangeID)
+	if err != nil {
+		return nil, nil, errors.SendError(c, errors.DatabaseError("Failed to retrieve jobs"))
+	}
+
+	result := &models.SymbolJobsResult{
+		Symbol:     symbol,
+		ExchangeID: exchangeID,
+		Action:     action,
+		Matched:    len(jobs),
+		Jobs:       make([]models.SymbolJobSummary, len(jobs)),
+	}
+	for i, job := range jobs {
+		result.Jobs[i] = models.SymbolJobSummary{
+			ID:                  job.ID,
+			ConnectorExchangeID: job.ConnectorExchangeID,
+			JobType:             job.GetJobType(),
+			Timeframe:           job.Timeframe,
+			PreviousStatus:      job.Status,
+		}
+	}
+	return result, jobs, nil
+}
+
 // ExecuteJob executes a job manually
 // @Summary Execute a job manually
-// @Description Triggers immediate execution of a data collection job
+// @Description Triggers immediate execution of a data collection job. A run that fails calling the exchange returns an error whose code tells its cause, with the execution result as details: RATE_LIMITED (429, retry after the Retry-After seconds), SYMBOL_INVALID (400, fix the job), EXCHANGE_UNAVAILABLE (503, retry later) or EXCHANGE_ERROR (502).
 // @Tags Jobs
 // @Accept json
 // @Produce json
 // @Param id path string true "Job ID"
-// @Success 200 {object} map[string]interface{} "Execution result"
+// @Success 200 {object} JobExecutionResponse "Execution result"
+// @Failure 400 {object} map[string]interface{} "Symbol not found on the exchange"
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Failure 409 {object} map[string]interface{} "Job is locked"
+// @Failure 429 {object} map[string]interface{} "Rate limited by the exchange"
 // @Failure 500 {object} map[string]interface{} "Execution failed"
+// @Failure 502 {object} map[string]interface{} "Exchange call failed"
+// @Failure 503 {object} map[string]interface{} "Exchange unavailable"
 // @Router /jobs/{id}/execute [post]
 func (h *JobHandler) ExecuteJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 2*time.Minute)
 	defer cancel()
 
 	id := c.Params("id")
@@ -538,59 +868,314 @@ func (h *JobHandler) ExecuteJob(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.ExchangeError("Job execution failed: "+err.Error()))
 	}
 
+	// A failed exchange call is reported by its cause so clients know
+	// whether to retry or fix the job
+	if !result.Success && result.Err != nil {
+		return sendExchangeCallError(c, result)
+	}
+
 	// Return result with success flag
-	return c.JSON(fiber.Map{
-		"success": result.Success,
-		"data":    result,
+	return c.JSON(JobExecutionResponse{
+		Success: result.Success,
+		Data:    result,
 	})
 }
 
+// sendExchangeCallError sends the error of a run that failed calling the
+// exchange, with the execution result as details
+func sendExchangeCallError(c *fiber.Ctx, result *models.JobExecutionResult) error {
+	var callErr *service.ExchangeCallError
+	if !stderrors.As(result.Err, &callErr) {
+		return errors.SendError(c, errors.ExchangeError("Job execution failed: "+result.Err.Error()).WithDetails(result))
+	}
+
+	var apiErr *errors.APIError
+	switch {
+	case stderrors.Is(callErr, service.ErrRateLimited):
+		apiErr = errors.RateLimited(fmt.Sprintf("Rate limited by exchange %s", callErr.ExchangeID))
+		if wait := time.Until(result.NextRunTime); wait > 0 {
+			c.Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		}
+	case stderrors.Is(callErr, service.ErrSymbolNotFound):
+		apiErr = errors.SymbolInvalid(callErr.Symbol, callErr.ExchangeID)
+	case stderrors.Is(callErr, service.ErrExchangeUnavailable):
+		apiErr = errors.ExchangeUnavailable(callErr.ExchangeID)
+	default:
+		apiErr = errors.ExchangeError("Job execution failed: " + callErr.Error())
+	}
+	return errors.SendError(c, apiErr.WithDetails(result))
+}
+
 // GetQueue retrieves upcoming job executions
-// GET /api/v1/jobs/queue
+// GET /api/v1/jobs/queue?limit=N
 func (h *JobHandler) GetQueue(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
-	// Get all active jobs sorted by next run time
-	filter := bson.M{"status": "active"}
-	jobs, err := h.jobRepo.FindAll(ctx, filter)
+	limit := int64(c.QueryInt("limit", int(pagination.DefaultLimit)))
+	if limit < 1 || limit > pagination.MaxLimit {
+		limit = pagination.MaxLimit
+	}
+
+	// Next N due jobs, sorted by next run time in Mongo
+	queuedJobs, err := h.jobRepo.FindActiveOrderedByNextRun(ctx, limit)
 	if err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to retrieve job queue"))
 	}
 
-	// Sort jobs by next_run_time (ascending)
-	// Filter out jobs without next_run_time
-	queuedJobs := make([]*models.Job, 0)
-	for _, job := range jobs {
-		if job.RunState.NextRunTime != nil {
-			queuedJobs = append(queuedJobs, job)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    queuedJobs,
+		"count":   len(queuedJobs),
+	})
+}
+
+// ReconcileSchedule reschedules jobs whose next run time has drifted into the past
+// @Summary Reconcile overdue job schedules
+// @Description Reschedules active jobs whose next run time is more than one timeframe in the past, e.g. after the scheduler was down, spreading them evenly over spread_seconds so they don't all run at once
+// @Tags Jobs
+// @Produce json
+// @Param spread_seconds query int false "Window the overdue jobs are spread over (max 86400)" default(300)
+// @Success 200 {object} ScheduleReconcileResponse "Reconciliation result"
+// @Failure 400 {object} map[string]interface{} "Invalid spread"
+// @Router /jobs/reconcile-schedule [post]
+func (h *JobHandler) ReconcileSchedule(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	spreadSeconds := c.QueryInt("spread_seconds", int(service.DefaultReconcileSpread/time.Second))
+	if spreadSeconds < 1 || spreadSeconds > 86400 {
+		return errors.SendError(c, errors.ValidationError("Invalid spread", map[string]string{
+			"spread_seconds": "must be between 1 and 86400",
+		}))
+	}
+
+	result, err := h.jobScheduler.ReconcileSchedule(ctx, time.Duration(spreadSeconds)*time.Second)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to reconcile job schedule"))
+	}
+
+	return c.JSON(ScheduleReconcileResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetJobRuns retrieves the most recent executions of a job
+// @Summary Get job run history
+// @Description Returns the most recent executions of a job (newest first) with their outcome, duration and candles fetched
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param limit query int false "Maximum number of runs to return" default(50)
+// @Success 200 {object} JobRunsResponse "Run history"
+// @Failure 400 {object} map[string]interface{} "Invalid job ID"
+// @Failure 404 {object} map[string]interface{} "Job not found"
+// @Router /jobs/{id}/runs [get]
+func (h *JobHandler) GetJobRuns(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	if _, err := h.jobRepo.FindByID(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid job ID format"))
 		}
+		return errors.SendError(c, errors.NotFound("Job"))
 	}
 
-	// Sort by next run time
-	for i := 0; i < len(queuedJobs); i++ {
-		for j := i + 1; j < len(queuedJobs); j++ {
-			if queuedJobs[i].RunState.NextRunTime.After(*queuedJobs[j].RunState.NextRunTime) {
-				queuedJobs[i], queuedJobs[j] = queuedJobs[j], queuedJobs[i]
-			}
+	limit := c.QueryInt("limit", 50)
+	if limit < 1 || limit > 1000 {
+		limit = 50
+	}
+
+	runs, err := h.jobRunRepo.FindByJob(ctx, id, int64(limit))
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to retrieve job runs"))
+	}
+
+	return c.JSON(JobRunsResponse{
+		Success: true,
+		Data:    runs,
+		Count:   len(runs),
+	})
+}
+
+// DeleteJobOHLCVRange deletes a job's candles within a time range
+// DELETE /api/v1/jobs/:id/ohlcv?start=...&end=...
+// start and end are inclusive Unix millisecond timestamps or RFC3339 times and
+// are both required, so a missing parameter can never delete the whole series
+func (h *JobHandler) DeleteJobOHLCVRange(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	job, err := h.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid job ID format"))
 		}
+		return errors.SendError(c, errors.NotFound("Job"))
+	}
+
+	startMs, err := parseRangeBound(c.Query("start"))
+	if err != nil {
+		return errors.SendError(c, errors.ValidationError("Invalid start", map[string]string{
+			"start": err.Error(),
+		}))
+	}
+	endMs, err := parseRangeBound(c.Query("end"))
+	if err != nil {
+		return errors.SendError(c, errors.ValidationError("Invalid end", map[string]string{
+			"end": err.Error(),
+		}))
+	}
+	if startMs > endMs {
+		return errors.SendError(c, errors.ValidationError("Invalid range", map[string]string{
+			"range": "start must not be after end",
+		}))
+	}
+
+	deleted, err := h.ohlcvRepo.DeleteRange(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, startMs, endMs)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to delete OHLCV range: "+err.Error()))
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    queuedJobs,
-		"count":   len(queuedJobs),
+		"data": fiber.Map{
+			"deleted_candles": deleted,
+			"start":           startMs,
+			"end":             endMs,
+		},
+	})
+}
+
+// maxIngestCandles caps the candles of one ingest request
+const maxIngestCandles = 10000
+
+// maxIngestErrors caps the per-candle errors reported for a rejected batch
+const maxIngestErrors = 20
+
+// IngestJobOHLCV stores candles pushed from a source other than CCXT
+// @Summary Push candles for a job
+// @Description Stores a JSON array of candles from an external feed under the job's exchange, symbol and timeframe, so they are checked and exported like fetched candles. Every candle must have finite, positive prices, a non-negative volume, a high and low that bound open and close, and a timestamp on a bar boundary of the job's timeframe; one invalid candle rejects the whole batch, with the invalid candles by index in the details. Indicators are calculated with the job's indicator config, using the stored candles before the batch as warm-up. Candles whose timestamp is already stored are not overwritten but counted in duplicates_skipped; delete the range first (DELETE /jobs/{id}/ohlcv) to replace them.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body []models.Candle true "Candles, in any order"
+// @Success 200 {object} map[string]interface{} "Candles stored"
+// @Failure 400 {object} map[string]interface{} "Invalid candles or not an OHLCV job"
+// @Failure 404 {object} map[string]interface{} "Job not found"
+// @Router /jobs/{id}/ohlcv/ingest [post]
+func (h *JobHandler) IngestJobOHLCV(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+
+	job, err := h.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return errors.SendError(c, errors.BadRequest("Invalid job ID format"))
+		}
+		return errors.SendError(c, errors.NotFound("Job"))
+	}
+	if job.GetJobType() != models.JobTypeOHLCV {
+		return errors.SendError(c, errors.BadRequest("Candles can only be pushed to ohlcv jobs"))
+	}
+
+	var candles []models.Candle
+	if err := c.BodyParser(&candles); err != nil {
+		return errors.SendError(c, errors.BadRequest("Request body must be a JSON array of candles"))
+	}
+	if len(candles) == 0 {
+		return errors.SendError(c, errors.ValidationError("No candles provided", map[string]string{
+			"candles": "at least one candle is required",
+		}))
+	}
+	if len(candles) > maxIngestCandles {
+		return errors.SendError(c, errors.ValidationError("Too many candles", map[string]string{
+			"candles": fmt.Sprintf("at most %d candles per request", maxIngestCandles),
+		}))
+	}
+
+	if details := validateIngestCandles(candles, job.Timeframe); len(details) > 0 {
+		return errors.SendError(c, errors.ValidationError(
+			fmt.Sprintf("Invalid candles for %s %s", job.Symbol, job.Timeframe), details))
+	}
+
+	stored, duplicates, err := h.jobExecutor.IngestCandles(ctx, job, candles)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to store candles: "+err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"received_candles":   len(candles),
+			"stored_candles":     stored,
+			"duplicates_skipped": duplicates,
+		},
 	})
 }
 
+// validateIngestCandles returns the errors of pushed candles keyed by index,
+// e.g. "candles[3]", up to maxIngestErrors of them
+func validateIngestCandles(candles []models.Candle, timeframe string) map[string]string {
+	details := make(map[string]string)
+	seen := make(map[int64]int, len(candles))
+	for i, candle := range candles {
+		if len(details) >= maxIngestErrors {
+			break
+		}
+		key := fmt.Sprintf("candles[%d]", i)
+		if err := models.ValidateCandle(candle, timeframe); err != nil {
+			details[key] = err.Error()
+			continue
+		}
+		if first, ok := seen[candle.Timestamp]; ok {
+			details[key] = fmt.Sprintf("duplicate timestamp of candles[%d]", first)
+			continue
+		}
+		seen[candle.Timestamp] = i
+	}
+	return details
+}
+
+// parseRangeBound parses a required time bound given as Unix milliseconds or RFC3339
+func parseRangeBound(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("required")
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ms, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("must be Unix milliseconds or RFC3339")
+	}
+	return t.UnixMilli(), nil
+}
+
 // GetJobOHLCVData retrieves paginated OHLCV data for a job
-// GET /api/v1/jobs/:id/ohlcv?page=1&limit=50
+// GET /api/v1/jobs/:id/ohlcv?page=1&limit=50&order=desc
+// Pass order=asc to page oldest first, and debug=true to include which storage
+// backend (chunked or legacy) served the data
 func (h *JobHandler) GetJobOHLCVData(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
 
+	ascending, err := repository.ParseCandleOrder(strings.ToLower(c.Query("order")))
+	if err != nil {
+		return errors.SendError(c, errors.ValidationError(err.Error(), nil))
+	}
+
 	// Get job to verify it exists and get details
 	job, err := h.jobRepo.FindByID(ctx, id)
 	if err != nil {
@@ -600,16 +1185,20 @@ func (h *JobHandler) GetJobOHLCVData(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Job"))
 	}
 
-	// Get pagination parameters
+	// Get pagination parameters; limits above the server maximum are clamped
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 50)
 
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 1000 {
+	if limit < 1 {
 		limit = 50
 	}
+	requestedLimit := limit
+	if limit > h.maxPageLimit {
+		limit = h.maxPageLimit
+	}
 
 	skip := (page - 1) * limit
 
@@ -627,29 +1216,39 @@ func (h *JobHandler) GetJobOHLCVData(c *fiber.Ctx) error {
 	}
 
 	// Fetch data with pagination
-	data, err := h.ohlcvRepo.FindWithPagination(ctx, filter, int64(skip), int64(limit))
+	data, source, err := h.ohlcvRepo.FindWithPaginationWithSource(ctx, filter, int64(skip), int64(limit), ascending)
 	if err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to fetch OHLCV data"))
 	}
 
 	totalPages := (total + int64(limit) - 1) / int64(limit)
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    data,
-		"pagination": fiber.Map{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": totalPages,
-		},
-	})
+	pageInfo := fiber.Map{
+		"page":        page,
+		"limit":       limit,
+		"total":       total,
+		"total_pages": totalPages,
+	}
+	if requestedLimit != limit {
+		pageInfo["requested_limit"] = requestedLimit
+		pageInfo["max_limit"] = h.maxPageLimit
+		pageInfo["limit_clamped"] = true
+	}
+
+	return c.JSON(withStorageDebug(c, fiber.Map{
+		"success":    true,
+		"data":       data,
+		"pagination": pageInfo,
+	}, source))
 }
 
 // ExportJobData exports job data in CSV or JSON format
-// GET /api/v1/jobs/:id/export?format=csv
+// GET /api/v1/jobs/:id/export?format=csv&order=desc
+// The candles are streamed newest first, or oldest first with order=asc, up to
+// the server's maximum export rows; a cut-off export carries
+// X-Export-Truncated and X-Export-Max-Rows.
 func (h *JobHandler) ExportJobData(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -663,6 +1262,11 @@ func (h *JobHandler) ExportJobData(c *fiber.Ctx) error {
 		}))
 	}
 
+	ascending, err := repository.ParseCandleOrder(strings.ToLower(c.Query("order")))
+	if err != nil {
+		return errors.SendError(c, errors.ValidationError(err.Error(), nil))
+	}
+
 	// Get job details
 	job, err := h.jobRepo.FindByID(ctx, id)
 	if err != nil {
@@ -672,51 +1276,31 @@ func (h *JobHandler) ExportJobData(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Job"))
 	}
 
-	// Fetch all OHLCV data for this job
-	filter := bson.M{
-		"exchange_id": job.ConnectorExchangeID,
-		"symbol":      job.Symbol,
-		"timeframe":   job.Timeframe,
-	}
-
-	data, err := h.ohlcvRepo.FindWithPagination(ctx, filter, 0, 10000) // Limit to 10000 records
+	load, err := h.exportCandles(ctx, c, job, ascending)
 	if err != nil {
 		return errors.SendError(c, errors.DatabaseError("Failed to fetch data for export"))
 	}
-
-	if len(data) == 0 {
+	if load == nil {
 		return errors.SendError(c, errors.NoData(fmt.Sprintf("%s/%s", job.Symbol, job.Timeframe)))
 	}
 
 	if format == "json" {
 		c.Set("Content-Type", "application/json")
 		c.Set("Content-Disposition", "attachment; filename="+job.Symbol+"_"+job.Timeframe+"_export.json")
-		return c.JSON(data)
+		return streamCandles(c, load, writeCandlesJSON)
 	}
 
 	// CSV format
 	c.Set("Content-Type", "text/csv")
 	c.Set("Content-Disposition", "attachment; filename="+job.Symbol+"_"+job.Timeframe+"_export.csv")
-
-	// Build CSV
-	csv := "timestamp,open,high,low,close,volume\n"
-	for _, record := range data {
-		timestamp := time.Unix(record.Timestamp/1000, (record.Timestamp%1000)*1000000)
-		csv += timestamp.Format(time.RFC3339) + ","
-		csv += formatFloat(record.Open) + ","
-		csv += formatFloat(record.High) + ","
-		csv += formatFloat(record.Low) + ","
-		csv += formatFloat(record.Close) + ","
-		csv += formatFloat(record.Volume) + "\n"
-	}
-
-	return c.SendString(csv)
+	return streamCandles(c, load, writeCandlesCSV)
 }
 
 // ExportJobDataForML exports job data optimized for machine learning
 // GET /api/v1/jobs/:id/export/ml
+// Limited and streamed like ExportJobData
 func (h *JobHandler) ExportJobDataForML(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -730,61 +1314,186 @@ func (h *JobHandler) ExportJobDataForML(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Job"))
 	}
 
-	// Fetch all OHLCV data
+	load, err := h.exportCandles(ctx, c, job, false)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to fetch data for ML export"))
+	}
+	if load == nil {
+		return errors.SendError(c, errors.NoData(fmt.Sprintf("%s/%s", job.Symbol, job.Timeframe)))
+	}
+
+	c.Set("Content-Type", "text/csv")
+	c.Set("Content-Disposition", "attachment; filename="+job.Symbol+"_"+job.Timeframe+"_ml.csv")
+	return streamCandles(c, load, writeCandlesMLCSV)
+}
+
+// candleLoader reads an export's candles from storage, handing them to fn in
+// batches in export order
+type candleLoader func(ctx context.Context, fn func(candles []models.Candle) error) error
+
+// exportStreamTimeout bounds how long a data export may keep reading candles
+// while its response is streamed
+const exportStreamTimeout = 10 * time.Minute
+
+// exportCandles returns the loader of a job's candles for a data export, newest
+// first or oldest first with ascending set, or nil when the job has no data.
+// Past the server's maximum export rows the oldest candles are cut off, which
+// is reported in the X-Export-Truncated and X-Export-Max-Rows headers.
+func (h *JobHandler) exportCandles(ctx context.Context, c *fiber.Ctx, job *models.Job, ascending bool) (candleLoader, error) {
+	_, _, total, err := h.ohlcvRepo.GetTimeRange(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	limit := int64(total)
+	if total > h.maxExportRows {
+		limit = int64(h.maxExportRows)
+		c.Set("X-Export-Truncated", "true")
+		c.Set("X-Export-Max-Rows", strconv.Itoa(h.maxExportRows))
+	}
+
+	// Truncation keeps the newest candles whatever the order, so oldest
+	// first skips the ones cut off
+	var skip int64
+	if ascending {
+		skip = int64(total) - limit
+	}
+
 	filter := bson.M{
 		"exchange_id": job.ConnectorExchangeID,
 		"symbol":      job.Symbol,
 		"timeframe":   job.Timeframe,
 	}
+	return func(ctx context.Context, fn func(candles []models.Candle) error) error {
+		return h.ohlcvRepo.StreamWithPagination(ctx, filter, skip, limit, ascending, fn)
+	}, nil
+}
 
-	data, err := h.ohlcvRepo.FindWithPagination(ctx, filter, 0, 10000)
-	if err != nil {
-		return errors.SendError(c, errors.DatabaseError("Failed to fetch data for ML export"))
-	}
+// streamCandles streams candles to the response through write as load reads
+// them, so neither the candles nor the body are ever held in memory as a
+// whole. The response is sent after the handler returns, so load gets its own
+// context.
+func streamCandles(c *fiber.Ctx, load candleLoader, write func(w io.Writer, candles iter.Seq[models.Candle]) error) error {
+	parent := c.UserContext()
+	pr, pw := io.Pipe()
+	go func() {
+		ctx, cancel := context.WithTimeout(parent, exportStreamTimeout)
+		defer cancel()
+
+		var loadErr error
+		candles := func(yield func(models.Candle) bool) {
+			loadErr = load(ctx, func(batch []models.Candle) error {
+				for _, candle := range batch {
+					if !yield(candle) {
+						return io.ErrClosedPipe
+					}
+				}
+				return nil
+			})
+		}
 
-	if len(data) == 0 {
-		return errors.SendError(c, errors.NoData(fmt.Sprintf("%s/%s", job.Symbol, job.Timeframe)))
+		bw := bufio.NewWriter(pw)
+		err := write(bw, candles)
+		if err == nil {
+			err = loadErr
+		}
+		if err == nil {
+			err = bw.Flush()
+		}
+		pw.CloseWithError(err)
+	}()
+	return c.SendStream(pr)
+}
+
+// writeCandlesCSV writes the OHLCV columns of candles as CSV
+func writeCandlesCSV(w io.Writer, candles iter.Seq[models.Candle]) error {
+	if _, err := io.WriteString(w, "timestamp,open,high,low,close,volume\n"); err != nil {
+		return err
 	}
+	for record := range candles {
+		timestamp := time.Unix(record.Timestamp/1000, (record.Timestamp%1000)*1000000)
+		if _, err := fmt.Fprintf(w, "%s,%s,%s,%s,%s,%s\n", timestamp.Format(time.RFC3339),
+			formatFloat(record.Open), formatFloat(record.High), formatFloat(record.Low),
+			formatFloat(record.Close), formatFloat(record.Volume)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	c.Set("Content-Type", "text/csv")
-	c.Set("Content-Disposition", "attachment; filename="+job.Symbol+"_"+job.Timeframe+"_ml.csv")
+// writeCandlesJSON writes candles as a JSON array, one candle at a time
+func writeCandlesJSON(w io.Writer, candles iter.Seq[models.Candle]) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for record := range candles {
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
 
-	// Build ML-optimized CSV with additional features
-	csv := "timestamp,open,high,low,close,volume,returns,log_returns,volatility,price_change\n"
+// writeCandlesMLCSV writes candles as CSV with simple return and range
+// features, each computed against the previous row
+func writeCandlesMLCSV(w io.Writer, candles iter.Seq[models.Candle]) error {
+	if _, err := io.WriteString(w, "timestamp,open,high,low,close,volume,returns,log_returns,volatility,price_change\n"); err != nil {
+		return err
+	}
 
-	for i, record := range data {
+	var prev *models.Candle
+	for record := range candles {
 		timestamp := time.Unix(record.Timestamp/1000, (record.Timestamp%1000)*1000000)
-		csv += timestamp.Format(time.RFC3339) + ","
-		csv += formatFloat(record.Open) + ","
-		csv += formatFloat(record.High) + ","
-		csv += formatFloat(record.Low) + ","
-		csv += formatFloat(record.Close) + ","
-		csv += formatFloat(record.Volume) + ","
+		row := timestamp.Format(time.RFC3339) + ","
+		row += formatFloat(record.Open) + ","
+		row += formatFloat(record.High) + ","
+		row += formatFloat(record.Low) + ","
+		row += formatFloat(record.Close) + ","
+		row += formatFloat(record.Volume) + ","
 
 		// Calculate features
-		if i > 0 {
-			prevClose := data[i-1].Close
+		if prev != nil {
+			prevClose := prev.Close
 			returns := (record.Close - prevClose) / prevClose
-			csv += formatFloat(returns) + ","
+			row += formatFloat(returns) + ","
 			if prevClose > 0 && record.Close > 0 {
-				csv += formatFloat(math.Log(record.Close / prevClose)) + ","
+				row += formatFloat(math.Log(record.Close/prevClose)) + ","
 			} else {
-				csv += "0,"
+				row += "0,"
 			}
-			csv += formatFloat(record.High - record.Low) + "," // volatility proxy
-			csv += formatFloat(record.Close - prevClose) + "\n" // price change
+			row += formatFloat(record.High-record.Low) + ","  // volatility proxy
+			row += formatFloat(record.Close-prevClose) + "\n" // price change
 		} else {
-			csv += "0,0,0,0\n"
+			row += "0,0,0,0\n"
 		}
-	}
 
-	return c.SendString(csv)
+		if _, err := io.WriteString(w, row); err != nil {
+			return err
+		}
+		prev = &record
+	}
+	return nil
 }
 
 // GetJobDependencies retrieves the dependencies for a job
 // GET /api/v1/jobs/:id/dependencies
 func (h *JobHandler) GetJobDependencies(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -821,7 +1530,7 @@ func (h *JobHandler) GetJobDependencies(c *fiber.Ctx) error {
 // SetJobDependencies sets the dependencies for a job
 // PUT /api/v1/jobs/:id/dependencies
 func (h *JobHandler) SetJobDependencies(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -892,7 +1601,7 @@ func (h *JobHandler) SetJobDependencies(c *fiber.Ctx) error {
 // GetJobDependents retrieves jobs that depend on a given job
 // GET /api/v1/jobs/:id/dependents
 func (h *JobHandler) GetJobDependents(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -932,7 +1641,7 @@ func (h *JobHandler) GetJobDependents(c *fiber.Ctx) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /jobs/{id}/quality [get]
 func (h *JobHandler) GetJobDataQuality(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -947,7 +1656,7 @@ func (h *JobHandler) GetJobDataQuality(c *fiber.Ctx) error {
 	}
 
 	// Analyze data quality
-	quality, err := h.ohlcvRepo.AnalyzeDataQuality(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	quality, err := h.ohlcvRepo.AnalyzeJobDataQuality(ctx, job)
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Failed to analyze data quality: "+err.Error()))
 	}
@@ -969,7 +1678,7 @@ func (h *JobHandler) GetJobDataQuality(c *fiber.Ctx) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /quality/summary [get]
 func (h *JobHandler) GetDataQualitySummary(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
 	defer cancel()
 
 	exchangeID := c.Query("exchange_id")
@@ -997,7 +1706,7 @@ func (h *JobHandler) GetDataQualitySummary(c *fiber.Ctx) error {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /quality [get]
 func (h *JobHandler) GetAllJobsDataQuality(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
 	defer cancel()
 
 	exchangeID := c.Query("exchange_id")
@@ -1009,14 +1718,14 @@ func (h *JobHandler) GetAllJobsDataQuality(c *fiber.Ctx) error {
 		filter["connector_exchange_id"] = exchangeID
 	}
 
-	jobs, err := h.jobRepo.FindAll(ctx, filter)
+	jobs, err := h.jobRepo.FindAll(ctx, repository.OHLCVJobs(filter))
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Failed to get jobs"))
 	}
 
 	var qualities []*models.DataQuality
 	for _, job := range jobs {
-		quality, err := h.ohlcvRepo.AnalyzeDataQuality(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		quality, err := h.ohlcvRepo.AnalyzeJobDataQuality(ctx, job)
 		if err != nil {
 			continue
 		}
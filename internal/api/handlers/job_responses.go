@@ -0,0 +1,29 @@
+package handlers
+
+import "github.com/yourusername/datacollector/internal/models"
+
+// JobExecutionResponse is the response of POST /jobs/:id/execute
+type JobExecutionResponse struct {
+	Success bool                       `json:"success"` // Whether the execution succeeded
+	Data    *models.JobExecutionResult `json:"data"`
+}
+
+// JobRunsResponse is the response of GET /jobs/:id/runs
+type JobRunsResponse struct {
+	Success bool             `json:"success"`
+	Data    []*models.JobRun `json:"data"`
+	Count   int              `json:"count"`
+}
+
+// ScheduleReconcileResponse is the response of POST /jobs/reconcile-schedule
+type ScheduleReconcileResponse struct {
+	Success bool                            `json:"success"`
+	Data    *models.ScheduleReconcileResult `json:"data"`
+}
+
+// SymbolJobsResponse is the response of the symbol-level bulk job operations
+type SymbolJobsResponse struct {
+	Success bool                     `json:"success"`
+	Message string                   `json:"message"`
+	Data    *models.SymbolJobsResult `json:"data"`
+}
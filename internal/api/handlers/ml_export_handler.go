@@ -2,15 +2,22 @@ package handlers
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/yourusername/datacollector/internal/api/errors"
+	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/service"
 )
@@ -18,15 +25,23 @@ import (
 // MLExportHandler handles ML export endpoints
 type MLExportHandler struct {
 	exportService *service.MLExportService
+	publicBaseURL string
 }
 
 // NewMLExportHandler creates a new ML export handler
-func NewMLExportHandler(exportService *service.MLExportService) *MLExportHandler {
+func NewMLExportHandler(exportService *service.MLExportService, cfg *config.Config) *MLExportHandler {
 	return &MLExportHandler{
 		exportService: exportService,
+		publicBaseURL: strings.TrimRight(cfg.Server.PublicBaseURL, "/"),
 	}
 }
 
+// downloadURL returns the URL clients download an export from, under the
+// configured public base URL
+func (h *MLExportHandler) downloadURL(id primitive.ObjectID) string {
+	return fmt.Sprintf("%s/api/v1/ml/export/jobs/%s/download", h.publicBaseURL, id.Hex())
+}
+
 // ============================================================================
 // Request/Response Types
 // ============================================================================
@@ -47,15 +62,34 @@ type ExportResponse struct {
 	ProcessedRecords int64    `json:"processed_records,omitempty"`
 	OutputPath      string    `json:"output_path,omitempty"`
 	FileSizeBytes   int64     `json:"file_size_bytes,omitempty"`
+	Checksum        string    `json:"checksum,omitempty"`
 	FeatureCount    int       `json:"feature_count,omitempty"`
 	RowCount        int64     `json:"row_count,omitempty"`
 	ColumnNames     []string  `json:"column_names,omitempty"`
 	StartedAt       *time.Time `json:"started_at,omitempty"`
 	CompletedAt     *time.Time `json:"completed_at,omitempty"`
 	LastError       string    `json:"last_error,omitempty"`
+	RequestID       string    `json:"request_id,omitempty"`
+	Errors          []string  `json:"errors,omitempty"`
+	Warnings        []models.ExportWarning `json:"warnings,omitempty"`
 	DownloadURL     string    `json:"download_url,omitempty"`
 }
 
+// CloneProfileRequest is the optional request body for cloning a profile
+type CloneProfileRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// DiffProfilesRequest names the two configs to compare; each side is a saved
+// profile ID, a preset name, or an inline config
+type DiffProfilesRequest struct {
+	ProfileA string                 `json:"profile_a,omitempty"`
+	ProfileB string                 `json:"profile_b,omitempty"`
+	ConfigA  *models.MLExportConfig `json:"config_a,omitempty"`
+	ConfigB  *models.MLExportConfig `json:"config_b,omitempty"`
+}
+
 // ConfigResponse wraps export config for API responses
 type ConfigResponse struct {
 	ID          string               `json:"id"`
@@ -74,16 +108,20 @@ type ConfigResponse struct {
 
 // StartExport starts a background ML export job
 // @Summary Start ML export
-// @Description Starts a background job to export data with feature engineering
+// @Description Starts a background job to export data with feature engineering. Retrying with the same Idempotency-Key header and body returns the original job instead of starting another.
 // @Tags ML Export
 // @Accept json
 // @Produce json
 // @Param request body StartExportRequest true "Export configuration"
+// @Param Idempotency-Key header string false "Client-chosen key that makes retries safe"
+// @Success 200 {object} ExportResponse "Export job already started with this Idempotency-Key"
 // @Success 202 {object} ExportResponse "Export job started"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Idempotency-Key in progress or used with a different request"
+// @Failure 503 {object} map[string]interface{} "Export disk limit reached or exports unavailable"
 // @Router /ml/export/start [post]
 func (h *MLExportHandler) StartExport(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	var req StartExportRequest
@@ -115,10 +153,65 @@ func (h *MLExportHandler) StartExport(c *fiber.Ctx) error {
 		req.Config.Format = models.MLExportFormatCSV
 	}
 
-	// Start export
-	exportJob, err := h.exportService.StartExport(ctx, req.Config, jobIDs)
+	if req.Config.MinBars.MinBars < 0 {
+		return errors.SendError(c, errors.ValidationError("Invalid min bars", map[string]string{
+			"min_bars": "must be >= 0 (0 derives it from the config)",
+		}))
+	}
+	switch req.Config.MinBars.Action {
+	case "", models.MinBarsActionFail, models.MinBarsActionSkip:
+	default:
+		return errors.SendError(c, errors.ValidationError("Invalid min bars action", map[string]string{
+			"action": "must be fail or skip",
+		}))
+	}
+
+	pre := req.Config.Preprocessing
+	if pre.MaxNaNFraction < 0 || pre.MaxNaNFraction > 1 || pre.MaxColNaNFraction < 0 || pre.MaxColNaNFraction > 1 {
+		return errors.SendError(c, errors.ValidationError("Invalid NaN threshold", map[string]string{
+			"max_nan_fraction":     "must be between 0 and 1",
+			"max_col_nan_fraction": "must be between 0 and 1",
+		}))
+	}
+	if req.Config.TailBars < 0 {
+		return errors.SendError(c, errors.ValidationError("Invalid tail bars", map[string]string{
+			"tail_bars": "must be >= 0 (0 = full history)",
+		}))
+	}
+	if pre.ForwardFillLimit < 0 {
+		return errors.SendError(c, errors.ValidationError("Invalid fill limit", map[string]string{
+			"forward_fill_limit": "must be >= 0 (0 = unlimited)",
+		}))
+	}
+	switch pre.ClipMethod {
+	case "", models.ClipMethodStdDev:
+	case models.ClipMethodPercentile:
+		if lower, upper := pre.ClipPercentiles(); lower < 0 || upper > 100 || lower >= upper {
+			return errors.SendError(c, errors.ValidationError("Invalid clip percentiles", map[string]string{
+				"lower_pct": "must be >= 0 and below upper_pct",
+				"upper_pct": "must be <= 100 and above lower_pct",
+			}))
+		}
+	default:
+		return errors.SendError(c, errors.ValidationError("Invalid clip method", map[string]string{
+			"clip_method": "must be stddev or percentile",
+		}))
+	}
+
+	if req.Config.Target.Enabled {
+		for i, spec := range req.Config.Target.Specs() {
+			if msg := validateTargetSpec(spec); msg != "" {
+				return errors.SendError(c, errors.ValidationError("Invalid target", map[string]string{
+					fmt.Sprintf("targets[%d]", i): msg,
+				}))
+			}
+		}
+	}
+
+	// Start export, or return the one a previous attempt with this key started
+	exportJob, replayed, err := h.exportService.StartExportIdempotent(ctx, service.IdempotencyScopeExport, c.Get("Idempotency-Key"), req.Config, jobIDs)
 	if err != nil {
-		return errors.SendError(c, errors.InternalError(err.Error()))
+		return sendStartExportError(c, err)
 	}
 
 	response := ExportResponse{
@@ -126,7 +219,16 @@ func (h *MLExportHandler) StartExport(c *fiber.Ctx) error {
 		Status:       string(exportJob.Status),
 		Progress:     exportJob.Progress,
 		CurrentPhase: exportJob.CurrentPhase,
-		DownloadURL:  fmt.Sprintf("/api/v1/ml/export/jobs/%s/download", exportJob.ID.Hex()),
+		DownloadURL:  h.downloadURL(exportJob.ID),
+	}
+
+	if replayed {
+		c.Set("Idempotent-Replayed", "true")
+		return c.JSON(fiber.Map{
+			"success": true,
+			"data":    response,
+			"message": "Export job already started with this idempotency key",
+		})
 	}
 
 	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
@@ -136,17 +238,63 @@ func (h *MLExportHandler) StartExport(c *fiber.Ctx) error {
 	})
 }
 
+// sendStartExportError maps an error starting an export or dataset to its
+// response status
+func sendStartExportError(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "insufficient data") {
+		return errors.SendError(c, errors.BadRequest(err.Error()))
+	}
+	if stderrors.Is(err, service.ErrIdempotencyKeyMismatch) || stderrors.Is(err, service.ErrIdempotencyKeyInProgress) {
+		return errors.SendError(c, errors.Conflict(err.Error()))
+	}
+	if strings.Contains(err.Error(), "failed to find job") {
+		return errors.SendError(c, errors.ValidationError("Job not found", map[string]string{
+			"job_ids": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "invalid auxiliary source") {
+		return errors.SendError(c, errors.ValidationError("Invalid auxiliary source", map[string]string{
+			"auxiliary_sources": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "invalid market sessions") {
+		return errors.SendError(c, errors.ValidationError("Invalid market sessions", map[string]string{
+			"features.market_sessions": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "invalid frac_diff") {
+		return errors.SendError(c, errors.ValidationError("Invalid frac_diff", map[string]string{
+			"features.frac_diff": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "invalid float format") {
+		return errors.SendError(c, errors.ValidationError("Invalid float format", map[string]string{
+			"float_format": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "invalid preprocessing") {
+		return errors.SendError(c, errors.ValidationError("Invalid preprocessing", map[string]string{
+			"preprocessing.warmup_rows": err.Error(),
+		}))
+	}
+	if strings.Contains(err.Error(), "disk limit") || strings.Contains(err.Error(), "exports unavailable") {
+		return errors.SendError(c, errors.ServiceUnavailable(err.Error()))
+	}
+	return errors.SendError(c, errors.InternalError(err.Error()))
+}
+
 // GetExportJob gets the status of an export job
 // @Summary Get export job status
-// @Description Returns the current status and progress of an export job
+// @Description Returns the current status and progress of an export job. warnings lists non-fatal issues that can leave the dataset with fewer rows than expected, such as source jobs without data, skipped for too few bars or covering only part of the time range; errors lists the failures, prefixed with the ID of the request that started the export. The response carries an ETag of the job's last update and honors If-None-Match.
 // @Tags ML Export
 // @Produce json
 // @Param id path string true "Export job ID"
 // @Success 200 {object} ExportResponse "Export job status"
+// @Success 304 "Not modified (If-None-Match matched ETag)"
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Router /ml/export/jobs/{id} [get]
 func (h *MLExportHandler) GetExportJob(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -159,6 +307,10 @@ func (h *MLExportHandler) GetExportJob(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Export job"))
 	}
 
+	if notModified(c, versionETag(exportJob.UpdatedAt, 1)) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	response := ExportResponse{
 		ID:               exportJob.ID.Hex(),
 		Status:           string(exportJob.Status),
@@ -168,16 +320,20 @@ func (h *MLExportHandler) GetExportJob(c *fiber.Ctx) error {
 		ProcessedRecords: exportJob.ProcessedRecords,
 		OutputPath:       exportJob.OutputPath,
 		FileSizeBytes:    exportJob.FileSizeBytes,
+		Checksum:         exportJob.Checksum,
 		FeatureCount:     exportJob.FeatureCount,
 		RowCount:         exportJob.RowCount,
 		ColumnNames:      exportJob.ColumnNames,
 		StartedAt:        exportJob.StartedAt,
 		CompletedAt:      exportJob.CompletedAt,
 		LastError:        exportJob.LastError,
+		RequestID:        exportJob.RequestID,
+		Errors:           exportJob.Errors,
+		Warnings:         exportJob.Warnings,
 	}
 
 	if exportJob.Status == models.MLExportStatusCompleted {
-		response.DownloadURL = fmt.Sprintf("/api/v1/ml/export/jobs/%s/download", exportJob.ID.Hex())
+		response.DownloadURL = h.downloadURL(exportJob.ID)
 	}
 
 	return c.JSON(fiber.Map{
@@ -196,7 +352,7 @@ func (h *MLExportHandler) GetExportJob(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{} "List of export jobs"
 // @Router /ml/export/jobs [get]
 func (h *MLExportHandler) ListExportJobs(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	limit := int64(c.QueryInt("limit", 20))
@@ -217,14 +373,16 @@ func (h *MLExportHandler) ListExportJobs(c *fiber.Ctx) error {
 			TotalRecords:     job.TotalRecords,
 			ProcessedRecords: job.ProcessedRecords,
 			FileSizeBytes:    job.FileSizeBytes,
+			Checksum:         job.Checksum,
 			FeatureCount:     job.FeatureCount,
 			RowCount:         job.RowCount,
 			StartedAt:        job.StartedAt,
 			CompletedAt:      job.CompletedAt,
 			LastError:        job.LastError,
+			Warnings:         job.Warnings,
 		}
 		if job.Status == models.MLExportStatusCompleted {
-			responses[i].DownloadURL = fmt.Sprintf("/api/v1/ml/export/jobs/%s/download", job.ID.Hex())
+			responses[i].DownloadURL = h.downloadURL(job.ID)
 		}
 	}
 
@@ -239,15 +397,20 @@ func (h *MLExportHandler) ListExportJobs(c *fiber.Ctx) error {
 
 // DownloadExport downloads the exported file
 // @Summary Download export file
-// @Description Downloads the completed export file
+// @Description Downloads the completed export file. Supports Range/If-Range requests (206 Partial Content) for resumable downloads and ETag/If-None-Match for conditional requests. Exports stored in S3 are redirected (307) to a presigned URL, or streamed from the bucket when presigning is disabled. Exports split by label download their manifest, which lists the split files with row counts, time ranges and checksums.
 // @Tags ML Export
 // @Produce application/octet-stream
 // @Param id path string true "Export job ID"
-// @Success 200 {file} binary "Export file"
+// @Param Range header string false "Byte range to resume a partial download (e.g. bytes=1024-)"
+// @Param columns query string false "Comma-separated subset of columns to return (CSV/JSONL only, e.g. close,rsi14)"
+// @Success 200 {file} binary "Export file (X-Content-SHA256 header carries the file checksum)"
+// @Success 206 {file} binary "Partial export file"
+// @Success 304 "Not modified (If-None-Match matched ETag)"
 // @Failure 404 {object} map[string]interface{} "Job not found or not completed"
+// @Failure 416 "Requested range not satisfiable"
 // @Router /ml/export/jobs/{id}/download [get]
 func (h *MLExportHandler) DownloadExport(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -260,6 +423,10 @@ func (h *MLExportHandler) DownloadExport(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Export job"))
 	}
 
+	if exportJob.Status == models.MLExportStatusExpired {
+		return errors.SendError(c, errors.NotFound("Export file has expired or been deleted"))
+	}
+
 	if exportJob.Status != models.MLExportStatusCompleted {
 		return errors.SendError(c, errors.BadRequest("Export job is not completed"))
 	}
@@ -268,10 +435,23 @@ func (h *MLExportHandler) DownloadExport(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.NotFound("Export file"))
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(exportJob.OutputPath); os.IsNotExist(err) {
+	// Check if file exists, on disk or in the object store
+	remote := service.IsRemoteExport(exportJob.OutputPath)
+	var fileInfo os.FileInfo
+	var remoteInfo service.ExportFileInfo
+	if remote {
+		remoteInfo, err = h.exportService.StatExportFile(ctx, exportJob.OutputPath)
+	} else {
+		fileInfo, err = os.Stat(exportJob.OutputPath)
+	}
+	if os.IsNotExist(err) {
+		// Keep the job status consistent with what's on disk
+		h.exportService.MarkExportFileMissing(ctx, exportJob.ID)
 		return errors.SendError(c, errors.NotFound("Export file has expired or been deleted"))
 	}
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
 
 	// Set headers for download
 	filename := filepath.Base(exportJob.OutputPath)
@@ -291,10 +471,217 @@ func (h *MLExportHandler) DownloadExport(c *fiber.Ctx) error {
 		contentType = "application/x-numpy"
 	case ".jsonl":
 		contentType = "application/x-jsonlines"
+	case ".json":
+		contentType = "application/json"
+	case ".zip":
+		contentType = "application/zip"
 	}
 	c.Set("Content-Type", contentType)
 
-	return c.SendFile(exportJob.OutputPath)
+	// Project a subset of columns while streaming; the full file checksum,
+	// ETag and byte ranges don't apply to the projected body
+	if columnsParam := c.Query("columns"); columnsParam != "" {
+		columns := strings.Split(columnsParam, ",")
+		for i := range columns {
+			columns[i] = strings.TrimSpace(columns[i])
+		}
+		if !service.SupportsProjection(exportJob.OutputPath) {
+			return errors.SendError(c, errors.BadRequest("Column selection is only supported for CSV and JSONL exports"))
+		}
+		if err := service.ValidateProjectionColumns(exportJob, columns); err != nil {
+			return errors.SendError(c, errors.ValidationError(err.Error(), map[string]interface{}{
+				"available_columns": exportJob.ColumnNames,
+			}))
+		}
+
+		pr, pw := io.Pipe()
+		streamCtx := logging.Detach(c.UserContext())
+		go func() {
+			pw.CloseWithError(h.exportService.StreamProjectedExport(streamCtx, exportJob, columns, pw))
+		}()
+		return c.SendStream(pr)
+	}
+
+	// Let clients verify the full download against the checksum recorded at write time
+	if exportJob.Checksum != "" {
+		c.Set("X-Content-SHA256", exportJob.Checksum)
+	}
+
+	if remote {
+		return h.sendRemoteExport(c, exportJob.OutputPath, remoteInfo)
+	}
+	return sendExportFile(c, exportJob.OutputPath, fileInfo)
+}
+
+// sendRemoteExport redirects to a presigned URL for an export stored in an
+// object store, or streams it from the bucket when presigning is disabled
+func (h *MLExportHandler) sendRemoteExport(c *fiber.Ctx, location string, info service.ExportFileInfo) error {
+	downloadURL, err := h.exportService.ExportDownloadURL(location)
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(fmt.Sprintf("failed to presign export download: %v", err)))
+	}
+	if downloadURL != "" {
+		return c.Redirect(downloadURL, fiber.StatusTemporaryRedirect)
+	}
+
+	// The body is read after the handler returns, so it can't use the request timeout
+	reader, err := h.exportService.OpenExportFile(logging.Detach(c.UserContext()), location)
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(fmt.Sprintf("failed to open export file: %v", err)))
+	}
+	if !info.ModTime.IsZero() {
+		c.Set(fiber.HeaderLastModified, info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	return c.SendStream(reader, int(info.Size))
+}
+
+// sendExportFile streams an export file with support for conditional and
+// byte-range requests so interrupted downloads can be resumed (e.g. curl -C -)
+func sendExportFile(c *fiber.Ctx, path string, fileInfo os.FileInfo) error {
+	size := fileInfo.Size()
+	etag := fmt.Sprintf("\"%x-%x\"", size, fileInfo.ModTime().UnixNano())
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, fileInfo.ModTime().UTC().Format(http.TimeFormat))
+
+	// Conditional GET: the client already has this exact file
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" && (match == etag || match == "*") {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(fmt.Sprintf("failed to open export file: %v", err)))
+	}
+
+	// Only honor Range when If-Range is absent or still matches the current file
+	rangeHeader := c.Get(fiber.HeaderRange)
+	ifRange := c.Get(fiber.HeaderIfRange)
+	if rangeHeader != "" && size > 0 && (ifRange == "" || ifRange == etag) {
+		ranges, err := c.Range(int(size))
+		if err == fiber.ErrRangeUnsatisfiable {
+			file.Close()
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+			return c.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+		}
+
+		// Malformed or non-byte ranges fall through to a full response.
+		// Multipart ranges are not supported, only the first range is served.
+		if err == nil && ranges.Type == "bytes" && len(ranges.Ranges) > 0 {
+			start := int64(ranges.Ranges[0].Start)
+			end := int64(ranges.Ranges[0].End)
+			length := end - start + 1
+
+			c.Status(fiber.StatusPartialContent)
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+			return c.SendStream(&sectionReadCloser{
+				SectionReader: io.NewSectionReader(file, start, length),
+				file:          file,
+			}, int(length))
+		}
+	}
+
+	// SendStream sets Content-Length and closes the file once the body is written
+	return c.SendStream(file, int(size))
+}
+
+// sectionReadCloser closes the underlying file once a ranged response is sent
+type sectionReadCloser struct {
+	*io.SectionReader
+	file *os.File
+}
+
+// Close closes the underlying file
+func (r *sectionReadCloser) Close() error {
+	return r.file.Close()
+}
+
+// VerifyExport re-hashes an export file and compares it with the recorded checksum
+// @Summary Verify export file integrity
+// @Description Re-computes the SHA-256 of the export file on disk and reports any checksum or size mismatch
+// @Tags ML Export
+// @Produce json
+// @Param id path string true "Export job ID"
+// @Success 200 {object} models.MLExportVerification "Verification result"
+// @Failure 400 {object} map[string]interface{} "Job not completed"
+// @Failure 404 {object} map[string]interface{} "Job not found"
+// @Router /ml/export/jobs/{id}/verify [get]
+func (h *MLExportHandler) VerifyExport(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
+	defer cancel()
+
+	id := c.Params("id")
+	if id == "" {
+		return errors.SendError(c, errors.BadRequest("Missing job ID"))
+	}
+
+	if _, err := h.exportService.GetExportJob(ctx, id); err != nil {
+		return errors.SendError(c, errors.NotFound("Export job"))
+	}
+
+	result, err := h.exportService.VerifyExportJob(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not completed") {
+			return errors.SendError(c, errors.BadRequest("Export job is not completed"))
+		}
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// PreviewExport returns the first and last rows of a completed export
+// @Summary Preview export rows
+// @Description Reads the columns and the first and last n rows of a completed export's output file without downloading it. Split outputs show the head of the first split file and the tail of the last.
+// @Tags ML Export
+// @Produce json
+// @Param id path string true "Export job ID"
+// @Param n query int false "Rows from each end (max 100)" default(10)
+// @Success 200 {object} models.MLExportPreview "Export preview"
+// @Failure 400 {object} map[string]interface{} "Invalid n, job not completed or format without preview"
+// @Failure 404 {object} map[string]interface{} "Job or file not found"
+// @Router /ml/export/jobs/{id}/preview [get]
+func (h *MLExportHandler) PreviewExport(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
+	defer cancel()
+
+	id := c.Params("id")
+	if id == "" {
+		return errors.SendError(c, errors.BadRequest("Missing job ID"))
+	}
+
+	n := c.QueryInt("n", service.DefaultPreviewRows)
+	if n < 1 || n > service.MaxPreviewRows {
+		return errors.SendError(c, errors.ValidationError("Invalid row count", map[string]string{
+			"n": fmt.Sprintf("must be between 1 and %d", service.MaxPreviewRows),
+		}))
+	}
+
+	if _, err := h.exportService.GetExportJob(ctx, id); err != nil {
+		return errors.SendError(c, errors.NotFound("Export job"))
+	}
+
+	preview, err := h.exportService.PreviewExport(ctx, id, n)
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return errors.SendError(c, errors.NotFound("Export file has expired or been deleted"))
+		case strings.Contains(err.Error(), "not completed"):
+			return errors.SendError(c, errors.BadRequest("Export job is not completed"))
+		case strings.Contains(err.Error(), "not supported"):
+			return errors.SendError(c, errors.BadRequest(err.Error()))
+		}
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    preview,
+	})
 }
 
 // CancelExport cancels a running export job
@@ -306,7 +693,7 @@ func (h *MLExportHandler) DownloadExport(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Router /ml/export/jobs/{id}/cancel [post]
 func (h *MLExportHandler) CancelExport(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -333,7 +720,7 @@ func (h *MLExportHandler) CancelExport(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Router /ml/export/jobs/{id} [delete]
 func (h *MLExportHandler) DeleteExport(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -351,6 +738,140 @@ func (h *MLExportHandler) DeleteExport(c *fiber.Ctx) error {
 	})
 }
 
+// DeleteExportsByStatus deletes all export jobs in a terminal status
+// @Summary Bulk delete export jobs
+// @Description Deletes all export jobs with the given status and their output files. Statuses other than failed require confirm=true
+// @Tags ML Export
+// @Produce json
+// @Param status query string true "Status (failed, completed, cancelled, expired)"
+// @Param confirm query bool false "Confirm deletion of non-failed jobs"
+// @Success 200 {object} map[string]interface{} "Deletion summary"
+// @Failure 400 {object} map[string]interface{} "Invalid status or missing confirmation"
+// @Router /ml/export/jobs [delete]
+func (h *MLExportHandler) DeleteExportsByStatus(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	status := models.MLExportStatus(c.Query("status"))
+	if status == "" {
+		return errors.SendError(c, errors.BadRequest("status query parameter is required"))
+	}
+	if !status.IsTerminal() {
+		return errors.SendError(c, errors.BadRequest("status must be one of: failed, completed, cancelled, expired"))
+	}
+	if status != models.MLExportStatusFailed && !c.QueryBool("confirm", false) {
+		return errors.SendError(c, errors.BadRequest(fmt.Sprintf("deleting %s export jobs requires confirm=true", status)))
+	}
+
+	result, err := h.exportService.DeleteExportJobsByStatus(ctx, status)
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetExportUsage returns disk usage of the export directory
+// @Summary Get export disk usage
+// @Description Returns the space used by export files and the configured limit
+// @Tags ML Export
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Disk usage"
+// @Router /ml/export/usage [get]
+func (h *MLExportHandler) GetExportUsage(c *fiber.Ctx) error {
+	usage, err := h.exportService.GetDiskUsage()
+	if err != nil {
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    usage,
+	})
+}
+
+// GetLatestFeatures returns the newest feature rows of a job for model inference
+// @Summary Get latest features for inference
+// @Description Generates features for a job's most recent candles with a saved profile, without targets, splits or sequences. With export_id, the rows use that export's feature columns and normalization params, so they match what a model trained on it expects. The profile defaults to the export's config.
+// @Tags ML Export
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param config_id query string false "Export profile ID (required without export_id)"
+// @Param export_id query string false "Export the model was trained on"
+// @Param rows query int false "Number of newest rows (default: 1, max: 500)"
+// @Success 200 {object} models.LatestFeatures "Latest feature rows"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Job, profile or export not found"
+// @Router /jobs/{id}/features/latest [get]
+func (h *MLExportHandler) GetLatestFeatures(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	jobID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return errors.SendError(c, errors.BadRequest("Invalid job ID"))
+	}
+
+	rows := c.QueryInt("rows", 1)
+	if rows < 1 || rows > service.MaxLatestFeatureRows {
+		return errors.SendError(c, errors.ValidationError("Invalid rows", map[string]string{
+			"rows": fmt.Sprintf("must be between 1 and %d", service.MaxLatestFeatureRows),
+		}))
+	}
+
+	configID := c.Query("config_id")
+	exportID := c.Query("export_id")
+	if configID == "" && exportID == "" {
+		return errors.SendError(c, errors.ValidationError("A profile is required", map[string]string{
+			"config_id": "required without export_id",
+		}))
+	}
+
+	var trainedOn *models.MLExportJob
+	if exportID != "" {
+		trainedOn, err = h.exportService.GetExportJob(ctx, exportID)
+		if err != nil {
+			return errors.SendError(c, errors.NotFound("Export job"))
+		}
+		if trainedOn.Status != models.MLExportStatusCompleted && trainedOn.Status != models.MLExportStatusExpired {
+			return errors.SendError(c, errors.BadRequest("Export job is not completed"))
+		}
+	}
+
+	var config models.MLExportConfig
+	if configID != "" {
+		profile, err := h.exportService.GetConfig(ctx, configID)
+		if err != nil {
+			return errors.SendError(c, errors.NotFound("Profile"))
+		}
+		config = *profile
+	} else {
+		config = trainedOn.Config
+	}
+
+	features, err := h.exportService.LatestFeatures(ctx, jobID, config, trainedOn, rows)
+	if err != nil {
+		if strings.Contains(err.Error(), "failed to find job") {
+			return errors.SendError(c, errors.NotFound("Job"))
+		}
+		if strings.Contains(err.Error(), "no data") {
+			return errors.SendError(c, errors.NotFound("Data for job"))
+		}
+		if strings.Contains(err.Error(), "does not produce the features") {
+			return errors.SendError(c, errors.BadRequest(err.Error()))
+		}
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    features,
+	})
+}
+
 // ============================================================================
 // Profile/Config Endpoints
 // ============================================================================
@@ -363,7 +884,7 @@ func (h *MLExportHandler) DeleteExport(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{} "List of presets"
 // @Router /ml/profiles/presets [get]
 func (h *MLExportHandler) GetPresets(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	presets, err := h.exportService.GetPresets(ctx)
@@ -395,7 +916,7 @@ func (h *MLExportHandler) GetPresets(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{} "List of profiles"
 // @Router /ml/profiles [get]
 func (h *MLExportHandler) ListProfiles(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	configs, err := h.exportService.ListConfigs(ctx)
@@ -433,7 +954,7 @@ func (h *MLExportHandler) ListProfiles(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Profile not found"
 // @Router /ml/profiles/{id} [get]
 func (h *MLExportHandler) GetProfile(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -474,7 +995,7 @@ func (h *MLExportHandler) GetProfile(c *fiber.Ctx) error {
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Router /ml/profiles [post]
 func (h *MLExportHandler) CreateProfile(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var config models.MLExportConfig
@@ -528,7 +1049,7 @@ func (h *MLExportHandler) CreateProfile(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Profile not found"
 // @Router /ml/profiles/{id} [put]
 func (h *MLExportHandler) UpdateProfile(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -580,6 +1101,129 @@ func (h *MLExportHandler) UpdateProfile(c *fiber.Ctx) error {
 	})
 }
 
+// CloneProfile copies a profile or preset into a new editable profile
+// @Summary Clone export profile
+// @Description Copies an export profile, or a built-in preset by ID or name (e.g. full_features), into a new non-preset profile. Without a name the clone is named after the source with a " (copy)" suffix.
+// @Tags ML Export
+// @Accept json
+// @Produce json
+// @Param id path string true "Profile ID or preset name"
+// @Param request body CloneProfileRequest false "Name and description of the clone"
+// @Success 201 {object} ConfigResponse "Profile cloned"
+// @Failure 404 {object} map[string]interface{} "Profile not found"
+// @Failure 409 {object} map[string]interface{} "Name already taken"
+// @Router /ml/profiles/{id}/clone [post]
+func (h *MLExportHandler) CloneProfile(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+	if id == "" {
+		return errors.SendError(c, errors.BadRequest("Missing profile ID"))
+	}
+
+	var req CloneProfileRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errors.SendError(c, errors.BadRequest("Invalid request body"))
+		}
+	}
+
+	config, err := h.exportService.CloneConfig(ctx, id, strings.TrimSpace(req.Name), req.Description)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.SendError(c, errors.NotFound("Profile"))
+		}
+		if strings.Contains(err.Error(), "already exists") {
+			return errors.SendError(c, errors.Conflict(err.Error()))
+		}
+		return errors.SendError(c, errors.InternalError(err.Error()))
+	}
+
+	response := ConfigResponse{
+		ID:          config.ID.Hex(),
+		Name:        config.Name,
+		Description: config.Description,
+		IsPreset:    config.IsPreset,
+		Format:      string(config.Format),
+		Config:      *config,
+		CreatedAt:   config.CreatedAt,
+		UpdatedAt:   config.UpdatedAt,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+		"message": "Profile cloned",
+	})
+}
+
+// DiffProfiles compares the columns and settings of two export configs
+// @Summary Diff export profiles
+// @Description Compares two export configs without running an export: the columns only one of them produces, whether their shared columns come in the same order, and the preprocessing, split, target, sequence and resample settings they differ in. Each side is a profile ID or preset name (profile_a/profile_b) or an inline config (config_a/config_b). Auxiliary source columns aren't included.
+// @Tags ML Export
+// @Accept json
+// @Produce json
+// @Param request body DiffProfilesRequest true "Configs to compare"
+// @Success 200 {object} models.ConfigDiff "Config diff"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Profile not found"
+// @Router /ml/profiles/diff [post]
+func (h *MLExportHandler) DiffProfiles(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	var req DiffProfilesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.SendError(c, errors.BadRequest("Invalid request body"))
+	}
+
+	configA, err := h.diffSide(ctx, "a", req.ProfileA, req.ConfigA)
+	if err != nil {
+		return errors.SendError(c, err)
+	}
+	configB, err := h.diffSide(ctx, "b", req.ProfileB, req.ConfigB)
+	if err != nil {
+		return errors.SendError(c, err)
+	}
+
+	diff, diffErr := h.exportService.DiffConfigs(*configA, *configB)
+	if diffErr != nil {
+		return errors.SendError(c, errors.InternalError(diffErr.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    diff,
+	})
+}
+
+// diffSide resolves one side of a DiffProfilesRequest to a config
+func (h *MLExportHandler) diffSide(ctx context.Context, side, profileID string, inline *models.MLExportConfig) (*models.MLExportConfig, *errors.APIError) {
+	profileID = strings.TrimSpace(profileID)
+	switch {
+	case profileID != "" && inline != nil:
+		return nil, errors.ValidationError("Give either a profile or a config for each side", map[string]string{
+			"profile_" + side: "set together with config_" + side,
+		})
+	case inline != nil:
+		return inline, nil
+	case profileID != "":
+		config, err := h.exportService.FindProfile(ctx, profileID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return nil, errors.NotFound("Profile " + profileID)
+			}
+			return nil, errors.InternalError(err.Error())
+		}
+		return config, nil
+	default:
+		return nil, errors.ValidationError("Missing config to compare", map[string]string{
+			"profile_" + side: "a profile ID, preset name or config_" + side + " is required",
+		})
+	}
+}
+
 // DeleteProfile deletes an export profile
 // @Summary Delete export profile
 // @Description Deletes an export configuration profile
@@ -590,7 +1234,7 @@ func (h *MLExportHandler) UpdateProfile(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Profile not found"
 // @Router /ml/profiles/{id} [delete]
 func (h *MLExportHandler) DeleteProfile(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -659,6 +1303,13 @@ func (h *MLExportHandler) GetSupportedFormats(c *fiber.Ctx) error {
 			"extension":   ".jsonl",
 			"compression": "optional gzip",
 		},
+		{
+			"id":          "json",
+			"name":        "JSON",
+			"description": "Single JSON document with schema, columns, rows and timestamps",
+			"extension":   ".json",
+			"compression": "optional gzip",
+		},
 	}
 
 	return c.JSON(fiber.Map{
@@ -679,18 +1330,19 @@ func (h *MLExportHandler) GetAvailableFeatures(c *fiber.Ctx) error {
 		"ohlcv": []string{"open", "high", "low", "close", "volume", "timestamp"},
 		"price_features": []string{
 			"returns", "log_returns", "price_change", "volatility",
-			"gaps", "body_ratio", "range_pct", "upper_wick", "lower_wick",
+			"gaps", "body_ratio", "range_pct", "upper_wick", "lower_wick", "frac_diff",
 		},
 		"temporal_features": []string{
 			"hour", "hour_sin", "hour_cos",
 			"day_of_week", "dow_sin", "dow_cos",
 			"day_of_month", "month", "month_sin", "month_cos",
-			"is_weekend", "quarter",
+			"is_weekend", "quarter", "market_session", "bar_gap",
 		},
 		"cross_features": []string{
 			"bb_position", "price_vs_sma20", "price_vs_sma50", "price_vs_sma200",
 			"ma_crossover", "rsi_oversold", "rsi_overbought",
 		},
+		"derivatives_features": []string{"funding_rate", "open_interest", "open_interest_value"},
 		"indicator_categories": fiber.Map{
 			"trend": []string{
 				"sma20", "sma50", "sma200", "ema12", "ema26", "ema50",
@@ -744,10 +1396,11 @@ func (h *MLExportHandler) GetAvailableFeatures(c *fiber.Ctx) error {
 			{"id": "zscore", "name": "Z-Score", "description": "Standardize to zero mean, unit variance"},
 			{"id": "robust", "name": "Robust", "description": "Scale using median and IQR"},
 		},
+		"descriptions": service.FeatureDescriptions(),
 		"nan_handling": []fiber.Map{
 			{"id": "drop", "name": "Drop", "description": "Remove rows with NaN"},
-			{"id": "forward_fill", "name": "Forward Fill", "description": "Fill with last valid value"},
-			{"id": "backward_fill", "name": "Backward Fill", "description": "Fill with next valid value"},
+			{"id": "forward_fill", "name": "Forward Fill", "description": "Fill with last valid value, across at most forward_fill_limit rows when set"},
+			{"id": "backward_fill", "name": "Backward Fill", "description": "Fill with next valid value, across at most forward_fill_limit rows when set"},
 			{"id": "interpolate", "name": "Interpolate", "description": "Linear interpolation"},
 			{"id": "zero", "name": "Zero", "description": "Replace with zero"},
 		},
@@ -789,16 +1442,20 @@ type CreateDatasetRequest struct {
 
 // CreateDataset creates a combined dataset from multiple jobs
 // @Summary Create combined dataset
-// @Description Creates a combined ML dataset from multiple data collection jobs
+// @Description Creates a combined ML dataset from multiple data collection jobs. With config.separate_files set, each job is exported on its own and the files are bundled into one .zip with a manifest.json mapping them to their jobs. The response includes each source's time range and flags sources that do not overlap. Retrying with the same Idempotency-Key header and body returns the original job instead of starting another.
 // @Tags ML Export
 // @Accept json
 // @Produce json
 // @Param request body CreateDatasetRequest true "Dataset configuration"
+// @Param Idempotency-Key header string false "Client-chosen key that makes retries safe"
+// @Success 200 {object} ExportResponse "Dataset creation already started with this Idempotency-Key"
 // @Success 202 {object} ExportResponse "Dataset creation started"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Idempotency-Key in progress or used with a different request"
+// @Failure 503 {object} map[string]interface{} "Export disk limit reached or exports unavailable"
 // @Router /ml/datasets [post]
 func (h *MLExportHandler) CreateDataset(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	var req CreateDatasetRequest
@@ -835,10 +1492,21 @@ func (h *MLExportHandler) CreateDataset(c *fiber.Ctx) error {
 	req.Config.Name = req.Name
 	req.Config.Description = req.Description
 
-	// Start export
-	exportJob, err := h.exportService.StartExport(ctx, req.Config, jobIDs)
+	// Report how the sources overlap so users know the dataset's structure up front
+	coverage, err := h.exportService.PreviewCoverage(ctx, jobIDs)
 	if err != nil {
-		return errors.SendError(c, errors.InternalError(err.Error()))
+		if strings.Contains(err.Error(), "failed to find job") {
+			return errors.SendError(c, errors.ValidationError("Job not found", map[string]string{
+				"job_ids": err.Error(),
+			}))
+		}
+		return errors.SendError(c, errors.DatabaseError("Failed to analyze source coverage"))
+	}
+
+	// Start export, or return the one a previous attempt with this key started
+	exportJob, replayed, err := h.exportService.StartExportIdempotent(ctx, service.IdempotencyScopeDataset, c.Get("Idempotency-Key"), req.Config, jobIDs)
+	if err != nil {
+		return sendStartExportError(c, err)
 	}
 
 	response := ExportResponse{
@@ -846,13 +1514,24 @@ func (h *MLExportHandler) CreateDataset(c *fiber.Ctx) error {
 		Status:       string(exportJob.Status),
 		Progress:     exportJob.Progress,
 		CurrentPhase: exportJob.CurrentPhase,
-		DownloadURL:  fmt.Sprintf("/api/v1/ml/export/jobs/%s/download", exportJob.ID.Hex()),
+		DownloadURL:  h.downloadURL(exportJob.ID),
+	}
+
+	if replayed {
+		c.Set("Idempotent-Replayed", "true")
+		return c.JSON(fiber.Map{
+			"success":  true,
+			"data":     response,
+			"coverage": coverage,
+			"message":  "Dataset creation already started with this idempotency key",
+		})
 	}
 
 	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
-		"success": true,
-		"data":    response,
-		"message": "Dataset creation started",
+		"success":  true,
+		"data":     response,
+		"coverage": coverage,
+		"message":  "Dataset creation started",
 	})
 }
 
@@ -866,7 +1545,7 @@ func (h *MLExportHandler) CreateDataset(c *fiber.Ctx) error {
 // @Failure 404 {object} map[string]interface{} "Job not found"
 // @Router /ml/export/jobs/{id}/metadata [get]
 func (h *MLExportHandler) GetExportMetadata(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -884,3 +1563,159 @@ func (h *MLExportHandler) GetExportMetadata(c *fiber.Ctx) error {
 		"data":    exportJob.Metadata,
 	})
 }
+
+// PreflightExport checks whether source jobs have enough bars for an export config
+// @Summary Preflight an export
+// @Description Checks, without starting an export, that each source job has enough bars for the largest window, lag, lookahead and sequence of a config. The config is a saved profile, a builtin preset, or the default config.
+// @Tags ML Export
+// @Produce json
+// @Param job_ids query string true "Comma-separated source job IDs"
+// @Param profile_id query string false "Saved export profile ID"
+// @Param preset query string false "Builtin preset name"
+// @Success 200 {object} models.ExportPreflight "Preflight result"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Profile or preset not found"
+// @Router /ml/export/preflight [get]
+func (h *MLExportHandler) PreflightExport(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	var jobIDs []primitive.ObjectID
+	for _, idStr := range strings.Split(c.Query("job_ids"), ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+		objID, err := primitive.ObjectIDFromHex(idStr)
+		if err != nil {
+			return errors.SendError(c, errors.ValidationError("Invalid job ID", map[string]string{
+				"job_id": idStr,
+			}))
+		}
+		jobIDs = append(jobIDs, objID)
+	}
+	if len(jobIDs) == 0 {
+		return errors.SendError(c, errors.ValidationError("No job IDs provided", map[string]string{
+			"job_ids": "at least one job ID is required",
+		}))
+	}
+
+	config := models.DefaultMLExportConfig()
+	if profileID := c.Query("profile_id"); profileID != "" {
+		profile, err := h.exportService.GetConfig(ctx, profileID)
+		if err != nil {
+			return errors.SendError(c, errors.NotFound("Profile"))
+		}
+		config = *profile
+	} else if presetName := c.Query("preset"); presetName != "" {
+		found := false
+		for _, preset := range models.GetBuiltinPresets() {
+			if preset.Name == presetName {
+				config = preset
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.SendError(c, errors.NotFound("Preset"))
+		}
+	}
+
+	preflight, err := h.exportService.Preflight(ctx, config, jobIDs)
+	if err != nil {
+		if strings.Contains(err.Error(), "failed to find job") {
+			return errors.SendError(c, errors.ValidationError("Job not found", map[string]string{
+				"job_ids": err.Error(),
+			}))
+		}
+		return errors.SendError(c, errors.DatabaseError("Failed to check source jobs"))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    preflight,
+	})
+}
+
+// validateTargetSpec returns a message describing what is wrong with a target
+// spec, or an empty string if it is valid
+func validateTargetSpec(spec models.TargetSpec) string {
+	switch spec.Type {
+	case models.TargetTypeFutureReturns, models.TargetTypeFutureDirection, models.TargetTypeFutureVolatility:
+	case models.TargetTypeFutureClass:
+		switch spec.BinMode {
+		case "", models.TargetBinModeFixed:
+			if len(spec.ClassificationBins) == 0 {
+				return "classification_bins are required for future_class"
+			}
+			if !sort.Float64sAreSorted(spec.ClassificationBins) {
+				return "classification_bins must be in ascending order"
+			}
+		case models.TargetBinModeQuantile:
+			if spec.NumClasses < 2 {
+				return "num_classes must be >= 2 for quantile bins"
+			}
+		default:
+			return "bin_mode must be fixed or quantile"
+		}
+	default:
+		return "type must be future_returns, future_direction, future_class or future_volatility"
+	}
+
+	for _, period := range spec.LookaheadPeriods {
+		if period <= 0 {
+			return "lookahead_periods must be positive"
+		}
+	}
+	return ""
+}
+
+// GetModelCard returns a data card documenting how a completed export was built
+// @Summary Get export data card
+// @Description Renders the export metadata (sources, date range, feature schema with NaN stats, normalization params, split and sequence info) and the config that produced it as a Markdown or JSON data card
+// @Tags ML Export
+// @Produce json
+// @Produce text/markdown
+// @Param id path string true "Export job ID"
+// @Param format query string false "Output format (json, markdown)" default(json)
+// @Success 200 {object} models.MLModelCard "Data card"
+// @Failure 400 {object} map[string]interface{} "Invalid format or export not completed"
+// @Failure 404 {object} map[string]interface{} "Job not found"
+// @Router /ml/export/jobs/{id}/model-card [get]
+func (h *MLExportHandler) GetModelCard(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	id := c.Params("id")
+	if id == "" {
+		return errors.SendError(c, errors.BadRequest("Missing job ID"))
+	}
+
+	format := strings.ToLower(c.Query("format", "json"))
+	if format != "json" && format != "markdown" && format != "md" {
+		return errors.SendError(c, errors.ValidationError("Invalid format", map[string]string{
+			"format": "must be json or markdown",
+		}))
+	}
+
+	exportJob, err := h.exportService.GetExportJob(ctx, id)
+	if err != nil {
+		return errors.SendError(c, errors.NotFound("Export job"))
+	}
+
+	if exportJob.Status != models.MLExportStatusCompleted && exportJob.Status != models.MLExportStatusExpired {
+		return errors.SendError(c, errors.BadRequest("Export is not completed"))
+	}
+
+	card := service.BuildModelCard(exportJob)
+
+	if format == "json" {
+		return c.JSON(fiber.Map{
+			"success": true,
+			"data":    card,
+		})
+	}
+
+	c.Set("Content-Type", "text/markdown; charset=utf-8")
+	return c.SendString(service.RenderModelCardMarkdown(card))
+}
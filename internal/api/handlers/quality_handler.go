@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -28,12 +30,31 @@ func NewQualityHandler(qualityService *service.QualityService, jobRepo *reposito
 
 // GetCachedSummary returns the cached quality summary
 // GET /api/v1/quality/summary
+// Passing include_gaps computes a live summary instead: include_gaps=false
+// aggregates chunk metadata for a fast overview, include_gaps=true also runs
+// gap detection on every series. The cached summary carries an ETag of its
+// last update and honors If-None-Match with 304 Not Modified.
 func (h *QualityHandler) GetCachedSummary(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	exchangeID := c.Query("exchange_id")
 
+	if c.Query("include_gaps") != "" {
+		ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
+		defer cancel()
+
+		summary, err := h.qualityService.ComputeSummary(ctx, exchangeID, c.QueryBool("include_gaps", true))
+		if err != nil {
+			return errors.SendError(c, errors.InternalError("Failed to compute quality summary: "+err.Error()))
+		}
+
+		return c.JSON(fiber.Map{
+			"success": true,
+			"data":    summary,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
 	summary, err := h.qualityService.GetCachedSummary(ctx, exchangeID)
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Failed to get quality summary: "+err.Error()))
@@ -46,6 +67,10 @@ func (h *QualityHandler) GetCachedSummary(c *fiber.Ctx) error {
 		}
 	}
 
+	if notModified(c, versionETag(summary.UpdatedAt, 1)) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    summary,
@@ -54,8 +79,10 @@ func (h *QualityHandler) GetCachedSummary(c *fiber.Ctx) error {
 
 // GetCachedResults returns all cached quality results
 // GET /api/v1/quality
+// The response carries an ETag of the latest check among the results and
+// honors If-None-Match with 304 Not Modified.
 func (h *QualityHandler) GetCachedResults(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	exchangeID := c.Query("exchange_id")
@@ -66,6 +93,16 @@ func (h *QualityHandler) GetCachedResults(c *fiber.Ctx) error {
 		return errors.SendError(c, errors.InternalError("Failed to get quality results: "+err.Error()))
 	}
 
+	var latest time.Time
+	for _, result := range results {
+		if result.UpdatedAt.After(latest) {
+			latest = result.UpdatedAt
+		}
+	}
+	if notModified(c, versionETag(latest, len(results))) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    results,
@@ -73,11 +110,32 @@ func (h *QualityHandler) GetCachedResults(c *fiber.Ctx) error {
 	})
 }
 
+// GetStaleJobs lists the active OHLCV jobs breaching their freshness SLA
+// GET /api/v1/jobs/stale?exchange_id=...
+// A job's SLA is its freshness_sla_minutes, or the end of the "fresh" range
+// of its freshness thresholds for its timeframe when that isn't set. Jobs
+// without data are listed first, then by how far past their SLA they are.
+func (h *QualityHandler) GetStaleJobs(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	stale, err := h.qualityService.FindStaleJobs(ctx, c.Query("exchange_id"))
+	if err != nil {
+		return errors.SendError(c, errors.InternalError("Failed to find stale jobs: "+err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    stale,
+		"count":   len(stale),
+	})
+}
+
 // GetJobQuality returns the cached quality for a specific job
 // GET /api/v1/jobs/:id/quality
 func (h *QualityHandler) GetJobQuality(c *fiber.Ctx) error {
 	// Use longer timeout since it may trigger analysis for uncached jobs
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 120*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -109,7 +167,7 @@ func (h *QualityHandler) GetJobQuality(c *fiber.Ctx) error {
 // StartQualityCheck starts a background quality check
 // POST /api/v1/quality/check
 func (h *QualityHandler) StartQualityCheck(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var req struct {
@@ -149,7 +207,7 @@ func (h *QualityHandler) StartQualityCheck(c *fiber.Ctx) error {
 // GetCheckJobStatus returns the status of a quality check job
 // GET /api/v1/quality/checks/:id
 func (h *QualityHandler) GetCheckJobStatus(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	checkID := c.Params("id")
@@ -172,7 +230,7 @@ func (h *QualityHandler) GetCheckJobStatus(c *fiber.Ctx) error {
 // GetActiveCheckJobs returns all active check jobs
 // GET /api/v1/quality/checks/active
 func (h *QualityHandler) GetActiveCheckJobs(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobs, err := h.qualityService.GetActiveCheckJobs(ctx)
@@ -190,7 +248,7 @@ func (h *QualityHandler) GetActiveCheckJobs(c *fiber.Ctx) error {
 // GetRecentCheckJobs returns recent check jobs
 // GET /api/v1/quality/checks
 func (h *QualityHandler) GetRecentCheckJobs(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	limit := c.QueryInt("limit", 20)
@@ -214,7 +272,7 @@ func (h *QualityHandler) GetRecentCheckJobs(c *fiber.Ctx) error {
 // POST /api/v1/jobs/:id/quality/refresh
 func (h *QualityHandler) RefreshJobQuality(c *fiber.Ctx) error {
 	// Use longer timeout for jobs with lots of data
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 120*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -239,7 +297,7 @@ func (h *QualityHandler) RefreshJobQuality(c *fiber.Ctx) error {
 // FillJobGaps starts a background gap fill job
 // POST /api/v1/jobs/:id/quality/fill-gaps
 func (h *QualityHandler) FillJobGaps(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -267,7 +325,7 @@ func (h *QualityHandler) FillJobGaps(c *fiber.Ctx) error {
 // GetGapFillStatus returns the status of a gap fill job
 // GET /api/v1/jobs/:id/quality/fill-gaps/status
 func (h *QualityHandler) GetGapFillStatus(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -307,7 +365,7 @@ func (h *QualityHandler) GetGapFillStatus(c *fiber.Ctx) error {
 // GetGapFillHistory returns the gap fill history for a job
 // GET /api/v1/jobs/:id/quality/fill-gaps/history
 func (h *QualityHandler) GetGapFillHistory(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -331,7 +389,7 @@ func (h *QualityHandler) GetGapFillHistory(c *fiber.Ctx) error {
 // StartBackfill starts a background backfill job to fetch historical data
 // POST /api/v1/jobs/:id/quality/backfill
 func (h *QualityHandler) StartBackfill(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -360,7 +418,7 @@ func (h *QualityHandler) StartBackfill(c *fiber.Ctx) error {
 // GetBackfillStatus returns the status of a backfill job
 // GET /api/v1/jobs/:id/quality/backfill/status
 func (h *QualityHandler) GetBackfillStatus(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
@@ -397,10 +455,68 @@ func (h *QualityHandler) GetBackfillStatus(c *fiber.Ctx) error {
 	})
 }
 
+// StartConnectorBackfill queues a backfill for every OHLCV job of a connector.
+// The backfills run one at a time through the connector's backfill worker.
+// POST /api/v1/connectors/:id/backfill
+func (h *QualityHandler) StartConnectorBackfill(c *fiber.Ctx) error {
+	// Each job is analyzed before its backfill is queued
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	connectorID := c.Params("id")
+
+	var req struct {
+		MonthsBack int    `json:"months_back"` // How many months back to fetch
+		TargetDate string `json:"target_date"` // Specific target date (YYYY-MM-DD)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		// Default values will be used
+	}
+
+	status, err := h.qualityService.StartConnectorBackfill(ctx, connectorID, req.MonthsBack, req.TargetDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.SendError(c, errors.NotFound("Connector"))
+		}
+		if strings.Contains(err.Error(), "invalid target date") {
+			return errors.SendError(c, errors.ValidationError(err.Error(), nil))
+		}
+		return errors.SendError(c, errors.InternalError("Failed to start connector backfill: "+err.Error()))
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": fmt.Sprintf("Backfill queued for %d jobs", status.TotalJobs),
+		"data":    status,
+	})
+}
+
+// GetConnectorBackfillStatus returns the aggregate and per-job progress of a
+// connector's most recent connector backfill
+// GET /api/v1/connectors/:id/backfill/status
+func (h *QualityHandler) GetConnectorBackfillStatus(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	status, err := h.qualityService.GetConnectorBackfillStatus(ctx, c.Params("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.SendError(c, errors.NotFound("Connector"))
+		}
+		return errors.SendError(c, errors.InternalError("Failed to get connector backfill status: "+err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    status,
+	})
+}
+
 // GetBackfillHistory returns the backfill history for a job
 // GET /api/v1/jobs/:id/quality/backfill/history
 func (h *QualityHandler) GetBackfillHistory(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	jobID := c.Params("id")
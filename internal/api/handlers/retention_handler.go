@@ -31,7 +31,7 @@ func NewRetentionHandler(retentionRepo *repository.RetentionRepository, retentio
 // GetPolicies retrieves all retention policies
 // GET /api/v1/retention/policies
 func (h *RetentionHandler) GetPolicies(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	policies, err := h.retentionRepo.FindAllPolicies(ctx)
@@ -49,7 +49,7 @@ func (h *RetentionHandler) GetPolicies(c *fiber.Ctx) error {
 // GetPolicy retrieves a single retention policy
 // GET /api/v1/retention/policies/:id
 func (h *RetentionHandler) GetPolicy(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -71,7 +71,7 @@ func (h *RetentionHandler) GetPolicy(c *fiber.Ctx) error {
 // CreatePolicy creates a new retention policy
 // POST /api/v1/retention/policies
 func (h *RetentionHandler) CreatePolicy(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var req models.RetentionPolicyCreateRequest
@@ -80,10 +80,53 @@ func (h *RetentionHandler) CreatePolicy(c *fiber.Ctx) error {
 	}
 
 	// Validate required fields
-	if req.Name == "" || req.RetentionDays < 1 {
-		return errors.SendError(c, errors.ValidationError("Invalid policy configuration", map[string]string{
-			"name":           "required",
-			"retention_days": "must be at least 1",
+	if req.Action == "" {
+		req.Action = models.RetentionPolicyActionDelete
+	}
+	switch req.Action {
+	case models.RetentionPolicyActionDelete:
+		if req.Name == "" || req.RetentionDays < 1 {
+			return errors.SendError(c, errors.ValidationError("Invalid policy configuration", map[string]string{
+				"name":           "required",
+				"retention_days": "must be at least 1",
+			}))
+		}
+	case models.RetentionPolicyActionDownsample:
+		if req.Name == "" || req.Timeframe == nil || req.TargetTimeframe == nil || req.AfterDays < 1 {
+			return errors.SendError(c, errors.ValidationError("Invalid downsample policy configuration", map[string]string{
+				"name":             "required",
+				"timeframe":        "required (source timeframe)",
+				"target_timeframe": "required",
+				"after_days":       "must be at least 1",
+			}))
+		}
+		sourceMs, err := models.GetTimeframeMs(*req.Timeframe)
+		if err != nil {
+			return errors.SendError(c, errors.ValidationError("Invalid timeframe", map[string]string{
+				"timeframe": err.Error(),
+			}))
+		}
+		targetMs, err := models.GetTimeframeMs(*req.TargetTimeframe)
+		if err != nil {
+			return errors.SendError(c, errors.ValidationError("Invalid target timeframe", map[string]string{
+				"target_timeframe": err.Error(),
+			}))
+		}
+		if targetMs <= sourceMs {
+			return errors.SendError(c, errors.ValidationError("Invalid target timeframe", map[string]string{
+				"target_timeframe": "must be coarser than timeframe",
+			}))
+		}
+	default:
+		return errors.SendError(c, errors.ValidationError("Invalid policy action", map[string]interface{}{
+			"action":         req.Action,
+			"allowed_values": []string{"delete", "downsample"},
+		}))
+	}
+
+	if req.MaxCandles != nil && *req.MaxCandles < 1 {
+		return errors.SendError(c, errors.ValidationError("Invalid max candles", map[string]string{
+			"max_candles": "must be at least 1",
 		}))
 	}
 
@@ -107,6 +150,10 @@ func (h *RetentionHandler) CreatePolicy(c *fiber.Ctx) error {
 		MaxCandles:     req.MaxCandles,
 		KeepLatestOnly: req.KeepLatestOnly,
 		RunSchedule:    req.RunSchedule,
+
+		Action:          req.Action,
+		TargetTimeframe: req.TargetTimeframe,
+		AfterDays:       req.AfterDays,
 	}
 
 	if err := h.retentionRepo.CreatePolicy(ctx, policy); err != nil {
@@ -122,7 +169,7 @@ func (h *RetentionHandler) CreatePolicy(c *fiber.Ctx) error {
 // UpdatePolicy updates a retention policy
 // PUT /api/v1/retention/policies/:id
 func (h *RetentionHandler) UpdatePolicy(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -149,6 +196,11 @@ func (h *RetentionHandler) UpdatePolicy(c *fiber.Ctx) error {
 		update["retention_days"] = *req.RetentionDays
 	}
 	if req.MaxCandles != nil {
+		if *req.MaxCandles < 1 {
+			return errors.SendError(c, errors.ValidationError("Invalid max candles", map[string]string{
+				"max_candles": "must be at least 1",
+			}))
+		}
 		update["max_candles"] = *req.MaxCandles
 	}
 	if req.KeepLatestOnly != nil {
@@ -157,6 +209,17 @@ func (h *RetentionHandler) UpdatePolicy(c *fiber.Ctx) error {
 	if req.RunSchedule != nil {
 		update["run_schedule"] = *req.RunSchedule
 	}
+	if req.TargetTimeframe != nil {
+		update["target_timeframe"] = *req.TargetTimeframe
+	}
+	if req.AfterDays != nil {
+		if *req.AfterDays < 1 {
+			return errors.SendError(c, errors.ValidationError("Invalid after days", map[string]string{
+				"after_days": "must be at least 1",
+			}))
+		}
+		update["after_days"] = *req.AfterDays
+	}
 
 	if len(update) == 0 {
 		return errors.SendError(c, errors.BadRequest("No fields to update"))
@@ -183,7 +246,7 @@ func (h *RetentionHandler) UpdatePolicy(c *fiber.Ctx) error {
 // DeletePolicy deletes a retention policy
 // DELETE /api/v1/retention/policies/:id
 func (h *RetentionHandler) DeletePolicy(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	id := c.Params("id")
@@ -204,7 +267,7 @@ func (h *RetentionHandler) DeletePolicy(c *fiber.Ctx) error {
 // GetConfig retrieves the retention configuration
 // GET /api/v1/retention/config
 func (h *RetentionHandler) GetConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	config, err := h.retentionRepo.GetConfig(ctx)
@@ -221,7 +284,7 @@ func (h *RetentionHandler) GetConfig(c *fiber.Ctx) error {
 // UpdateConfig updates the retention configuration
 // PUT /api/v1/retention/config
 func (h *RetentionHandler) UpdateConfig(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var config models.RetentionConfig
@@ -248,27 +311,29 @@ func (h *RetentionHandler) UpdateConfig(c *fiber.Ctx) error {
 }
 
 // RunCleanup manually triggers a cleanup operation
-// POST /api/v1/retention/cleanup
+// POST /api/v1/retention/cleanup?dry_run=true
 func (h *RetentionHandler) RunCleanup(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
 	defer cancel()
 
-	summary, err := h.retentionService.RunCleanup(ctx)
+	dryRun := c.QueryBool("dry_run", false)
+
+	summary, err := h.retentionService.RunCleanup(ctx, dryRun)
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Cleanup failed: "+err.Error()))
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Cleanup completed",
+		"message": cleanupMessage("Cleanup", dryRun),
 		"data":    summary,
 	})
 }
 
 // RunDefaultCleanup runs cleanup with default retention days
-// POST /api/v1/retention/cleanup/default
+// POST /api/v1/retention/cleanup/default?dry_run=true
 func (h *RetentionHandler) RunDefaultCleanup(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
 	defer cancel()
 
 	days := c.QueryInt("days", 365)
@@ -278,22 +343,24 @@ func (h *RetentionHandler) RunDefaultCleanup(c *fiber.Ctx) error {
 		}))
 	}
 
-	result, err := h.retentionService.RunDefaultCleanup(ctx, days)
+	dryRun := c.QueryBool("dry_run", false)
+
+	result, err := h.retentionService.RunDefaultCleanup(ctx, days, dryRun)
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Cleanup failed: "+err.Error()))
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Default cleanup completed",
+		"message": cleanupMessage("Default cleanup", dryRun),
 		"data":    result,
 	})
 }
 
 // CleanupExchange runs cleanup for a specific exchange
-// POST /api/v1/retention/cleanup/exchange/:exchangeId
+// POST /api/v1/retention/cleanup/exchange/:exchangeId?dry_run=true
 func (h *RetentionHandler) CleanupExchange(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
 	defer cancel()
 
 	exchangeID := c.Params("exchangeId")
@@ -305,14 +372,16 @@ func (h *RetentionHandler) CleanupExchange(c *fiber.Ctx) error {
 		}))
 	}
 
-	result, err := h.retentionService.CleanupByExchange(ctx, exchangeID, days)
+	dryRun := c.QueryBool("dry_run", false)
+
+	result, err := h.retentionService.CleanupByExchange(ctx, exchangeID, days, dryRun)
 	if err != nil {
 		return errors.SendError(c, errors.InternalError("Cleanup failed: "+err.Error()))
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Exchange cleanup completed",
+		"message": cleanupMessage("Exchange cleanup", dryRun),
 		"data":    result,
 	})
 }
@@ -320,7 +389,7 @@ func (h *RetentionHandler) CleanupExchange(c *fiber.Ctx) error {
 // GetDataUsage returns data usage statistics
 // GET /api/v1/retention/usage
 func (h *RetentionHandler) GetDataUsage(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
 	exchangeID := c.Query("exchange_id", "")
@@ -347,7 +416,7 @@ func (h *RetentionHandler) GetDataUsage(c *fiber.Ctx) error {
 // DeleteEmptyChunks removes chunks with no candles
 // POST /api/v1/retention/cleanup/empty
 func (h *RetentionHandler) DeleteEmptyChunks(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Minute)
 	defer cancel()
 
 	deleted, err := h.retentionService.DeleteEmptyChunks(ctx)
@@ -361,3 +430,36 @@ func (h *RetentionHandler) DeleteEmptyChunks(c *fiber.Ctx) error {
 		"chunks_deleted": deleted,
 	})
 }
+
+// RepairChunkBoundaries recomputes each chunk's candle count and time range
+// from its candles and fixes the chunks that don't match
+// POST /api/v1/retention/chunks/repair?dry_run=true&exchange_id=binance
+func (h *RetentionHandler) RepairChunkBoundaries(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Minute)
+	defer cancel()
+
+	dryRun := c.QueryBool("dry_run", false)
+
+	result, err := h.retentionService.RepairChunkBoundaries(ctx, c.Query("exchange_id"), dryRun)
+	if err != nil {
+		return errors.SendError(c, errors.DatabaseError("Failed to check chunk consistency"))
+	}
+
+	message := "Chunk consistency check completed"
+	if dryRun {
+		message = "Chunk consistency dry run completed, nothing was repaired"
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": message,
+		"data":    result,
+	})
+}
+
+// cleanupMessage describes a finished cleanup, noting when nothing was deleted
+func cleanupMessage(operation string, dryRun bool) string {
+	if dryRun {
+		return operation + " dry run completed, nothing was deleted"
+	}
+	return operation + " completed"
+}
@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// DefaultLimit is used when no limit is given. It is large so clients that
+	// predate pagination keep receiving full lists.
+	DefaultLimit int64 = 10000
+
+	// MaxLimit caps the number of items returned by a single page
+	MaxLimit int64 = 10000
+)
+
+// Params holds the limit/offset requested by a client
+type Params struct {
+	Limit  int64
+	Offset int64
+}
+
+// Meta describes the page returned in a list response
+type Meta struct {
+	Total      int64 `json:"total"`
+	Limit      int64 `json:"limit"`
+	Offset     int64 `json:"offset"`
+	Page       int64 `json:"page"`
+	TotalPages int64 `json:"total_pages"`
+	HasMore    bool  `json:"has_more"`
+}
+
+// FromQuery reads ?limit= and ?offset= from the request, clamping invalid values
+func FromQuery(c *fiber.Ctx) Params {
+	limit := int64(c.QueryInt("limit", int(DefaultLimit)))
+	offset := int64(c.QueryInt("offset", 0))
+
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return Params{Limit: limit, Offset: offset}
+}
+
+// NewMeta builds page metadata for a result set of total items
+func NewMeta(p Params, total int64) Meta {
+	totalPages := (total + p.Limit - 1) / p.Limit
+
+	return Meta{
+		Total:      total,
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+		Page:       p.Offset/p.Limit + 1,
+		TotalPages: totalPages,
+		HasMore:    p.Offset+p.Limit < total,
+	}
+}
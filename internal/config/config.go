@@ -15,24 +15,60 @@ type Config struct {
 	Database       DatabaseConfig
 	Exchange       ExchangeConfig
 	HistoricalData HistoricalDataConfig
+	MLExport       MLExportConfig
+	Jobs           JobsConfig
+	Quality        QualityConfig
+	Storage        StorageConfig
 }
 
 // ServerConfig holds HTTP server configuration
 type ServerConfig struct {
 	Port string
 	Host string
+
+	// MaxPageLimit caps the limit of paginated candle reads; larger limits are
+	// clamped. MaxExportRows caps the candles of one job data export, which is
+	// streamed, to bound the memory a single request can use.
+	MaxPageLimit  int
+	MaxExportRows int
+
+	// PublicBaseURL is where clients reach the API, e.g.
+	// https://example.com/datacollector behind a path-prefixing reverse proxy.
+	// Download URLs in responses are built from it; when empty they are
+	// paths relative to the host.
+	PublicBaseURL string
 }
 
 // DatabaseConfig holds MongoDB configuration
 type DatabaseConfig struct {
 	URI      string
 	Database string
+
+	// Write concern for all writes: "majority", a number of nodes, or empty
+	// for the server default. WriteJournal also waits for the journal.
+	WriteConcern string
+	WriteJournal bool
 }
 
 // ExchangeConfig holds exchange-related configuration
 type ExchangeConfig struct {
 	EnableRateLimit bool
 	RequestTimeout  int // in milliseconds
+
+	// Consecutive failures after which a connector's circuit breaker opens
+	// and its jobs are skipped (0 = disabled)
+	CircuitBreakerThreshold int
+
+	// Seconds an open circuit waits before letting a probe execution through
+	CircuitBreakerCooldownSeconds int
+
+	// Minutes cached exchange metadata is served before it is fetched again
+	// (0 = until refreshed)
+	MetadataCacheTTLMinutes int
+
+	// Minutes between background refreshes of cached exchange metadata
+	// (0 = disabled)
+	MetadataRefreshMinutes int
 }
 
 // HistoricalDataConfig holds configuration for historical data fetching
@@ -49,10 +85,111 @@ type HistoricalDataConfig struct {
 	// Maximum candles to fetch per execution (rate limit protection)
 	MaxCandlesPerFetch int
 
+	// Maximum candles a catch-up run pages through before stopping until the
+	// next run (0 = page until the present)
+	MaxCandlesPerRun int
+
+	// Maximum trades a trades job pages through per run (0 = page until the
+	// present)
+	MaxTradesPerRun int
+
 	// Backfill batch size for large gaps
 	BackfillBatchSize int
 }
 
+// MLExportConfig holds configuration for ML dataset exports
+type MLExportConfig struct {
+	// Hours to keep export files after completion when the export config
+	// does not set its own retention (0 = never expire)
+	DefaultRetentionHours int
+
+	// How often the background cleanup removes expired exports
+	CleanupIntervalMinutes int
+
+	// Maximum total size of files in the export directory (0 = unlimited)
+	MaxDiskBytes int64
+
+	// Free space below which the export directory's filesystem is reported
+	// as low by /health (0 = no check)
+	MinFreeDiskBytes int64
+
+	// Number of source jobs whose candles are loaded concurrently
+	LoadConcurrency int
+
+	// Hours a generated feature matrix is cached for reuse by later exports
+	// of unchanged data with the same feature config (0 = disabled)
+	FeatureCacheTTLHours int
+
+	// Maximum number of source jobs an export with auto_recalculate_missing
+	// recalculates before loading (0 = none)
+	MaxAutoRecalculations int
+
+	// Hours an Idempotency-Key sent to export start or dataset creation is
+	// remembered; retries with the same key return the original job
+	IdempotencyKeyTTLHours int
+
+	// S3-compatible object store (AWS, MinIO) export files are stored in, so
+	// every API replica can serve them. Exports stay on local disk when
+	// S3Bucket is empty.
+	S3 S3Config
+}
+
+// S3Config holds the connection settings of an S3-compatible object store
+type S3Config struct {
+	Endpoint  string // e.g. https://s3.amazonaws.com or http://minio:9000
+	Region    string
+	Bucket    string
+	Prefix    string // Key prefix of stored objects
+	AccessKey string
+	SecretKey string
+
+	// Address the bucket in the path (endpoint/bucket/key) instead of the
+	// host name; MinIO needs this
+	PathStyle bool
+
+	// Redirect downloads to a presigned URL instead of streaming the object
+	// through the API
+	PresignDownloads bool
+
+	// Minutes a presigned download URL stays valid
+	PresignExpiryMinutes int
+}
+
+// JobsConfig holds configuration for job execution
+type JobsConfig struct {
+	// Number of most recent executions kept in each job's run history
+	RunHistorySize int
+}
+
+// QualityConfig holds configuration for data quality analysis
+type QualityConfig struct {
+	// Age of the newest candle, in bars, up to which data counts as fresh
+	FreshMultiplier float64
+
+	// Age of the newest candle, in bars, up to which data counts as stale;
+	// older data is very stale
+	StaleMultiplier float64
+
+	// Re-analyze a job's quality in the background after an execution or
+	// ingest stores candles for it, instead of waiting for the hourly check
+	RefreshOnWrite bool
+
+	// Seconds a job must go without new writes before its quality is
+	// re-analyzed, so rapid runs trigger a single analysis
+	RefreshDebounceSeconds int
+}
+
+// StorageConfig holds configuration for OHLCV storage
+type StorageConfig struct {
+	// Store chunk candles as a zstd-compressed blob instead of a BSON array.
+	// Saves disk space at the cost of CPU on every chunk read and write.
+	CompressChunks bool
+
+	// Hours between background scans repairing chunks whose candle count or
+	// time range no longer matches their candles (0 disables)
+	ConsistencyCheckHours int
+}
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if it exists
@@ -62,14 +199,27 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+
+			MaxPageLimit:  getEnvInt("SERVER_MAX_PAGE_LIMIT", 1000),
+			MaxExportRows: getEnvInt("SERVER_MAX_EXPORT_ROWS", 1000000),
+			PublicBaseURL: getEnv("SERVER_PUBLIC_BASE_URL", ""),
 		},
 		Database: DatabaseConfig{
 			URI:      getEnv("MONGODB_URI", "mongodb://localhost:27017"),
 			Database: getEnv("MONGODB_DATABASE", "datacollector"),
+
+			WriteConcern: getEnv("MONGODB_WRITE_CONCERN", ""),
+			WriteJournal: getEnvBool("MONGODB_WRITE_JOURNAL", false),
 		},
 		Exchange: ExchangeConfig{
 			EnableRateLimit: getEnvBool("EXCHANGE_ENABLE_RATE_LIMIT", true),
 			RequestTimeout:  getEnvInt("EXCHANGE_REQUEST_TIMEOUT", 30000),
+
+			CircuitBreakerThreshold:       getEnvInt("EXCHANGE_CIRCUIT_BREAKER_THRESHOLD", 5),
+			CircuitBreakerCooldownSeconds: getEnvInt("EXCHANGE_CIRCUIT_BREAKER_COOLDOWN_SECONDS", 300),
+
+			MetadataCacheTTLMinutes: getEnvInt("EXCHANGE_METADATA_CACHE_TTL_MINUTES", 1440),
+			MetadataRefreshMinutes:  getEnvInt("EXCHANGE_METADATA_REFRESH_MINUTES", 360),
 		},
 		HistoricalData: HistoricalDataConfig{
 			Start1m:            getEnvInt("HISTORICAL_START_1m", 7),     // 7 days for 1m candles
@@ -80,8 +230,45 @@ func Load() (*Config, error) {
 			Start1d:            getEnvInt("HISTORICAL_START_1d", 1095),  // 3 years for 1d candles
 			Start1w:            getEnvInt("HISTORICAL_START_1w", 1825),  // 5 years for 1w candles
 			MaxCandlesPerFetch: getEnvInt("MAX_CANDLES_PER_FETCH", 1000),
+			MaxCandlesPerRun:   getEnvInt("MAX_CANDLES_PER_RUN", 50000),
+			MaxTradesPerRun:    getEnvInt("MAX_TRADES_PER_RUN", 50000),
 			BackfillBatchSize:  getEnvInt("BACKFILL_BATCH_SIZE", 500),
 		},
+		MLExport: MLExportConfig{
+			DefaultRetentionHours:  getEnvInt("ML_EXPORT_RETENTION_HOURS", 24),
+			CleanupIntervalMinutes: getEnvInt("ML_EXPORT_CLEANUP_INTERVAL_MINUTES", 60),
+			MaxDiskBytes:           int64(getEnvInt("ML_EXPORT_MAX_DISK_BYTES", 0)),
+			MinFreeDiskBytes:       int64(getEnvInt("ML_EXPORT_MIN_FREE_DISK_BYTES", 1<<30)),
+			LoadConcurrency:        getEnvInt("ML_EXPORT_LOAD_CONCURRENCY", 4),
+			FeatureCacheTTLHours:   getEnvInt("ML_EXPORT_FEATURE_CACHE_TTL_HOURS", 24),
+			MaxAutoRecalculations:  getEnvInt("ML_EXPORT_MAX_AUTO_RECALCULATIONS", 5),
+			IdempotencyKeyTTLHours: getEnvInt("ML_EXPORT_IDEMPOTENCY_TTL_HOURS", 24),
+			S3: S3Config{
+				Endpoint:             getEnv("ML_EXPORT_S3_ENDPOINT", "https://s3.amazonaws.com"),
+				Region:               getEnv("ML_EXPORT_S3_REGION", "us-east-1"),
+				Bucket:               getEnv("ML_EXPORT_S3_BUCKET", ""),
+				Prefix:               getEnv("ML_EXPORT_S3_PREFIX", "ml_exports"),
+				AccessKey:            getEnv("ML_EXPORT_S3_ACCESS_KEY", ""),
+				SecretKey:            getEnv("ML_EXPORT_S3_SECRET_KEY", ""),
+				PathStyle:            getEnvBool("ML_EXPORT_S3_PATH_STYLE", true),
+				PresignDownloads:     getEnvBool("ML_EXPORT_S3_PRESIGN_DOWNLOADS", true),
+				PresignExpiryMinutes: getEnvInt("ML_EXPORT_S3_PRESIGN_EXPIRY_MINUTES", 15),
+			},
+		},
+		Jobs: JobsConfig{
+			RunHistorySize: getEnvInt("JOB_RUN_HISTORY_SIZE", 100),
+		},
+		Quality: QualityConfig{
+			FreshMultiplier: getEnvFloat("QUALITY_FRESH_MULTIPLIER", 2),
+			StaleMultiplier: getEnvFloat("QUALITY_STALE_MULTIPLIER", 10),
+
+			RefreshOnWrite:         getEnvBool("QUALITY_REFRESH_ON_WRITE", false),
+			RefreshDebounceSeconds: getEnvInt("QUALITY_REFRESH_DEBOUNCE_SECONDS", 30),
+		},
+		Storage: StorageConfig{
+			CompressChunks:        getEnvBool("OHLCV_COMPRESS_CHUNKS", false),
+			ConsistencyCheckHours: getEnvInt("OHLCV_CONSISTENCY_CHECK_HOURS", 24),
+		},
 	}
 
 	// Validate required fields
@@ -124,6 +311,18 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat retrieves a float environment variable or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		floatVal, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return defaultValue
+		}
+		return floatVal
+	}
+	return defaultValue
+}
+
 // GetHistoricalStartDate returns the start date for historical data fetching based on timeframe
 func (h *HistoricalDataConfig) GetHistoricalStartDate(timeframe string) time.Time {
 	var daysBack int
@@ -14,6 +14,7 @@ import (
 // metadataCache caches exchange metadata to avoid repeated instantiation
 var (
 	metadataCache     = make(map[string]*ExchangeMetadata)
+	metadataCacheTTL  time.Duration
 	metadataCacheLock sync.RWMutex
 	supportedCache    []string
 	supportedCacheLock sync.RWMutex
@@ -134,17 +135,27 @@ func ClearCache() {
 	metadataCache = make(map[string]*ExchangeMetadata)
 	metadataCacheLock.Unlock()
 
+	ClearSupportedCache()
+
+	log.Println("[EXCHANGE] Cache cleared")
+}
+
+// ClearSupportedCache clears the supported exchanges cache so the next lookup
+// rediscovers them, keeping cached metadata
+func ClearSupportedCache() {
 	supportedCacheLock.Lock()
 	supportedCache = nil
 	supportedCacheLock.Unlock()
-
-	log.Println("[EXCHANGE] Cache cleared")
 }
 
 // Adapter defines the interface for exchange operations
 type Adapter interface {
 	LoadMarkets() error
 	FetchOHLCV(symbol, timeframe string, since *time.Time, limit int) ([]models.Candle, error)
+	FetchTrades(symbol string, since *time.Time, limit int) ([]models.Trade, error)
+	FetchOrderBook(symbol string, limit int) (*models.OrderBookSnapshot, error)
+	FetchFundingRateHistory(symbol string, since *time.Time, limit int) ([]models.FundingRate, error)
+	FetchOpenInterestHistory(symbol, timeframe string, since *time.Time, limit int) ([]models.OpenInterest, error)
 	GetExchangeID() string
 	Close() error
 }
@@ -229,6 +240,12 @@ func DebugExchange(exchangeID string) map[string]interface{} {
 
 // NewCCXTAdapter creates a new CCXT adapter
 func NewCCXTAdapter(exchangeID string, enableRateLimit bool) (*CCXTAdapter, error) {
+	return NewCCXTAdapterWithTimeout(exchangeID, enableRateLimit, 30000)
+}
+
+// NewCCXTAdapterWithTimeout creates an adapter whose HTTP requests to the
+// exchange time out after timeoutMs milliseconds
+func NewCCXTAdapterWithTimeout(exchangeID string, enableRateLimit bool, timeoutMs int) (*CCXTAdapter, error) {
 	ccxtExchangeID := mapExchangeID(exchangeID)
 	log.Printf("[EXCHANGE] NewCCXTAdapter called for '%s' (mapped to '%s')", exchangeID, ccxtExchangeID)
 
@@ -256,7 +273,7 @@ func NewCCXTAdapter(exchangeID string, enableRateLimit bool) (*CCXTAdapter, erro
 
 	options := map[string]interface{}{
 		"enableRateLimit": enableRateLimit,
-		"timeout":         30000,
+		"timeout":         timeoutMs,
 	}
 
 	exchange := ccxt.CreateExchange(ccxtExchangeID, options)
@@ -317,6 +334,168 @@ func (a *CCXTAdapter) FetchOHLCV(symbol, timeframe string, since *time.Time, lim
 	return candles, nil
 }
 
+// FetchTrades fetches public trades for a symbol, oldest first
+func (a *CCXTAdapter) FetchTrades(symbol string, since *time.Time, limit int) ([]models.Trade, error) {
+	var options []ccxt.FetchTradesOptions
+
+	if since != nil {
+		options = append(options, ccxt.WithFetchTradesSince(since.UnixMilli()))
+	}
+
+	if limit > 0 {
+		options = append(options, ccxt.WithFetchTradesLimit(int64(limit)))
+	}
+
+	tradeData, err := a.exchange.FetchTrades(symbol, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trades: %w", err)
+	}
+
+	trades := make([]models.Trade, 0, len(tradeData))
+	for _, t := range tradeData {
+		// Skip trades the exchange reported without a time or price
+		if t.Timestamp == nil || t.Price == nil || t.Amount == nil {
+			continue
+		}
+		trade := models.Trade{
+			Timestamp: *t.Timestamp,
+			Price:     *t.Price,
+			Amount:    *t.Amount,
+		}
+		if t.Id != nil {
+			trade.TradeID = *t.Id
+		}
+		if t.Side != nil {
+			trade.Side = *t.Side
+		}
+		if t.Cost != nil {
+			trade.Cost = *t.Cost
+		}
+		if t.TakerOrMaker != nil {
+			trade.TakerOrMaker = *t.TakerOrMaker
+		}
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// FetchOrderBook fetches an order book snapshot for a symbol with up to limit
+// levels per side (0 = exchange default)
+func (a *CCXTAdapter) FetchOrderBook(symbol string, limit int) (*models.OrderBookSnapshot, error) {
+	var options []ccxt.FetchOrderBookOptions
+
+	if limit > 0 {
+		options = append(options, ccxt.WithFetchOrderBookLimit(int64(limit)))
+	}
+
+	book, err := a.exchange.FetchOrderBook(symbol, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order book: %w", err)
+	}
+
+	snapshot := &models.OrderBookSnapshot{
+		Bids: book.Bids,
+		Asks: book.Asks,
+	}
+	// Not every exchange timestamps its order books; fall back to the
+	// time the snapshot was received
+	if book.Timestamp != nil && *book.Timestamp > 0 {
+		snapshot.Timestamp = *book.Timestamp
+	} else {
+		snapshot.Timestamp = time.Now().UnixMilli()
+	}
+	if book.Nonce != nil {
+		snapshot.Nonce = *book.Nonce
+	}
+
+	return snapshot, nil
+}
+
+// FetchFundingRateHistory fetches the funding rate history of a perpetual
+// futures symbol, oldest first
+func (a *CCXTAdapter) FetchFundingRateHistory(symbol string, since *time.Time, limit int) ([]models.FundingRate, error) {
+	options := []ccxt.FetchFundingRateHistoryOptions{ccxt.WithFetchFundingRateHistorySymbol(symbol)}
+
+	if since != nil {
+		options = append(options, ccxt.WithFetchFundingRateHistorySince(since.UnixMilli()))
+	}
+
+	if limit > 0 {
+		options = append(options, ccxt.WithFetchFundingRateHistoryLimit(int64(limit)))
+	}
+
+	history, err := a.exchange.FetchFundingRateHistory(options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch funding rate history: %w", err)
+	}
+
+	rates := make([]models.FundingRate, 0, len(history))
+	for _, h := range history {
+		if h.Timestamp == nil || h.FundingRate == nil {
+			continue
+		}
+		rates = append(rates, models.FundingRate{
+			Timestamp:   *h.Timestamp,
+			FundingRate: *h.FundingRate,
+		})
+	}
+
+	return rates, nil
+}
+
+// FetchOpenInterestHistory fetches the open interest history of a perpetual
+// futures symbol at the given timeframe, oldest first
+func (a *CCXTAdapter) FetchOpenInterestHistory(symbol, timeframe string, since *time.Time, limit int) ([]models.OpenInterest, error) {
+	options := []ccxt.FetchOpenInterestHistoryOptions{ccxt.WithFetchOpenInterestHistoryTimeframe(timeframe)}
+
+	if since != nil {
+		options = append(options, ccxt.WithFetchOpenInterestHistorySince(since.UnixMilli()))
+	}
+
+	if limit > 0 {
+		options = append(options, ccxt.WithFetchOpenInterestHistoryLimit(int64(limit)))
+	}
+
+	history, err := a.exchange.FetchOpenInterestHistory(symbol, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open interest history: %w", err)
+	}
+
+	points := make([]models.OpenInterest, 0, len(history))
+	for _, h := range history {
+		if h.Timestamp == nil || h.OpenInterestAmount == nil {
+			continue
+		}
+		point := models.OpenInterest{
+			Timestamp:    *h.Timestamp,
+			OpenInterest: *h.OpenInterestAmount,
+		}
+		if h.OpenInterestValue != nil {
+			point.OpenInterestValue = *h.OpenInterestValue
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// Ping performs the cheapest call available to check that the exchange is
+// reachable: fetchTime when the exchange supports it, otherwise loading markets
+func (a *CCXTAdapter) Ping() error {
+	if hasFetchTime, ok := a.exchange.GetHas()["fetchTime"].(bool); ok && hasFetchTime {
+		if _, err := a.exchange.FetchTime(); err != nil {
+			return fmt.Errorf("failed to fetch server time: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := a.exchange.LoadMarkets(); err != nil {
+		return fmt.Errorf("failed to load markets: %w", err)
+	}
+	return nil
+}
+
 // GetExchangeID returns the exchange identifier
 func (a *CCXTAdapter) GetExchangeID() string {
 	return a.exchangeID
@@ -343,20 +522,104 @@ type ExchangeMetadata struct {
 	OHLCVLimit  int               `json:"ohlcv_limit"`
 	Symbols     []string          `json:"symbols,omitempty"`
 	SymbolCount int               `json:"symbol_count"`
+
+	// Capabilities records which of the CCXT methods jobs depend on the
+	// exchange supports, natively or emulated
+	Capabilities map[string]bool `json:"capabilities"`
+
+	// Features records how the exchange supports each of capabilityFeatures:
+	// "native", "emulated" or "unsupported"
+	Features map[string]string `json:"features"`
+
+	// MarketTypes lists the market types the exchange trades (spot, margin,
+	// swap, future, option)
+	MarketTypes []string `json:"market_types"`
+
+	LastRefreshed time.Time `json:"last_refreshed"`
+	RefreshError  string    `json:"refresh_error,omitempty"` // Set while serving a copy whose last refresh failed
 }
 
-// GetExchangeMetadata fetches metadata dynamically from CCXT
+// GetExchangeMetadata returns an exchange's metadata, fetching it from CCXT
+// when it isn't cached or the cached copy is older than the cache TTL. When a
+// refresh fails the cached copy is served, stale but usable.
 func GetExchangeMetadata(exchangeID string) (*ExchangeMetadata, error) {
 	ccxtExchangeID := mapExchangeID(exchangeID)
 
 	// Check cache first
 	metadataCacheLock.RLock()
-	if cached, ok := metadataCache[ccxtExchangeID]; ok {
-		metadataCacheLock.RUnlock()
+	cached, ok := metadataCache[ccxtExchangeID]
+	ttl := metadataCacheTTL
+	metadataCacheLock.RUnlock()
+	if ok && (ttl <= 0 || time.Since(cached.LastRefreshed) < ttl) {
 		return cached, nil
 	}
+
+	metadata, err := RefreshExchangeMetadata(exchangeID)
+	if err != nil && metadata != nil {
+		return metadata, nil
+	}
+	return metadata, err
+}
+
+// RefreshExchangeMetadata fetches an exchange's metadata from CCXT and caches
+// it. If the fetch fails and a cached copy exists, the copy is kept, marked
+// with the refresh error, and returned along with the error.
+func RefreshExchangeMetadata(exchangeID string) (*ExchangeMetadata, error) {
+	ccxtExchangeID := mapExchangeID(exchangeID)
+
+	metadata, err := fetchExchangeMetadata(ccxtExchangeID)
+
+	metadataCacheLock.Lock()
+	defer metadataCacheLock.Unlock()
+
+	if err != nil {
+		cached, ok := metadataCache[ccxtExchangeID]
+		if !ok {
+			return nil, err
+		}
+		stale := *cached
+		stale.RefreshError = err.Error()
+		metadataCache[ccxtExchangeID] = &stale
+		log.Printf("[EXCHANGE] Failed to refresh metadata for %s, serving copy from %s: %v",
+			ccxtExchangeID, cached.LastRefreshed.Format(time.RFC3339), err)
+		return &stale, err
+	}
+
+	metadataCache[ccxtExchangeID] = metadata
+	return metadata, nil
+}
+
+// RefreshCachedMetadata refreshes the metadata of every cached exchange and
+// returns how many refreshes succeeded and failed
+func RefreshCachedMetadata() (int, int) {
+	metadataCacheLock.RLock()
+	exchangeIDs := make([]string, 0, len(metadataCache))
+	for id := range metadataCache {
+		exchangeIDs = append(exchangeIDs, id)
+	}
 	metadataCacheLock.RUnlock()
 
+	refreshed, failed := 0, 0
+	for _, id := range exchangeIDs {
+		if _, err := RefreshExchangeMetadata(id); err != nil {
+			failed++
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, failed
+}
+
+// SetMetadataCacheTTL sets how long cached exchange metadata is served before
+// it is fetched again (0 = until refreshed or cleared)
+func SetMetadataCacheTTL(ttl time.Duration) {
+	metadataCacheLock.Lock()
+	metadataCacheTTL = ttl
+	metadataCacheLock.Unlock()
+}
+
+// fetchExchangeMetadata builds an exchange's metadata from a CCXT instance
+func fetchExchangeMetadata(ccxtExchangeID string) (*ExchangeMetadata, error) {
 	// Create exchange instance to fetch metadata
 	options := map[string]interface{}{
 		"enableRateLimit": false,
@@ -376,6 +639,7 @@ func GetExchangeMetadata(exchangeID string) (*ExchangeMetadata, error) {
 		Timeout:   30000,
 		HasOHLCV:  false,
 		OHLCVLimit: 500,
+		LastRefreshed: time.Now(),
 	}
 
 	// Get timeframes dynamically
@@ -394,6 +658,19 @@ func GetExchangeMetadata(exchangeID string) (*ExchangeMetadata, error) {
 	if hasFetchOHLCV, ok := has["fetchOHLCV"]; ok {
 		metadata.HasOHLCV = hasFetchOHLCV == true
 	}
+	metadata.Features = make(map[string]string, len(capabilityFeatures))
+	for _, feature := range capabilityFeatures {
+		metadata.Features[feature] = featureSupport(has[feature])
+	}
+	metadata.Capabilities = make(map[string]bool, len(jobCapabilities))
+	for _, capability := range jobCapabilities {
+		metadata.Capabilities[capability] = featureSupport(has[capability]) != FeatureUnsupported
+	}
+	for _, marketType := range marketTypes {
+		if has[marketType] == true {
+			metadata.MarketTypes = append(metadata.MarketTypes, marketType)
+		}
+	}
 
 	// Get features for OHLCV limit
 	features := exchange.GetFeatures()
@@ -418,17 +695,196 @@ func GetExchangeMetadata(exchangeID string) (*ExchangeMetadata, error) {
 		}
 	}
 
-	// Cache the result
-	metadataCacheLock.Lock()
-	metadataCache[ccxtExchangeID] = metadata
-	metadataCacheLock.Unlock()
-
 	log.Printf("[EXCHANGE] Fetched metadata for %s: %d timeframes, OHLCV limit: %d",
 		ccxtExchangeID, len(metadata.Timeframes), metadata.OHLCVLimit)
 
 	return metadata, nil
 }
 
+// jobCapabilities maps each job type to the CCXT method it collects with
+var jobCapabilities = map[string]string{
+	models.JobTypeOHLCV:        "fetchOHLCV",
+	models.JobTypeTrades:       "fetchTrades",
+	models.JobTypeOrderBook:    "fetchOrderBook",
+	models.JobTypeFunding:      "fetchFundingRateHistory",
+	models.JobTypeOpenInterest: "fetchOpenInterestHistory",
+}
+
+// Levels of support CCXT reports for a method
+const (
+	FeatureNative      = "native"
+	FeatureEmulated    = "emulated"
+	FeatureUnsupported = "unsupported"
+)
+
+// capabilityFeatures are the CCXT methods reported in capability documents:
+// those jobs collect with and the ones their related features rely on
+var capabilityFeatures = []string{
+	"fetchOHLCV",
+	"fetchTrades",
+	"fetchOrderBook",
+	"fetchFundingRate",
+	"fetchFundingRateHistory",
+	"fetchOpenInterest",
+	"fetchOpenInterestHistory",
+	"fetchTicker",
+	"fetchTime",
+}
+
+// marketTypes are the market types CCXT flags in an exchange's has map
+var marketTypes = []string{"spot", "margin", "swap", "future", "option"}
+
+// jobTypeOrder lists job types in the order capability documents report them
+var jobTypeOrder = []string{
+	models.JobTypeOHLCV,
+	models.JobTypeTrades,
+	models.JobTypeOrderBook,
+	models.JobTypeFunding,
+	models.JobTypeOpenInterest,
+}
+
+// featureSupport normalizes a CCXT has value, which is true, false, nil or
+// "emulated"
+func featureSupport(has interface{}) string {
+	switch has {
+	case true:
+		return FeatureNative
+	case "emulated":
+		return FeatureEmulated
+	default:
+		return FeatureUnsupported
+	}
+}
+
+// JobTypeSupport reports whether an exchange can run jobs of one type
+type JobTypeSupport struct {
+	JobType   string `json:"job_type"`
+	Method    string `json:"method"`  // CCXT method the job collects with
+	Support   string `json:"support"` // "native", "emulated" or "unsupported"
+	Supported bool   `json:"supported"`
+
+	// Timeframes the exchange serves for job types whose data has a
+	// timeframe; for other job types the timeframe only sets how often the
+	// job runs and any timeframe is accepted
+	Timeframes []string `json:"timeframes,omitempty"`
+}
+
+// ExchangeCapabilities is a normalized report of what an exchange supports,
+// built from CCXT's has and timeframes maps
+type ExchangeCapabilities struct {
+	ExchangeID  string            `json:"exchange_id"`
+	Name        string            `json:"name"`
+	MarketTypes []string          `json:"market_types"`
+	Timeframes  []string          `json:"timeframes"` // Shortest first
+	OHLCVLimit  int               `json:"ohlcv_limit"`
+	JobTypes    []JobTypeSupport  `json:"job_types"`
+	Features    map[string]string `json:"features"`
+
+	LastRefreshed time.Time `json:"last_refreshed"`
+	RefreshError  string    `json:"refresh_error,omitempty"`
+}
+
+// GetExchangeCapabilities returns an exchange's capability report, from its
+// cached metadata
+func GetExchangeCapabilities(exchangeID string) (*ExchangeCapabilities, error) {
+	metadata, err := GetExchangeMetadata(exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	return newExchangeCapabilities(metadata), nil
+}
+
+// newExchangeCapabilities builds the capability report of an exchange's metadata
+func newExchangeCapabilities(metadata *ExchangeMetadata) *ExchangeCapabilities {
+	timeframes := make([]string, 0, len(metadata.Timeframes))
+	for tf := range metadata.Timeframes {
+		timeframes = append(timeframes, tf)
+	}
+	sort.Slice(timeframes, func(i, j int) bool {
+		di := models.GetTimeframeDurationMinutes(timeframes[i])
+		dj := models.GetTimeframeDurationMinutes(timeframes[j])
+		if di != dj {
+			return di < dj
+		}
+		return timeframes[i] < timeframes[j]
+	})
+
+	marketTypes := metadata.MarketTypes
+	if marketTypes == nil {
+		marketTypes = []string{}
+	}
+
+	capabilities := &ExchangeCapabilities{
+		ExchangeID:    metadata.ID,
+		Name:          metadata.Name,
+		MarketTypes:   marketTypes,
+		Timeframes:    timeframes,
+		OHLCVLimit:    metadata.OHLCVLimit,
+		JobTypes:      make([]JobTypeSupport, 0, len(jobTypeOrder)),
+		Features:      metadata.Features,
+		LastRefreshed: metadata.LastRefreshed,
+		RefreshError:  metadata.RefreshError,
+	}
+
+	for _, jobType := range jobTypeOrder {
+		method := jobCapabilities[jobType]
+		support := metadata.Features[method]
+		if support == "" {
+			support = FeatureUnsupported
+		}
+		entry := JobTypeSupport{
+			JobType:   jobType,
+			Method:    method,
+			Support:   support,
+			Supported: support != FeatureUnsupported,
+		}
+		if entry.Supported && (jobType == models.JobTypeOHLCV || jobType == models.JobTypeOpenInterest) {
+			entry.Timeframes = timeframes
+		}
+		capabilities.JobTypes = append(capabilities.JobTypes, entry)
+	}
+
+	return capabilities
+}
+
+// JobCapability returns the CCXT method a job type collects with
+func JobCapability(jobType string) string {
+	return jobCapabilities[jobType]
+}
+
+// SupportsJobType reports whether an exchange supports the CCXT method a job
+// type collects with, from its cached metadata
+func SupportsJobType(exchangeID, jobType string) (bool, error) {
+	metadata, err := GetExchangeMetadata(exchangeID)
+	if err != nil {
+		return false, err
+	}
+	capability, ok := jobCapabilities[jobType]
+	if !ok {
+		return false, fmt.Errorf("unknown job type: %s", jobType)
+	}
+	if metadata.Capabilities == nil {
+		return false, fmt.Errorf("capabilities unknown for %s", exchangeID)
+	}
+	return metadata.Capabilities[capability], nil
+}
+
+// SupportedTimeframes returns the sorted timeframes an exchange supports,
+// from its cached metadata
+func SupportedTimeframes(exchangeID string) ([]string, error) {
+	metadata, err := GetExchangeMetadata(exchangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeframes := make([]string, 0, len(metadata.Timeframes))
+	for tf := range metadata.Timeframes {
+		timeframes = append(timeframes, tf)
+	}
+	sort.Strings(timeframes)
+	return timeframes, nil
+}
+
 // GetExchangeSymbols fetches all available symbols for an exchange
 func GetExchangeSymbols(exchangeID string) ([]string, error) {
 	ccxtExchangeID := mapExchangeID(exchangeID)
@@ -456,6 +912,36 @@ func GetExchangeSymbols(exchangeID string) ([]string, error) {
 	return symbols, nil
 }
 
+// GetExchangeMarketStatus loads an exchange's markets and returns whether each
+// symbol's market is active. Markets whose status the exchange doesn't report
+// are treated as active.
+func GetExchangeMarketStatus(exchangeID string) (map[string]bool, error) {
+	ccxtExchangeID := mapExchangeID(exchangeID)
+
+	options := map[string]interface{}{
+		"enableRateLimit": true,
+		"timeout":         30000,
+	}
+
+	exchange := ccxt.CreateExchange(ccxtExchangeID, options)
+	if exchange == nil {
+		return nil, fmt.Errorf("failed to create exchange instance for %s", ccxtExchangeID)
+	}
+	defer exchange.Close()
+
+	markets, err := exchange.LoadMarkets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+
+	status := make(map[string]bool, len(markets))
+	for symbol, market := range markets {
+		status[symbol] = market.Active == nil || *market.Active
+	}
+
+	return status, nil
+}
+
 // GetAllExchangesMetadata fetches metadata for all supported exchanges
 func GetAllExchangesMetadata() []ExchangeMetadata {
 	exchanges := GetSupportedExchanges()
@@ -0,0 +1,70 @@
+// Package logging carries a request ID through contexts so the log lines of
+// one request, including the background work it starts, can be correlated
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// RequestIDHeader is the header a request ID is read from and returned in
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// validRequestID limits client-supplied IDs to what is safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// NewRequestID generates a random 16 character request ID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// RequestIDFromHeader returns the client-supplied request ID if it is usable,
+// otherwise a new one
+func RequestIDFromHeader(header string) string {
+	if validRequestID.MatchString(header) {
+		return header
+	}
+	return NewRequestID()
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Detach returns a background context carrying ctx's request ID, for work
+// that must outlive ctx's deadline but still log under its request
+func Detach(ctx context.Context) context.Context {
+	return WithRequestID(context.Background(), RequestID(ctx))
+}
+
+// Printf logs like log.Printf, prefixed with ctx's request ID when it has one
+func Printf(ctx context.Context, format string, args ...interface{}) {
+	if id := RequestID(ctx); id != "" {
+		log.Printf("[req=%s] %s", id, fmt.Sprintf(format, args...))
+		return
+	}
+	log.Printf(format, args...)
+}
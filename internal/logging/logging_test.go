@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDFromHeader(t *testing.T) {
+	if got := RequestIDFromHeader("abc-123"); got != "abc-123" {
+		t.Errorf("valid header replaced with %q", got)
+	}
+	for _, header := range []string{"", "has space", "line\nbreak", strings.Repeat("a", 65)} {
+		got := RequestIDFromHeader(header)
+		if got == header || len(got) != 16 {
+			t.Errorf("header %q gave %q, want a new 16 character ID", header, got)
+		}
+	}
+}
+
+func TestPrintf(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	ctx := WithRequestID(context.Background(), "req1")
+	Printf(ctx, "[ML_EXPORT] exported %d rows", 5)
+	Printf(context.Background(), "[ML_EXPORT] no request")
+
+	want := "[req=req1] [ML_EXPORT] exported 5 rows\n[ML_EXPORT] no request\n"
+	if buf.String() != want {
+		t.Errorf("logged %q, want %q", buf.String(), want)
+	}
+	if RequestID(WithRequestID(context.Background(), "")) != "" {
+		t.Error("empty request ID should not be stored")
+	}
+}
@@ -17,6 +17,12 @@ type Connector struct {
 
 	RateLimit RateLimit `bson:"rate_limit" json:"rate_limit"`
 
+	// Timeout for a single exchange request in milliseconds (0 = EXCHANGE_REQUEST_TIMEOUT)
+	RequestTimeoutMs int `bson:"request_timeout_ms,omitempty" json:"request_timeout_ms,omitempty"`
+
+	// Indicator config used by the connector's jobs (empty = global default config)
+	IndicatorConfigID string `bson:"indicator_config_id,omitempty" json:"indicator_config_id,omitempty"`
+
 	// Health monitoring
 	Health ConnectorHealth `bson:"health" json:"health"`
 
@@ -37,6 +43,21 @@ type ConnectorHealth struct {
 	LastResponseMs       int64      `bson:"last_response_ms" json:"last_response_ms"`                               // Last response time in ms
 	LastHealthCheck      *time.Time `bson:"last_health_check,omitempty" json:"last_health_check,omitempty"`         // Last health check timestamp
 	UptimePercentage     float64    `bson:"uptime_percentage" json:"uptime_percentage"`                             // Uptime percentage (0-100)
+	CircuitState         string     `bson:"circuit_state,omitempty" json:"circuit_state,omitempty"`                 // "closed", "open", "half_open"
+	CircuitOpenUntil     *time.Time `bson:"circuit_open_until,omitempty" json:"circuit_open_until,omitempty"`       // When an open circuit lets a probe through
+	History              []HealthBucket `bson:"history,omitempty" json:"-"`                                       // Hourly call buckets, see /health/history
+}
+
+// Circuit breaker states of a connector
+const (
+	CircuitClosed   = "closed"    // Executions run normally
+	CircuitOpen     = "open"      // Executions are skipped until the cooldown ends
+	CircuitHalfOpen = "half_open" // A single probe execution is in flight
+)
+
+// IsCircuitOpen reports whether the connector's circuit breaker is open or probing
+func (h ConnectorHealth) IsCircuitOpen() bool {
+	return h.CircuitState == CircuitOpen || h.CircuitState == CircuitHalfOpen
 }
 
 // RateLimit holds rate limiting configuration and state
@@ -68,6 +89,8 @@ type ConnectorCreateRequest struct {
 		PeriodMs   int `json:"period_ms" validate:"required,min=1000"`    // Period in milliseconds
 		MinDelayMs int `json:"min_delay_ms" validate:"omitempty,min=100"` // Min delay between calls (default: calculated from limit/period)
 	} `json:"rate_limit"`
+	RequestTimeoutMs  int    `json:"request_timeout_ms,omitempty" validate:"omitempty,min=1000"` // Per-request timeout (default: EXCHANGE_REQUEST_TIMEOUT)
+	IndicatorConfigID string `json:"indicator_config_id,omitempty"`                              // Indicator config for the connector's jobs (default: global default config)
 }
 
 // ConnectorUpdateRequest is the DTO for updating a connector
@@ -79,6 +102,8 @@ type ConnectorUpdateRequest struct {
 		PeriodMs   *int `json:"period_ms,omitempty" validate:"omitempty,min=1000"`
 		MinDelayMs *int `json:"min_delay_ms,omitempty" validate:"omitempty,min=100"`
 	} `json:"rate_limit,omitempty"`
+	RequestTimeoutMs  *int    `json:"request_timeout_ms,omitempty" validate:"omitempty,min=0"` // 0 reverts to EXCHANGE_REQUEST_TIMEOUT
+	IndicatorConfigID *string `json:"indicator_config_id,omitempty"`                           // Empty string reverts to the global default config
 }
 
 // ConnectorResponse is the enhanced DTO with additional computed fields
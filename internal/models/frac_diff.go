@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// Default parameters of the frac_diff price feature
+const (
+	DefaultFracDiffD         = 0.4
+	DefaultFracDiffThreshold = 1e-4
+)
+
+// FracDiffConfig configures the frac_diff price feature, the fixed-width
+// window fractional difference of the log close (López de Prado, Advances in
+// Financial Machine Learning, ch. 5). Zero fields use the defaults.
+type FracDiffConfig struct {
+	// D is the order of differencing, between 0 and 1 exclusive. Lower values
+	// keep more memory of the price level, higher values are closer to log
+	// returns.
+	D float64 `bson:"d" json:"d"`
+
+	// Threshold drops the weights whose magnitude falls below it, which fixes
+	// the window width
+	Threshold float64 `bson:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// params returns d and the weight threshold with defaults filled in. A nil
+// config uses all defaults.
+func (c *FracDiffConfig) params() (d, threshold float64) {
+	d, threshold = DefaultFracDiffD, DefaultFracDiffThreshold
+	if c == nil {
+		return d, threshold
+	}
+	if c.D != 0 {
+		d = c.D
+	}
+	if c.Threshold != 0 {
+		threshold = c.Threshold
+	}
+	return d, threshold
+}
+
+// Validate checks that d is between 0 and 1 and the threshold between 0 and 1
+func (c *FracDiffConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	d, threshold := c.params()
+	if math.IsNaN(d) || d <= 0 || d >= 1 {
+		return fmt.Errorf("invalid frac_diff: d must be between 0 and 1 exclusive")
+	}
+	if math.IsNaN(threshold) || threshold <= 0 || threshold >= 1 {
+		return fmt.Errorf("invalid frac_diff: threshold must be between 0 and 1 exclusive")
+	}
+	return nil
+}
+
+// Weights returns the fractional differencing weights, newest bar first:
+// w0 = 1 and wk = -w(k-1) * (d - k + 1) / k, stopping at the first weight
+// whose magnitude is below the threshold
+func (c *FracDiffConfig) Weights() []float64 {
+	d, threshold := c.params()
+	weights := []float64{1}
+	for k := 1; ; k++ {
+		w := -weights[k-1] * (d - float64(k) + 1) / float64(k)
+		if math.Abs(w) < threshold {
+			return weights
+		}
+		weights = append(weights, w)
+	}
+}
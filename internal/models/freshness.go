@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FreshnessThresholds sets how old the newest candle may get, in bars of the
+// job's timeframe, before its data is reported as stale or very stale
+type FreshnessThresholds struct {
+	FreshMultiplier float64 `bson:"fresh_multiplier" json:"fresh_multiplier"` // Up to this many bars old is "fresh"
+	StaleMultiplier float64 `bson:"stale_multiplier" json:"stale_multiplier"` // Up to this many bars old is "stale", beyond is "very_stale"
+}
+
+// DefaultFreshnessThresholds matches the historical 2x/10x bar duration limits
+var DefaultFreshnessThresholds = FreshnessThresholds{FreshMultiplier: 2, StaleMultiplier: 10}
+
+// StaleJob is an active OHLCV job whose newest candle is older than its
+// freshness SLA allows
+type StaleJob struct {
+	JobID         primitive.ObjectID `json:"job_id"`
+	ExchangeID    string             `json:"exchange_id"`
+	Symbol        string             `json:"symbol"`
+	Timeframe     string             `json:"timeframe"`
+	NewestCandle  *time.Time         `json:"newest_candle,omitempty"` // Nil when the job has no data yet
+	AgeMinutes    int64              `json:"age_minutes"`             // Age of the newest candle, from its close when the job drops unclosed bars
+	SLAMinutes    int64              `json:"sla_minutes"`             // 0 for a job without data and a derived SLA
+	SLASource     string             `json:"sla_source"`              // "job" when set on the job, "timeframe" when derived
+	BreachMinutes int64              `json:"breach_minutes"`          // How far past the SLA the data is
+	DataFreshness string             `json:"data_freshness"`          // Freshness class from the thresholds, or "no_data"
+}
+
+// Validate checks that the multipliers are positive and ordered
+func (t FreshnessThresholds) Validate() error {
+	if t.FreshMultiplier <= 0 {
+		return fmt.Errorf("fresh_multiplier must be greater than 0")
+	}
+	if t.StaleMultiplier < t.FreshMultiplier {
+		return fmt.Errorf("stale_multiplier must be greater than or equal to fresh_multiplier")
+	}
+	return nil
+}
+
+// FreshnessReference returns the time a series' age is measured from, given
+// its newest candle's open time. Runs that drop the still-forming bar store a
+// candle only once it has closed, so its age counts from the close; counting
+// from the open would make a job running on time a bar behind already.
+func FreshnessReference(timeframe string, newest time.Time, excludesUnclosedBar bool) time.Time {
+	if !excludesUnclosedBar {
+		return newest
+	}
+	return time.UnixMilli(NextBarTime(timeframe, newest.UnixMilli()))
+}
+
+// ClassifyFreshness returns "fresh", "stale" or "very_stale" for a series whose
+// age is measured from newest (see FreshnessReference). Intraday timeframes compare the candle's age
+// against a fixed multiple of the bar duration. Daily and higher timeframes
+// step forward through the expected next bar times instead, so a monthly
+// series is measured in calendar months and a weekly one in Monday-aligned weeks.
+func ClassifyFreshness(timeframe string, newest, now time.Time, t FreshnessThresholds) string {
+	switch nowMs := now.UnixMilli(); {
+	case nowMs <= freshnessLimit(timeframe, newest, t.FreshMultiplier):
+		return "fresh"
+	case nowMs <= freshnessLimit(timeframe, newest, t.StaleMultiplier):
+		return "stale"
+	default:
+		return "very_stale"
+	}
+}
+
+// FreshnessSLADeadline returns when a series whose age is measured from newest
+// breaches its freshness SLA. An SLA of slaMinutes > 0 is measured from
+// newest; otherwise the SLA is the end of the "fresh" range of
+// the thresholds, so it follows the timeframe like ClassifyFreshness.
+func FreshnessSLADeadline(timeframe string, newest time.Time, slaMinutes int, t FreshnessThresholds) time.Time {
+	if slaMinutes > 0 {
+		return newest.Add(time.Duration(slaMinutes) * time.Minute)
+	}
+	return time.UnixMilli(freshnessLimit(timeframe, newest, t.FreshMultiplier))
+}
+
+// freshnessLimit returns the time, in Unix milliseconds, at which a series
+// whose newest candle opened at newest becomes bars bars old
+func freshnessLimit(timeframe string, newest time.Time, bars float64) int64 {
+	durationMs := barMs(timeframe)
+	if durationMs >= 24*60*60*1000 {
+		return advanceBars(timeframe, TimeframeBucketStart(timeframe, newest.UnixMilli()), bars)
+	}
+	return newest.UnixMilli() + int64(bars*float64(durationMs))
+}
+
+// advanceBars returns the time bars bars after the bar opening at ts, following
+// the expected next bar times for whole bars and the nominal bar duration for
+// any fraction
+func advanceBars(timeframe string, ts int64, bars float64) int64 {
+	whole := int(bars)
+	for i := 0; i < whole; i++ {
+		ts = NextBarTime(timeframe, ts)
+	}
+	durationMs := barMs(timeframe)
+	return ts + int64((bars-float64(whole))*float64(durationMs))
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyFreshnessIntraday(t *testing.T) {
+	newest := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{90 * time.Minute, "fresh"},
+		{2 * time.Hour, "fresh"},
+		{9 * time.Hour, "stale"},
+		{11 * time.Hour, "very_stale"},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyFreshness("1h", newest, newest.Add(tt.age), DefaultFreshnessThresholds); got != tt.want {
+			t.Errorf("age %s: got %s, want %s", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyFreshnessMonthly(t *testing.T) {
+	// Two monthly bars after January are February and March, so data is
+	// still fresh on 2024-03-01 even though less than 60 days have passed
+	newest := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got := ClassifyFreshness("1M", newest, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), DefaultFreshnessThresholds); got != "fresh" {
+		t.Errorf("got %s, want fresh", got)
+	}
+	if got := ClassifyFreshness("1M", newest, time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC), DefaultFreshnessThresholds); got != "stale" {
+		t.Errorf("got %s, want stale", got)
+	}
+}
+
+func TestClassifyFreshnessCustomThresholds(t *testing.T) {
+	newest := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	thresholds := FreshnessThresholds{FreshMultiplier: 1.5, StaleMultiplier: 3}
+
+	if got := ClassifyFreshness("1d", newest, newest.Add(36*time.Hour), thresholds); got != "fresh" {
+		t.Errorf("got %s, want fresh", got)
+	}
+	if got := ClassifyFreshness("1d", newest, newest.Add(37*time.Hour), thresholds); got != "stale" {
+		t.Errorf("got %s, want stale", got)
+	}
+	if got := ClassifyFreshness("1d", newest, newest.Add(73*time.Hour), thresholds); got != "very_stale" {
+		t.Errorf("got %s, want very_stale", got)
+	}
+}
+
+func TestClassifyFreshnessJobOnSchedule(t *testing.T) {
+	// A 1h job running at 12:40 stores bars up to the one opening at 11:00,
+	// as the 12:00 bar is still forming. Just before its next run at 13:40
+	// the newest candle opened 2h39m ago, yet the job is on time.
+	newest := time.Date(2024, time.March, 1, 11, 0, 0, 0, time.UTC)
+	beforeNextRun := time.Date(2024, time.March, 1, 13, 39, 0, 0, time.UTC)
+
+	reference := FreshnessReference("1h", newest, true)
+	if got := ClassifyFreshness("1h", reference, beforeNextRun, DefaultFreshnessThresholds); got != "fresh" {
+		t.Errorf("job excluding the unclosed bar: got %s, want fresh", got)
+	}
+	if deadline := FreshnessSLADeadline("1h", reference, 0, DefaultFreshnessThresholds); !beforeNextRun.Before(deadline) {
+		t.Errorf("job excluding the unclosed bar breaches its SLA at %s, before its next run", deadline)
+	}
+
+	// Storing the forming bar, the newest candle would have opened at 12:00
+	if got := FreshnessReference("1h", newest, false); !got.Equal(newest) {
+		t.Errorf("job keeping the unclosed bar: reference = %s, want the open time %s", got, newest)
+	}
+}
+
+func TestFreshnessThresholdsValidate(t *testing.T) {
+	if err := (FreshnessThresholds{FreshMultiplier: 3, StaleMultiplier: 2}).Validate(); err == nil {
+		t.Error("expected error when stale multiplier is below fresh multiplier")
+	}
+	if err := (FreshnessThresholds{FreshMultiplier: 0, StaleMultiplier: 2}).Validate(); err == nil {
+		t.Error("expected error for zero fresh multiplier")
+	}
+	if err := DefaultFreshnessThresholds.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFreshnessSLADeadline(t *testing.T) {
+	newest := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	// Without a job SLA the deadline is where the data stops being fresh
+	if got, want := FreshnessSLADeadline("1h", newest, 0, DefaultFreshnessThresholds), newest.Add(2*time.Hour); !got.Equal(want) {
+		t.Errorf("1h default: got %s, want %s", got, want)
+	}
+	monthly := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := FreshnessSLADeadline("1M", monthly, 0, DefaultFreshnessThresholds), time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("1M default: got %s, want %s", got, want)
+	}
+
+	if got, want := FreshnessSLADeadline("1h", newest, 30, DefaultFreshnessThresholds), newest.Add(30*time.Minute); !got.Equal(want) {
+		t.Errorf("job SLA: got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// HealthHistoryHours is the number of hourly buckets kept in a connector's
+// health history
+const HealthHistoryHours = 24
+
+// HealthBucket aggregates the exchange calls of a connector over one hour
+type HealthBucket struct {
+	Hour           time.Time `bson:"hour" json:"hour"`                         // Start of the hour (UTC)
+	Calls          int64     `bson:"calls" json:"calls"`                       // Calls made during the hour
+	Failures       int64     `bson:"failures" json:"failures"`                 // Failed calls during the hour
+	TotalLatencyMs int64     `bson:"total_latency_ms" json:"total_latency_ms"` // Summed latency of successful calls
+}
+
+// HealthHistoryPoint is one hour of a connector's health history as returned by the API
+type HealthHistoryPoint struct {
+	Hour             time.Time `json:"hour"`
+	Calls            int64     `json:"calls"`
+	Failures         int64     `json:"failures"`
+	ErrorRatePercent float64   `json:"error_rate_percent"`
+	AvgLatencyMs     float64   `json:"avg_latency_ms"`
+}
+
+// HealthHistorySeries turns stored buckets into one point per hour for the
+// given number of hours ending at now, oldest first. Hours without calls are
+// returned as zero points so the series can be plotted directly.
+func HealthHistorySeries(buckets []HealthBucket, now time.Time, hours int) []HealthHistoryPoint {
+	byHour := make(map[int64]HealthBucket, len(buckets))
+	for _, b := range buckets {
+		byHour[b.Hour.UTC().Truncate(time.Hour).Unix()] = b
+	}
+
+	current := now.UTC().Truncate(time.Hour)
+	points := make([]HealthHistoryPoint, 0, hours)
+	for i := hours - 1; i >= 0; i-- {
+		hour := current.Add(-time.Duration(i) * time.Hour)
+		point := HealthHistoryPoint{Hour: hour}
+		if b, ok := byHour[hour.Unix()]; ok {
+			point.Calls = b.Calls
+			point.Failures = b.Failures
+			if b.Calls > 0 {
+				point.ErrorRatePercent = float64(b.Failures) / float64(b.Calls) * 100
+			}
+			if successes := b.Calls - b.Failures; successes > 0 {
+				point.AvgLatencyMs = float64(b.TotalLatencyMs) / float64(successes)
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthHistorySeries(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC)
+	buckets := []HealthBucket{
+		{Hour: time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC), Calls: 3},                                    // outside the window
+		{Hour: time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC), Calls: 4, Failures: 1, TotalLatencyMs: 600}, // 3 successes
+		{Hour: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), Calls: 2, Failures: 2},
+	}
+
+	points := HealthHistorySeries(buckets, now, 3)
+	if len(points) != 3 {
+		t.Fatalf("got %d points, want 3", len(points))
+	}
+
+	if !points[0].Hour.Equal(time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("first hour = %s, want 10:00", points[0].Hour)
+	}
+	if points[0].ErrorRatePercent != 25 || points[0].AvgLatencyMs != 200 {
+		t.Errorf("10:00 = %+v, want 25%% errors and 200ms latency", points[0])
+	}
+	if points[1].Calls != 0 || points[1].AvgLatencyMs != 0 {
+		t.Errorf("11:00 = %+v, want an empty point", points[1])
+	}
+	if points[2].ErrorRatePercent != 100 || points[2].AvgLatencyMs != 0 {
+		t.Errorf("12:00 = %+v, want 100%% errors and no latency", points[2])
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+// IndicatorCatalogEntry describes an exportable indicator column and the
+// IndicatorConfig settings that control it
+type IndicatorCatalogEntry struct {
+	Name        string `json:"name"`      // Export column, e.g. "sma20"
+	Indicator   string `json:"indicator"` // Indicator spec producing the column, e.g. "sma"
+	Category    string `json:"category"`  // trend, momentum, volatility, volume
+	Description string `json:"description"`
+
+	// Period is the value the column needs in a period list setting, e.g. 20
+	// in trend.sma_periods for sma20. 0 when the indicator has a single period.
+	Period int `json:"period,omitempty"`
+
+	// EnabledField is the config setting that turns the indicator on, e.g.
+	// "trend.sma_enabled"; DefaultEnabled is its built-in default
+	EnabledField   string `json:"enabled_field"`
+	DefaultEnabled bool   `json:"default_enabled"`
+
+	// Params are the config settings the calculation reads, with their
+	// built-in default values
+	Params        []string               `json:"params"`
+	DefaultParams map[string]interface{} `json:"default_params"`
+
+	// WarmupBars is the number of leading bars without a value at the default
+	// params
+	WarmupBars int `json:"warmup_bars"`
+}
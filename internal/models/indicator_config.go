@@ -267,6 +267,123 @@ func DefaultVolumeConfig() VolumeConfig {
 	}
 }
 
+// ExpectedIndicators returns the stored names of the indicators this config
+// sets on the latest candle of a series long enough to cover every period.
+// Periods without a storage field (e.g. SMA 100) and values that are
+// legitimately unset at the latest bar (Ichimoku chikou, a neutral SuperTrend
+// signal) are left out.
+func (c *IndicatorConfig) ExpectedIndicators() []string {
+	var names []string
+	addPeriods := func(prefix string, periods []int, stored ...int) {
+		for _, period := range periods {
+			for _, s := range stored {
+				if period == s {
+					names = append(names, fmt.Sprintf("%s%d", prefix, period))
+				}
+			}
+		}
+	}
+
+	if c.EnableTrend {
+		t := c.Trend
+		if t.SMAEnabled {
+			addPeriods("sma", t.SMAPeriods, 20, 50, 200)
+		}
+		if t.EMAEnabled {
+			addPeriods("ema", t.EMAPeriods, 12, 26, 50)
+		}
+		if t.DEMAEnabled && t.DEMAPeriod > 0 {
+			names = append(names, "dema")
+		}
+		if t.TEMAEnabled && t.TEMAPeriod > 0 {
+			names = append(names, "tema")
+		}
+		if t.WMAEnabled && t.WMAPeriod > 0 {
+			names = append(names, "wma")
+		}
+		if t.HMAEnabled && t.HMAPeriod > 0 {
+			names = append(names, "hma")
+		}
+		if t.VWMAEnabled && t.VWMAPeriod > 0 {
+			names = append(names, "vwma")
+		}
+		if t.IchimokuEnabled {
+			names = append(names, "ichimoku_tenkan", "ichimoku_kijun", "ichimoku_senkou_a", "ichimoku_senkou_b")
+		}
+		if t.ADXEnabled && t.ADXPeriod > 0 {
+			names = append(names, "adx", "plus_di", "minus_di")
+		}
+		if t.SuperTrendEnabled && t.SuperTrendPeriod > 0 {
+			names = append(names, "supertrend")
+		}
+	}
+
+	if c.EnableMomentum {
+		m := c.Momentum
+		if m.RSIEnabled {
+			addPeriods("rsi", m.RSIPeriods, 6, 14, 24)
+		}
+		if m.StochEnabled {
+			names = append(names, "stoch_k", "stoch_d")
+		}
+		if m.MACDEnabled {
+			names = append(names, "macd", "macd_signal", "macd_hist")
+		}
+		if m.ROCEnabled && m.ROCPeriod > 0 {
+			names = append(names, "roc")
+		}
+		if m.CCIEnabled && m.CCIPeriod > 0 {
+			names = append(names, "cci")
+		}
+		if m.WilliamsREnabled && m.WilliamsRPeriod > 0 {
+			names = append(names, "williams_r")
+		}
+		if m.MomentumEnabled && m.MomentumPeriod > 0 {
+			names = append(names, "momentum")
+		}
+	}
+
+	if c.EnableVolatility {
+		v := c.Volatility
+		if v.BollingerEnabled && v.BollingerPeriod > 0 {
+			names = append(names, "bb_upper", "bb_middle", "bb_lower", "bb_bandwidth", "bb_percent_b")
+		}
+		if v.ATREnabled && v.ATRPeriod > 0 {
+			names = append(names, "atr")
+		}
+		if v.KeltnerEnabled && v.KeltnerPeriod > 0 {
+			names = append(names, "keltner_upper", "keltner_middle", "keltner_lower")
+		}
+		if v.DonchianEnabled && v.DonchianPeriod > 0 {
+			names = append(names, "donchian_upper", "donchian_middle", "donchian_lower")
+		}
+		if v.StdDevEnabled && v.StdDevPeriod > 0 {
+			names = append(names, "stddev")
+		}
+	}
+
+	if c.EnableVolume {
+		v := c.Volume
+		if v.OBVEnabled {
+			names = append(names, "obv")
+		}
+		if v.VWAPEnabled {
+			names = append(names, "vwap")
+		}
+		if v.MFIEnabled && v.MFIPeriod > 0 {
+			names = append(names, "mfi")
+		}
+		if v.CMFEnabled && v.CMFPeriod > 0 {
+			names = append(names, "cmf")
+		}
+		if v.VolumeSMAEnabled && v.VolumeSMAPeriod > 0 {
+			names = append(names, "volume_sma")
+		}
+	}
+
+	return names
+}
+
 // IndicatorConfigCreateRequest for creating a new config
 type IndicatorConfigCreateRequest struct {
 	Name             string           `json:"name" validate:"required"`
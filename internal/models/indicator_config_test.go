@@ -540,3 +540,43 @@ func TestConfigValidationResultAddError(t *testing.T) {
 		t.Errorf("expected 2 errors, got %d", len(result.Errors))
 	}
 }
+
+func TestExpectedIndicators(t *testing.T) {
+	config := DefaultIndicatorConfig()
+	expected := make(map[string]bool)
+	for _, name := range config.ExpectedIndicators() {
+		expected[name] = true
+	}
+
+	for _, name := range []string{"sma20", "sma200", "ema26", "rsi14", "macd_hist", "bb_percent_b", "atr", "obv", "volume_sma"} {
+		if !expected[name] {
+			t.Errorf("expected %s in default config indicators", name)
+		}
+	}
+	for _, name := range []string{"ichimoku_chikou", "supertrend_signal"} {
+		if expected[name] {
+			t.Errorf("%s can be unset on the latest candle and should not be expected", name)
+		}
+	}
+
+	// Periods without a storage field and disabled categories are not expected
+	config.Trend.SMAPeriods = []int{100}
+	config.EnableVolume = false
+	for _, name := range config.ExpectedIndicators() {
+		if name == "sma20" || name == "obv" {
+			t.Errorf("did not expect %s", name)
+		}
+	}
+}
+
+func TestComputesIndicatorsOnIngest(t *testing.T) {
+	var job Job
+	if !job.ComputesIndicatorsOnIngest() {
+		t.Error("jobs should compute indicators on ingest by default")
+	}
+	raw := false
+	job.ComputeIndicatorsOnIngest = &raw
+	if job.ComputesIndicatorsOnIngest() {
+		t.Error("compute_indicators_on_ingest=false should store raw candles")
+	}
+}
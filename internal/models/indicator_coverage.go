@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// IndicatorCoverage reports which indicators are populated on a job's latest candle
+type IndicatorCoverage struct {
+	JobID              string   `json:"job_id"`
+	ExchangeID         string   `json:"exchange_id"`
+	Symbol             string   `json:"symbol"`
+	Timeframe          string   `json:"timeframe"`
+	ConfigName         string   `json:"config_name,omitempty"` // Indicator config the job's candles are computed with
+	Expected           []string `json:"expected,omitempty"`
+	LatestTimestamp    int64    `json:"latest_timestamp,omitempty"`
+	HasData            bool     `json:"has_data"`
+	Populated          []string `json:"populated"`
+	Missing            []string `json:"missing"`
+	NeedsRecalculation bool     `json:"needs_recalculation"`
+
+	// Set for jobs that store candles without computing indicators on ingest;
+	// their indicators are not checked
+	RawOHLCV bool `json:"raw_ohlcv,omitempty"`
+}
+
+// IndicatorCoverageReport summarizes indicator coverage across active jobs
+type IndicatorCoverageReport struct {
+	TotalJobs          int                 `json:"total_jobs"`
+	CompleteJobs       int                 `json:"complete_jobs"`
+	NeedsRecalculation int                 `json:"needs_recalculation"`
+	NoData             int                 `json:"no_data"`
+	RawOHLCVJobs       int                 `json:"raw_ohlcv_jobs"`
+	Jobs               []IndicatorCoverage `json:"jobs"`
+	CheckedAt          time.Time           `json:"checked_at"`
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IndicatorSpec describes an indicator that can be recalculated on its own
+type IndicatorSpec struct {
+	Name     string   `json:"name"`     // Name used in the API, e.g. "rsi"
+	Category string   `json:"category"` // "trend", "momentum", "volatility" or "volume"
+	Enabled  string   `json:"-"`        // Config flag enabling the indicator
+	Params   []string `json:"params"`   // Config fields parameterizing the indicator
+	Fields   []string `json:"fields"`   // Stored indicator fields it produces
+}
+
+// indicatorSpecs lists every indicator computed by the indicator service
+var indicatorSpecs = []IndicatorSpec{
+	// Trend
+	{Name: "sma", Category: "trend", Enabled: "sma_enabled", Params: []string{"sma_periods"}, Fields: []string{"sma20", "sma50", "sma200"}},
+	{Name: "ema", Category: "trend", Enabled: "ema_enabled", Params: []string{"ema_periods"}, Fields: []string{"ema12", "ema26", "ema50"}},
+	{Name: "dema", Category: "trend", Enabled: "dema_enabled", Params: []string{"dema_period"}, Fields: []string{"dema"}},
+	{Name: "tema", Category: "trend", Enabled: "tema_enabled", Params: []string{"tema_period"}, Fields: []string{"tema"}},
+	{Name: "wma", Category: "trend", Enabled: "wma_enabled", Params: []string{"wma_period"}, Fields: []string{"wma"}},
+	{Name: "hma", Category: "trend", Enabled: "hma_enabled", Params: []string{"hma_period"}, Fields: []string{"hma"}},
+	{Name: "vwma", Category: "trend", Enabled: "vwma_enabled", Params: []string{"vwma_period"}, Fields: []string{"vwma"}},
+	{Name: "ichimoku", Category: "trend", Enabled: "ichimoku_enabled",
+		Params: []string{"ichimoku_tenkan", "ichimoku_kijun", "ichimoku_senkou_b", "ichimoku_displacement"},
+		Fields: []string{"ichimoku_tenkan", "ichimoku_kijun", "ichimoku_senkou_a", "ichimoku_senkou_b", "ichimoku_chikou"}},
+	{Name: "adx", Category: "trend", Enabled: "adx_enabled", Params: []string{"adx_period"}, Fields: []string{"adx", "plus_di", "minus_di"}},
+	{Name: "supertrend", Category: "trend", Enabled: "supertrend_enabled",
+		Params: []string{"supertrend_period", "supertrend_multiplier"}, Fields: []string{"supertrend", "supertrend_signal"}},
+
+	// Momentum
+	{Name: "rsi", Category: "momentum", Enabled: "rsi_enabled", Params: []string{"rsi_periods"}, Fields: []string{"rsi6", "rsi14", "rsi24"}},
+	{Name: "stochastic", Category: "momentum", Enabled: "stoch_enabled",
+		Params: []string{"stoch_k", "stoch_d", "stoch_smooth"}, Fields: []string{"stoch_k", "stoch_d"}},
+	{Name: "macd", Category: "momentum", Enabled: "macd_enabled",
+		Params: []string{"macd_fast", "macd_slow", "macd_signal"}, Fields: []string{"macd", "macd_signal", "macd_hist"}},
+	{Name: "roc", Category: "momentum", Enabled: "roc_enabled", Params: []string{"roc_period"}, Fields: []string{"roc"}},
+	{Name: "cci", Category: "momentum", Enabled: "cci_enabled", Params: []string{"cci_period"}, Fields: []string{"cci"}},
+	{Name: "williams_r", Category: "momentum", Enabled: "williams_r_enabled", Params: []string{"williams_r_period"}, Fields: []string{"williams_r"}},
+	{Name: "momentum", Category: "momentum", Enabled: "momentum_enabled", Params: []string{"momentum_period"}, Fields: []string{"momentum"}},
+
+	// Volatility
+	{Name: "bollinger", Category: "volatility", Enabled: "bollinger_enabled",
+		Params: []string{"bollinger_period", "bollinger_stddev"},
+		Fields: []string{"bb_upper", "bb_middle", "bb_lower", "bb_bandwidth", "bb_percent_b"}},
+	{Name: "atr", Category: "volatility", Enabled: "atr_enabled", Params: []string{"atr_period"}, Fields: []string{"atr"}},
+	{Name: "keltner", Category: "volatility", Enabled: "keltner_enabled",
+		Params: []string{"keltner_period", "keltner_atr_period", "keltner_multiplier"},
+		Fields: []string{"keltner_upper", "keltner_middle", "keltner_lower"}},
+	{Name: "donchian", Category: "volatility", Enabled: "donchian_enabled",
+		Params: []string{"donchian_period"}, Fields: []string{"donchian_upper", "donchian_middle", "donchian_lower"}},
+	{Name: "stddev", Category: "volatility", Enabled: "stddev_enabled", Params: []string{"stddev_period"}, Fields: []string{"stddev"}},
+
+	// Volume
+	{Name: "obv", Category: "volume", Enabled: "obv_enabled", Fields: []string{"obv"}},
+	{Name: "vwap", Category: "volume", Enabled: "vwap_enabled", Fields: []string{"vwap"}},
+	{Name: "mfi", Category: "volume", Enabled: "mfi_enabled", Params: []string{"mfi_period"}, Fields: []string{"mfi"}},
+	{Name: "cmf", Category: "volume", Enabled: "cmf_enabled", Params: []string{"cmf_period"}, Fields: []string{"cmf"}},
+	{Name: "volume_sma", Category: "volume", Enabled: "volume_sma_enabled", Params: []string{"volume_sma_period"}, Fields: []string{"volume_sma"}},
+}
+
+// FindIndicatorSpec returns the spec of the indicator with the given name
+func FindIndicatorSpec(name string) (IndicatorSpec, bool) {
+	for _, spec := range indicatorSpecs {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return IndicatorSpec{}, false
+}
+
+// IndicatorSpecs returns the specs of all recalculable indicators
+func IndicatorSpecs() []IndicatorSpec {
+	specs := make([]IndicatorSpec, len(indicatorSpecs))
+	copy(specs, indicatorSpecs)
+	return specs
+}
+
+// IndicatorNames returns the names of all recalculable indicators, sorted
+func IndicatorNames() []string {
+	names := make([]string, 0, len(indicatorSpecs))
+	for _, spec := range indicatorSpecs {
+		names = append(names, spec.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SingleIndicatorConfig derives from base a config that computes only the
+// given indicator. params override the base settings of that indicator and
+// are keyed by config field name (e.g. {"rsi_periods": [14]}); the result
+// should still be checked with Validate.
+func SingleIndicatorConfig(base *IndicatorConfig, spec IndicatorSpec, params map[string]interface{}) (*IndicatorConfig, error) {
+	for key := range params {
+		if !containsString(spec.Params, key) {
+			if len(spec.Params) == 0 {
+				return nil, fmt.Errorf("%s takes no parameters", spec.Name)
+			}
+			return nil, fmt.Errorf("unknown parameter %q for %s (valid: %s)", key, spec.Name, strings.Join(spec.Params, ", "))
+		}
+	}
+
+	config := &IndicatorConfig{
+		ID:         base.ID,
+		Name:       base.Name,
+		Trend:      base.Trend,
+		Momentum:   base.Momentum,
+		Volatility: base.Volatility,
+		Volume:     base.Volume,
+	}
+
+	var category interface{}
+	switch spec.Category {
+	case "trend":
+		config.EnableTrend = true
+		category = &config.Trend
+	case "momentum":
+		config.EnableMomentum = true
+		category = &config.Momentum
+	case "volatility":
+		config.EnableVolatility = true
+		category = &config.Volatility
+	case "volume":
+		config.EnableVolume = true
+		category = &config.Volume
+	default:
+		return nil, fmt.Errorf("unknown indicator category %q", spec.Category)
+	}
+
+	// Round-trip the category settings through JSON so they can be edited by field name
+	raw, err := json.Marshal(category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s settings: %w", spec.Category, err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode %s settings: %w", spec.Category, err)
+	}
+
+	for key := range settings {
+		if strings.HasSuffix(key, "_enabled") {
+			settings[key] = false
+		}
+	}
+	settings[spec.Enabled] = true
+	for key, value := range params {
+		settings[key] = value
+	}
+
+	if raw, err = json.Marshal(settings); err != nil {
+		return nil, fmt.Errorf("failed to encode parameters: %w", err)
+	}
+	if err := json.Unmarshal(raw, category); err != nil {
+		return nil, fmt.Errorf("invalid parameters for %s: %w", spec.Name, err)
+	}
+
+	return config, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
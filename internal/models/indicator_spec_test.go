@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestIndicatorSpecsMatchConfigAndStorage(t *testing.T) {
+	defaults := DefaultIndicatorConfig()
+	categories := map[string]interface{}{
+		"trend":      defaults.Trend,
+		"momentum":   defaults.Momentum,
+		"volatility": defaults.Volatility,
+		"volume":     defaults.Volume,
+	}
+
+	for _, spec := range indicatorSpecs {
+		raw, _ := json.Marshal(categories[spec.Category])
+		var settings map[string]interface{}
+		_ = json.Unmarshal(raw, &settings)
+
+		for _, key := range append([]string{spec.Enabled}, spec.Params...) {
+			if _, ok := settings[key]; !ok {
+				t.Errorf("%s: %s is not a %s config field", spec.Name, key, spec.Category)
+			}
+		}
+		for _, field := range spec.Fields {
+			if _, ok := indicatorFieldIndex[field]; !ok {
+				t.Errorf("%s: %s is not a stored indicator", spec.Name, field)
+			}
+		}
+	}
+}
+
+func TestSingleIndicatorConfig(t *testing.T) {
+	spec, ok := FindIndicatorSpec("rsi")
+	if !ok {
+		t.Fatal("rsi spec not found")
+	}
+
+	config, err := SingleIndicatorConfig(DefaultIndicatorConfig(), spec, map[string]interface{}{"rsi_periods": []int{14}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result := config.Validate(); !result.Valid {
+		t.Fatalf("expected valid config, got %+v", result.Errors)
+	}
+	if got := config.ExpectedIndicators(); !reflect.DeepEqual(got, []string{"rsi14"}) {
+		t.Errorf("expected only rsi14, got %v", got)
+	}
+	if config.Momentum.MACDEnabled || config.EnableTrend {
+		t.Error("expected every other indicator to be disabled")
+	}
+
+	if _, err := SingleIndicatorConfig(DefaultIndicatorConfig(), spec, map[string]interface{}{"macd_fast": 5}); err == nil {
+		t.Error("expected an error for a parameter of another indicator")
+	}
+	if _, err := SingleIndicatorConfig(DefaultIndicatorConfig(), spec, map[string]interface{}{"rsi_periods": "fourteen"}); err == nil {
+		t.Error("expected an error for a mistyped parameter")
+	}
+
+	spec, _ = FindIndicatorSpec("macd")
+	config, err = SingleIndicatorConfig(DefaultIndicatorConfig(), spec, map[string]interface{}{"macd_fast": 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result := config.Validate(); result.Valid {
+		t.Error("expected fast >= slow to fail validation")
+	}
+}
+
+func TestIndicatorsFieldHelpers(t *testing.T) {
+	rsi, macd := 55.0, 1.5
+	ind := Indicators{RSI14: &rsi, MACD: &macd}
+
+	if got := ind.FieldValue("rsi14"); got != 55.0 {
+		t.Errorf("FieldValue(rsi14) = %v, want 55", got)
+	}
+	if got := ind.FieldValue("rsi6"); got != nil {
+		t.Errorf("FieldValue(rsi6) = %v, want nil", got)
+	}
+
+	var copied Indicators
+	copied.CopyFields(ind, []string{"rsi14", "rsi6"})
+	if copied.RSI14 == nil || copied.MACD != nil {
+		t.Errorf("CopyFields copied the wrong fields: %+v", copied)
+	}
+
+	ind.ClearFields([]string{"rsi14"})
+	if ind.RSI14 != nil || ind.MACD == nil {
+		t.Errorf("ClearFields cleared the wrong fields: %+v", ind)
+	}
+}
@@ -6,23 +6,88 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Job types, i.e. the kind of market data a job collects
+const (
+	JobTypeOHLCV        = "ohlcv"
+	JobTypeTrades       = "trades"
+	JobTypeOrderBook    = "orderbook"
+	JobTypeFunding      = "funding"       // Perpetual futures funding rate history
+	JobTypeOpenInterest = "open_interest" // Perpetual futures open interest history
+)
+
+// DefaultOrderBookDepth is the number of price levels per side an orderbook
+// job requests when the job does not set a depth
+const DefaultOrderBookDepth = 50
+
+// IsValidJobType reports whether t is a known job type
+func IsValidJobType(t string) bool {
+	switch t {
+	case JobTypeOHLCV, JobTypeTrades, JobTypeOrderBook, JobTypeFunding, JobTypeOpenInterest:
+		return true
+	}
+	return false
+}
+
 // Job represents an ingestion task for a symbol + timeframe
+// For trades, orderbook and funding jobs the timeframe only sets how often the
+// job runs; open_interest jobs also collect open interest at that interval
 type Job struct {
 	ID                  primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
 	ConnectorExchangeID string               `bson:"connector_exchange_id" json:"connector_exchange_id"`
 	Symbol              string               `bson:"symbol" json:"symbol"`
 	Timeframe           string               `bson:"timeframe" json:"timeframe"` // "1m", "5m", "1h", etc.
+	JobType             string               `bson:"job_type" json:"job_type"`   // "ohlcv", "trades", "orderbook", "funding", "open_interest"
 	Status              string               `bson:"status" json:"status"`       // "active", "paused", "error"
 	CollectHistorical   bool                 `bson:"collect_historical" json:"collect_historical"`
-	DependsOn           []primitive.ObjectID `bson:"depends_on,omitempty" json:"depends_on,omitempty"` // Job IDs that must complete first
+	DependsOn           []primitive.ObjectID `bson:"depends_on,omitempty" json:"depends_on,omitempty"`                       // Job IDs that must complete first
+	Freshness           *FreshnessThresholds `bson:"freshness,omitempty" json:"freshness,omitempty"`                         // Overrides the global freshness thresholds
+	FreshnessSLAMinutes int                  `bson:"freshness_sla_minutes,omitempty" json:"freshness_sla_minutes,omitempty"` // Max age of the newest candle before the job is listed as stale, from its close when unclosed bars are excluded (defaults from the timeframe)
+	IndicatorConfigID   string               `bson:"indicator_config_id,omitempty" json:"indicator_config_id,omitempty"`     // Overrides the connector's indicator config
+	OrderBookDepth      int                  `bson:"orderbook_depth,omitempty" json:"orderbook_depth,omitempty"`             // Levels per side, orderbook jobs only
+	ExcludeUnclosedBar  *bool                `bson:"exclude_unclosed_bar,omitempty" json:"exclude_unclosed_bar,omitempty"`   // Don't store the still-forming latest candle (default true), OHLCV jobs only
 	CreatedAt           time.Time            `bson:"created_at" json:"created_at"`
 	UpdatedAt           time.Time            `bson:"updated_at" json:"updated_at"`
 
+	// Calculate indicators before storing candles (default true), OHLCV jobs only
+	ComputeIndicatorsOnIngest *bool `bson:"compute_indicators_on_ingest,omitempty" json:"compute_indicators_on_ingest,omitempty"`
+
 	Schedule Schedule `bson:"schedule" json:"schedule"`
 	Cursor   Cursor   `bson:"cursor" json:"cursor"`
 	RunState RunState `bson:"run_state" json:"run_state"`
 }
 
+// GetJobType returns the job type, treating jobs created before job types
+// existed as OHLCV jobs
+func (j *Job) GetJobType() string {
+	if j.JobType == "" {
+		return JobTypeOHLCV
+	}
+	return j.JobType
+}
+
+// ExcludesUnclosedBar reports whether runs drop the latest candle while it is
+// still forming, so only final values are stored. It is fetched again on the
+// next run once closed. GetLastCandle and the freshness metrics then lag by up
+// to one bar, as the newest stored candle is the last closed one.
+func (j *Job) ExcludesUnclosedBar() bool {
+	return j.ExcludeUnclosedBar == nil || *j.ExcludeUnclosedBar
+}
+
+// ComputesIndicatorsOnIngest reports whether runs and pushed candles have their
+// indicators calculated before they are stored. Without it only raw OHLCV is
+// stored, and exports compute the indicators they need from it.
+func (j *Job) ComputesIndicatorsOnIngest() bool {
+	return j.ComputeIndicatorsOnIngest == nil || *j.ComputeIndicatorsOnIngest
+}
+
+// GetOrderBookDepth returns the orderbook depth, falling back to the default
+func (j *Job) GetOrderBookDepth() int {
+	if j.OrderBookDepth > 0 {
+		return j.OrderBookDepth
+	}
+	return DefaultOrderBookDepth
+}
+
 // Schedule defines when the job should run
 type Schedule struct {
 	Mode string  `bson:"mode" json:"mode"` // "timeframe", "cron"
@@ -30,6 +95,8 @@ type Schedule struct {
 }
 
 // Cursor tracks the job's progress
+// For other job types than OHLCV, LastCandleTime holds the time of the last
+// stored trade, snapshot or data point
 type Cursor struct {
 	LastCandleTime *time.Time `bson:"last_candle_time,omitempty" json:"last_candle_time,omitempty"`
 }
@@ -50,9 +117,20 @@ type JobCreateRequest struct {
 	ConnectorExchangeID string   `json:"connector_exchange_id" validate:"required"`
 	Symbol              string   `json:"symbol" validate:"required"`
 	Timeframe           string   `json:"timeframe" validate:"required"`
+	JobType             string   `json:"job_type,omitempty" validate:"omitempty,oneof=ohlcv trades orderbook funding open_interest"` // Defaults to "ohlcv"
+	OrderBookDepth      int      `json:"orderbook_depth,omitempty" validate:"omitempty,min=1"`                                       // Orderbook jobs only (defaults to 50)
 	Status              string   `json:"status" validate:"omitempty,oneof=active paused"`
 	CollectHistorical   bool     `json:"collect_historical"`
 	DependsOn           []string `json:"depends_on,omitempty"` // Job IDs (as strings) that must complete first
+
+	Freshness           *FreshnessThresholds `json:"freshness,omitempty"`             // Per-job freshness thresholds (defaults to global)
+	FreshnessSLAMinutes int                  `json:"freshness_sla_minutes,omitempty"` // Freshness SLA in minutes (defaults from the timeframe)
+
+	IndicatorConfigID string `json:"indicator_config_id,omitempty"` // Indicator config override (defaults to the connector's)
+
+	ExcludeUnclosedBar *bool `json:"exclude_unclosed_bar,omitempty"` // Don't store the still-forming latest candle (defaults to true)
+
+	ComputeIndicatorsOnIngest *bool `json:"compute_indicators_on_ingest,omitempty"` // Calculate indicators before storing candles (defaults to true)
 }
 
 // JobUpdateRequest is the DTO for updating a job
@@ -61,6 +139,17 @@ type JobUpdateRequest struct {
 	Timeframe         *string   `json:"timeframe,omitempty"`
 	CollectHistorical *bool     `json:"collect_historical,omitempty"`
 	DependsOn         *[]string `json:"depends_on,omitempty"` // Job IDs (as strings) - use empty array to clear dependencies
+
+	Freshness      *FreshnessThresholds `json:"freshness,omitempty"`       // Per-job freshness thresholds
+	ClearFreshness bool                 `json:"clear_freshness,omitempty"` // Revert to the global freshness thresholds
+
+	FreshnessSLAMinutes *int `json:"freshness_sla_minutes,omitempty"` // Freshness SLA in minutes, 0 reverts to the timeframe default
+
+	IndicatorConfigID *string `json:"indicator_config_id,omitempty"` // Empty string reverts to the connector's config
+
+	ExcludeUnclosedBar *bool `json:"exclude_unclosed_bar,omitempty"` // Don't store the still-forming latest candle
+
+	ComputeIndicatorsOnIngest *bool `json:"compute_indicators_on_ingest,omitempty"` // Calculate indicators before storing candles
 }
 
 // JobDependency represents a dependency relationship between jobs
@@ -73,6 +162,54 @@ type JobDependency struct {
 type DependencyStatus struct {
 	JobID            string   `json:"job_id"`
 	DependsOn        []string `json:"depends_on"`
-	BlockedBy        []string `json:"blocked_by"`        // Dependencies that haven't completed recently
+	BlockedBy        []string `json:"blocked_by"`         // Dependencies that haven't completed recently
 	AllDepsCompleted bool     `json:"all_deps_completed"` // Whether all dependencies completed recently
 }
+
+// JobRun records the outcome of a single job execution
+type JobRun struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobID          primitive.ObjectID `bson:"job_id" json:"job_id"`
+	StartedAt      time.Time          `bson:"started_at" json:"started_at"`
+	DurationMs     int64              `bson:"duration_ms" json:"duration_ms"`
+	Success        bool               `bson:"success" json:"success"`
+	CandlesFetched int                `bson:"candles_fetched" json:"candles_fetched"`
+	Message        string             `bson:"message,omitempty" json:"message,omitempty"`
+	Error          *string            `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// RescheduledJob records a job whose overdue next run time was moved forward
+type RescheduledJob struct {
+	JobID               primitive.ObjectID `json:"job_id"`
+	Symbol              string             `json:"symbol"`
+	Timeframe           string             `json:"timeframe"`
+	PreviousNextRunTime time.Time          `json:"previous_next_run_time"`
+	NextRunTime         time.Time          `json:"next_run_time"`
+}
+
+// ScheduleReconcileResult summarizes a schedule reconciliation
+type ScheduleReconcileResult struct {
+	Checked       int              `json:"checked"`  // Active jobs whose next run time had passed
+	Adjusted      int              `json:"adjusted"` // Jobs more than one timeframe overdue that were rescheduled
+	SpreadSeconds int              `json:"spread_seconds"`
+	Jobs          []RescheduledJob `json:"jobs"`
+}
+
+// SymbolJobsResult summarizes a bulk pause, resume or delete of the jobs of a symbol
+type SymbolJobsResult struct {
+	Symbol     string             `json:"symbol"`
+	ExchangeID string             `json:"exchange_id,omitempty"` // Empty when the operation spanned all exchanges
+	Action     string             `json:"action"`                // "pause", "resume" or "delete"
+	Matched    int                `json:"matched"`
+	Affected   int64              `json:"affected"` // Jobs whose status changed, or that were deleted
+	Jobs       []SymbolJobSummary `json:"jobs"`
+}
+
+// SymbolJobSummary identifies a job matched by a symbol-level bulk operation
+type SymbolJobSummary struct {
+	ID                  primitive.ObjectID `json:"id"`
+	ConnectorExchangeID string             `json:"connector_exchange_id"`
+	JobType             string             `json:"job_type"`
+	Timeframe           string             `json:"timeframe"`
+	PreviousStatus      string             `json:"previous_status"`
+}
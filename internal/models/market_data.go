@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Trade represents a single public trade
+type Trade struct {
+	Timestamp    int64   `bson:"timestamp" json:"timestamp"` // Unix milliseconds
+	TradeID      string  `bson:"trade_id,omitempty" json:"trade_id,omitempty"`
+	Side         string  `bson:"side,omitempty" json:"side,omitempty"` // "buy" or "sell"
+	Price        float64 `bson:"price" json:"price"`
+	Amount       float64 `bson:"amount" json:"amount"`
+	Cost         float64 `bson:"cost,omitempty" json:"cost,omitempty"`
+	TakerOrMaker string  `bson:"taker_or_maker,omitempty" json:"taker_or_maker,omitempty"`
+}
+
+// TradeChunk represents an hourly chunk of trades
+// Unique identifier: (exchange_id, symbol, hour)
+// Trades are chunked by hour rather than by month like candles, as a busy
+// market produces enough trades in a month to exceed the 16MB document limit
+type TradeChunk struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ExchangeID  string             `bson:"exchange_id" json:"exchange_id"`
+	Symbol      string             `bson:"symbol" json:"symbol"`
+	Hour        string             `bson:"hour" json:"hour"`             // Format: "YYYY-MM-DDTHH" in UTC (e.g., "2024-01-15T09")
+	StartTime   time.Time          `bson:"start_time" json:"start_time"` // First trade timestamp in chunk
+	EndTime     time.Time          `bson:"end_time" json:"end_time"`     // Last trade timestamp in chunk
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	TradesCount int                `bson:"trades_count" json:"trades_count"`
+	Trades      []Trade            `bson:"trades" json:"trades"` // Sorted by timestamp ascending (oldest first)
+}
+
+// OrderBookSnapshot represents the order book of a symbol at one point in time
+// Each level is a [price, amount] pair; bids are sorted best (highest) first
+// and asks best (lowest) first
+type OrderBookSnapshot struct {
+	Timestamp int64       `bson:"timestamp" json:"timestamp"` // Unix milliseconds
+	Nonce     int64       `bson:"nonce,omitempty" json:"nonce,omitempty"`
+	Bids      [][]float64 `bson:"bids" json:"bids"`
+	Asks      [][]float64 `bson:"asks" json:"asks"`
+}
+
+// OrderBookChunk represents an hourly chunk of order book snapshots
+// Unique identifier: (exchange_id, symbol, hour)
+type OrderBookChunk struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ExchangeID     string              `bson:"exchange_id" json:"exchange_id"`
+	Symbol         string              `bson:"symbol" json:"symbol"`
+	Hour           string              `bson:"hour" json:"hour"`             // Format: "YYYY-MM-DDTHH" in UTC
+	StartTime      time.Time           `bson:"start_time" json:"start_time"` // First snapshot timestamp in chunk
+	EndTime        time.Time           `bson:"end_time" json:"end_time"`     // Last snapshot timestamp in chunk
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time           `bson:"updated_at" json:"updated_at"`
+	SnapshotsCount int                 `bson:"snapshots_count" json:"snapshots_count"`
+	Snapshots      []OrderBookSnapshot `bson:"snapshots" json:"snapshots"` // Sorted by timestamp ascending (oldest first)
+}
+
+// GetHourFromTimestamp extracts the UTC hour bucket of a Unix millisecond
+// timestamp, used to chunk trades and order book snapshots
+func GetHourFromTimestamp(timestampMs int64) string {
+	return time.UnixMilli(timestampMs).UTC().Format("2006-01-02T15")
+}
+
+// FundingRate is a perpetual futures funding rate at its settlement time
+// Funding settles every few hours, so rates are stored one document per point
+// in the funding_rates collection, unique on (exchange_id, symbol, timestamp)
+type FundingRate struct {
+	Timestamp   int64   `bson:"timestamp" json:"timestamp"` // Unix milliseconds
+	FundingRate float64 `bson:"funding_rate" json:"funding_rate"`
+}
+
+// OpenInterest is the open interest of a perpetual futures contract at one
+// point in time, stored one document per point in the open_interest collection
+type OpenInterest struct {
+	Timestamp         int64   `bson:"timestamp" json:"timestamp"`                                         // Unix milliseconds
+	OpenInterest      float64 `bson:"open_interest" json:"open_interest"`                                 // In contracts or base currency
+	OpenInterestValue float64 `bson:"open_interest_value,omitempty" json:"open_interest_value,omitempty"` // In quote currency, when the exchange reports it
+}
+
+// DerivativesData holds the funding rate and open interest in effect at a
+// candle's open time. It is joined onto candles for ML exports and never stored.
+type DerivativesData struct {
+	FundingRate       *float64
+	OpenInterest      *float64
+	OpenInterestValue *float64
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetHourFromTimestampUsesUTC(t *testing.T) {
+	ts := time.Date(2024, time.January, 15, 9, 59, 59, 0, time.FixedZone("UTC+5", 5*3600)).UnixMilli()
+	if got := GetHourFromTimestamp(ts); got != "2024-01-15T04" {
+		t.Errorf("GetHourFromTimestamp = %s, want 2024-01-15T04", got)
+	}
+}
+
+func TestJobTypeDefaults(t *testing.T) {
+	job := &Job{}
+	if job.GetJobType() != JobTypeOHLCV {
+		t.Errorf("GetJobType() = %q, want %q for a job without a type", job.GetJobType(), JobTypeOHLCV)
+	}
+	if job.GetOrderBookDepth() != DefaultOrderBookDepth {
+		t.Errorf("GetOrderBookDepth() = %d, want %d", job.GetOrderBookDepth(), DefaultOrderBookDepth)
+	}
+
+	job = &Job{JobType: JobTypeOrderBook, OrderBookDepth: 10}
+	if job.GetJobType() != JobTypeOrderBook || job.GetOrderBookDepth() != 10 {
+		t.Errorf("unexpected job type/depth: %s/%d", job.GetJobType(), job.GetOrderBookDepth())
+	}
+	if IsValidJobType("candles") {
+		t.Error("IsValidJobType accepted an unknown type")
+	}
+}
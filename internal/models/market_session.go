@@ -0,0 +1,103 @@
+package models
+
+import "fmt"
+
+// Market session codes of the market_session temporal feature. A bar whose
+// hour falls in more than one session is an overlap.
+const (
+	MarketSessionOffHours = iota
+	MarketSessionAsian
+	MarketSessionEuropean
+	MarketSessionUS
+	MarketSessionOverlap
+)
+
+// MarketSessionLegend names each market session code, indexed by code
+var MarketSessionLegend = []string{"off_hours", "asian", "european", "us", "overlap"}
+
+// SessionHours is the UTC hours a market session is open, from Start up to
+// but excluding End. A session whose End is at or before its Start wraps past
+// midnight.
+type SessionHours struct {
+	Start int `bson:"start" json:"start"` // 0-23
+	End   int `bson:"end" json:"end"`     // 1-24
+}
+
+// contains reports whether the session is open during the UTC hour
+func (s SessionHours) contains(hour int) bool {
+	if s.End > s.Start {
+		return hour >= s.Start && hour < s.End
+	}
+	return hour >= s.Start || hour < s.End
+}
+
+// Default market session hours (UTC): Tokyo, London and New York roughly
+// from open to close
+var (
+	DefaultAsianSession    = SessionHours{Start: 0, End: 9}
+	DefaultEuropeanSession = SessionHours{Start: 7, End: 16}
+	DefaultUSSession       = SessionHours{Start: 13, End: 22}
+)
+
+// MarketSessionConfig configures the market_session temporal feature. Unset
+// sessions use the default hours.
+type MarketSessionConfig struct {
+	Asian    *SessionHours `bson:"asian,omitempty" json:"asian,omitempty"`
+	European *SessionHours `bson:"european,omitempty" json:"european,omitempty"`
+	US       *SessionHours `bson:"us,omitempty" json:"us,omitempty"`
+
+	// OneHot emits one 0/1 column per session code, market_session_<name>,
+	// instead of a single integer code column
+	OneHot bool `bson:"one_hot" json:"one_hot"`
+}
+
+// sessions returns the Asian, European and US session hours in code order,
+// with defaults filled in. A nil config uses all defaults.
+func (c *MarketSessionConfig) sessions() [3]SessionHours {
+	sessions := [3]SessionHours{DefaultAsianSession, DefaultEuropeanSession, DefaultUSSession}
+	if c == nil {
+		return sessions
+	}
+	for i, custom := range []*SessionHours{c.Asian, c.European, c.US} {
+		if custom != nil {
+			sessions[i] = *custom
+		}
+	}
+	return sessions
+}
+
+// Validate checks that every configured session has hours within a day and
+// isn't empty
+func (c *MarketSessionConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for i, s := range c.sessions() {
+		name := MarketSessionLegend[MarketSessionAsian+i]
+		if s.Start < 0 || s.Start > 23 {
+			return fmt.Errorf("invalid market sessions: %s start must be between 0 and 23", name)
+		}
+		if s.End < 1 || s.End > 24 {
+			return fmt.Errorf("invalid market sessions: %s end must be between 1 and 24", name)
+		}
+		if s.End == s.Start {
+			return fmt.Errorf("invalid market sessions: %s session is empty", name)
+		}
+	}
+	return nil
+}
+
+// SessionAt returns the market session code of a UTC hour
+func (c *MarketSessionConfig) SessionAt(hour int) int {
+	code := MarketSessionOffHours
+	for i, s := range c.sessions() {
+		if !s.contains(hour) {
+			continue
+		}
+		if code != MarketSessionOffHours {
+			return MarketSessionOverlap
+		}
+		code = MarketSessionAsian + i
+	}
+	return code
+}
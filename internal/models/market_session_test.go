@@ -0,0 +1,56 @@
+package models
+
+import "testing"
+
+func TestMarketSessionAt(t *testing.T) {
+	var defaults *MarketSessionConfig
+	cases := map[int]int{
+		0:  MarketSessionAsian,
+		6:  MarketSessionAsian,
+		7:  MarketSessionOverlap, // Asian and European
+		9:  MarketSessionEuropean,
+		13: MarketSessionOverlap, // European and US
+		16: MarketSessionUS,
+		21: MarketSessionUS,
+		22: MarketSessionOffHours,
+		23: MarketSessionOffHours,
+	}
+	for hour, want := range cases {
+		if got := defaults.SessionAt(hour); got != want {
+			t.Errorf("hour %d = %s, want %s", hour, MarketSessionLegend[got], MarketSessionLegend[want])
+		}
+	}
+
+	// An Asian session wrapping past midnight
+	custom := &MarketSessionConfig{Asian: &SessionHours{Start: 23, End: 8}}
+	if got := custom.SessionAt(23); got != MarketSessionAsian {
+		t.Errorf("hour 23 = %s, want asian", MarketSessionLegend[got])
+	}
+	if got := custom.SessionAt(8); got != MarketSessionEuropean {
+		t.Errorf("hour 8 = %s, want european", MarketSessionLegend[got])
+	}
+}
+
+func TestMarketSessionConfigValidate(t *testing.T) {
+	valid := []*MarketSessionConfig{
+		nil,
+		{},
+		{Asian: &SessionHours{Start: 23, End: 8}, US: &SessionHours{Start: 13, End: 24}},
+	}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", c, err)
+		}
+	}
+
+	invalid := []*MarketSessionConfig{
+		{Asian: &SessionHours{Start: 24, End: 8}},
+		{European: &SessionHours{Start: 7, End: 0}},
+		{US: &SessionHours{Start: 13, End: 13}},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", c)
+		}
+	}
+}
@@ -14,6 +14,7 @@ const (
 	MLExportFormatParquet MLExportFormat = "parquet"
 	MLExportFormatNumpy   MLExportFormat = "numpy"
 	MLExportFormatJSONL   MLExportFormat = "jsonl"
+	MLExportFormatJSON    MLExportFormat = "json"
 )
 
 // MLExportStatus represents the status of an export job
@@ -25,6 +26,7 @@ const (
 	MLExportStatusCompleted MLExportStatus = "completed"
 	MLExportStatusFailed    MLExportStatus = "failed"
 	MLExportStatusCancelled MLExportStatus = "cancelled"
+	MLExportStatusExpired   MLExportStatus = "expired" // Completed, but the output file is gone
 )
 
 // NormalizationType represents normalization methods
@@ -58,6 +60,14 @@ const (
 	TargetTypeFutureVolatility TargetType = "future_volatility"
 )
 
+// VolumeAggregation selects which volume is reported as Volume after resampling
+type VolumeAggregation string
+
+const (
+	VolumeAggregationBase  VolumeAggregation = "base"  // Volume is the summed base volume (default)
+	VolumeAggregationQuote VolumeAggregation = "quote" // Volume is the summed quote volume
+)
+
 // MLExportConfig represents configuration for ML data export
 type MLExportConfig struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
@@ -71,8 +81,73 @@ type MLExportConfig struct {
 	Preprocessing PreprocessConfig   `bson:"preprocessing" json:"preprocessing"`
 	Split         SplitConfig        `bson:"split" json:"split"`
 	Sequence      SequenceConfig     `bson:"sequence" json:"sequence"`
-	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+	Resample      ResampleConfig     `bson:"resample" json:"resample"`
+	MinBars       MinBarsConfig      `bson:"min_bars" json:"min_bars"`
+	Alignment     AlignmentConfig    `bson:"alignment" json:"alignment"`
+
+	// ColumnOrder pins the listed columns to the front of the export, in this
+	// order, for pipelines that address columns by index. Other columns follow
+	// in the canonical order; listed columns the export doesn't produce are ignored.
+	ColumnOrder []string `bson:"column_order,omitempty" json:"column_order,omitempty"`
+
+	// TailBars limits the export to the most recent N bars of each source job
+	// (0 = full history). The bars before them that features need to warm up
+	// are still loaded, then dropped before preprocessing.
+	TailBars int `bson:"tail_bars,omitempty" json:"tail_bars,omitempty"`
+
+	// AuxiliarySources joins columns of other collection jobs onto every row,
+	// e.g. the funding rate or a second symbol's returns as a market factor
+	AuxiliarySources []AuxSource `bson:"auxiliary_sources,omitempty" json:"auxiliary_sources,omitempty"`
+
+	// ExcludeUnclosedBar drops each source's latest bar while it is still
+	// forming (default true), as its values are provisional until it closes
+	ExcludeUnclosedBar *bool `bson:"exclude_unclosed_bar,omitempty" json:"exclude_unclosed_bar,omitempty"`
+
+	// FloatFormat and FloatPrecision control how CSV and JSONL exports write
+	// floats: fixed (default) with FloatPrecision decimals, general with
+	// FloatPrecision significant digits, or scientific. FloatPrecision 0 uses
+	// the writer default of 8. JSONL without a FloatFormat keeps full precision.
+	FloatFormat    FloatFormat `bson:"float_format,omitempty" json:"float_format,omitempty"`
+	FloatPrecision int         `bson:"float_precision,omitempty" json:"float_precision,omitempty"`
+
+	// SeparateFiles exports each source job on its own instead of merging
+	// them, bundling the per-job files into one .zip with a manifest.json
+	// mapping files to source jobs
+	SeparateFiles bool `bson:"separate_files,omitempty" json:"separate_files,omitempty"`
+
+	// RetentionHours controls how long the export file is kept after completion.
+	// nil falls back to the server default, 0 means the file never expires.
+	RetentionHours *int `bson:"retention_hours,omitempty" json:"retention_hours,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ExcludesUnclosedBar reports whether the export drops a still-forming latest bar
+func (c *MLExportConfig) ExcludesUnclosedBar() bool {
+	return c.ExcludeUnclosedBar == nil || *c.ExcludeUnclosedBar
+}
+
+// ExpiresAt returns when an export completed at completedAt should be removed,
+// or nil if it should be kept indefinitely
+func (c *MLExportConfig) ExpiresAt(completedAt time.Time, defaultRetentionHours int) *time.Time {
+	hours := defaultRetentionHours
+	if c.RetentionHours != nil {
+		hours = *c.RetentionHours
+	}
+	if hours <= 0 {
+		return nil
+	}
+	expiresAt := completedAt.Add(time.Duration(hours) * time.Hour)
+	return &expiresAt
+}
+
+// AuxSource selects columns of a collection job to join onto an export.
+// Columns are named <prefix>_<column>, e.g. "eth_usdt_returns".
+type AuxSource struct {
+	JobID   primitive.ObjectID `bson:"job_id" json:"job_id"`
+	Columns []string           `bson:"columns" json:"columns"`                   // ohlcv jobs: open, high, low, close, volume, returns, log_returns or a stored indicator; funding jobs: funding_rate; open_interest jobs: open_interest, open_interest_value
+	Prefix  string             `bson:"prefix,omitempty" json:"prefix,omitempty"` // Defaults to the job's symbol in lower case, e.g. "eth_usdt"
 }
 
 // FeatureConfig defines which features to include in export
@@ -88,8 +163,17 @@ type FeatureConfig struct {
 	SpecificIndicators   []string `bson:"specific_indicators,omitempty" json:"specific_indicators,omitempty"`   // sma20, rsi14, etc.
 	ExcludeIndicators    []string `bson:"exclude_indicators,omitempty" json:"exclude_indicators,omitempty"`
 
+	// Compute indicators missing from stored candles using the active indicator config
+	ComputeMissingIndicators bool `bson:"compute_missing_indicators" json:"compute_missing_indicators"`
+
+	// Recalculate and store indicators missing from source jobs before the export
+	AutoRecalculateMissing bool `bson:"auto_recalculate_missing" json:"auto_recalculate_missing"`
+
 	// Price-based features
-	PriceFeatures []string `bson:"price_features,omitempty" json:"price_features,omitempty"` // returns, log_returns, volatility, price_change, gaps, body_ratio, range_pct
+	PriceFeatures []string `bson:"price_features,omitempty" json:"price_features,omitempty"` // returns, log_returns, volatility, price_change, gaps, body_ratio, range_pct, frac_diff
+
+	// Order and weight threshold of the frac_diff price feature
+	FracDiff *FracDiffConfig `bson:"frac_diff,omitempty" json:"frac_diff,omitempty"`
 
 	// Lagged features
 	LaggedFeatures LagConfig `bson:"lagged_features" json:"lagged_features"`
@@ -98,10 +182,17 @@ type FeatureConfig struct {
 	RollingFeatures RollingConfig `bson:"rolling_features" json:"rolling_features"`
 
 	// Temporal features
-	TemporalFeatures []string `bson:"temporal_features,omitempty" json:"temporal_features,omitempty"` // hour, hour_sin, hour_cos, day_of_week, dow_sin, dow_cos, month, is_weekend
+	TemporalFeatures []string `bson:"temporal_features,omitempty" json:"temporal_features,omitempty"` // hour, hour_sin, hour_cos, day_of_week, dow_sin, dow_cos, month, is_weekend, market_session, bar_gap
+
+	// Session hours and encoding of the market_session temporal feature
+	MarketSessions *MarketSessionConfig `bson:"market_sessions,omitempty" json:"market_sessions,omitempty"`
 
 	// Cross-indicator features
 	CrossFeatures []string `bson:"cross_features,omitempty" json:"cross_features,omitempty"` // bb_position, price_vs_sma20, ma_crossover, rsi_divergence
+
+	// Derivatives features, joined from the symbol's funding rate and open
+	// interest jobs: the last value at or before each candle's open time
+	DerivativesFeatures []string `bson:"derivatives_features,omitempty" json:"derivatives_features,omitempty"` // funding_rate, open_interest, open_interest_value
 }
 
 // LagConfig defines lagged feature configuration
@@ -121,10 +212,47 @@ type RollingConfig struct {
 
 // TargetConfig defines target variable generation
 type TargetConfig struct {
-	Enabled            bool       `bson:"enabled" json:"enabled"`
-	Type               TargetType `bson:"type" json:"type"`
-	LookaheadPeriods   []int      `bson:"lookahead_periods,omitempty" json:"lookahead_periods,omitempty"` // e.g., [1, 5, 10]
-	ClassificationBins []float64  `bson:"classification_bins,omitempty" json:"classification_bins,omitempty"` // For multi-class: [-0.02, -0.01, 0.01, 0.02]
+	Enabled            bool          `bson:"enabled" json:"enabled"`
+	Type               TargetType    `bson:"type" json:"type"`
+	LookaheadPeriods   []int         `bson:"lookahead_periods,omitempty" json:"lookahead_periods,omitempty"`     // e.g., [1, 5, 10]
+	ClassificationBins []float64     `bson:"classification_bins,omitempty" json:"classification_bins,omitempty"` // For multi-class: [-0.02, -0.01, 0.01, 0.02]
+	BinMode            TargetBinMode `bson:"bin_mode,omitempty" json:"bin_mode,omitempty"`                       // fixed (default) or quantile
+	NumClasses         int           `bson:"num_classes,omitempty" json:"num_classes,omitempty"`                 // Class count for quantile mode
+	Targets            []TargetSpec  `bson:"targets,omitempty" json:"targets,omitempty"`                         // Additional targets, each with its own bins
+}
+
+// TargetBinMode selects how classification bin edges are chosen
+type TargetBinMode string
+
+const (
+	TargetBinModeFixed    TargetBinMode = "fixed"    // Edges are the configured classification bins
+	TargetBinModeQuantile TargetBinMode = "quantile" // Edges split the future returns into equally populated classes
+)
+
+// TargetSpec defines one family of target columns with its own binning
+type TargetSpec struct {
+	Name               string        `bson:"name,omitempty" json:"name,omitempty"` // Column prefix, defaults to the type: target_<name>_<period>
+	Type               TargetType    `bson:"type" json:"type"`
+	LookaheadPeriods   []int         `bson:"lookahead_periods,omitempty" json:"lookahead_periods,omitempty"`
+	ClassificationBins []float64     `bson:"classification_bins,omitempty" json:"classification_bins,omitempty"`
+	BinMode            TargetBinMode `bson:"bin_mode,omitempty" json:"bin_mode,omitempty"`
+	NumClasses         int           `bson:"num_classes,omitempty" json:"num_classes,omitempty"`
+}
+
+// Specs returns every target spec: the top-level target (when a type is set)
+// followed by the additional targets
+func (t TargetConfig) Specs() []TargetSpec {
+	specs := make([]TargetSpec, 0, len(t.Targets)+1)
+	if t.Type != "" {
+		specs = append(specs, TargetSpec{
+			Type:               t.Type,
+			LookaheadPeriods:   t.LookaheadPeriods,
+			ClassificationBins: t.ClassificationBins,
+			BinMode:            t.BinMode,
+			NumClasses:         t.NumClasses,
+		})
+	}
+	return append(specs, t.Targets...)
 }
 
 // PreprocessConfig defines preprocessing options
@@ -135,6 +263,62 @@ type PreprocessConfig struct {
 	ClipOutliers    bool              `bson:"clip_outliers" json:"clip_outliers"`
 	OutlierStdDev   float64           `bson:"outlier_stddev,omitempty" json:"outlier_stddev,omitempty"` // Clip at N std devs
 	InfHandling     string            `bson:"inf_handling,omitempty" json:"inf_handling,omitempty"`     // drop, replace_nan, clip
+
+	// ClipMethod picks how ClipOutliers bounds each column: stddev (default)
+	// clips at OutlierStdDev standard deviations from the mean of all rows;
+	// percentile clips at the LowerPct and UpperPct percentiles (0-100, default
+	// 1 and 99) of the training rows, which the outliers themselves barely
+	// move. Percentile clipping leaves categorical columns alone.
+	ClipMethod string  `bson:"clip_method,omitempty" json:"clip_method,omitempty"`
+	LowerPct   float64 `bson:"lower_pct,omitempty" json:"lower_pct,omitempty"`
+	UpperPct   float64 `bson:"upper_pct,omitempty" json:"upper_pct,omitempty"`
+
+	// With RemoveNaNRows, keep rows whose fraction of NaN values is at or below
+	// this threshold (0 = drop any row with a NaN)
+	MaxNaNFraction float64 `bson:"max_nan_fraction,omitempty" json:"max_nan_fraction,omitempty"`
+
+	// Drop feature columns whose fraction of NaN values is above this threshold
+	// before filling and row removal (0 = keep all columns). Targets are never dropped.
+	MaxColNaNFraction float64 `bson:"max_col_nan_fraction,omitempty" json:"max_col_nan_fraction,omitempty"`
+
+	// Forward and backward fill carry a value across at most this many
+	// consecutive NaN rows (0 = unlimited). The rest of a longer gap, and rows
+	// with no value to carry, stay NaN, so with RemoveNaNRows they are dropped
+	// (subject to MaxNaNFraction) instead of holding a stale value.
+	ForwardFillLimit int `bson:"forward_fill_limit,omitempty" json:"forward_fill_limit,omitempty"`
+
+	// TrimWarmup drops the first WarmupRows rows before preprocessing, where
+	// long lookbacks leave features NaN, regardless of NaNs further on.
+	// WarmupRows 0 uses the feature config's warm-up (the largest indicator,
+	// rolling and lag period). Exports limited by TailBars already drop them.
+	TrimWarmup bool `bson:"trim_warmup,omitempty" json:"trim_warmup,omitempty"`
+	WarmupRows int  `bson:"warmup_rows,omitempty" json:"warmup_rows,omitempty"`
+
+	// ProtectColumns names columns, such as split keys, that clipping,
+	// normalization and NaN column dropping leave as they are. Target
+	// columns are always protected.
+	ProtectColumns []string `bson:"protect_columns,omitempty" json:"protect_columns,omitempty"`
+}
+
+// Outlier clipping methods
+const (
+	ClipMethodStdDev     = "stddev"
+	ClipMethodPercentile = "percentile"
+)
+
+// Default percentiles of percentile clipping
+const (
+	DefaultClipLowerPct = 1.0
+	DefaultClipUpperPct = 99.0
+)
+
+// ClipPercentiles returns the percentiles percentile clipping uses, applying
+// the defaults when neither is set
+func (p PreprocessConfig) ClipPercentiles() (float64, float64) {
+	if p.LowerPct == 0 && p.UpperPct == 0 {
+		return DefaultClipLowerPct, DefaultClipUpperPct
+	}
+	return p.LowerPct, p.UpperPct
 }
 
 // SplitConfig defines train/validation/test split
@@ -145,6 +329,12 @@ type SplitConfig struct {
 	TestRatio       float64 `bson:"test_ratio" json:"test_ratio"`
 	TimeBased       bool    `bson:"time_based" json:"time_based"` // True = chronological split (no look-ahead bias)
 	Shuffle         bool    `bson:"shuffle" json:"shuffle"`       // Only if TimeBased is false
+
+	// ShuffleIndex keeps rows chronological but adds a shuffle_index column giving
+	// a reproducible random order of the training rows (-1 for other splits).
+	// Only used with TimeBased splits.
+	ShuffleIndex bool  `bson:"shuffle_index" json:"shuffle_index"`
+	ShuffleSeed  int64 `bson:"shuffle_seed" json:"shuffle_seed"`
 }
 
 // SequenceConfig defines sequence generation for RNN/LSTM/Transformer
@@ -155,6 +345,57 @@ type SequenceConfig struct {
 	IncludeTarget bool `bson:"include_target" json:"include_target"` // Include target in sequence output
 }
 
+// ResampleConfig defines resampling of source candles to a coarser timeframe
+// Base Volume, QuoteVolume and TradeCount are summed independently per bucket.
+// VWAP-like fields are not recomputed since that would require tick data.
+type ResampleConfig struct {
+	Enabled           bool              `bson:"enabled" json:"enabled"`
+	Timeframe         string            `bson:"timeframe,omitempty" json:"timeframe,omitempty"`                   // Target timeframe, e.g. "1h"
+	VolumeAggregation VolumeAggregation `bson:"volume_aggregation,omitempty" json:"volume_aggregation,omitempty"` // base (default), quote
+}
+
+// AlignmentConfig snaps each source's candles onto the bar grid of its
+// timeframe before sources are merged, so series from exchanges whose candle
+// times are a few seconds off line up on the same timestamps. A candle within
+// the tolerance of a bar boundary is moved onto it; one further off, or one
+// landing on a bar already taken, is dropped and reported in the warnings.
+type AlignmentConfig struct {
+	Enabled     bool  `bson:"enabled" json:"enabled"`
+	ToleranceMs int64 `bson:"tolerance_ms,omitempty" json:"tolerance_ms,omitempty"` // Default 10% of a bar, at most half a bar
+}
+
+// FloatFormat selects how text export formats write floats
+type FloatFormat string
+
+const (
+	FloatFormatFixed      FloatFormat = "fixed"      // Fixed decimals, e.g. 0.00001234 (default)
+	FloatFormatGeneral    FloatFormat = "general"    // Significant digits regardless of magnitude, e.g. 1.234e-05
+	FloatFormatScientific FloatFormat = "scientific" // Always an exponent, e.g. 1.23400000e-05
+)
+
+// Valid reports whether the float format is known, treating empty as the default
+func (f FloatFormat) Valid() bool {
+	switch f {
+	case "", FloatFormatFixed, FloatFormatGeneral, FloatFormatScientific:
+		return true
+	}
+	return false
+}
+
+// MinBarsAction selects what happens to a source job shorter than the required bars
+type MinBarsAction string
+
+const (
+	MinBarsActionFail MinBarsAction = "fail" // Fail the export listing the short sources (default)
+	MinBarsActionSkip MinBarsAction = "skip" // Leave short sources out and report them in the metadata
+)
+
+// MinBarsConfig guards against source jobs too short for the config's lookback
+type MinBarsConfig struct {
+	MinBars int           `bson:"min_bars,omitempty" json:"min_bars,omitempty"` // 0 = derive from features, target and sequence config
+	Action  MinBarsAction `bson:"action,omitempty" json:"action,omitempty"`     // fail (default), skip
+}
+
 // MLExportJob represents a background ML export job
 type MLExportJob struct {
 	ID     primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
@@ -168,10 +409,15 @@ type MLExportJob struct {
 	ProcessedRecords int64   `bson:"processed_records" json:"processed_records"`
 	CurrentPhase     string  `bson:"current_phase,omitempty" json:"current_phase,omitempty"` // loading, features, preprocessing, writing
 
+	// RequestID is the ID of the request that started the export, which
+	// prefixes the export's log lines
+	RequestID string `bson:"request_id,omitempty" json:"request_id,omitempty"`
+
 	// Results
 	OutputPath    string   `bson:"output_path,omitempty" json:"output_path,omitempty"`
 	OutputFiles   []string `bson:"output_files,omitempty" json:"output_files,omitempty"` // For split outputs
 	FileSizeBytes int64    `bson:"file_size_bytes" json:"file_size_bytes"`
+	Checksum      string   `bson:"checksum,omitempty" json:"checksum,omitempty"` // SHA-256 (hex) of the output file
 	FeatureCount  int      `bson:"feature_count" json:"feature_count"`
 	ColumnNames   []string `bson:"column_names,omitempty" json:"column_names,omitempty"`
 	RowCount      int64    `bson:"row_count" json:"row_count"`
@@ -184,9 +430,95 @@ type MLExportJob struct {
 	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
 	ExpiresAt   *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"` // Auto-cleanup time
 	LastError   string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
-	Errors      []string   `bson:"errors,omitempty" json:"errors,omitempty"`
+	Errors      []string   `bson:"errors,omitempty" json:"errors,omitempty"` // Prefixed with the request ID, e.g. "[req-id] message"
 	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+
+	// Warnings are non-fatal issues that shaped the output, such as source jobs
+	// left out for having no data
+	Warnings []ExportWarning `bson:"warnings,omitempty" json:"warnings,omitempty"`
+}
+
+// MLExportCleanupResult summarizes one run of the export cleanup
+type MLExportCleanupResult struct {
+	FilesRemoved      int `json:"files_removed"`
+	RecordsRemoved    int `json:"records_removed"`
+	RecordsReconciled int `json:"records_reconciled"` // Completed jobs whose file was already missing
+}
+
+// MLExportBulkDeleteResult summarizes a bulk deletion of export jobs by status
+type MLExportBulkDeleteResult struct {
+	Status       MLExportStatus `json:"status"`
+	JobsDeleted  int64          `json:"jobs_deleted"`
+	FilesRemoved int            `json:"files_removed"`
+	BytesRemoved int64          `json:"bytes_removed"`
+}
+
+// IsTerminal returns true if no further processing will happen for the status
+func (s MLExportStatus) IsTerminal() bool {
+	switch s {
+	case MLExportStatusCompleted, MLExportStatusFailed, MLExportStatusCancelled, MLExportStatusExpired:
+		return true
+	}
+	return false
+}
+
+// MLExportDiskUsage reports disk space used by export files
+type MLExportDiskUsage struct {
+	ExportDir      string  `json:"export_dir"`
+	FileCount      int     `json:"file_count"`
+	UsedBytes      int64   `json:"used_bytes"`
+	MaxBytes       int64   `json:"max_bytes"` // 0 = unlimited
+	AvailableBytes int64   `json:"available_bytes,omitempty"`
+	UsagePercent   float64 `json:"usage_percent,omitempty"`
+	OverLimit      bool    `json:"over_limit"`
+}
+
+// MLExportHealth reports whether exports can currently be written
+type MLExportHealth struct {
+	Status       string   `json:"status"` // available, warning or unavailable
+	ExportDir    string   `json:"export_dir"`
+	Writable     bool     `json:"writable"`
+	Error        string   `json:"error,omitempty"`
+	FreeBytes    *int64   `json:"free_bytes,omitempty"` // nil when the platform can't report it
+	MinFreeBytes int64    `json:"min_free_bytes"`       // 0 = not checked
+	LowDisk      bool     `json:"low_disk"`
+	RunningJobs  int      `json:"running_jobs"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// MLExportVerification is the result of re-hashing an export file on disk
+type MLExportVerification struct {
+	ExportJobID      string `json:"export_job_id"`
+	OutputPath       string `json:"output_path"`
+	FileExists       bool   `json:"file_exists"`
+	ExpectedChecksum string `json:"expected_checksum,omitempty"`
+	ActualChecksum   string `json:"actual_checksum,omitempty"`
+	ChecksumMatch    bool   `json:"checksum_match"`
+	ExpectedSize     int64  `json:"expected_size"`
+	ActualSize       int64  `json:"actual_size"`
+	SizeMatch        bool   `json:"size_match"`
+	Valid            bool   `json:"valid"`
+	Message          string `json:"message,omitempty"`
+}
+
+// MLExportPreview holds the first and last rows of a completed export's
+// output, read from the file without downloading it
+type MLExportPreview struct {
+	ExportJobID string               `json:"export_job_id"`
+	Format      MLExportFormat       `json:"format"`
+	Columns     []string             `json:"columns"`
+	RowCount    int64                `json:"row_count"`
+	Head        []MLExportPreviewRow `json:"head"`
+	Tail        []MLExportPreviewRow `json:"tail"`
+}
+
+// MLExportPreviewRow is one row of an export preview, aligned with the
+// preview's columns
+type MLExportPreviewRow struct {
+	Timestamp *time.Time `json:"timestamp,omitempty"` // NumPy outputs carry no timestamps
+	Split     string     `json:"split,omitempty"`
+	Values    []*float64 `json:"values"` // null for NaN and infinite values
 }
 
 // MLExportMetadata stores metadata for reproducibility
@@ -199,6 +531,102 @@ type MLExportMetadata struct {
 	NormalizationParams map[string]NormParams   `bson:"normalization_params,omitempty" json:"normalization_params,omitempty"`
 	SplitInfo           *SplitInfo              `bson:"split_info,omitempty" json:"split_info,omitempty"`
 	SequenceInfo        *SequenceInfo           `bson:"sequence_info,omitempty" json:"sequence_info,omitempty"`
+	SkippedSources      []SkippedSourceInfo     `bson:"skipped_sources,omitempty" json:"skipped_sources,omitempty"`
+	Coverage            *DatasetCoverage        `bson:"coverage,omitempty" json:"coverage,omitempty"`
+	Targets             []TargetInfo            `bson:"targets,omitempty" json:"targets,omitempty"`
+	DroppedColumns      []DroppedColumnInfo     `bson:"dropped_columns,omitempty" json:"dropped_columns,omitempty"`
+	DroppedRows         *DroppedRowsInfo        `bson:"dropped_rows,omitempty" json:"dropped_rows,omitempty"`
+	ClipBounds          map[string]ClipBounds   `bson:"clip_bounds,omitempty" json:"clip_bounds,omitempty"`
+	RecalculatedJobs    []RecalculatedJobInfo   `bson:"recalculated_jobs,omitempty" json:"recalculated_jobs,omitempty"`
+	Tail                *TailInfo               `bson:"tail,omitempty" json:"tail,omitempty"`
+	TrimmedWarmupRows   int                     `bson:"trimmed_warmup_rows,omitempty" json:"trimmed_warmup_rows,omitempty"` // Leading rows dropped by TrimWarmup
+	AuxiliarySources    []AuxSourceInfo         `bson:"auxiliary_sources,omitempty" json:"auxiliary_sources,omitempty"`
+}
+
+// AuxSourceInfo records an auxiliary source joined onto an export
+type AuxSourceInfo struct {
+	JobID      primitive.ObjectID `bson:"job_id" json:"job_id"`
+	JobType    string             `bson:"job_type" json:"job_type"`
+	ExchangeID string             `bson:"exchange_id" json:"exchange_id"`
+	Symbol     string             `bson:"symbol" json:"symbol"`
+	Timeframe  string             `bson:"timeframe" json:"timeframe"`
+	Columns    []string           `bson:"columns" json:"columns"`         // Joined column names
+	PointCount int64              `bson:"point_count" json:"point_count"` // Points loaded from the source
+	StartTime  time.Time          `bson:"start_time" json:"start_time"`   // When the first point became known (candles: at close)
+	EndTime    time.Time          `bson:"end_time" json:"end_time"`       // When the last point became known
+}
+
+// TailInfo records how an export was limited to its most recent bars
+type TailInfo struct {
+	TailBars      int   `bson:"tail_bars" json:"tail_bars"`           // Requested bars per source job
+	LoadedBars    int64 `bson:"loaded_bars" json:"loaded_bars"`       // Bars features were generated from, warm-up included
+	WarmupRows    int   `bson:"warmup_rows" json:"warmup_rows"`       // Leading rows dropped after feature generation
+	EffectiveBars int64 `bson:"effective_bars" json:"effective_bars"` // Bars left for the export
+}
+
+// RecalculatedJobInfo records a source job whose stored indicators were
+// recalculated before the export because requested indicators were missing
+type RecalculatedJobInfo struct {
+	JobID          primitive.ObjectID `bson:"job_id" json:"job_id"`
+	Symbol         string             `bson:"symbol" json:"symbol"`
+	Timeframe      string             `bson:"timeframe" json:"timeframe"`
+	Missing        []string           `bson:"missing" json:"missing"`
+	CandlesUpdated int                `bson:"candles_updated" json:"candles_updated"`
+	Skipped        bool               `bson:"skipped,omitempty" json:"skipped,omitempty"` // Over the per-export recalculation limit
+	Error          string             `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// DroppedColumnInfo records a feature column removed during preprocessing
+type DroppedColumnInfo struct {
+	Name        string  `bson:"name" json:"name"`
+	NaNFraction float64 `bson:"nan_fraction" json:"nan_fraction"`
+	Reason      string  `bson:"reason" json:"reason"`
+}
+
+// DroppedRowsInfo records the rows removed during preprocessing
+type DroppedRowsInfo struct {
+	Count          int     `bson:"count" json:"count"`
+	MaxNaNFraction float64 `bson:"max_nan_fraction" json:"max_nan_fraction"`
+	FirstTimestamp int64   `bson:"first_timestamp,omitempty" json:"first_timestamp,omitempty"`
+	LastTimestamp  int64   `bson:"last_timestamp,omitempty" json:"last_timestamp,omitempty"`
+	Reason         string  `bson:"reason" json:"reason"`
+}
+
+// ClipBounds records the range outlier clipping held a column to
+type ClipBounds struct {
+	Method  string  `bson:"method" json:"method"` // stddev or percentile
+	Lower   float64 `bson:"lower" json:"lower"`
+	Upper   float64 `bson:"upper" json:"upper"`
+	FitRows int     `bson:"fit_rows" json:"fit_rows"` // Leading rows the bounds were computed from
+	Clipped int     `bson:"clipped" json:"clipped"`   // Values moved onto a bound
+}
+
+// TargetInfo records how a generated target column was computed
+type TargetInfo struct {
+	Column             string        `bson:"column" json:"column"`
+	Type               TargetType    `bson:"type" json:"type"`
+	LookaheadPeriod    int           `bson:"lookahead_period" json:"lookahead_period"`
+	BinMode            TargetBinMode `bson:"bin_mode,omitempty" json:"bin_mode,omitempty"`
+	ClassificationBins []float64     `bson:"classification_bins,omitempty" json:"classification_bins,omitempty"` // Edges the classes were assigned with
+	QuantileEdges      []float64     `bson:"quantile_edges,omitempty" json:"quantile_edges,omitempty"`           // Computed edges in quantile mode
+	NumClasses         int           `bson:"num_classes,omitempty" json:"num_classes,omitempty"`
+}
+
+// MLModelCard is a data card summarizing how an export was built, for
+// reproducibility documentation. It only repackages stored job metadata.
+type MLModelCard struct {
+	ExportJobID   string           `json:"export_job_id"`
+	Name          string           `json:"name,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	Format        MLExportFormat   `json:"format"`
+	CreatedAt     time.Time        `json:"created_at"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	RowCount      int64            `json:"row_count"`
+	FeatureCount  int              `json:"feature_count"`
+	FileSizeBytes int64            `json:"file_size_bytes"`
+	Checksum      string           `json:"checksum,omitempty"`
+	Metadata      MLExportMetadata `json:"metadata"`
+	Config        MLExportConfig   `json:"config"`
 }
 
 // DataRange describes the time range of exported data
@@ -220,6 +648,87 @@ type SourceJobInfo struct {
 	BarCount   int64              `bson:"bar_count" json:"bar_count"`
 	StartTime  time.Time          `bson:"start_time" json:"start_time"`
 	EndTime    time.Time          `bson:"end_time" json:"end_time"`
+
+	// First bar of the TailBars window; bars from StartTime up to it were
+	// loaded as feature warm-up only
+	TailStart *time.Time `bson:"tail_start,omitempty" json:"tail_start,omitempty"`
+
+	// With alignment, the candles moved onto the bar grid and those dropped
+	// for being too far off it
+	SnappedBars   int64 `bson:"snapped_bars,omitempty" json:"snapped_bars,omitempty"`
+	UnalignedBars int64 `bson:"unaligned_bars,omitempty" json:"unaligned_bars,omitempty"`
+}
+
+// Coverage statuses describing how well a dataset's source jobs overlap in time
+const (
+	CoverageStatusFull     = "full"     // Sources cover (nearly) the same range
+	CoverageStatusPartial  = "partial"  // Sources share most of their range
+	CoverageStatusSparse   = "sparse"   // Sources share only a small part of their range
+	CoverageStatusDisjoint = "disjoint" // At least two sources have no common range at all
+)
+
+// DatasetCoverage describes the time ranges of a dataset's source jobs and how they overlap
+type DatasetCoverage struct {
+	Status       string           `bson:"status" json:"status"`
+	Sources      []SourceCoverage `bson:"sources" json:"sources"`
+	OverlapStart *time.Time       `bson:"overlap_start,omitempty" json:"overlap_start,omitempty"` // Range covered by every source
+	OverlapEnd   *time.Time       `bson:"overlap_end,omitempty" json:"overlap_end,omitempty"`
+	OverlapRatio float64          `bson:"overlap_ratio" json:"overlap_ratio"` // Common range / combined range (0-1)
+	Warnings     []string         `bson:"warnings,omitempty" json:"warnings,omitempty"`
+}
+
+// SourceCoverage is a source job's range and the share of it inside the common range
+type SourceCoverage struct {
+	SourceJobInfo `bson:",inline"`
+	OverlapRatio  float64 `bson:"overlap_ratio" json:"overlap_ratio"` // Common range / this source's range (0-1)
+}
+
+// SkippedSourceInfo describes a source job left out of an export for having too few bars
+type SkippedSourceInfo struct {
+	JobID        primitive.ObjectID `bson:"job_id" json:"job_id"`
+	Symbol       string             `bson:"symbol" json:"symbol"`
+	Timeframe    string             `bson:"timeframe" json:"timeframe"`
+	BarCount     int64              `bson:"bar_count" json:"bar_count"`
+	RequiredBars int                `bson:"required_bars" json:"required_bars"`
+}
+
+// Codes of export warnings
+const (
+	ExportWarningNoData       = "source_no_data"        // A source job had no candles and was left out
+	ExportWarningTooFewBars   = "source_too_few_bars"   // A source job had fewer bars than required and was skipped
+	ExportWarningPartialRange = "source_partial_range"  // A source job covers less of the time range than the others
+	ExportWarningUnaligned    = "source_unaligned_bars" // Bars of a source job couldn't be aligned to the bar grid and were dropped
+)
+
+// ExportWarning is a non-fatal issue of an export, tagged with the ID of the
+// request that started it so it can be matched to the export's log lines
+type ExportWarning struct {
+	Code      string              `bson:"code" json:"code"`
+	Message   string              `bson:"message" json:"message"`
+	JobID     *primitive.ObjectID `bson:"job_id,omitempty" json:"job_id,omitempty"` // Source job the warning is about
+	RequestID string              `bson:"request_id,omitempty" json:"request_id,omitempty"`
+	At        time.Time           `bson:"at" json:"at"`
+}
+
+// ExportPreflight reports whether the source jobs have enough bars for an export config
+type ExportPreflight struct {
+	OK            bool              `json:"ok"`
+	RequiredBars  int               `json:"required_bars"`
+	FeatureWarmup int               `json:"feature_warmup"` // Leading bars the largest window/lag needs
+	Lookahead     int               `json:"lookahead"`      // Trailing bars the largest target lookahead needs
+	Sources       []PreflightSource `json:"sources"`
+	Errors        []string          `json:"errors,omitempty"`
+}
+
+// PreflightSource is the bar count check of one source job
+type PreflightSource struct {
+	JobID      primitive.ObjectID `json:"job_id"`
+	Symbol     string             `json:"symbol"`
+	Timeframe  string             `json:"timeframe"`
+	BarCount   int64              `json:"bar_count"`
+	Estimated  bool               `json:"estimated,omitempty"` // Bar count estimated after resampling
+	Shortfall  int64              `json:"shortfall,omitempty"`
+	Sufficient bool               `json:"sufficient"`
 }
 
 // FeatureSchema describes a feature column
@@ -234,6 +743,9 @@ type FeatureSchema struct {
 	Max         float64 `bson:"max,omitempty" json:"max,omitempty"`
 	Mean        float64 `bson:"mean,omitempty" json:"mean,omitempty"`
 	Std         float64 `bson:"std,omitempty" json:"std,omitempty"`
+
+	// Categories names the values of a categorical column, indexed by value
+	Categories []string `bson:"categories,omitempty" json:"categories,omitempty"`
 }
 
 // NormParams stores normalization parameters for a feature
@@ -258,6 +770,61 @@ type SplitInfo struct {
 	TestStart  time.Time `bson:"test_start,omitempty" json:"test_start,omitempty"`
 	TestEnd    time.Time `bson:"test_end,omitempty" json:"test_end,omitempty"`
 	TestRows   int64     `bson:"test_rows" json:"test_rows"`
+
+	// Set when a shuffle_index column was emitted for the training rows
+	ShuffleSeed *int64 `bson:"shuffle_seed,omitempty" json:"shuffle_seed,omitempty"`
+}
+
+// SplitManifest lists the files of an export written as one file per split.
+// It is written next to them as <base>_manifest.json and is the export's
+// output file, so downloading the export returns it.
+type SplitManifest struct {
+	ExportJobID string              `json:"export_job_id"`
+	Format      MLExportFormat      `json:"format"`
+	Columns     []string            `json:"columns"`
+	Files       []SplitManifestFile `json:"files"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// SplitManifestFile describes one split file of a SplitManifest
+type SplitManifestFile struct {
+	Split     string    `json:"split"` // train, validation, test
+	File      string    `json:"file"`  // file name, relative to the manifest
+	RowCount  int64     `json:"row_count"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	SizeBytes int64     `json:"size_bytes"`
+	Checksum  string    `json:"checksum"` // SHA-256 hex
+}
+
+// BundleManifest lists the files of an export written with SeparateFiles.
+// It is stored as manifest.json at the root of the export's .zip, with each
+// source job's files under a directory named after the job ID.
+type BundleManifest struct {
+	ExportJobID    string              `json:"export_job_id"`
+	Format         MLExportFormat      `json:"format"`
+	Jobs           []BundleManifestJob `json:"jobs"`
+	SkippedSources []SkippedSourceInfo `json:"skipped_sources,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// BundleManifestJob describes the files exported for one source job
+type BundleManifestJob struct {
+	JobID               string                `json:"job_id"`
+	ExchangeID          string                `json:"exchange_id"`
+	Symbol              string                `json:"symbol"`
+	Timeframe           string                `json:"timeframe"`
+	RowCount            int64                 `json:"row_count"`
+	Columns             []string              `json:"columns"`
+	NormalizationParams map[string]NormParams `json:"normalization_params,omitempty"`
+	Files               []BundleManifestFile  `json:"files"`
+}
+
+// BundleManifestFile describes one file of a BundleManifest
+type BundleManifestFile struct {
+	File      string `json:"file"` // path inside the zip
+	SizeBytes int64  `json:"size_bytes"`
+	Checksum  string `json:"checksum"` // SHA-256 hex
 }
 
 // SequenceInfo describes sequence generation details
@@ -287,14 +854,61 @@ type MLDataset struct {
 
 // FeatureMatrix represents computed features ready for export
 type FeatureMatrix struct {
-	Columns     []string             `json:"columns"`
-	Data        [][]float64          `json:"data"`
-	Timestamps  []int64              `json:"timestamps"`
-	RowCount    int                  `json:"row_count"`
-	ColumnCount int                  `json:"column_count"`
-	Schema      []FeatureSchema      `json:"schema"`
-	SplitLabels []string             `json:"split_labels,omitempty"` // train, validation, test per row
-	Sequences   [][][]float64        `json:"sequences,omitempty"`    // For sequence output
+	Columns        []string              `json:"columns"`
+	Data           [][]float64           `json:"data"`
+	Timestamps     []int64               `json:"timestamps"`
+	RowCount       int                   `json:"row_count"`
+	ColumnCount    int                   `json:"column_count"`
+	Schema         []FeatureSchema       `json:"schema"`
+	SplitLabels    []string              `json:"split_labels,omitempty"`    // train, validation, test per row
+	Targets        []TargetInfo          `json:"targets,omitempty"`         // How each target column was computed
+	DroppedColumns []DroppedColumnInfo   `json:"dropped_columns,omitempty"` // Columns removed during preprocessing
+	DroppedRows    *DroppedRowsInfo      `json:"dropped_rows,omitempty"`    // Rows removed during preprocessing
+	ClipBounds     map[string]ClipBounds `json:"clip_bounds,omitempty"`     // Outlier clipping bounds per column
+	Sequences      [][][]float64         `json:"sequences,omitempty"`       // For sequence output
+}
+
+// MLFeatureCacheEntry stores a generated feature matrix so re-exports of the same
+// data with the same feature config can skip feature generation
+type MLFeatureCacheEntry struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Key           string               `bson:"key" json:"key"` // Hash of the feature config and source data range
+	JobIDs        []primitive.ObjectID `bson:"job_ids" json:"job_ids"`
+	DataUpdatedAt time.Time            `bson:"data_updated_at" json:"data_updated_at"` // Latest source update when the matrix was built
+	Matrix        []byte               `bson:"matrix" json:"-"`                        // gzip-compressed gob of the FeatureMatrix
+	RowCount      int                  `bson:"row_count" json:"row_count"`
+	ColumnCount   int                  `bson:"column_count" json:"column_count"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	ExpiresAt     time.Time            `bson:"expires_at" json:"expires_at"`
+}
+
+// LatestFeatures holds the newest feature rows of a job, for model inference
+type LatestFeatures struct {
+	JobID       primitive.ObjectID `json:"job_id"`
+	Symbol      string             `json:"symbol"`
+	Timeframe   string             `json:"timeframe"`
+	ExportJobID string             `json:"export_job_id,omitempty"` // Export whose columns and normalization were applied
+	Normalized  bool               `json:"normalized"`
+	Columns     []string           `json:"columns"`
+	Rows        []FeatureRow       `json:"rows"` // Oldest first
+}
+
+// FeatureRow is one row of features, aligned with the columns it was returned with
+type FeatureRow struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Values    []*float64 `json:"values"` // null where the feature has no value yet
+}
+
+// IdempotencyKey records the job created for a client-supplied
+// Idempotency-Key so retried requests return it instead of starting another
+type IdempotencyKey struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Scope       string              `bson:"scope" json:"scope"` // Operation the key was sent to, e.g. "export"
+	Key         string              `bson:"key" json:"key"`
+	RequestHash string              `bson:"request_hash" json:"request_hash"` // Retries must send the same request
+	ExportJobID *primitive.ObjectID `bson:"export_job_id,omitempty" json:"export_job_id,omitempty"` // Unset while the first request is in flight
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	ExpiresAt   time.Time           `bson:"expires_at" json:"expires_at"`
 }
 
 // DefaultMLExportConfig returns a default configuration
@@ -579,3 +1193,23 @@ func GetBuiltinPresets() []MLExportConfig {
 		},
 	}
 }
+
+// ConfigDiff compares the columns two export configs produce, derived from the
+// configs alone, and the settings they differ in
+type ConfigDiff struct {
+	ColumnCountA    int           `json:"column_count_a"`
+	ColumnCountB    int           `json:"column_count_b"`
+	CommonColumns   int           `json:"common_columns"`
+	SameColumnOrder bool          `json:"same_column_order"`
+	OnlyInA         []string      `json:"only_in_a"`
+	OnlyInB         []string      `json:"only_in_b"`
+	Settings        []SettingDiff `json:"settings"`
+}
+
+// SettingDiff is a preprocessing, split, target, sequence or resample setting
+// the two configs of a ConfigDiff set differently
+type SettingDiff struct {
+	Field string      `json:"field"` // Dotted path, e.g. "split.train_ratio"
+	A     interface{} `json:"a"`
+	B     interface{} `json:"b"`
+}
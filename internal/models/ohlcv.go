@@ -1,6 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -34,7 +38,22 @@ type OHLCVChunk struct {
 	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
 	CandlesCount int                `bson:"candles_count" json:"candles_count"`
-	Candles      []Candle           `bson:"candles" json:"candles"` // Sorted by timestamp descending (newest first)
+	Candles      []Candle           `bson:"candles,omitempty" json:"candles"` // Sorted by timestamp descending (newest first)
+	CandlesBlob  []byte             `bson:"candles_blob,omitempty" json:"-"`  // zstd-compressed candles, replaces Candles when chunk compression is enabled
+}
+
+// Storage backends that can serve an OHLCV read
+const (
+	StorageBackendChunked = "chunked"
+	StorageBackendLegacy  = "legacy"
+	StorageBackendNone    = "none"
+)
+
+// StorageSource describes which storage backend served an OHLCV read
+// Only exposed on read endpoints when ?debug=true is passed
+type StorageSource struct {
+	Backend    string `json:"backend"`     // "chunked", "legacy", or "none"
+	ChunksRead int    `json:"chunks_read"` // Number of monthly chunks read (0 for legacy)
 }
 
 // GetYearMonthFromTimestamp extracts the year-month string from a Unix millisecond timestamp
@@ -46,13 +65,17 @@ func GetYearMonthFromTimestamp(timestampMs int64) string {
 // Candle represents a single OHLCV candle with indicators
 // Note: Newest candles are at index 0, oldest at the end
 type Candle struct {
-	Timestamp  int64      `bson:"timestamp" json:"timestamp"`   // Unix milliseconds
-	Open       float64    `bson:"open" json:"open"`
-	High       float64    `bson:"high" json:"high"`
-	Low        float64    `bson:"low" json:"low"`
-	Close      float64    `bson:"close" json:"close"`
-	Volume     float64    `bson:"volume" json:"volume"`
-	Indicators Indicators `bson:"indicators,omitempty" json:"indicators,omitempty"`
+	Timestamp   int64      `bson:"timestamp" json:"timestamp"` // Unix milliseconds
+	Open        float64    `bson:"open" json:"open"`
+	High        float64    `bson:"high" json:"high"`
+	Low         float64    `bson:"low" json:"low"`
+	Close       float64    `bson:"close" json:"close"`
+	Volume      float64    `bson:"volume" json:"volume"`
+	QuoteVolume float64    `bson:"quote_volume,omitempty" json:"quote_volume,omitempty"` // Optional, only set when the source provides it
+	TradeCount  int64      `bson:"trade_count,omitempty" json:"trade_count,omitempty"`   // Optional, only set when the source provides it
+	Indicators  Indicators `bson:"indicators,omitempty" json:"indicators,omitempty"`
+
+	Derivatives *DerivativesData `bson:"-" json:"-"` // Joined for ML exports only, never stored
 }
 
 // Indicators holds computed technical indicators
@@ -172,6 +195,76 @@ type Indicators struct {
 	VolumeSMA *float64 `bson:"volume_sma,omitempty" json:"volume_sma,omitempty"`
 }
 
+// FillMissing copies every indicator that is nil on ind but set on src
+func (ind *Indicators) FillMissing(src Indicators) {
+	dst := reflect.ValueOf(ind).Elem()
+	from := reflect.ValueOf(src)
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Field(i).IsNil() && !from.Field(i).IsNil() {
+			dst.Field(i).Set(from.Field(i))
+		}
+	}
+}
+
+// Populated returns the stored names of the indicators that are set
+func (ind Indicators) Populated() []string {
+	v := reflect.ValueOf(ind)
+	t := v.Type()
+	var names []string
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsNil() {
+			names = append(names, strings.Split(t.Field(i).Tag.Get("bson"), ",")[0])
+		}
+	}
+	return names
+}
+
+// indicatorFieldIndex maps stored indicator names to their Indicators field index
+var indicatorFieldIndex = func() map[string]int {
+	t := reflect.TypeOf(Indicators{})
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		index[strings.Split(t.Field(i).Tag.Get("bson"), ",")[0]] = i
+	}
+	return index
+}()
+
+// ClearFields unsets the indicators with the given stored names
+func (ind *Indicators) ClearFields(names []string) {
+	v := reflect.ValueOf(ind).Elem()
+	for _, name := range names {
+		if i, ok := indicatorFieldIndex[name]; ok {
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		}
+	}
+}
+
+// CopyFields copies the indicators with the given stored names from src,
+// including unset ones
+func (ind *Indicators) CopyFields(src Indicators, names []string) {
+	dst := reflect.ValueOf(ind).Elem()
+	from := reflect.ValueOf(src)
+	for _, name := range names {
+		if i, ok := indicatorFieldIndex[name]; ok {
+			dst.Field(i).Set(from.Field(i))
+		}
+	}
+}
+
+// FieldValue returns the value of the indicator with the given stored name,
+// or nil when it is unset or unknown
+func (ind Indicators) FieldValue(name string) interface{} {
+	i, ok := indicatorFieldIndex[name]
+	if !ok {
+		return nil
+	}
+	field := reflect.ValueOf(ind).Field(i)
+	if field.IsNil() {
+		return nil
+	}
+	return field.Elem().Interface()
+}
+
 // JobExecutionResult represents the result of a job execution
 type JobExecutionResult struct {
 	Success         bool      `json:"success"`
@@ -180,6 +273,10 @@ type JobExecutionResult struct {
 	ExecutionTimeMs int64     `json:"execution_time_ms"`
 	NextRunTime     time.Time `json:"next_run_time"`
 	Error           *string   `json:"error,omitempty"`
+
+	// Err is the cause of a run that failed calling the exchange, matching
+	// the executor's error classes with errors.Is
+	Err error `json:"-"`
 }
 
 // OHLCVStats represents aggregate statistics for OHLCV data
@@ -233,42 +330,60 @@ type DataQualitySummary struct {
 	TotalGaps            int     `json:"total_gaps"`
 	FreshDataJobs        int     `json:"fresh_data_jobs"`
 	StaleDataJobs        int     `json:"stale_data_jobs"`
+	SkippedJobs          int     `json:"skipped_jobs"`  // Series whose gap analysis failed, left out of every other count
+	GapsIncluded         bool    `json:"gaps_included"` // False when computed from chunk metadata without gap detection
+}
+
+// CandleClosed reports whether a candle of the timeframe had closed at now.
+// The exchange's latest candle is still forming until then, and its values
+// change until it closes. Monthly candles close at the next calendar month.
+// A candle of an unknown timeframe counts as closed, so it is never dropped
+// for a duration that was guessed.
+func CandleClosed(c Candle, timeframe string, now time.Time) bool {
+	if timeframe == "1M" {
+		return NextBarTime(timeframe, c.Timestamp) <= now.UnixMilli()
+	}
+	durationMs, err := GetTimeframeMs(timeframe)
+	if err != nil {
+		return true
+	}
+	return c.Timestamp+durationMs <= now.UnixMilli()
+}
+
+// ValidateCandle checks a candle from outside CCXT before it is stored for a
+// timeframe: its prices and volume are finite, high and low bound open and
+// close, and it opens on a bar boundary of the timeframe
+func ValidateCandle(c Candle, timeframe string) error {
+	if c.Timestamp <= 0 {
+		return fmt.Errorf("timestamp must be positive Unix milliseconds")
+	}
+	for _, v := range []float64{c.Open, c.High, c.Low, c.Close, c.Volume} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("prices and volume must be finite")
+		}
+	}
+	if c.Open <= 0 || c.High <= 0 || c.Low <= 0 || c.Close <= 0 {
+		return fmt.Errorf("prices must be positive")
+	}
+	if c.Volume < 0 {
+		return fmt.Errorf("volume must not be negative")
+	}
+	if c.Low > math.Min(c.Open, c.Close) || c.High < math.Max(c.Open, c.Close) {
+		return fmt.Errorf("high and low must bound open and close")
+	}
+	if start := TimeframeBucketStart(timeframe, c.Timestamp); start != c.Timestamp {
+		return fmt.Errorf("timestamp %d is not aligned to %s bars (bar opens at %d)", c.Timestamp, timeframe, start)
+	}
+	return nil
 }
 
-// GetTimeframeDurationMinutes returns the duration of a timeframe in minutes
+// GetTimeframeDurationMinutes returns the duration of a timeframe in minutes,
+// or 60 for an unknown timeframe; sub-minute timeframes count as one minute.
+// Use GetTimeframeMs where an unknown timeframe should be an error.
 func GetTimeframeDurationMinutes(timeframe string) int64 {
-	switch timeframe {
-	case "1m":
-		return 1
-	case "3m":
-		return 3
-	case "5m":
-		return 5
-	case "15m":
-		return 15
-	case "30m":
-		return 30
-	case "1h":
+	durationMs, err := GetTimeframeMs(timeframe)
+	if err != nil {
 		return 60
-	case "2h":
-		return 120
-	case "4h":
-		return 240
-	case "6h":
-		return 360
-	case "8h":
-		return 480
-	case "12h":
-		return 720
-	case "1d":
-		return 1440
-	case "3d":
-		return 4320
-	case "1w":
-		return 10080
-	case "1M":
-		return 43200 // Approximate month
-	default:
-		return 60 // Default to 1 hour
 	}
+	return max(durationMs/60_000, 1)
 }
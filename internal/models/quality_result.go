@@ -201,6 +201,9 @@ type BackfillJob struct {
 	Timeframe      string             `bson:"timeframe" json:"timeframe"`
 	Status         BackfillStatus     `bson:"status" json:"status"`
 
+	// Set when the backfill was started for all jobs of a connector at once
+	ConnectorBackfillID *primitive.ObjectID `bson:"connector_backfill_id,omitempty" json:"connector_backfill_id,omitempty"`
+
 	// Target date range
 	TargetStartDate time.Time `bson:"target_start_date" json:"target_start_date"` // How far back to fetch
 	CurrentOldest   time.Time `bson:"current_oldest" json:"current_oldest"`       // Current oldest data point
@@ -221,6 +224,75 @@ type BackfillJob struct {
 	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
 }
 
+// ConnectorBackfillStatus aggregates the backfill jobs started together for all
+// jobs of a connector. They run one at a time through the connector's backfill
+// worker, so at most one is running.
+type ConnectorBackfillStatus struct {
+	ID         primitive.ObjectID `json:"id"`
+	ExchangeID string             `json:"exchange_id"`
+	Status     BackfillStatus     `json:"status"` // running until every job has completed or failed
+
+	TotalJobs      int     `json:"total_jobs"`
+	PendingJobs    int     `json:"pending_jobs"`
+	RunningJobs    int     `json:"running_jobs"`
+	CompletedJobs  int     `json:"completed_jobs"`
+	FailedJobs     int     `json:"failed_jobs"`
+	CandlesFetched int     `json:"candles_fetched"`
+	Progress       float64 `json:"progress"` // 0-100, mean of the jobs' progress
+
+	Jobs    []*BackfillJob          `json:"jobs"`
+	Skipped []ConnectorBackfillSkip `json:"skipped,omitempty"` // Only reported when the backfill is started
+}
+
+// ConnectorBackfillSkip is a job of the connector left out of a connector backfill
+type ConnectorBackfillSkip struct {
+	JobID     primitive.ObjectID `json:"job_id"`
+	Symbol    string             `json:"symbol"`
+	Timeframe string             `json:"timeframe"`
+	Reason    string             `json:"reason"`
+}
+
+// SummarizeConnectorBackfill aggregates the backfill jobs of a connector backfill
+func SummarizeConnectorBackfill(id primitive.ObjectID, exchangeID string, jobs []*BackfillJob) *ConnectorBackfillStatus {
+	status := &ConnectorBackfillStatus{
+		ID:         id,
+		ExchangeID: exchangeID,
+		TotalJobs:  len(jobs),
+		Jobs:       jobs,
+	}
+
+	for _, job := range jobs {
+		switch job.Status {
+		case BackfillPending:
+			status.PendingJobs++
+		case BackfillRunning:
+			status.RunningJobs++
+		case BackfillCompleted:
+			status.CompletedJobs++
+		case BackfillFailed:
+			status.FailedJobs++
+		}
+		status.CandlesFetched += job.CandlesFetched
+		status.Progress += job.Progress
+	}
+	if len(jobs) > 0 {
+		status.Progress /= float64(len(jobs))
+	}
+
+	switch {
+	case status.RunningJobs > 0 || (status.PendingJobs > 0 && status.PendingJobs < len(jobs)):
+		status.Status = BackfillRunning
+	case status.PendingJobs > 0:
+		status.Status = BackfillPending
+	case len(jobs) > 0 && status.FailedJobs == len(jobs):
+		status.Status = BackfillFailed
+	default:
+		status.Status = BackfillCompleted
+	}
+
+	return status
+}
+
 // BackfillRequest represents a request to backfill historical data
 type BackfillRequest struct {
 	JobID       string `json:"job_id"`
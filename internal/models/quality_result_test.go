@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSummarizeConnectorBackfill(t *testing.T) {
+	jobs := func(statuses ...BackfillStatus) []*BackfillJob {
+		var out []*BackfillJob
+		for _, status := range statuses {
+			job := &BackfillJob{Status: status, CandlesFetched: 100}
+			if status == BackfillCompleted || status == BackfillFailed {
+				job.Progress = 100
+			}
+			out = append(out, job)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name string
+		jobs []*BackfillJob
+		want BackfillStatus
+	}{
+		{"queued", jobs(BackfillPending, BackfillPending), BackfillPending},
+		{"first running", jobs(BackfillRunning, BackfillPending), BackfillRunning},
+		{"between jobs", jobs(BackfillCompleted, BackfillPending), BackfillRunning},
+		{"done", jobs(BackfillCompleted, BackfillFailed), BackfillCompleted},
+		{"all failed", jobs(BackfillFailed, BackfillFailed), BackfillFailed},
+		{"no jobs", nil, BackfillCompleted},
+	}
+	for _, tc := range cases {
+		if got := SummarizeConnectorBackfill(primitive.NilObjectID, "binance", tc.jobs).Status; got != tc.want {
+			t.Errorf("%s: status = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	status := SummarizeConnectorBackfill(primitive.NilObjectID, "binance", jobs(BackfillCompleted, BackfillRunning, BackfillPending, BackfillFailed))
+	if status.TotalJobs != 4 || status.CompletedJobs != 1 || status.RunningJobs != 1 || status.PendingJobs != 1 || status.FailedJobs != 1 {
+		t.Errorf("counts = %+v", status)
+	}
+	if status.CandlesFetched != 400 || status.Progress != 50 {
+		t.Errorf("candles = %d, progress = %g, want 400 and 50", status.CandlesFetched, status.Progress)
+	}
+}
@@ -15,6 +15,14 @@ const (
 	RetentionPolicyTypeTimeframe  RetentionPolicyType = "timeframe"
 )
 
+// RetentionPolicyAction is what a policy does with data past its threshold
+type RetentionPolicyAction string
+
+const (
+	RetentionPolicyActionDelete     RetentionPolicyAction = "delete"     // Drop old chunks (default)
+	RetentionPolicyActionDownsample RetentionPolicyAction = "downsample" // Roll old candles up to a coarser timeframe
+)
+
 // RetentionPolicy defines data retention rules
 type RetentionPolicy struct {
 	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
@@ -31,6 +39,12 @@ type RetentionPolicy struct {
 	MaxCandles      *int64              `bson:"max_candles,omitempty" json:"max_candles,omitempty"` // Keep max N candles per symbol
 	KeepLatestOnly  bool                `bson:"keep_latest_only" json:"keep_latest_only"` // Only keep most recent chunk
 
+	// Downsampling (Action = "downsample"): candles of Timeframe older than
+	// AfterDays are resampled into TargetTimeframe, then the originals are deleted
+	Action          RetentionPolicyAction `bson:"action,omitempty" json:"action,omitempty"`
+	TargetTimeframe *string               `bson:"target_timeframe,omitempty" json:"target_timeframe,omitempty"`
+	AfterDays       int                   `bson:"after_days,omitempty" json:"after_days,omitempty"`
+
 	// Schedule
 	RunSchedule     string              `bson:"run_schedule,omitempty" json:"run_schedule,omitempty"` // cron expression
 	LastRunAt       *time.Time          `bson:"last_run_at,omitempty" json:"last_run_at,omitempty"`
@@ -78,6 +92,44 @@ type RetentionCleanupResult struct {
 	StartedAt       time.Time `json:"started_at"`
 	CompletedAt     time.Time `json:"completed_at"`
 	Error           string    `json:"error,omitempty"`
+
+	// Populated for dry runs: the chunks that would be deleted or trimmed
+	DryRun bool                    `json:"dry_run,omitempty"`
+	Chunks []RetentionChunkPreview `json:"chunks,omitempty"`
+
+	// Populated by downsample policies
+	Rollups []RetentionRollup `json:"rollups,omitempty"`
+}
+
+// RetentionRollup records one series rolled up by a downsample policy
+type RetentionRollup struct {
+	ExchangeID      string    `json:"exchange_id"`
+	Symbol          string    `json:"symbol"`
+	SourceTimeframe string    `json:"source_timeframe"`
+	TargetTimeframe string    `json:"target_timeframe"`
+	Before          time.Time `json:"before"` // Candles before this time were rolled up
+	CandlesRead     int64     `json:"candles_read"`
+	CandlesWritten  int64     `json:"candles_written"`
+	CandlesDeleted  int64     `json:"candles_deleted"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// Actions a retention cleanup can take on a chunk
+const (
+	RetentionActionDelete = "delete" // Whole chunk removed
+	RetentionActionTrim   = "trim"   // Oldest candles removed from the chunk
+)
+
+// RetentionChunkPreview describes one chunk affected by a retention cleanup
+type RetentionChunkPreview struct {
+	ChunkID         primitive.ObjectID `bson:"_id" json:"chunk_id"`
+	ExchangeID      string             `bson:"exchange_id" json:"exchange_id"`
+	Symbol          string             `bson:"symbol" json:"symbol"`
+	Timeframe       string             `bson:"timeframe" json:"timeframe"`
+	YearMonth       string             `bson:"year_month" json:"year_month"`
+	CandlesCount    int64              `bson:"candles_count" json:"candles_count"`
+	Action          string             `bson:"-" json:"action"`
+	CandlesToDelete int64              `bson:"-" json:"candles_to_delete"`
 }
 
 // RetentionCleanupSummary aggregates multiple cleanup results
@@ -89,6 +141,41 @@ type RetentionCleanupSummary struct {
 	Results             []RetentionCleanupResult `json:"results"`
 	StartedAt           time.Time                `json:"started_at"`
 	CompletedAt         time.Time                `json:"completed_at"`
+	DryRun              bool                     `json:"dry_run,omitempty"`
+}
+
+// ChunkConsistencyResult reports a scan of OHLCV chunks whose stored count or
+// time range no longer matched their candles
+type ChunkConsistencyResult struct {
+	ExchangeID       string          `json:"exchange_id,omitempty"`
+	ChunksScanned    int64           `json:"chunks_scanned"`
+	ChunksMismatched int64           `json:"chunks_mismatched"`
+	ChunksFixed      int64           `json:"chunks_fixed"`
+	ChunksChanged    int64           `json:"chunks_changed"` // Written during the scan, left for the next one
+	DryRun           bool            `json:"dry_run,omitempty"`
+	Mismatches       []ChunkMismatch `json:"mismatches,omitempty"` // The first MaxReportedChunkMismatches
+	Duration         int64           `json:"duration_ms"`
+	StartedAt        time.Time       `json:"started_at"`
+	CompletedAt      time.Time       `json:"completed_at"`
+}
+
+// MaxReportedChunkMismatches caps the mismatches listed in a consistency result
+const MaxReportedChunkMismatches = 100
+
+// ChunkMismatch describes a chunk whose stored count or time range differs
+// from its candles
+type ChunkMismatch struct {
+	ChunkID     primitive.ObjectID `json:"chunk_id"`
+	ExchangeID  string             `json:"exchange_id"`
+	Symbol      string             `json:"symbol"`
+	Timeframe   string             `json:"timeframe"`
+	YearMonth   string             `json:"year_month"`
+	StoredCount int                `json:"stored_count"`
+	ActualCount int                `json:"actual_count"`
+	StoredStart time.Time          `json:"stored_start"`
+	ActualStart time.Time          `json:"actual_start"`
+	StoredEnd   time.Time          `json:"stored_end"`
+	ActualEnd   time.Time          `json:"actual_end"`
 }
 
 // RetentionPolicyCreateRequest for creating new retention policies
@@ -102,6 +189,10 @@ type RetentionPolicyCreateRequest struct {
 	MaxCandles     *int64              `json:"max_candles,omitempty"`
 	KeepLatestOnly bool                `json:"keep_latest_only"`
 	RunSchedule    string              `json:"run_schedule,omitempty"`
+
+	Action          RetentionPolicyAction `json:"action,omitempty"`
+	TargetTimeframe *string               `json:"target_timeframe,omitempty"`
+	AfterDays       int                   `json:"after_days,omitempty"`
 }
 
 // RetentionPolicyUpdateRequest for updating retention policies
@@ -112,6 +203,9 @@ type RetentionPolicyUpdateRequest struct {
 	MaxCandles     *int64  `json:"max_candles,omitempty"`
 	KeepLatestOnly *bool   `json:"keep_latest_only,omitempty"`
 	RunSchedule    *string `json:"run_schedule,omitempty"`
+
+	TargetTimeframe *string `json:"target_timeframe,omitempty"`
+	AfterDays       *int    `json:"after_days,omitempty"`
 }
 
 // DataUsageStats provides information about data storage usage
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeframeMs maps each supported timeframe to its bar duration in
+// milliseconds. Monthly bars are nominally 30 days; NextBarTime and
+// BarsBetween follow the calendar for them.
+var timeframeMs = map[string]int64{
+	"1s":  1_000,
+	"1m":  60_000,
+	"3m":  3 * 60_000,
+	"5m":  5 * 60_000,
+	"15m": 15 * 60_000,
+	"30m": 30 * 60_000,
+	"1h":  60 * 60_000,
+	"2h":  2 * 60 * 60_000,
+	"4h":  4 * 60 * 60_000,
+	"6h":  6 * 60 * 60_000,
+	"8h":  8 * 60 * 60_000,
+	"12h": 12 * 60 * 60_000,
+	"1d":  24 * 60 * 60_000,
+	"3d":  3 * 24 * 60 * 60_000,
+	"1w":  7 * 24 * 60 * 60_000,
+	"1M":  30 * 24 * 60 * 60_000,
+}
+
+// GetTimeframeMs returns the bar duration of the timeframe in milliseconds,
+// or an error for a timeframe it doesn't know
+func GetTimeframeMs(timeframe string) (int64, error) {
+	durationMs, ok := timeframeMs[timeframe]
+	if !ok {
+		return 0, fmt.Errorf("unknown timeframe %q", timeframe)
+	}
+	return durationMs, nil
+}
+
+// barMs returns the bar duration of the timeframe in milliseconds for the
+// helpers here that can't fail, falling back to an hour for an unknown
+// timeframe like GetTimeframeDurationMinutes
+func barMs(timeframe string) int64 {
+	if durationMs, err := GetTimeframeMs(timeframe); err == nil {
+		return durationMs
+	}
+	return 60 * 60_000
+}
+
+// IsCalendarTimeframe reports whether bars of the timeframe follow calendar
+// boundaries (weeks open on Monday, months on the 1st, UTC) rather than a
+// fixed number of minutes since the epoch
+func IsCalendarTimeframe(timeframe string) bool {
+	return timeframe == "1w" || timeframe == "1M"
+}
+
+// TimeframeBucketStart returns the open time in Unix milliseconds of the bar
+// of the timeframe that contains ts
+func TimeframeBucketStart(timeframe string, ts int64) int64 {
+	t := time.UnixMilli(ts).UTC()
+	switch timeframe {
+	case "1M":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	case "1w":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		// Weekday counts from Sunday; weekly bars open on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset).UnixMilli()
+	default:
+		durationMs := barMs(timeframe)
+		return ts - ts%durationMs
+	}
+}
+
+// NextBarTime returns the open time of the bar that follows the bar opening at ts
+func NextBarTime(timeframe string, ts int64) int64 {
+	if timeframe == "1M" {
+		return time.UnixMilli(ts).UTC().AddDate(0, 1, 0).UnixMilli()
+	}
+	return ts + barMs(timeframe)
+}
+
+// BarsBetween returns how many whole bars of the timeframe separate the bar
+// opening at start from the bar opening at end. Monthly bars are counted in
+// calendar months, so a February bar is one bar long like a 31-day month.
+func BarsBetween(timeframe string, start, end int64) int64 {
+	if timeframe == "1M" {
+		s := time.UnixMilli(start).UTC()
+		e := time.UnixMilli(end).UTC()
+		months := int64(e.Year()-s.Year())*12 + int64(e.Month()-s.Month())
+		// A partial month does not count as a whole bar
+		if e.Before(s.AddDate(0, int(months), 0)) {
+			months--
+		}
+		return months
+	}
+	return (end - start) / barMs(timeframe)
+}
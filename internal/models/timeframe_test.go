@@ -0,0 +1,182 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func ms(year int, month time.Month, day, hour int) int64 {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC).UnixMilli()
+}
+
+func TestTimeframeBucketStartMonthly(t *testing.T) {
+	tests := []struct {
+		ts   int64
+		want int64
+	}{
+		{ms(2024, time.February, 29, 23), ms(2024, time.February, 1, 0)}, // Leap day
+		{ms(2024, time.March, 31, 12), ms(2024, time.March, 1, 0)},       // 31-day month
+		{ms(2024, time.March, 1, 0), ms(2024, time.March, 1, 0)},
+	}
+
+	for _, tt := range tests {
+		if got := TimeframeBucketStart("1M", tt.ts); got != tt.want {
+			t.Errorf("TimeframeBucketStart(1M, %s) = %s, want %s",
+				time.UnixMilli(tt.ts).UTC(), time.UnixMilli(got).UTC(), time.UnixMilli(tt.want).UTC())
+		}
+	}
+}
+
+func TestTimeframeBucketStartWeekly(t *testing.T) {
+	// 2024-02-29 is a Thursday; its weekly bar opens on Monday 2024-02-26
+	got := TimeframeBucketStart("1w", ms(2024, time.February, 29, 15))
+	if want := ms(2024, time.February, 26, 0); got != want {
+		t.Errorf("got %s, want %s", time.UnixMilli(got).UTC(), time.UnixMilli(want).UTC())
+	}
+
+	// A Sunday belongs to the week that opened the previous Monday
+	got = TimeframeBucketStart("1w", ms(2024, time.March, 3, 10))
+	if want := ms(2024, time.February, 26, 0); got != want {
+		t.Errorf("got %s, want %s", time.UnixMilli(got).UTC(), time.UnixMilli(want).UTC())
+	}
+}
+
+func TestNextBarTimeMonthly(t *testing.T) {
+	if got, want := NextBarTime("1M", ms(2024, time.January, 1, 0)), ms(2024, time.February, 1, 0); got != want {
+		t.Errorf("after January got %s, want %s", time.UnixMilli(got).UTC(), time.UnixMilli(want).UTC())
+	}
+	if got, want := NextBarTime("1M", ms(2024, time.February, 1, 0)), ms(2024, time.March, 1, 0); got != want {
+		t.Errorf("after February got %s, want %s", time.UnixMilli(got).UTC(), time.UnixMilli(want).UTC())
+	}
+}
+
+func TestBarsBetweenMonthly(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int64
+		want       int64
+	}{
+		{"february to march", ms(2024, time.February, 1, 0), ms(2024, time.March, 1, 0), 1},
+		{"march to april", ms(2024, time.March, 1, 0), ms(2024, time.April, 1, 0), 1},
+		{"january to april", ms(2024, time.January, 1, 0), ms(2024, time.April, 1, 0), 3},
+		{"across a year", ms(2023, time.November, 1, 0), ms(2024, time.February, 1, 0), 3},
+		{"partial month", ms(2024, time.January, 15, 0), ms(2024, time.February, 10, 0), 0},
+	}
+
+	for _, tt := range tests {
+		if got := BarsBetween("1M", tt.start, tt.end); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBarsBetweenFixed(t *testing.T) {
+	if got := BarsBetween("1h", ms(2024, time.March, 1, 0), ms(2024, time.March, 1, 5)); got != 5 {
+		t.Errorf("got %d, want 5", got)
+	}
+	if got := BarsBetween("1w", ms(2024, time.February, 26, 0), ms(2024, time.March, 11, 0)); got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestGetTimeframeMs(t *testing.T) {
+	const minute, hour, day = int64(60_000), int64(3_600_000), int64(86_400_000)
+	want := map[string]int64{
+		"1s":  1_000,
+		"1m":  minute,
+		"3m":  3 * minute,
+		"5m":  5 * minute,
+		"15m": 15 * minute,
+		"30m": 30 * minute,
+		"1h":  hour,
+		"2h":  2 * hour,
+		"4h":  4 * hour,
+		"6h":  6 * hour,
+		"8h":  8 * hour,
+		"12h": 12 * hour,
+		"1d":  day,
+		"3d":  3 * day,
+		"1w":  7 * day,
+		"1M":  30 * day,
+	}
+	for timeframe, durationMs := range want {
+		got, err := GetTimeframeMs(timeframe)
+		if err != nil || got != durationMs {
+			t.Errorf("GetTimeframeMs(%s) = %d, %v, want %d", timeframe, got, err, durationMs)
+		}
+		if minutes := GetTimeframeDurationMinutes(timeframe); minutes != max(durationMs/minute, 1) {
+			t.Errorf("GetTimeframeDurationMinutes(%s) = %d, want %d", timeframe, minutes, max(durationMs/minute, 1))
+		}
+	}
+	if len(timeframeMs) != len(want) {
+		t.Errorf("the table has %d timeframes, the test %d", len(timeframeMs), len(want))
+	}
+
+	for _, timeframe := range []string{"", "7m", "1H", "1y"} {
+		if _, err := GetTimeframeMs(timeframe); err == nil {
+			t.Errorf("GetTimeframeMs(%q) accepted an unknown timeframe", timeframe)
+		}
+	}
+}
+
+func TestCandleClosed(t *testing.T) {
+	open := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	candle := Candle{Timestamp: open.UnixMilli()}
+
+	if CandleClosed(candle, "1h", open.Add(59*time.Minute)) {
+		t.Error("1h candle closed 59 minutes after its open")
+	}
+	if !CandleClosed(candle, "1h", open.Add(time.Hour)) {
+		t.Error("1h candle still open an hour after its open")
+	}
+	if !CandleClosed(candle, "5m", open.Add(30*time.Minute)) {
+		t.Error("5m candle still open 30 minutes after its open")
+	}
+
+	// March has 31 days, so its monthly candle is open past the nominal 30
+	monthly := Candle{Timestamp: ms(2024, time.March, 1, 0)}
+	if CandleClosed(monthly, "1M", time.UnixMilli(ms(2024, time.March, 31, 12))) {
+		t.Error("March candle closed on March 31")
+	}
+	if !CandleClosed(monthly, "1M", time.UnixMilli(ms(2024, time.April, 1, 0))) {
+		t.Error("March candle still open on April 1")
+	}
+
+	var job Job
+	if !job.ExcludesUnclosedBar() {
+		t.Error("jobs should exclude the unclosed bar by default")
+	}
+	keep := false
+	job.ExcludeUnclosedBar = &keep
+	if job.ExcludesUnclosedBar() {
+		t.Error("exclude_unclosed_bar=false should keep the unclosed bar")
+	}
+}
+
+func TestValidateCandle(t *testing.T) {
+	valid := Candle{Timestamp: ms(2024, time.March, 4, 5), Open: 100, High: 110, Low: 95, Close: 105, Volume: 3}
+	if err := ValidateCandle(valid, "1h"); err != nil {
+		t.Errorf("valid candle rejected: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		edit      func(c *Candle)
+		timeframe string
+	}{
+		{"misaligned", func(c *Candle) { c.Timestamp += 60_000 }, "1h"},
+		{"not a weekly open", func(c *Candle) {}, "1w"},
+		{"high below close", func(c *Candle) { c.High = 104 }, "1h"},
+		{"low above open", func(c *Candle) { c.Low = 101 }, "1h"},
+		{"negative volume", func(c *Candle) { c.Volume = -1 }, "1h"},
+		{"zero price", func(c *Candle) { c.Open = 0 }, "1h"},
+		{"missing timestamp", func(c *Candle) { c.Timestamp = 0 }, "1h"},
+	}
+	for _, tt := range tests {
+		c := valid
+		tt.edit(&c)
+		if err := ValidateCandle(c, tt.timeframe); err == nil {
+			t.Errorf("%s: candle accepted", tt.name)
+		}
+	}
+}
@@ -113,6 +113,36 @@ func (r *ConnectorRepository) FindAll(ctx context.Context, filter bson.M) ([]*mo
 	return connectors, nil
 }
 
+// FindAllPaginated retrieves one page of connectors matching the filter along with the total match count
+func (r *ConnectorRepository) FindAllPaginated(ctx context.Context, filter bson.M, skip, limit int64) ([]*models.Connector, int64, error) {
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count connectors: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find connectors: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	connectors := []*models.Connector{}
+	if err := cursor.All(ctx, &connectors); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode connectors: %w", err)
+	}
+
+	return connectors, total, nil
+}
+
 // Update updates a connector
 func (r *ConnectorRepository) Update(ctx context.Context, id string, update bson.M) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -309,7 +339,7 @@ func (r *ConnectorRepository) RecordSuccessfulCall(ctx context.Context, exchange
 		return fmt.Errorf("failed to record successful call: %w", err)
 	}
 
-	return nil
+	return r.recordHealthBucket(ctx, exchangeID, now, false, responseTimeMs)
 }
 
 // RecordFailedCall records a failed API call and updates health metrics
@@ -360,6 +390,111 @@ func (r *ConnectorRepository) RecordFailedCall(ctx context.Context, exchangeID s
 		return fmt.Errorf("failed to record failed call: %w", err)
 	}
 
+	return r.recordHealthBucket(ctx, exchangeID, now, true, 0)
+}
+
+// recordHealthBucket adds a call to the current hourly bucket of the health
+// history, dropping the oldest buckets beyond models.HealthHistoryHours
+func (r *ConnectorRepository) recordHealthBucket(ctx context.Context, exchangeID string, at time.Time, failed bool, latencyMs int64) error {
+	hour := at.UTC().Truncate(time.Hour)
+	failures := 0
+	if failed {
+		failures = 1
+	}
+
+	history := bson.M{"$ifNull": bson.A{"$health.history", bson.A{}}}
+	isCurrent := bson.M{"$eq": bson.A{"$$b.hour", hour}}
+	emptyBucket := bson.M{"hour": hour, "calls": 0, "failures": 0, "total_latency_ms": 0}
+
+	update := bson.A{
+		bson.M{"$set": bson.M{"health.history": bson.M{"$let": bson.M{
+			"vars": bson.M{
+				"current": bson.M{"$ifNull": bson.A{
+					bson.M{"$arrayElemAt": bson.A{bson.M{"$filter": bson.M{"input": history, "as": "b", "cond": isCurrent}}, 0}},
+					emptyBucket,
+				}},
+			},
+			"in": bson.M{"$slice": bson.A{
+				bson.M{"$concatArrays": bson.A{
+					bson.M{"$filter": bson.M{"input": history, "as": "b", "cond": bson.M{"$not": bson.A{isCurrent}}}},
+					bson.A{bson.M{
+						"hour":             hour,
+						"calls":            bson.M{"$add": bson.A{"$$current.calls", 1}},
+						"failures":         bson.M{"$add": bson.A{"$$current.failures", failures}},
+						"total_latency_ms": bson.M{"$add": bson.A{"$$current.total_latency_ms", latencyMs}},
+					}},
+				}},
+				-models.HealthHistoryHours,
+			}},
+		}}}},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"exchange_id": exchangeID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to record health history: %w", err)
+	}
+
+	return nil
+}
+
+// OpenCircuit opens a connector's circuit breaker until the given time and
+// marks a healthy connector as degraded
+func (r *ConnectorRepository) OpenCircuit(ctx context.Context, exchangeID string, until time.Time) error {
+	now := time.Now()
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"health.circuit_state":      models.CircuitOpen,
+			"health.circuit_open_until": until,
+			"health.status": bson.M{"$cond": bson.A{
+				bson.M{"$in": bson.A{bson.M{"$ifNull": bson.A{"$health.status", ""}}, bson.A{"", "healthy"}}},
+				"degraded",
+				"$health.status",
+			}},
+			"updated_at": now,
+		}},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"exchange_id": exchangeID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to open circuit: %w", err)
+	}
+	return nil
+}
+
+// TryProbeCircuit moves an open circuit whose cooldown has ended to half-open
+// and reports whether this caller won the probe. The probe slot is held until
+// the given time, so a probe that never reports back is retried later.
+func (r *ConnectorRepository) TryProbeCircuit(ctx context.Context, exchangeID string, until time.Time) (bool, error) {
+	filter := bson.M{
+		"exchange_id":               exchangeID,
+		"health.circuit_state":      bson.M{"$in": bson.A{models.CircuitOpen, models.CircuitHalfOpen}},
+		"health.circuit_open_until": bson.M{"$lte": time.Now()},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"health.circuit_state":      models.CircuitHalfOpen,
+			"health.circuit_open_until": until,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to probe circuit: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// CloseCircuit closes a connector's circuit breaker
+func (r *ConnectorRepository) CloseCircuit(ctx context.Context, exchangeID string) error {
+	update := bson.M{
+		"$set":   bson.M{"health.circuit_state": models.CircuitClosed, "updated_at": time.Now()},
+		"$unset": bson.M{"health.circuit_open_until": ""},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"exchange_id": exchangeID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to close circuit: %w", err)
+	}
 	return nil
 }
 
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // Database wraps the MongoDB client and provides access to collections
@@ -16,12 +18,21 @@ type Database struct {
 	Database *mongo.Database
 }
 
-// Connect establishes a connection to MongoDB
-func Connect(uri, dbName string) (*Database, error) {
+// Connect establishes a connection to MongoDB. writeConcern is "majority", a
+// number of nodes, or empty for the server default; journal also waits for
+// writes to reach the journal.
+func Connect(uri, dbName, writeConcern string, journal bool) (*Database, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	clientOptions := options.Client().ApplyURI(uri)
+	wc, err := parseWriteConcern(writeConcern, journal)
+	if err != nil {
+		return nil, err
+	}
+	if wc != nil {
+		clientOptions.SetWriteConcern(wc)
+	}
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
@@ -40,6 +51,31 @@ func Connect(uri, dbName string) (*Database, error) {
 	return db, nil
 }
 
+// parseWriteConcern builds the write concern Connect applies, nil to keep the
+// server default
+func parseWriteConcern(w string, journal bool) (*writeconcern.WriteConcern, error) {
+	if w == "" && !journal {
+		return nil, nil
+	}
+
+	wc := &writeconcern.WriteConcern{}
+	switch {
+	case w == "":
+	case w == "majority":
+		wc.W = "majority"
+	default:
+		n, err := strconv.Atoi(w)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid write concern %q: must be \"majority\" or a number of nodes", w)
+		}
+		wc.W = n
+	}
+	if journal {
+		wc.Journal = &journal
+	}
+	return wc, nil
+}
+
 // Close disconnects from MongoDB
 func (db *Database) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// DerivativesRepository handles database operations for funding rate and open
+// interest time series, stored one document per point
+type DerivativesRepository struct {
+	fundingCollection      *mongo.Collection
+	openInterestCollection *mongo.Collection
+}
+
+// NewDerivativesRepository creates a new derivatives repository
+func NewDerivativesRepository(db *Database) *DerivativesRepository {
+	fundingCollection := db.GetCollection("funding_rates")
+	openInterestCollection := db.GetCollection("open_interest")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Unique on (exchange_id, symbol, timestamp), which also serves range queries
+	pointIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "timestamp", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := fundingCollection.Indexes().CreateOne(ctx, pointIndex); err != nil {
+		log.Printf("[DERIVATIVES_REPO] Warning: Failed to create funding rates index: %v", err)
+	}
+	if _, err := openInterestCollection.Indexes().CreateOne(ctx, pointIndex); err != nil {
+		log.Printf("[DERIVATIVES_REPO] Warning: Failed to create open interest index: %v", err)
+	}
+
+	return &DerivativesRepository{
+		fundingCollection:      fundingCollection,
+		openInterestCollection: openInterestCollection,
+	}
+}
+
+// UpsertFundingRates stores funding rates, overwriting points already stored
+// at the same timestamp. It returns the number of new points stored.
+func (r *DerivativesRepository) UpsertFundingRates(ctx context.Context, exchangeID, symbol string, rates []models.FundingRate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(rates))
+	for _, rate := range rates {
+		writes = append(writes, pointUpsert(exchangeID, symbol, rate.Timestamp, bson.M{
+			"funding_rate": rate.FundingRate,
+		}))
+	}
+
+	return r.bulkUpsert(ctx, r.fundingCollection, "funding rates", exchangeID, symbol, writes)
+}
+
+// UpsertOpenInterest stores open interest points, overwriting points already
+// stored at the same timestamp. It returns the number of new points stored.
+func (r *DerivativesRepository) UpsertOpenInterest(ctx context.Context, exchangeID, symbol string, points []models.OpenInterest) (int, error) {
+	if len(points) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(points))
+	for _, point := range points {
+		fields := bson.M{"open_interest": point.OpenInterest}
+		if point.OpenInterestValue != 0 {
+			fields["open_interest_value"] = point.OpenInterestValue
+		}
+		writes = append(writes, pointUpsert(exchangeID, symbol, point.Timestamp, fields))
+	}
+
+	return r.bulkUpsert(ctx, r.openInterestCollection, "open interest points", exchangeID, symbol, writes)
+}
+
+// pointUpsert builds an upsert of one time-series point
+func pointUpsert(exchangeID, symbol string, timestamp int64, fields bson.M) mongo.WriteModel {
+	now := time.Now()
+	fields["updated_at"] = now
+	return mongo.NewUpdateOneModel().
+		SetFilter(bson.M{"exchange_id": exchangeID, "symbol": symbol, "timestamp": timestamp}).
+		SetUpdate(bson.M{
+			"$set":         fields,
+			"$setOnInsert": bson.M{"created_at": now},
+		}).
+		SetUpsert(true)
+}
+
+// bulkUpsert runs the point upserts and returns the number of new points
+func (r *DerivativesRepository) bulkUpsert(ctx context.Context, collection *mongo.Collection, what, exchangeID, symbol string, writes []mongo.WriteModel) (int, error) {
+	result, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("failed to store %s: %w", what, err)
+	}
+
+	log.Printf("[DERIVATIVES_REPO] Stored %d new %s for %s-%s", result.UpsertedCount, what, exchangeID, symbol)
+	return int(result.UpsertedCount), nil
+}
+
+// FindFundingRates returns the funding rates in effect between startMs and
+// endMs (Unix milliseconds, inclusive), oldest first. The last rate before
+// startMs is included so the first candle of the range has a value.
+func (r *DerivativesRepository) FindFundingRates(ctx context.Context, exchangeID, symbol string, startMs, endMs int64) ([]models.FundingRate, error) {
+	var rates []models.FundingRate
+	if err := r.findRange(ctx, r.fundingCollection, exchangeID, symbol, startMs, endMs, &rates); err != nil {
+		return nil, fmt.Errorf("failed to find funding rates: %w", err)
+	}
+	return rates, nil
+}
+
+// FindOpenInterest returns the open interest points in effect between startMs
+// and endMs (Unix milliseconds, inclusive), oldest first, like FindFundingRates
+func (r *DerivativesRepository) FindOpenInterest(ctx context.Context, exchangeID, symbol string, startMs, endMs int64) ([]models.OpenInterest, error) {
+	var points []models.OpenInterest
+	if err := r.findRange(ctx, r.openInterestCollection, exchangeID, symbol, startMs, endMs, &points); err != nil {
+		return nil, fmt.Errorf("failed to find open interest: %w", err)
+	}
+	return points, nil
+}
+
+// findRange decodes the points from the last one at or before startMs up to
+// endMs into results
+func (r *DerivativesRepository) findRange(ctx context.Context, collection *mongo.Collection, exchangeID, symbol string, startMs, endMs int64, results interface{}) error {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timestamp":   bson.M{"$lte": startMs},
+	}
+
+	var previous struct {
+		Timestamp int64 `bson:"timestamp"`
+	}
+	err := collection.FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}})).Decode(&previous)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	if err == nil {
+		startMs = previous.Timestamp
+	}
+
+	filter["timestamp"] = bson.M{"$gte": startMs, "$lte": endMs}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, results)
+}
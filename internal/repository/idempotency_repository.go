@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// IdempotencyRepository handles persistence of idempotency keys
+type IdempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *Database) *IdempotencyRepository {
+	collection := db.GetCollection("idempotency_keys")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// One entry per key and operation; MongoDB removes entries once expires_at has passed
+	collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "scope", Value: 1}, {Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+
+	return &IdempotencyRepository{
+		collection: collection,
+	}
+}
+
+// Reserve claims the key for a new request. If an unexpired entry already
+// holds the key it is returned instead and nothing is written.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, entry *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, entry)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	// The TTL monitor only runs every minute, so take over an expired entry ourselves
+	expired := bson.M{
+		"scope":      entry.Scope,
+		"key":        entry.Key,
+		"expires_at": bson.M{"$lte": time.Now()},
+	}
+	replaced, err := r.collection.ReplaceOne(ctx, expired, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if replaced.MatchedCount > 0 {
+		return nil, nil
+	}
+
+	var existing models.IdempotencyKey
+	err = r.collection.FindOne(ctx, bson.M{"scope": entry.Scope, "key": entry.Key}).Decode(&existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+	return &existing, nil
+}
+
+// SetExportJob records the export job created for a reserved key
+func (r *IdempotencyRepository) SetExportJob(ctx context.Context, scope, key string, exportJobID primitive.ObjectID) error {
+	filter := bson.M{"scope": scope, "key": key}
+	update := bson.M{"$set": bson.M{"export_job_id": exportJobID}}
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release deletes a reservation whose request failed, so it can be retried
+func (r *IdempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"scope": scope, "key": key}); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -22,12 +22,13 @@ type JobRepository struct {
 func NewJobRepository(db *Database) *JobRepository {
 	collection := db.GetCollection("jobs")
 
-	// Create unique compound index on (exchange_id, symbol, timeframe)
+	// Create unique compound index on (exchange_id, symbol, timeframe, job_type)
 	indexModel := mongo.IndexModel{
 		Keys: bson.D{
 			{Key: "connector_exchange_id", Value: 1},
 			{Key: "symbol", Value: 1},
 			{Key: "timeframe", Value: 1},
+			{Key: "job_type", Value: 1},
 		},
 		Options: options.Index().SetUnique(true),
 	}
@@ -43,6 +44,12 @@ func NewJobRepository(db *Database) *JobRepository {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Jobs created before job types existed are OHLCV jobs. Backfill their
+	// type and replace the old unique index, which would otherwise stop a
+	// trades or orderbook job from sharing a symbol and timeframe with one.
+	_, _ = collection.UpdateMany(ctx, bson.M{"job_type": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"job_type": models.JobTypeOHLCV}})
+	_, _ = collection.Indexes().DropOne(ctx, "connector_exchange_id_1_symbol_1_timeframe_1")
+
 	_, _ = collection.Indexes().CreateOne(ctx, indexModel)
 	_, _ = collection.Indexes().CreateOne(ctx, statusIndexModel)
 
@@ -62,6 +69,10 @@ func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
 		job.Status = "active"
 	}
 
+	if job.JobType == "" {
+		job.JobType = models.JobTypeOHLCV
+	}
+
 	// Initialize schedule mode if not set
 	if job.Schedule.Mode == "" {
 		job.Schedule.Mode = "timeframe"
@@ -70,7 +81,7 @@ func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
 	_, err := r.collection.InsertOne(ctx, job)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return fmt.Errorf("job already exists for %s/%s/%s", job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+			return fmt.Errorf("%s job already exists for %s/%s/%s", job.JobType, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
 		}
 		return fmt.Errorf("failed to create job: %w", err)
 	}
@@ -97,13 +108,68 @@ func (r *JobRepository) FindByID(ctx context.Context, id string) (*models.Job, e
 	return &job, nil
 }
 
+// OHLCVJobs restricts a job filter to OHLCV jobs, for the features that
+// only work on candles (quality checks, indicator recalculation)
+func OHLCVJobs(filter bson.M) bson.M {
+	filter["job_type"] = bson.M{"$nin": []string{
+		models.JobTypeTrades, models.JobTypeOrderBook, models.JobTypeFunding, models.JobTypeOpenInterest,
+	}}
+	return filter
+}
+
 // FindAll retrieves all jobs with optional filters
 func (r *JobRepository) FindAll(ctx context.Context, filter bson.M) ([]*models.Job, error) {
+	return r.FindAllSorted(ctx, filter, nil)
+}
+
+// jobSortFields maps the sort keys accepted by the API to job document fields
+var jobSortFields = map[string]string{
+	"created_at":    "created_at",
+	"next_run_time": "run_state.next_run_time",
+	"last_run_time": "run_state.last_run_time",
+	"symbol":        "symbol",
+	"status":        "status",
+}
+
+// JobSort builds a sort document for the given API sort key and order (asc or desc).
+// An empty field sorts by newest first.
+func JobSort(field, order string) (bson.D, error) {
+	if field == "" {
+		return defaultJobSort(), nil
+	}
+
+	key, ok := jobSortFields[field]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort field %q: must be one of created_at, next_run_time, last_run_time, symbol, status", field)
+	}
+
+	direction := 1
+	switch order {
+	case "", "asc":
+	case "desc":
+		direction = -1
+	default:
+		return nil, fmt.Errorf("invalid sort order %q: must be asc or desc", order)
+	}
+
+	// Tie-break on _id so paging through equal keys is stable
+	return bson.D{{Key: key, Value: direction}, {Key: "_id", Value: direction}}, nil
+}
+
+func defaultJobSort() bson.D {
+	return bson.D{{Key: "created_at", Value: -1}}
+}
+
+// FindAllSorted retrieves all jobs matching the filter in the given order (newest first if sort is nil)
+func (r *JobRepository) FindAllSorted(ctx context.Context, filter bson.M, sort bson.D) ([]*models.Job, error) {
 	if filter == nil {
 		filter = bson.M{}
 	}
+	if sort == nil {
+		sort = defaultJobSort()
+	}
 
-	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find jobs: %w", err)
 	}
@@ -117,6 +183,65 @@ func (r *JobRepository) FindAll(ctx context.Context, filter bson.M) ([]*models.J
 	return jobs, nil
 }
 
+// FindAllPaginated retrieves one page of jobs matching the filter along with the total match count.
+// A nil sort orders newest first.
+func (r *JobRepository) FindAllPaginated(ctx context.Context, filter bson.M, sort bson.D, skip, limit int64) ([]*models.Job, int64, error) {
+	if filter == nil {
+		filter = bson.M{}
+	}
+	if sort == nil {
+		sort = defaultJobSort()
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode jobs: %w", err)
+	}
+
+	return jobs, total, nil
+}
+
+// FindActiveOrderedByNextRun retrieves up to limit active jobs that have a next run time, soonest first
+func (r *JobRepository) FindActiveOrderedByNextRun(ctx context.Context, limit int64) ([]*models.Job, error) {
+	filter := bson.M{
+		"status":                  "active",
+		"run_state.next_run_time": bson.M{"$ne": nil},
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "run_state.next_run_time", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find queued jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
 // FindByConnector retrieves all jobs for a specific connector
 func (r *JobRepository) FindByConnector(ctx context.Context, exchangeID string) ([]*models.Job, error) {
 	filter := bson.M{"connector_exchange_id": exchangeID}
@@ -328,9 +453,13 @@ func (r *JobRepository) CountActiveByConnector(ctx context.Context, exchangeID s
 	return count, nil
 }
 
-// UpdateStatusByConnector updates status for all jobs of a connector
-func (r *JobRepository) UpdateStatusByConnector(ctx context.Context, exchangeID string, status string) error {
-	filter := bson.M{"connector_exchange_id": exchangeID}
+// UpdateStatusByConnector updates status for all jobs of a connector and
+// returns the number of jobs whose status changed
+func (r *JobRepository) UpdateStatusByConnector(ctx context.Context, exchangeID string, status string) (int64, error) {
+	filter := bson.M{
+		"connector_exchange_id": exchangeID,
+		"status":                bson.M{"$ne": status},
+	}
 	update := bson.M{
 		"$set": bson.M{
 			"status":     status,
@@ -338,12 +467,61 @@ func (r *JobRepository) UpdateStatusByConnector(ctx context.Context, exchangeID
 		},
 	}
 
-	_, err := r.collection.UpdateMany(ctx, filter, update)
+	result, err := r.collection.UpdateMany(ctx, filter, update)
 	if err != nil {
-		return fmt.Errorf("failed to update jobs status: %w", err)
+		return 0, fmt.Errorf("failed to update jobs status: %w", err)
 	}
 
-	return nil
+	return result.ModifiedCount, nil
+}
+
+// symbolFilter matches the jobs of a symbol, on one exchange when exchangeID
+// is set and on all exchanges otherwise
+func symbolFilter(symbol, exchangeID string) bson.M {
+	filter := bson.M{"symbol": symbol}
+	if exchangeID != "" {
+		filter["connector_exchange_id"] = exchangeID
+	}
+	return filter
+}
+
+// FindBySymbol retrieves the jobs of a symbol, optionally scoped to one exchange
+func (r *JobRepository) FindBySymbol(ctx context.Context, symbol, exchangeID string) ([]*models.Job, error) {
+	return r.FindAll(ctx, symbolFilter(symbol, exchangeID))
+}
+
+// UpdateStatusBySymbol updates status for all jobs of a symbol, optionally
+// scoped to one exchange, and returns the number of jobs whose status changed
+func (r *JobRepository) UpdateStatusBySymbol(ctx context.Context, symbol, exchangeID, status string) (int64, error) {
+	filter := symbolFilter(symbol, exchangeID)
+	filter["status"] = bson.M{"$ne": status}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update jobs status: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// DeleteByIDs deletes the given jobs and returns the number deleted
+func (r *JobRepository) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete jobs: %w", err)
+	}
+
+	return result.DeletedCount, nil
 }
 
 // FixMissingNextRunTime sets next_run_time for jobs that don't have it
@@ -373,6 +551,66 @@ func (r *JobRepository) FixMissingNextRunTime(ctx context.Context) (int64, error
 	return result.ModifiedCount, nil
 }
 
+// FindOverdueJobs retrieves active, unlocked jobs whose next run time is
+// before now, most overdue first
+func (r *JobRepository) FindOverdueJobs(ctx context.Context, now time.Time) ([]*models.Job, error) {
+	filter := bson.M{
+		"status":                  "active",
+		"run_state.next_run_time": bson.M{"$lt": now},
+		"$or": []bson.M{
+			{"run_state.locked_until": bson.M{"$lte": now}},
+			{"run_state.locked_until": nil},
+		},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "run_state.next_run_time", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overdue jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// RescheduleJobs sets the new next run time of each job. A job whose next run
+// time changed since it was read, e.g. because it ran meanwhile, is left alone.
+// It returns the number of jobs updated.
+func (r *JobRepository) RescheduleJobs(ctx context.Context, jobs []models.RescheduledJob) (int64, error) {
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"_id":                     job.JobID,
+				"run_state.next_run_time": job.PreviousNextRunTime,
+			}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"run_state.next_run_time": job.NextRunTime,
+					"updated_at":              now,
+				},
+			}))
+	}
+
+	result, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("failed to reschedule jobs: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
 // IncrementConsecutiveFailures increments the consecutive failure counter
 func (r *JobRepository) IncrementConsecutiveFailures(ctx context.Context, jobID string) error {
 	objID, err := primitive.ObjectIDFromHex(jobID)
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// JobRunRepository handles database operations for job execution history
+type JobRunRepository struct {
+	collection *mongo.Collection
+}
+
+// NewJobRunRepository creates a new job run repository
+func NewJobRunRepository(db *Database) *JobRunRepository {
+	collection := db.GetCollection("job_runs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Runs are always read and trimmed newest first per job
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "job_id", Value: 1},
+			{Key: "started_at", Value: -1},
+		},
+	}
+	_, _ = collection.Indexes().CreateOne(ctx, indexModel)
+
+	return &JobRunRepository{
+		collection: collection,
+	}
+}
+
+// Record inserts a run and drops the job's older runs beyond the newest keep
+func (r *JobRunRepository) Record(ctx context.Context, run *models.JobRun, keep int) error {
+	run.ID = primitive.NewObjectID()
+
+	if _, err := r.collection.InsertOne(ctx, run); err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+
+	if keep <= 0 {
+		return nil
+	}
+
+	// Find the oldest run still kept, then delete everything older
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetSkip(int64(keep - 1)).
+		SetProjection(bson.M{"started_at": 1})
+
+	var cutoff models.JobRun
+	err := r.collection.FindOne(ctx, bson.M{"job_id": run.JobID}, opts).Decode(&cutoff)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find job run cutoff: %w", err)
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{
+		"job_id":     run.JobID,
+		"started_at": bson.M{"$lt": cutoff.StartedAt},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to trim job runs: %w", err)
+	}
+
+	return nil
+}
+
+// FindByJob retrieves the most recent runs of a job, newest first
+func (r *JobRunRepository) FindByJob(ctx context.Context, jobID string, limit int64) ([]*models.JobRun, error) {
+	objectID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job ID: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"job_id": objectID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job runs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	runs := []*models.JobRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, fmt.Errorf("failed to decode job runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// DeleteByJob removes all recorded runs of a job
+func (r *JobRunRepository) DeleteByJob(ctx context.Context, jobID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"job_id": jobID}); err != nil {
+		return fmt.Errorf("failed to delete job runs: %w", err)
+	}
+	return nil
+}
+
+// DeleteByJobs removes all recorded runs of the given jobs
+func (r *JobRunRepository) DeleteByJobs(ctx context.Context, jobIDs []primitive.ObjectID) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"job_id": bson.M{"$in": jobIDs}}); err != nil {
+		return fmt.Errorf("failed to delete job runs: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/yourusername/datacollector/internal/models"
@@ -19,30 +20,26 @@ type MLExportRepository struct {
 	datasetCollection *mongo.Collection
 }
 
-// NewMLExportRepository creates a new ML export repository
-func NewMLExportRepository(db *Database) *MLExportRepository {
-	configCollection := db.GetCollection("ml_export_configs")
-	jobCollection := db.GetCollection("ml_export_jobs")
-	datasetCollection := db.GetCollection("ml_datasets")
-
-	// Create indexes for configs
-	configCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+// Indexes of the ML export collections, created by NewMLExportRepository.
+// Compound indexes lead with the filtered field and end with the sort, so
+// listings filtered by status or preset flag don't sort in memory.
+var (
+	mlExportConfigIndexes = []mongo.IndexModel{
 		{
 			Keys:    bson.D{{Key: "name", Value: 1}},
 			Options: options.Index().SetUnique(true),
 		},
 		{
-			Keys: bson.D{{Key: "is_preset", Value: 1}},
+			Keys: bson.D{{Key: "is_preset", Value: 1}, {Key: "created_at", Value: -1}},
 		},
 		{
 			Keys: bson.D{{Key: "is_default", Value: 1}},
 		},
-	})
+	}
 
-	// Create indexes for jobs
-	jobCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+	mlExportJobIndexes = []mongo.IndexModel{
 		{
-			Keys: bson.D{{Key: "status", Value: 1}},
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
 		},
 		{
 			Keys: bson.D{{Key: "job_ids", Value: 1}},
@@ -53,10 +50,9 @@ func NewMLExportRepository(db *Database) *MLExportRepository {
 		{
 			Keys: bson.D{{Key: "expires_at", Value: 1}},
 		},
-	})
+	}
 
-	// Create indexes for datasets
-	datasetCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+	mlDatasetIndexes = []mongo.IndexModel{
 		{
 			Keys:    bson.D{{Key: "name", Value: 1}},
 			Options: options.Index().SetUnique(true),
@@ -67,7 +63,27 @@ func NewMLExportRepository(db *Database) *MLExportRepository {
 		{
 			Keys: bson.D{{Key: "created_at", Value: -1}},
 		},
-	})
+	}
+)
+
+// NewMLExportRepository creates a new ML export repository
+func NewMLExportRepository(db *Database) *MLExportRepository {
+	configCollection := db.GetCollection("ml_export_configs")
+	jobCollection := db.GetCollection("ml_export_jobs")
+	datasetCollection := db.GetCollection("ml_datasets")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := configCollection.Indexes().CreateMany(ctx, mlExportConfigIndexes); err != nil {
+		log.Printf("[ML_EXPORT_REPO] Warning: Failed to create config indexes: %v", err)
+	}
+	if _, err := jobCollection.Indexes().CreateMany(ctx, mlExportJobIndexes); err != nil {
+		log.Printf("[ML_EXPORT_REPO] Warning: Failed to create job indexes: %v", err)
+	}
+	if _, err := datasetCollection.Indexes().CreateMany(ctx, mlDatasetIndexes); err != nil {
+		log.Printf("[ML_EXPORT_REPO] Warning: Failed to create dataset indexes: %v", err)
+	}
 
 	return &MLExportRepository{
 		configCollection:  configCollection,
@@ -447,10 +463,9 @@ func (r *MLExportRepository) UpdateExportJobStatus(ctx context.Context, id primi
 	return nil
 }
 
-// CompleteExportJob marks job as complete with results
-func (r *MLExportRepository) CompleteExportJob(ctx context.Context, id primitive.ObjectID, outputPath string, outputFiles []string, fileSize int64, featureCount int, columnNames []string, rowCount int64, metadata models.MLExportMetadata) error {
+// CompleteExportJob marks job as complete with results (a nil expiresAt keeps the export indefinitely)
+func (r *MLExportRepository) CompleteExportJob(ctx context.Context, id primitive.ObjectID, outputPath string, outputFiles []string, fileSize int64, featureCount int, columnNames []string, rowCount int64, metadata models.MLExportMetadata, expiresAt *time.Time) error {
 	now := time.Now()
-	expiresAt := now.Add(24 * time.Hour) // Files expire after 24 hours
 
 	update := bson.M{
 		"$set": bson.M{
@@ -491,6 +506,18 @@ func (r *MLExportRepository) DeleteExportJob(ctx context.Context, id primitive.O
 	return nil
 }
 
+// DeleteExportJobsByIDs deletes the given export jobs in a single operation
+func (r *MLExportRepository) DeleteExportJobsByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result, err := r.jobCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // FindExpiredExportJobs finds jobs that have expired
 func (r *MLExportRepository) FindExpiredExportJobs(ctx context.Context) ([]models.MLExportJob, error) {
 	filter := bson.M{
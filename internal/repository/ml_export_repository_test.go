@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// wantMLExportIndexes are the index keys the export listings rely on, per
+// collection, in the key pattern form MongoDB reports
+var wantMLExportIndexes = map[string][]bson.D{
+	"ml_export_jobs": {
+		{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		{{Key: "created_at", Value: -1}},
+		{{Key: "expires_at", Value: 1}},
+	},
+	"ml_export_configs": {
+		{{Key: "name", Value: 1}},
+		{{Key: "is_preset", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+}
+
+// indexKeyString renders index keys comparably, e.g. "status:1,created_at:-1"
+func indexKeyString(keys bson.D) string {
+	s := ""
+	for i, k := range keys {
+		if i > 0 {
+			s += ","
+		}
+		s += fmt.Sprintf("%s:%v", k.Key, k.Value)
+	}
+	return s
+}
+
+func TestMLExportIndexDefinitions(t *testing.T) {
+	defined := map[string][]mongo.IndexModel{
+		"ml_export_jobs":    mlExportJobIndexes,
+		"ml_export_configs": mlExportConfigIndexes,
+	}
+
+	for collection, wants := range wantMLExportIndexes {
+		have := make(map[string]bool)
+		for _, index := range defined[collection] {
+			have[indexKeyString(index.Keys.(bson.D))] = true
+		}
+		for _, want := range wants {
+			if !have[indexKeyString(want)] {
+				t.Errorf("%s: no index on %s", collection, indexKeyString(want))
+			}
+		}
+	}
+}
+
+// TestMLExportIndexesCreated checks the indexes exist in a real database. It
+// runs against MONGODB_TEST_URI and is skipped when that isn't set.
+func TestMLExportIndexesCreated(t *testing.T) {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set")
+	}
+
+	dbName := fmt.Sprintf("datacollector_test_%d", time.Now().UnixNano())
+	db, err := Connect(uri, dbName, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	defer db.Close()
+	defer db.Database.Drop(ctx)
+
+	NewMLExportRepository(db)
+
+	for collection, wants := range wantMLExportIndexes {
+		cursor, err := db.GetCollection(collection).Indexes().List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var indexes []struct {
+			Key bson.D `bson:"key"`
+		}
+		if err := cursor.All(ctx, &indexes); err != nil {
+			t.Fatal(err)
+		}
+
+		have := make(map[string]bool)
+		for _, index := range indexes {
+			have[indexKeyString(index.Key)] = true
+		}
+		for _, want := range wants {
+			if !have[indexKeyString(want)] {
+				t.Errorf("%s: index on %s not created", collection, indexKeyString(want))
+			}
+		}
+	}
+}
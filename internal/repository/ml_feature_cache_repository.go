@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// MLFeatureCacheRepository handles persistence of cached feature matrices
+type MLFeatureCacheRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMLFeatureCacheRepository creates a new feature cache repository
+func NewMLFeatureCacheRepository(db *Database) *MLFeatureCacheRepository {
+	collection := db.GetCollection("ml_feature_cache")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// One entry per key; MongoDB removes entries once expires_at has passed
+	collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+
+	return &MLFeatureCacheRepository{
+		collection: collection,
+	}
+}
+
+// FindByKey returns the unexpired cache entry for key, or nil if there is none
+func (r *MLFeatureCacheRepository) FindByKey(ctx context.Context, key string) (*models.MLFeatureCacheEntry, error) {
+	filter := bson.M{
+		"key":        key,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+
+	var entry models.MLFeatureCacheEntry
+	err := r.collection.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find feature cache entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// Save stores the entry, replacing any previous entry with the same key
+func (r *MLFeatureCacheRepository) Save(ctx context.Context, entry *models.MLFeatureCacheEntry) error {
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"key": entry.Key}, entry, opts); err != nil {
+		return fmt.Errorf("failed to save feature cache entry: %w", err)
+	}
+	return nil
+}
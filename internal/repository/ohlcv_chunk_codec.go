@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/klauspost/compress/zstd"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// Compressed chunks store their candles as a zstd-compressed BSON document in
+// candles_blob instead of the candles array. Reads decompress transparently,
+// so both formats can live side by side in the same collection.
+var (
+	chunkEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	chunkDecoder, _ = zstd.NewReader(nil)
+)
+
+// chunkCandles wraps the candle array so it can be marshaled as a BSON document
+type chunkCandles struct {
+	Candles []models.Candle `bson:"candles"`
+}
+
+// compressCandles encodes candles into a candles_blob value
+func compressCandles(candles []models.Candle) ([]byte, int, error) {
+	raw, err := bson.Marshal(chunkCandles{Candles: candles})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal candles: %w", err)
+	}
+	return chunkEncoder.EncodeAll(raw, nil), len(raw), nil
+}
+
+// decompressCandles reverses compressCandles
+func decompressCandles(blob []byte) ([]models.Candle, error) {
+	raw, err := chunkDecoder.DecodeAll(blob, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress candles: %w", err)
+	}
+	var decoded chunkCandles
+	if err := bson.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal candles: %w", err)
+	}
+	return decoded.Candles, nil
+}
+
+// decodeChunk fills chunk.Candles from candles_blob for compressed chunks
+func decodeChunk(chunk *models.OHLCVChunk) error {
+	if len(chunk.CandlesBlob) == 0 {
+		return nil
+	}
+	candles, err := decompressCandles(chunk.CandlesBlob)
+	if err != nil {
+		return fmt.Errorf("chunk %s: %w", chunk.YearMonth, err)
+	}
+	chunk.Candles = candles
+	chunk.CandlesBlob = nil
+	return nil
+}
+
+// chunkCandlesUpdate builds the $set and $unset documents that store candles
+// in a chunk, either as a compressed blob or as a plain array
+func chunkCandlesUpdate(candles []models.Candle, compress bool) (bson.M, bson.M, error) {
+	if !compress {
+		return bson.M{"candles": candles}, bson.M{"candles_blob": ""}, nil
+	}
+	blob, rawSize, err := compressCandles(candles)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("[OHLCV_REPO] Compressed %d candles: %d -> %d bytes", len(candles), rawSize, len(blob))
+	return bson.M{"candles_blob": blob}, bson.M{"candles": ""}, nil
+}
+
+// chunkCandleCountExpr is an aggregation expression for the number of candles
+// in a chunk: compressed chunks only expose candles_count, plain chunks are
+// counted from the array itself
+var chunkCandleCountExpr = bson.M{"$cond": bson.A{
+	bson.M{"$eq": bson.A{bson.M{"$type": "$candles_blob"}, "binData"}},
+	"$candles_count",
+	bson.M{"$size": bson.M{"$ifNull": bson.A{"$candles", bson.A{}}}},
+}}
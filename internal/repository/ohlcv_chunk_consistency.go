@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// chunkScanBatchSize is how many chunks a consistency scan reads per batch;
+// chunks carry a month of candles, so batches stay small
+const chunkScanBatchSize = 20
+
+// RepairChunkBoundaries scans the chunks, of one exchange or all of them, and
+// recomputes each chunk's candle count and start and end times from its
+// candles. Chunks that don't match are rewritten unless dryRun is set. A
+// repair only applies while the chunk is unchanged since it was read, so a
+// concurrent upsert is never overwritten; such chunks are counted as changed
+// and left for the next scan.
+func (r *OHLCVRepository) RepairChunkBoundaries(ctx context.Context, exchangeID string, dryRun bool) (*models.ChunkConsistencyResult, error) {
+	result := &models.ChunkConsistencyResult{
+		ExchangeID: exchangeID,
+		DryRun:     dryRun,
+		StartedAt:  time.Now(),
+	}
+
+	filter := bson.M{}
+	if exchangeID != "" {
+		filter["exchange_id"] = exchangeID
+	}
+	cursor, err := r.chunksCollection.Find(ctx, filter, options.Find().SetBatchSize(chunkScanBatchSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan chunks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var chunk models.OHLCVChunk
+		if err := cursor.Decode(&chunk); err != nil {
+			return nil, fmt.Errorf("failed to decode chunk: %w", err)
+		}
+		if err := decodeChunk(&chunk); err != nil {
+			log.Printf("[OHLCV_REPO] Skipping unreadable chunk %s: %v", chunk.ID.Hex(), err)
+			continue
+		}
+		result.ChunksScanned++
+
+		mismatch := chunkMismatch(&chunk)
+		if mismatch == nil {
+			continue
+		}
+		result.ChunksMismatched++
+		if len(result.Mismatches) < models.MaxReportedChunkMismatches {
+			result.Mismatches = append(result.Mismatches, *mismatch)
+		}
+		if dryRun {
+			continue
+		}
+
+		set := bson.M{"candles_count": mismatch.ActualCount}
+		if mismatch.ActualCount > 0 {
+			set["start_time"] = mismatch.ActualStart
+			set["end_time"] = mismatch.ActualEnd
+		}
+		update, err := r.chunksCollection.UpdateOne(ctx,
+			bson.M{"_id": chunk.ID, "updated_at": chunk.UpdatedAt},
+			bson.M{"$set": set})
+		if err != nil {
+			return nil, fmt.Errorf("failed to repair chunk %s: %w", chunk.ID.Hex(), err)
+		}
+		if update.MatchedCount == 0 {
+			result.ChunksChanged++
+			continue
+		}
+		result.ChunksFixed++
+		log.Printf("[OHLCV_REPO] Repaired chunk %s %s/%s/%s: count %d -> %d", chunk.YearMonth,
+			chunk.ExchangeID, chunk.Symbol, chunk.Timeframe, mismatch.StoredCount, mismatch.ActualCount)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan chunks: %w", err)
+	}
+
+	result.CompletedAt = time.Now()
+	result.Duration = result.CompletedAt.Sub(result.StartedAt).Milliseconds()
+	return result, nil
+}
+
+// chunkMismatch compares a decoded chunk's stored count and time range with
+// its candles, returning nil when they agree. The range of an empty chunk
+// isn't checked, as it has none.
+func chunkMismatch(chunk *models.OHLCVChunk) *models.ChunkMismatch {
+	mismatch := &models.ChunkMismatch{
+		ChunkID:     chunk.ID,
+		ExchangeID:  chunk.ExchangeID,
+		Symbol:      chunk.Symbol,
+		Timeframe:   chunk.Timeframe,
+		YearMonth:   chunk.YearMonth,
+		StoredCount: chunk.CandlesCount,
+		ActualCount: len(chunk.Candles),
+		StoredStart: chunk.StartTime,
+		StoredEnd:   chunk.EndTime,
+	}
+	if len(chunk.Candles) == 0 {
+		if chunk.CandlesCount == 0 {
+			return nil
+		}
+		return mismatch
+	}
+
+	// Don't rely on the array being sorted, that's one of the things that
+	// can go wrong
+	oldest, newest := chunk.Candles[0].Timestamp, chunk.Candles[0].Timestamp
+	for _, c := range chunk.Candles[1:] {
+		oldest = min(oldest, c.Timestamp)
+		newest = max(newest, c.Timestamp)
+	}
+	mismatch.ActualStart = time.UnixMilli(oldest)
+	mismatch.ActualEnd = time.UnixMilli(newest)
+
+	if chunk.CandlesCount == len(chunk.Candles) &&
+		chunk.StartTime.UnixMilli() == oldest &&
+		chunk.EndTime.UnixMilli() == newest {
+		return nil
+	}
+	return mismatch
+}
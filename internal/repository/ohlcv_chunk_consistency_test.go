@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestChunkMismatch(t *testing.T) {
+	candles := benchChunkCandles(10)
+	oldest, newest := candles[9].Timestamp, candles[0].Timestamp
+	consistent := func() *models.OHLCVChunk {
+		c := make([]models.Candle, len(candles))
+		copy(c, candles)
+		return &models.OHLCVChunk{
+			CandlesCount: len(c),
+			StartTime:    time.UnixMilli(oldest),
+			EndTime:      time.UnixMilli(newest),
+			Candles:      c,
+		}
+	}
+
+	if m := chunkMismatch(consistent()); m != nil {
+		t.Errorf("consistent chunk reported as %+v", m)
+	}
+
+	// A count left behind by a concurrent upsert
+	chunk := consistent()
+	chunk.CandlesCount = 12
+	if m := chunkMismatch(chunk); m == nil || m.StoredCount != 12 || m.ActualCount != 10 {
+		t.Errorf("count mismatch reported as %+v", m)
+	}
+
+	// An end time older than the newest candle, with the array out of order
+	chunk = consistent()
+	chunk.EndTime = time.UnixMilli(candles[1].Timestamp)
+	chunk.Candles[0], chunk.Candles[5] = chunk.Candles[5], chunk.Candles[0]
+	m := chunkMismatch(chunk)
+	if m == nil {
+		t.Fatal("end time mismatch not reported")
+	}
+	if m.ActualEnd.UnixMilli() != newest || m.ActualStart.UnixMilli() != oldest {
+		t.Errorf("actual range %v to %v, want %v to %v", m.ActualStart, m.ActualEnd, time.UnixMilli(oldest), time.UnixMilli(newest))
+	}
+
+	// Empty chunks only need their count to agree
+	if m := chunkMismatch(&models.OHLCVChunk{}); m != nil {
+		t.Errorf("empty chunk reported as %+v", m)
+	}
+	if m := chunkMismatch(&models.OHLCVChunk{CandlesCount: 3}); m == nil || m.ActualCount != 0 {
+		t.Errorf("empty chunk with a count reported as %+v", m)
+	}
+}
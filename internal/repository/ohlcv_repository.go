@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -18,6 +19,8 @@ import (
 type OHLCVRepository struct {
 	collection      *mongo.Collection // Legacy single-document collection
 	chunksCollection *mongo.Collection // New chunked storage collection
+	freshness       models.FreshnessThresholds
+	compressChunks  bool // Write chunk candles as a compressed blob
 }
 
 // NewOHLCVRepository creates a new OHLCV repository
@@ -74,6 +77,7 @@ func NewOHLCVRepository(db *Database) *OHLCVRepository {
 	return &OHLCVRepository{
 		collection:      collection,
 		chunksCollection: chunksCollection,
+		freshness:       models.DefaultFreshnessThresholds,
 	}
 }
 
@@ -127,9 +131,10 @@ func (r *OHLCVRepository) upsertChunk(ctx context.Context, exchangeID, symbol, t
 
 	now := time.Now()
 
-	// Find existing chunk
+	// Find existing chunk; a plain chunk's candles are only read when the new
+	// ones can't simply be appended
 	var existingChunk models.OHLCVChunk
-	err := r.chunksCollection.FindOne(ctx, filter).Decode(&existingChunk)
+	err := r.chunksCollection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"candles": 0})).Decode(&existingChunk)
 
 	if err == mongo.ErrNoDocuments {
 		// Create new chunk
@@ -154,6 +159,16 @@ func (r *OHLCVRepository) upsertChunk(ctx context.Context, exchangeID, symbol, t
 			Candles:      candles,
 		}
 
+		if r.compressChunks {
+			blob, rawSize, err := compressCandles(candles)
+			if err != nil {
+				return 0, fmt.Errorf("failed to compress chunk %s: %w", yearMonth, err)
+			}
+			log.Printf("[OHLCV_REPO] Compressed chunk %s: %d -> %d bytes", yearMonth, rawSize, len(blob))
+			chunk.Candles = nil
+			chunk.CandlesBlob = blob
+		}
+
 		_, err := r.chunksCollection.InsertOne(ctx, chunk)
 		if err != nil {
 			return 0, fmt.Errorf("failed to insert chunk %s: %w", yearMonth, err)
@@ -165,6 +180,25 @@ func (r *OHLCVRepository) upsertChunk(ctx context.Context, exchangeID, symbol, t
 		return 0, fmt.Errorf("failed to find chunk: %w", err)
 	}
 
+	// Candles newer than all stored ones are appended without rewriting the chunk
+	if appended, ok := appendableCandles(&existingChunk, candles, r.compressChunks); ok {
+		count, err := r.appendToChunk(ctx, filter, &existingChunk, appended, now)
+		if err != errChunkChanged {
+			return count, err
+		}
+	}
+
+	// Otherwise merge into the full candle array and rewrite it
+	if len(existingChunk.CandlesBlob) == 0 {
+		err = r.chunksCollection.FindOne(ctx, filter).Decode(&existingChunk)
+	}
+	if err == nil {
+		err = decodeChunk(&existingChunk)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find chunk: %w", err)
+	}
+
 	// Merge new candles with existing ones, avoiding duplicates
 	existingTimestamps := make(map[int64]bool)
 	for _, c := range existingChunk.Candles {
@@ -192,15 +226,15 @@ func (r *OHLCVRepository) upsertChunk(ctx context.Context, exchangeID, symbol, t
 	endTime := time.UnixMilli(allCandles[0].Timestamp)
 
 	// Replace the entire candles array (more efficient than $push for merging)
-	update := bson.M{
-		"$set": bson.M{
-			"candles":       allCandles,
-			"candles_count": len(allCandles),
-			"start_time":    startTime,
-			"end_time":      endTime,
-			"updated_at":    now,
-		},
+	set, unset, err := chunkCandlesUpdate(allCandles, r.compressChunks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode chunk %s: %w", yearMonth, err)
 	}
+	set["candles_count"] = len(allCandles)
+	set["start_time"] = startTime
+	set["end_time"] = endTime
+	set["updated_at"] = now
+	update := bson.M{"$set": set, "$unset": unset}
 
 	_, err = r.chunksCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -211,6 +245,73 @@ func (r *OHLCVRepository) upsertChunk(ctx context.Context, exchangeID, symbol, t
 	return len(newUniqueCandles), nil
 }
 
+// errChunkChanged reports a chunk written by someone else since it was read
+var errChunkChanged = errors.New("chunk changed since it was read")
+
+// appendableCandles returns the candles to append to a chunk when the new
+// candles only extend it: every one is newer than the chunk's newest candle,
+// or is that candle again (already stored, so dropped). Compressed chunks and
+// batches reaching back into the chunk need the full rewrite.
+func appendableCandles(chunk *models.OHLCVChunk, candles []models.Candle, compress bool) ([]models.Candle, bool) {
+	if compress || len(chunk.CandlesBlob) > 0 || chunk.CandlesCount == 0 {
+		return nil, false
+	}
+
+	newest := chunk.EndTime.UnixMilli()
+	seen := make(map[int64]bool, len(candles))
+	appended := make([]models.Candle, 0, len(candles))
+	for _, c := range candles {
+		if c.Timestamp < newest {
+			return nil, false
+		}
+		if c.Timestamp == newest || seen[c.Timestamp] {
+			continue
+		}
+		seen[c.Timestamp] = true
+		appended = append(appended, c)
+	}
+	sortCandlesDesc(appended)
+	return appended, true
+}
+
+// chunkAppendUpdate builds the update that adds candles, sorted newest first
+// and all newer than the chunk's candles, to the front of a plain chunk. Only
+// the new candles are sent; $position 0 keeps the array newest first without
+// a server-side $sort.
+func chunkAppendUpdate(candles []models.Candle, now time.Time) bson.M {
+	return bson.M{
+		"$push": bson.M{"candles": bson.M{"$each": candles, "$position": 0}},
+		"$inc":  bson.M{"candles_count": len(candles)},
+		"$set":  bson.M{"end_time": time.UnixMilli(candles[0].Timestamp), "updated_at": now},
+	}
+}
+
+// appendToChunk appends candles to a plain chunk. The update only matches
+// while the chunk's newest candle is unchanged, so a concurrent write makes
+// it return errChunkChanged instead of storing candles out of order.
+func (r *OHLCVRepository) appendToChunk(ctx context.Context, filter bson.M, chunk *models.OHLCVChunk, candles []models.Candle, now time.Time) (int, error) {
+	if len(candles) == 0 {
+		log.Printf("[OHLCV_REPO] No new unique candles for chunk %s", chunk.YearMonth)
+		return 0, nil
+	}
+
+	guarded := bson.M{"end_time": chunk.EndTime, "candles_blob": bson.M{"$exists": false}}
+	for k, v := range filter {
+		guarded[k] = v
+	}
+
+	result, err := r.chunksCollection.UpdateOne(ctx, guarded, chunkAppendUpdate(candles, now))
+	if err != nil {
+		return 0, fmt.Errorf("failed to append to chunk %s: %w", chunk.YearMonth, err)
+	}
+	if result.MatchedCount == 0 {
+		return 0, errChunkChanged
+	}
+
+	log.Printf("[OHLCV_REPO] Appended %d candles to chunk %s (total: %d)", len(candles), chunk.YearMonth, chunk.CandlesCount+len(candles))
+	return len(candles), nil
+}
+
 // sortCandlesDesc sorts candles by timestamp in descending order (newest first)
 func sortCandlesDesc(candles []models.Candle) {
 	for i := 0; i < len(candles)-1; i++ {
@@ -294,6 +395,15 @@ func (r *OHLCVRepository) UpsertCandlesLegacy(ctx context.Context, exchangeID, s
 // FindByJob retrieves all candles for a specific job by aggregating chunks
 // Returns an OHLCVDocument for backward compatibility
 func (r *OHLCVRepository) FindByJob(ctx context.Context, exchangeID, symbol, timeframe string) (*models.OHLCVDocument, error) {
+	doc, _, err := r.FindByJobWithSource(ctx, exchangeID, symbol, timeframe)
+	return doc, err
+}
+
+// FindByJobWithSource behaves like FindByJob but also reports which storage
+// backend (chunked or legacy) served the data and how many chunks were read
+func (r *OHLCVRepository) FindByJobWithSource(ctx context.Context, exchangeID, symbol, timeframe string) (*models.OHLCVDocument, *models.StorageSource, error) {
+	source := &models.StorageSource{Backend: models.StorageBackendNone}
+
 	filter := bson.M{
 		"exchange_id": exchangeID,
 		"symbol":      symbol,
@@ -303,10 +413,13 @@ func (r *OHLCVRepository) FindByJob(ctx context.Context, exchangeID, symbol, tim
 	// First try chunked storage
 	chunks, err := r.findAllChunks(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, source, err
 	}
 
 	if len(chunks) > 0 {
+		source.Backend = models.StorageBackendChunked
+		source.ChunksRead = len(chunks)
+
 		// Aggregate all candles from chunks
 		var allCandles []models.Candle
 		for _, chunk := range chunks {
@@ -323,7 +436,7 @@ func (r *OHLCVRepository) FindByJob(ctx context.Context, exchangeID, symbol, tim
 			CandlesCount: len(allCandles),
 			Candles:      allCandles,
 			UpdatedAt:    chunks[0].UpdatedAt,
-		}, nil
+		}, source, nil
 	}
 
 	// Fall back to legacy storage
@@ -331,12 +444,13 @@ func (r *OHLCVRepository) FindByJob(ctx context.Context, exchangeID, symbol, tim
 	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil // No document yet
+			return nil, source, nil // No document yet
 		}
-		return nil, fmt.Errorf("failed to find OHLCV document: %w", err)
+		return nil, source, fmt.Errorf("failed to find OHLCV document: %w", err)
 	}
 
-	return &doc, nil
+	source.Backend = models.StorageBackendLegacy
+	return &doc, source, nil
 }
 
 // findAllChunks retrieves all chunks for a given filter
@@ -355,6 +469,12 @@ func (r *OHLCVRepository) findAllChunks(ctx context.Context, filter bson.M) ([]m
 		return nil, fmt.Errorf("failed to decode chunks: %w", err)
 	}
 
+	for i := range chunks {
+		if err := decodeChunk(&chunks[i]); err != nil {
+			return nil, err
+		}
+	}
+
 	return chunks, nil
 }
 
@@ -372,6 +492,9 @@ func (r *OHLCVRepository) GetLastCandle(ctx context.Context, exchangeID, symbol,
 
 	var chunk models.OHLCVChunk
 	err := r.chunksCollection.FindOne(ctx, filter, opts).Decode(&chunk)
+	if err == nil {
+		err = decodeChunk(&chunk)
+	}
 	if err == nil && len(chunk.Candles) > 0 {
 		return &chunk.Candles[0], nil // Newest candle is first
 	}
@@ -424,6 +547,95 @@ func (r *OHLCVRepository) GetCandlesCount(ctx context.Context, exchangeID, symbo
 	return doc.CandlesCount, nil
 }
 
+// GetTimeRange returns the first and last candle time and the candle count of a job
+// without loading its candles. A zero count means the job has no data.
+func (r *OHLCVRepository) GetTimeRange(ctx context.Context, exchangeID, symbol, timeframe string) (time.Time, time.Time, int, error) {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+	}
+
+	// Chunk bounds are enough, so skip the candle arrays
+	opts := options.Find().SetProjection(bson.M{"start_time": 1, "end_time": 1, "candles_count": 1})
+	cursor, err := r.chunksCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to find chunks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var chunks []models.OHLCVChunk
+	if err := cursor.All(ctx, &chunks); err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to decode chunks: %w", err)
+	}
+
+	if len(chunks) > 0 {
+		var start, end time.Time
+		count := 0
+		for _, chunk := range chunks {
+			if chunk.CandlesCount == 0 {
+				continue
+			}
+			if start.IsZero() || chunk.StartTime.Before(start) {
+				start = chunk.StartTime
+			}
+			if chunk.EndTime.After(end) {
+				end = chunk.EndTime
+			}
+			count += chunk.CandlesCount
+		}
+		return start, end, count, nil
+	}
+
+	// Fall back to legacy storage (candles are newest first)
+	doc, err := r.FindByJob(ctx, exchangeID, symbol, timeframe)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, err
+	}
+	if doc == nil || len(doc.Candles) == 0 {
+		return time.Time{}, time.Time{}, 0, nil
+	}
+
+	start := time.UnixMilli(doc.Candles[len(doc.Candles)-1].Timestamp)
+	end := time.UnixMilli(doc.Candles[0].Timestamp)
+	return start, end, len(doc.Candles), nil
+}
+
+// GetLastUpdated returns when a job's stored candles were last written, or a
+// zero time if the job has no data
+func (r *OHLCVRepository) GetLastUpdated(ctx context.Context, exchangeID, symbol, timeframe string) (time.Time, error) {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+	}
+
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetProjection(bson.M{"updated_at": 1})
+
+	var chunk models.OHLCVChunk
+	err := r.chunksCollection.FindOne(ctx, filter, opts).Decode(&chunk)
+	if err == nil {
+		return chunk.UpdatedAt, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return time.Time{}, fmt.Errorf("failed to find latest chunk: %w", err)
+	}
+
+	// Fall back to legacy storage
+	var doc models.OHLCVDocument
+	err = r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"updated_at": 1})).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to find OHLCV document: %w", err)
+	}
+
+	return doc.UpdatedAt, nil
+}
+
 // GetRecentCandles retrieves the N most recent candles for a job
 func (r *OHLCVRepository) GetRecentCandles(ctx context.Context, exchangeID, symbol, timeframe string, limit int) ([]models.Candle, error) {
 	filter := bson.M{
@@ -473,6 +685,53 @@ func (r *OHLCVRepository) GetRecentCandles(ctx context.Context, exchangeID, symb
 	return doc.Candles, nil
 }
 
+// FindCandlesInRange retrieves a series' candles with timestamps in
+// [startMs, endMs], newest first. Only the chunks overlapping the range are
+// read; series still in legacy storage are read whole and filtered.
+func (r *OHLCVRepository) FindCandlesInRange(ctx context.Context, exchangeID, symbol, timeframe string, startMs, endMs int64) ([]models.Candle, error) {
+	chunkFilter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+		"start_time":  bson.M{"$lte": time.UnixMilli(endMs)},
+		"end_time":    bson.M{"$gte": time.UnixMilli(startMs)},
+	}
+
+	chunks, err := r.findAllChunks(ctx, chunkFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []models.Candle
+	if len(chunks) > 0 {
+		for _, chunk := range chunks {
+			stored = append(stored, chunk.Candles...)
+		}
+	} else {
+		// Fall back to legacy storage
+		filter := bson.M{
+			"exchange_id": exchangeID,
+			"symbol":      symbol,
+			"timeframe":   timeframe,
+		}
+		var doc models.OHLCVDocument
+		err := r.collection.FindOne(ctx, filter).Decode(&doc)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("failed to find OHLCV document: %w", err)
+		}
+		stored = doc.Candles
+	}
+
+	candles := make([]models.Candle, 0, len(stored))
+	for _, c := range stored {
+		if c.Timestamp >= startMs && c.Timestamp <= endMs {
+			candles = append(candles, c)
+		}
+	}
+	sortCandlesDesc(candles)
+	return candles, nil
+}
+
 // DeleteByJob deletes all OHLCV data (chunks and legacy) for a specific job
 func (r *OHLCVRepository) DeleteByJob(ctx context.Context, exchangeID, symbol, timeframe string) error {
 	filter := bson.M{
@@ -502,6 +761,312 @@ func (r *OHLCVRepository) DeleteByJob(ctx context.Context, exchangeID, symbol, t
 	return nil
 }
 
+// DeleteRange deletes the candles of a series with timestamps in [startMs, endMs]
+// from chunked and legacy storage and returns how many were removed. Affected
+// chunks are rewritten with their remaining candles, or deleted once empty.
+func (r *OHLCVRepository) DeleteRange(ctx context.Context, exchangeID, symbol, timeframe string, startMs, endMs int64) (int64, error) {
+	if startMs > endMs {
+		return 0, fmt.Errorf("invalid range: start %d is after end %d", startMs, endMs)
+	}
+
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+	}
+
+	// Only chunks overlapping the range need to change
+	chunkFilter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+		"start_time":  bson.M{"$lte": time.UnixMilli(endMs)},
+		"end_time":    bson.M{"$gte": time.UnixMilli(startMs)},
+	}
+
+	chunks, err := r.findAllChunks(ctx, chunkFilter)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	now := time.Now()
+	for _, chunk := range chunks {
+		kept := make([]models.Candle, 0, len(chunk.Candles))
+		for _, c := range chunk.Candles {
+			if c.Timestamp < startMs || c.Timestamp > endMs {
+				kept = append(kept, c)
+			}
+		}
+
+		removed := len(chunk.Candles) - len(kept)
+		if removed == 0 {
+			continue
+		}
+
+		if len(kept) == 0 {
+			if _, err := r.chunksCollection.DeleteOne(ctx, bson.M{"_id": chunk.ID}); err != nil {
+				return deleted, fmt.Errorf("failed to delete chunk %s: %w", chunk.YearMonth, err)
+			}
+			log.Printf("[OHLCV_REPO] Deleted emptied chunk %s for %s-%s-%s", chunk.YearMonth, exchangeID, symbol, timeframe)
+		} else {
+			// Chunk candles are stored newest first
+			set, unset, err := chunkCandlesUpdate(kept, r.compressChunks)
+			if err != nil {
+				return deleted, fmt.Errorf("failed to encode chunk %s: %w", chunk.YearMonth, err)
+			}
+			set["candles_count"] = len(kept)
+			set["start_time"] = time.UnixMilli(kept[len(kept)-1].Timestamp)
+			set["end_time"] = time.UnixMilli(kept[0].Timestamp)
+			set["updated_at"] = now
+			update := bson.M{"$set": set, "$unset": unset}
+			if _, err := r.chunksCollection.UpdateOne(ctx, bson.M{"_id": chunk.ID}, update); err != nil {
+				return deleted, fmt.Errorf("failed to update chunk %s: %w", chunk.YearMonth, err)
+			}
+		}
+		deleted += int64(removed)
+	}
+
+	// Legacy documents hold the whole series in one candles array
+	var doc models.OHLCVDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return deleted, fmt.Errorf("failed to find legacy OHLCV document: %w", err)
+	}
+	if err == nil {
+		kept := make([]models.Candle, 0, len(doc.Candles))
+		for _, c := range doc.Candles {
+			if c.Timestamp < startMs || c.Timestamp > endMs {
+				kept = append(kept, c)
+			}
+		}
+
+		if removed := len(doc.Candles) - len(kept); removed > 0 {
+			update := bson.M{
+				"$set": bson.M{
+					"candles":       kept,
+					"candles_count": len(kept),
+					"updated_at":    now,
+				},
+			}
+			if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+				return deleted, fmt.Errorf("failed to update legacy OHLCV document: %w", err)
+			}
+			deleted += int64(removed)
+		}
+	}
+
+	log.Printf("[OHLCV_REPO] Deleted %d candles in [%d, %d] for %s-%s-%s", deleted, startMs, endMs, exchangeID, symbol, timeframe)
+	return deleted, nil
+}
+
+// UpdateIndicatorFields writes the given indicator fields of candles back to
+// storage, leaving OHLCV values and every other indicator untouched. Plain
+// chunks are updated in place field by field; compressed chunks have no
+// addressable candles and are rewritten. A nil fields replaces the candles'
+// whole indicators sub-document. Returns the number of candles updated.
+func (r *OHLCVRepository) UpdateIndicatorFields(ctx context.Context, exchangeID, symbol, timeframe string, candles []models.Candle, fields []string) (int, error) {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+	}
+
+	label := "all indicators"
+	if fields != nil {
+		label = fmt.Sprint(fields)
+	}
+
+	byTimestamp := make(map[int64]models.Indicators, len(candles))
+	for _, c := range candles {
+		byTimestamp[c.Timestamp] = c.Indicators
+	}
+
+	// fieldUpdate builds the $set/$unset entries for one candle of a candles array
+	fieldUpdate := func(set, unset bson.M, position int, ind models.Indicators) {
+		if fields == nil {
+			set[fmt.Sprintf("candles.%d.indicators", position)] = ind
+			return
+		}
+		for _, field := range fields {
+			path := fmt.Sprintf("candles.%d.indicators.%s", position, field)
+			if value := ind.FieldValue(field); value != nil {
+				set[path] = value
+			} else {
+				unset[path] = ""
+			}
+		}
+	}
+
+	cursor, err := r.chunksCollection.Find(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find chunks: %w", err)
+	}
+	var chunks []models.OHLCVChunk
+	if err := cursor.All(ctx, &chunks); err != nil {
+		return 0, fmt.Errorf("failed to decode chunks: %w", err)
+	}
+
+	updated := 0
+	now := time.Now()
+	for _, chunk := range chunks {
+		compressed := len(chunk.CandlesBlob) > 0
+		if err := decodeChunk(&chunk); err != nil {
+			return updated, err
+		}
+
+		set, unset := bson.M{}, bson.M{}
+		matched := 0
+		for i := range chunk.Candles {
+			ind, ok := byTimestamp[chunk.Candles[i].Timestamp]
+			if !ok {
+				continue
+			}
+			matched++
+			if compressed && fields == nil {
+				chunk.Candles[i].Indicators = ind
+			} else if compressed {
+				chunk.Candles[i].Indicators.CopyFields(ind, fields)
+			} else {
+				fieldUpdate(set, unset, i, ind)
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+
+		if compressed {
+			if set, unset, err = chunkCandlesUpdate(chunk.Candles, true); err != nil {
+				return updated, fmt.Errorf("failed to encode chunk %s: %w", chunk.YearMonth, err)
+			}
+		}
+		set["updated_at"] = now
+		update := bson.M{"$set": set}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+		if _, err := r.chunksCollection.UpdateOne(ctx, bson.M{"_id": chunk.ID}, update); err != nil {
+			return updated, fmt.Errorf("failed to update chunk %s: %w", chunk.YearMonth, err)
+		}
+		updated += matched
+	}
+
+	if len(chunks) > 0 {
+		log.Printf("[OHLCV_REPO] Updated %s on %d candles for %s-%s-%s", label, updated, exchangeID, symbol, timeframe)
+		return updated, nil
+	}
+
+	// Legacy documents hold the whole series in one candles array
+	var doc models.OHLCVDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find legacy OHLCV document: %w", err)
+	}
+
+	set, unset := bson.M{"updated_at": now}, bson.M{}
+	for i, c := range doc.Candles {
+		if ind, ok := byTimestamp[c.Timestamp]; ok {
+			fieldUpdate(set, unset, i, ind)
+			updated++
+		}
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update); err != nil {
+		return 0, fmt.Errorf("failed to update legacy OHLCV document: %w", err)
+	}
+
+	log.Printf("[OHLCV_REPO] Updated %s on %d legacy candles for %s-%s-%s", label, updated, exchangeID, symbol, timeframe)
+	return updated, nil
+}
+
+// FindLegacySeries lists the series still held in legacy single-document
+// storage, without their candles
+func (r *OHLCVRepository) FindLegacySeries(ctx context.Context, exchangeID string) ([]models.OHLCVDocument, error) {
+	filter := bson.M{}
+	if exchangeID != "" {
+		filter["exchange_id"] = exchangeID
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"candles": 0}).
+		SetSort(bson.D{{Key: "exchange_id", Value: 1}, {Key: "symbol", Value: 1}, {Key: "timeframe", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find legacy documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []models.OHLCVDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode legacy documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+// MigrateLegacySeries moves a series from legacy storage into chunks. The
+// candles are written through UpsertCandles, which skips candles a chunk
+// already holds, and the legacy document is only deleted once every one of its
+// candles is found in the chunks. Running it again after a failure or an
+// interruption is therefore safe. It returns the number of legacy candles moved.
+func (r *OHLCVRepository) MigrateLegacySeries(ctx context.Context, exchangeID, symbol, timeframe string) (int, error) {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+	}
+
+	var doc models.OHLCVDocument
+	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil // Already migrated
+		}
+		return 0, fmt.Errorf("failed to find legacy document: %w", err)
+	}
+
+	if len(doc.Candles) > 0 {
+		if _, err := r.UpsertCandles(ctx, exchangeID, symbol, timeframe, doc.Candles); err != nil {
+			return 0, fmt.Errorf("failed to write chunks: %w", err)
+		}
+
+		// UpsertCandles logs and skips chunks it fails to write, so check that
+		// every legacy candle made it before dropping the legacy copy
+		chunks, err := r.findAllChunks(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		stored := make(map[int64]bool)
+		for _, chunk := range chunks {
+			for _, c := range chunk.Candles {
+				stored[c.Timestamp] = true
+			}
+		}
+		missing := 0
+		for _, c := range doc.Candles {
+			if !stored[c.Timestamp] {
+				missing++
+			}
+		}
+		if missing > 0 {
+			return 0, fmt.Errorf("verification failed: %d of %d legacy candles missing from chunks", missing, len(doc.Candles))
+		}
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": doc.ID}); err != nil {
+		return 0, fmt.Errorf("failed to delete legacy document: %w", err)
+	}
+
+	log.Printf("[OHLCV_REPO] Migrated %d legacy candles for %s-%s-%s into chunks", len(doc.Candles), exchangeID, symbol, timeframe)
+	return len(doc.Candles), nil
+}
+
 // BulkInsert is deprecated - use UpsertCandles instead
 // Kept for backward compatibility during migration
 func (r *OHLCVRepository) BulkInsert(ctx context.Context, ohlcvList []models.Candle, exchangeID, symbol, timeframe string) (int, error) {
@@ -591,6 +1156,10 @@ func (r *OHLCVRepository) GetStatsByExchange(ctx context.Context, exchangeID str
 		}
 	}
 
+	if len(symbols) > 0 {
+		stats.UniqueExchanges = 1
+	}
+
 	return stats, nil
 }
 
@@ -657,15 +1226,28 @@ func (r *OHLCVRepository) GetAllStats(ctx context.Context) (*models.OHLCVStats,
 	return stats, nil
 }
 
-// FindWithPagination returns paginated candles for the given filter
+// FindWithPagination returns paginated candles for the given filter, newest first
 func (r *OHLCVRepository) FindWithPagination(ctx context.Context, filter bson.M, skip, limit int64) ([]models.Candle, error) {
+	candles, _, err := r.FindWithPaginationWithSource(ctx, filter, skip, limit, false)
+	return candles, err
+}
+
+// FindWithPaginationWithSource behaves like FindWithPagination but also reports
+// which storage backend served the data and how many chunks were read. With
+// ascending set, candles are paged oldest first instead.
+func (r *OHLCVRepository) FindWithPaginationWithSource(ctx context.Context, filter bson.M, skip, limit int64, ascending bool) ([]models.Candle, *models.StorageSource, error) {
+	source := &models.StorageSource{Backend: models.StorageBackendNone}
+
 	// First try chunked storage
 	chunks, err := r.findAllChunks(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, source, err
 	}
 
 	if len(chunks) > 0 {
+		source.Backend = models.StorageBackendChunked
+		source.ChunksRead = len(chunks)
+
 		// Aggregate all candles from chunks
 		var allCandles []models.Candle
 		for _, chunk := range chunks {
@@ -675,17 +1257,79 @@ func (r *OHLCVRepository) FindWithPagination(ctx context.Context, filter bson.M,
 		// Sort candles by timestamp descending (newest first)
 		sortCandlesDesc(allCandles)
 
-		total := int64(len(allCandles))
-		if skip >= total {
-			return []models.Candle{}, nil
+		return candlePage(allCandles, skip, limit, ascending), source, nil
+	}
+
+	// Fall back to legacy storage
+	var doc models.OHLCVDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return []models.Candle{}, source, nil
+		}
+		return nil, source, fmt.Errorf("failed to find candles: %w", err)
+	}
+
+	source.Backend = models.StorageBackendLegacy
+
+	// Sort candles by timestamp descending (newest first)
+	candles := doc.Candles
+	sortCandlesDesc(candles)
+
+	return candlePage(candles, skip, limit, ascending), source, nil
+}
+
+// StreamWithPagination pages candles like FindWithPaginationWithSource, but
+// reads the series one chunk at a time and hands each chunk's share of the
+// page to fn, so memory stays bounded by a chunk rather than the whole series.
+// An error returned by fn stops the stream and is returned as is.
+func (r *OHLCVRepository) StreamWithPagination(ctx context.Context, filter bson.M, skip, limit int64, ascending bool, fn func(candles []models.Candle) error) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	order := -1
+	if ascending {
+		order = 1
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "year_month", Value: order}})
+	cursor, err := r.chunksCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("failed to find chunks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	chunked := false
+	for limit > 0 && cursor.Next(ctx) {
+		chunked = true
+
+		var chunk models.OHLCVChunk
+		if err := cursor.Decode(&chunk); err != nil {
+			return fmt.Errorf("failed to decode chunk: %w", err)
+		}
+		if err := decodeChunk(&chunk); err != nil {
+			return err
 		}
 
-		end := skip + limit
-		if end > total {
-			end = total
+		// Whole chunks before the page are skipped without sorting them
+		if n := int64(len(chunk.Candles)); skip >= n {
+			skip -= n
+			continue
 		}
+		sortCandlesDesc(chunk.Candles)
+		page := candlePage(chunk.Candles, skip, limit, ascending)
+		skip = 0
+		limit -= int64(len(page))
 
-		return allCandles[skip:end], nil
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read chunks: %w", err)
+	}
+	if chunked {
+		return nil
 	}
 
 	// Fall back to legacy storage
@@ -693,14 +1337,21 @@ func (r *OHLCVRepository) FindWithPagination(ctx context.Context, filter bson.M,
 	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return []models.Candle{}, nil
+			return nil
 		}
-		return nil, fmt.Errorf("failed to find candles: %w", err)
+		return fmt.Errorf("failed to find candles: %w", err)
 	}
 
-	total := int64(len(doc.Candles))
+	sortCandlesDesc(doc.Candles)
+	return fn(candlePage(doc.Candles, skip, limit, ascending))
+}
+
+// candlePage returns one page of newest-first candles. With ascending set the
+// page is taken from the oldest end and returned oldest first.
+func candlePage(candles []models.Candle, skip, limit int64, ascending bool) []models.Candle {
+	total := int64(len(candles))
 	if skip >= total {
-		return []models.Candle{}, nil
+		return []models.Candle{}
 	}
 
 	end := skip + limit
@@ -708,15 +1359,55 @@ func (r *OHLCVRepository) FindWithPagination(ctx context.Context, filter bson.M,
 		end = total
 	}
 
-	// Sort candles by timestamp descending (newest first)
-	candles := doc.Candles
-	sortCandlesDesc(candles)
+	if !ascending {
+		return candles[skip:end]
+	}
 
-	return candles[skip:end], nil
+	page := make([]models.Candle, 0, end-skip)
+	for i := total - 1 - skip; i >= total-end; i-- {
+		page = append(page, candles[i])
+	}
+	return page
+}
+
+// ParseCandleOrder reports whether order (asc or desc) asks for candles oldest
+// first. An empty order keeps the default of newest first.
+func ParseCandleOrder(order string) (bool, error) {
+	switch order {
+	case "", "desc":
+		return false, nil
+	case "asc":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid order %q: must be asc or desc", order)
+	}
+}
+
+// SetChunkCompression enables or disables compression of chunk candles on
+// write. Chunks keep their current format until they are next rewritten, and
+// both formats are always readable.
+func (r *OHLCVRepository) SetChunkCompression(enabled bool) {
+	r.compressChunks = enabled
+}
+
+// SetFreshnessThresholds sets the global thresholds used to classify data
+// freshness for jobs without their own
+func (r *OHLCVRepository) SetFreshnessThresholds(thresholds models.FreshnessThresholds) {
+	r.freshness = thresholds
+}
+
+// AnalyzeJobDataQuality analyzes the data quality of a job's data, using the
+// job's freshness thresholds when it has them
+func (r *OHLCVRepository) AnalyzeJobDataQuality(ctx context.Context, job *models.Job) (*models.DataQuality, error) {
+	return r.analyzeDataQuality(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, job)
 }
 
 // AnalyzeDataQuality analyzes the data quality for a specific job's data
 func (r *OHLCVRepository) AnalyzeDataQuality(ctx context.Context, exchangeID, symbol, timeframe string) (*models.DataQuality, error) {
+	return r.analyzeDataQuality(ctx, exchangeID, symbol, timeframe, nil)
+}
+
+func (r *OHLCVRepository) analyzeDataQuality(ctx context.Context, exchangeID, symbol, timeframe string, job *models.Job) (*models.DataQuality, error) {
 	// Get all candles
 	doc, err := r.FindByJob(ctx, exchangeID, symbol, timeframe)
 	if err != nil {
@@ -735,58 +1426,178 @@ func (r *OHLCVRepository) AnalyzeDataQuality(ctx context.Context, exchangeID, sy
 		return quality, nil
 	}
 
-	quality.TotalCandles = int64(len(doc.Candles))
+	if err := r.analyzeCandles(quality, doc.Candles, job); err != nil {
+		return nil, err
+	}
+	return quality, nil
+}
+
+// analyzeCandles fills quality from a series' candles, classifying freshness
+// for job when it is not nil. Only OHLCV timestamps are looked at, so candles
+// stored without indicators analyze the same.
+func (r *OHLCVRepository) analyzeCandles(quality *models.DataQuality, stored []models.Candle, job *models.Job) error {
+	quality.TotalCandles = int64(len(stored))
 
 	// Get candles sorted by timestamp ascending for gap analysis
-	candles := make([]models.Candle, len(doc.Candles))
-	copy(candles, doc.Candles)
+	candles := make([]models.Candle, len(stored))
+	copy(candles, stored)
 	sortCandlesAsc(candles)
 
 	// Find oldest and newest candles
 	quality.OldestCandle = time.UnixMilli(candles[0].Timestamp)
 	quality.NewestCandle = time.UnixMilli(candles[len(candles)-1].Timestamp)
 
-	// Calculate expected candles based on timeframe and date range
-	timeframeDuration := models.GetTimeframeDurationMinutes(timeframe)
-	totalDuration := quality.NewestCandle.Sub(quality.OldestCandle).Minutes()
-	quality.ExpectedCandles = int64(totalDuration/float64(timeframeDuration)) + 1
+	scoreCoverage(quality)
+	r.classifyFreshness(quality, job)
+
+	// Detect gaps in the data
+	gaps, err := detectGaps(candles, quality.Timeframe)
+	if err != nil {
+		return fmt.Errorf("failed to detect gaps: %w", err)
+	}
+	quality.Gaps = gaps
+	quality.GapsDetected = len(quality.Gaps)
+
+	// Determine overall quality status
+	quality.QualityStatus = calculateQualityStatus(quality.CompletenessScore, quality.GapsDetected, quality.DataFreshness, quality.TotalCandles)
+
+	return nil
+}
+
+// AnalyzeDataCoverage returns candle counts, time range, completeness and
+// freshness for every series stored in chunks, optionally filtered by exchange.
+// It aggregates chunk metadata instead of loading candles, so gaps are not
+// detected; QualityStatus assumes a single gap when candles are missing.
+func (r *OHLCVRepository) AnalyzeDataCoverage(ctx context.Context, exchangeID string) ([]*models.DataQuality, error) {
+	filter := bson.M{}
+	if exchangeID != "" {
+		filter["exchange_id"] = exchangeID
+	}
+
+	pipeline := []bson.M{
+		{"$match": filter},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"exchange_id": "$exchange_id",
+					"symbol":      "$symbol",
+					"timeframe":   "$timeframe",
+				},
+				"total_candles": bson.M{"$sum": "$candles_count"},
+				"oldest":        bson.M{"$min": "$start_time"},
+				"newest":        bson.M{"$max": "$end_time"},
+			},
+		},
+	}
+
+	cursor, err := r.chunksCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate coverage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID struct {
+			ExchangeID string `bson:"exchange_id"`
+			Symbol     string `bson:"symbol"`
+			Timeframe  string `bson:"timeframe"`
+		} `bson:"_id"`
+		TotalCandles int64     `bson:"total_candles"`
+		Oldest       time.Time `bson:"oldest"`
+		Newest       time.Time `bson:"newest"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode coverage: %w", err)
+	}
+
+	qualities := make([]*models.DataQuality, 0, len(results))
+	for _, res := range results {
+		quality := &models.DataQuality{
+			ExchangeID:   res.ID.ExchangeID,
+			Symbol:       res.ID.Symbol,
+			Timeframe:    res.ID.Timeframe,
+			TotalCandles: res.TotalCandles,
+			OldestCandle: res.Oldest,
+			NewestCandle: res.Newest,
+		}
+		if quality.TotalCandles == 0 {
+			quality.QualityStatus = "poor"
+			quality.DataFreshness = "no_data"
+			qualities = append(qualities, quality)
+			continue
+		}
+
+		scoreCoverage(quality)
+		r.ClassifyCoverage(quality, nil)
+		qualities = append(qualities, quality)
+	}
+
+	return qualities, nil
+}
+
+// ClassifyCoverage re-evaluates the freshness and quality status of a result
+// from AnalyzeDataCoverage for the job collecting the series
+func (r *OHLCVRepository) ClassifyCoverage(quality *models.DataQuality, job *models.Job) {
+	if quality.TotalCandles == 0 {
+		return
+	}
+	r.classifyFreshness(quality, job)
+
+	// Missing candles form at least one gap
+	gaps := 0
+	if quality.MissingCandles > 0 {
+		gaps = 1
+	}
+	quality.QualityStatus = calculateQualityStatus(quality.CompletenessScore, gaps, quality.DataFreshness, quality.TotalCandles)
+}
+
+// scoreCoverage fills the expected and missing candle counts and completeness
+// from the candle count and the oldest and newest candle times
+func scoreCoverage(quality *models.DataQuality) {
+	// Monthly bars are counted in calendar months
+	quality.ExpectedCandles = models.BarsBetween(quality.Timeframe, quality.OldestCandle.UnixMilli(), quality.NewestCandle.UnixMilli()) + 1
 
-	// Calculate missing candles
 	quality.MissingCandles = quality.ExpectedCandles - quality.TotalCandles
 	if quality.MissingCandles < 0 {
 		quality.MissingCandles = 0 // More candles than expected (possible duplicates or overlap)
 	}
 
-	// Calculate completeness score
 	if quality.ExpectedCandles > 0 {
 		quality.CompletenessScore = (float64(quality.TotalCandles) / float64(quality.ExpectedCandles)) * 100
 		if quality.CompletenessScore > 100 {
 			quality.CompletenessScore = 100
 		}
 	}
+}
 
-	// Calculate data freshness
-	freshnessMinutes := time.Since(quality.NewestCandle).Minutes()
-	quality.FreshnessMinutes = int64(freshnessMinutes)
-
-	// Freshness based on timeframe
-	expectedFreshness := float64(timeframeDuration) * 2 // Allow 2x timeframe for "fresh"
-	if freshnessMinutes <= expectedFreshness {
-		quality.DataFreshness = "fresh"
-	} else if freshnessMinutes <= expectedFreshness*5 {
-		quality.DataFreshness = "stale"
-	} else {
-		quality.DataFreshness = "very_stale"
+// classifyFreshness sets the data freshness from the age of the newest candle,
+// using the job's thresholds or the repository's global ones. The age of a
+// job's data counts from the newest candle's close when the job drops the
+// still-forming bar; a series without a job is measured from the open.
+func (r *OHLCVRepository) classifyFreshness(quality *models.DataQuality, job *models.Job) {
+	var freshness *models.FreshnessThresholds
+	reference := quality.NewestCandle
+	if job != nil {
+		freshness = job.Freshness
+		reference = models.FreshnessReference(quality.Timeframe, quality.NewestCandle, job.ExcludesUnclosedBar())
 	}
 
-	// Detect gaps in the data
-	quality.Gaps = detectGaps(candles, timeframeDuration)
-	quality.GapsDetected = len(quality.Gaps)
-
-	// Determine overall quality status
-	quality.QualityStatus = calculateQualityStatus(quality.CompletenessScore, quality.GapsDetected, quality.DataFreshness, quality.TotalCandles)
+	quality.FreshnessMinutes = int64(time.Since(quality.NewestCandle).Minutes())
+	quality.DataFreshness = models.ClassifyFreshness(quality.Timeframe, reference, time.Now(), r.FreshnessThresholds(freshness))
+}
 
-	return quality, nil
+// FreshnessThresholds returns the thresholds freshness is classified with:
+// the given job thresholds, else the repository's global ones, falling back
+// to the defaults when the chosen ones are invalid
+func (r *OHLCVRepository) FreshnessThresholds(freshness *models.FreshnessThresholds) models.FreshnessThresholds {
+	thresholds := r.freshness
+	if freshness != nil {
+		thresholds = *freshness
+	}
+	if thresholds.Validate() != nil {
+		thresholds = models.DefaultFreshnessThresholds
+	}
+	return thresholds
 }
 
 // sortCandlesAsc sorts candles by timestamp in ascending order (oldest first)
@@ -800,22 +1611,28 @@ func sortCandlesAsc(candles []models.Candle) {
 	}
 }
 
-// detectGaps finds gaps in the candle data
-func detectGaps(candles []models.Candle, timeframeDurationMinutes int64) []models.DataGap {
+// detectGaps finds gaps in the candle data. Bars are counted with
+// models.BarsBetween, so monthly gaps are measured in calendar months;
+// for fixed timeframes a spacing up to 10% over one bar is tolerated.
+// An unknown timeframe is an error rather than a guessed bar duration.
+func detectGaps(candles []models.Candle, timeframe string) ([]models.DataGap, error) {
+	expectedGapMs, err := models.GetTimeframeMs(timeframe)
+	if err != nil {
+		return nil, err
+	}
 	if len(candles) < 2 {
-		return nil
+		return nil, nil
 	}
 
 	var gaps []models.DataGap
-	expectedGapMs := timeframeDurationMinutes * 60 * 1000 // Convert minutes to milliseconds
-	tolerance := expectedGapMs + (expectedGapMs / 10)     // Allow 10% tolerance
+	tolerance := expectedGapMs + (expectedGapMs / 10) // Allow 10% tolerance
 
 	for i := 1; i < len(candles); i++ {
 		actualGap := candles[i].Timestamp - candles[i-1].Timestamp
 
 		// If gap is more than expected (with tolerance), we have missing candles
-		if actualGap > tolerance {
-			missingCount := int((actualGap / expectedGapMs) - 1)
+		if models.IsCalendarTimeframe(timeframe) || actualGap > tolerance {
+			missingCount := int(models.BarsBetween(timeframe, candles[i-1].Timestamp, candles[i].Timestamp) - 1)
 			if missingCount > 0 {
 				gap := models.DataGap{
 					StartTime:       time.UnixMilli(candles[i-1].Timestamp),
@@ -828,7 +1645,7 @@ func detectGaps(candles []models.Candle, timeframeDurationMinutes int64) []model
 		}
 	}
 
-	return gaps
+	return gaps, nil
 }
 
 // calculateQualityStatus determines the overall quality status
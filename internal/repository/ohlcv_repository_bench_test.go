@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/yourusername/datacollector/internal/models"
+	"github.com/yourusername/datacollector/internal/service/indicators"
+)
+
+// benchChunkCandles returns a month of 1m candles, newest first, with the
+// default indicators filled in like stored candles
+func benchChunkCandles(n int) []models.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	rsi, ema := 55.0, 42000.0
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		candles[n-1-i] = models.Candle{
+			Timestamp: start + int64(i)*60_000,
+			Open:      42000, High: 42100, Low: 41900, Close: 42050, Volume: 12.5,
+			Indicators: models.Indicators{RSI14: &rsi, EMA12: &ema, EMA26: &ema},
+		}
+	}
+	return candles
+}
+
+func TestAppendableCandles(t *testing.T) {
+	stored := benchChunkCandles(10)
+	chunk := &models.OHLCVChunk{CandlesCount: len(stored), EndTime: time.UnixMilli(stored[0].Timestamp)}
+	newest := stored[0].Timestamp
+
+	next := models.Candle{Timestamp: newest + 60_000}
+	after := models.Candle{Timestamp: newest + 120_000}
+	appended, ok := appendableCandles(chunk, []models.Candle{stored[0], next, after, next}, false)
+	if !ok {
+		t.Fatal("in-order candles should be appendable")
+	}
+	if len(appended) != 2 || appended[0].Timestamp != after.Timestamp || appended[1].Timestamp != next.Timestamp {
+		t.Errorf("appended = %v, want the two new candles newest first", appended)
+	}
+
+	if _, ok := appendableCandles(chunk, []models.Candle{stored[3], next}, false); ok {
+		t.Error("candles older than the newest stored one should need a rewrite")
+	}
+	if _, ok := appendableCandles(chunk, []models.Candle{next}, true); ok {
+		t.Error("compressed chunks should need a rewrite")
+	}
+}
+
+// BenchmarkChunkUpdate compares the update document sent to add a handful of
+// candles to a full month of 1m candles: the full rewrite encodes every
+// candle, the append only the new ones. bytes/op is the encoded update size.
+func BenchmarkChunkUpdate(b *testing.B) {
+	const monthOf1m, added = 31 * 24 * 60, 5
+
+	all := benchChunkCandles(monthOf1m + added)
+	now := time.Now()
+
+	b.Run("full_rewrite", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			set, unset, err := chunkCandlesUpdate(all, false)
+			if err != nil {
+				b.Fatal(err)
+			}
+			set["candles_count"] = len(all)
+			data, err := bson.Marshal(bson.M{"$set": set, "$unset": unset})
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "bytes/op")
+	})
+
+	b.Run("append", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			data, err := bson.Marshal(chunkAppendUpdate(all[:added], now))
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "bytes/op")
+	})
+}
+
+// realisticChunkCandles returns n candles spaced stepMs apart, newest first,
+// following a random walk at a $0.01 tick and carrying the default config's
+// indicators
+func realisticChunkCandles(b *testing.B, n int, stepMs int64) []models.Candle {
+	rng := rand.New(rand.NewSource(1))
+	tick := func(v float64) float64 { return math.Round(v*100) / 100 }
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	price := 42000.0
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		open := price
+		price *= 1 + rng.NormFloat64()*0.0008
+		candles[n-1-i] = models.Candle{
+			Timestamp: start + int64(i)*stepMs,
+			Open:      tick(open),
+			High:      tick(math.Max(open, price) * (1 + rng.Float64()*0.0004)),
+			Low:       tick(math.Min(open, price) * (1 - rng.Float64()*0.0004)),
+			Close:     tick(price),
+			Volume:    math.Round(rng.ExpFloat64()*12*1e5) / 1e5,
+		}
+	}
+
+	candles, err := indicators.NewService().CalculateAll(candles)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return candles
+}
+
+// BenchmarkChunkCompression compresses a month of candles with the default
+// indicators, as stored with OHLCV_COMPRESS_CHUNKS, for each timeframe.
+// raw_bytes is the BSON candle array, blob_bytes the candles_blob written
+// instead; a chunk document must stay under MongoDB's 16MB limit.
+func BenchmarkChunkCompression(b *testing.B) {
+	for _, timeframe := range []string{"1m", "5m", "15m", "1h"} {
+		b.Run(timeframe, func(b *testing.B) {
+			stepMs, err := models.GetTimeframeMs(timeframe)
+			if err != nil {
+				b.Fatal(err)
+			}
+			candles := realisticChunkCandles(b, int(31*24*time.Hour/time.Millisecond)/int(stepMs), stepMs)
+
+			var raw, blob int
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				data, rawSize, err := compressCandles(candles)
+				if err != nil {
+					b.Fatal(err)
+				}
+				raw, blob = rawSize, len(data)
+			}
+			b.ReportMetric(float64(raw), "raw_bytes")
+			b.ReportMetric(float64(blob), "blob_bytes")
+		})
+	}
+}
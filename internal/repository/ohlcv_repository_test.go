@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestCandlePage(t *testing.T) {
+	candles := benchChunkCandles(5)
+	timestamps := func(page []models.Candle) []int64 {
+		var ts []int64
+		for _, c := range page {
+			ts = append(ts, (c.Timestamp-candles[4].Timestamp)/60_000)
+		}
+		return ts
+	}
+
+	cases := []struct {
+		skip, limit int64
+		ascending   bool
+		want        []int64
+	}{
+		{0, 2, false, []int64{4, 3}},
+		{2, 2, false, []int64{2, 1}},
+		{0, 2, true, []int64{0, 1}},
+		{2, 2, true, []int64{2, 3}},
+		{4, 10, true, []int64{4}},
+		{5, 10, true, nil},
+	}
+	for _, tc := range cases {
+		got := timestamps(candlePage(candles, tc.skip, tc.limit, tc.ascending))
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("skip=%d limit=%d ascending=%v: got %v, want %v", tc.skip, tc.limit, tc.ascending, got, tc.want)
+		}
+	}
+	if candles[0].Timestamp < candles[4].Timestamp {
+		t.Error("ascending paging reordered the source candles")
+	}
+}
+
+func TestAnalyzeCandlesWithoutIndicators(t *testing.T) {
+	newest := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	rsi := 55.0
+
+	// Ten hourly candles, newest first, with the fourth one missing
+	var raw, computed []models.Candle
+	for i := 0; i < 10; i++ {
+		if i == 3 {
+			continue
+		}
+		candle := models.Candle{Timestamp: newest.Add(-time.Duration(i) * time.Hour).UnixMilli(), Open: 100, High: 101, Low: 99, Close: 100.5, Volume: 10}
+		raw = append(raw, candle)
+		candle.Indicators = models.Indicators{RSI14: &rsi}
+		computed = append(computed, candle)
+	}
+
+	r := &OHLCVRepository{}
+	analyze := func(candles []models.Candle) *models.DataQuality {
+		quality := &models.DataQuality{ExchangeID: "binance", Symbol: "BTC/USDT", Timeframe: "1h"}
+		if err := r.analyzeCandles(quality, candles, nil); err != nil {
+			t.Fatalf("analyzeCandles() error = %v", err)
+		}
+		return quality
+	}
+
+	got, want := analyze(raw), analyze(computed)
+	if got.TotalCandles != 9 || got.GapsDetected != 1 {
+		t.Errorf("raw candles: total = %d, gaps = %d, want 9 and 1", got.TotalCandles, got.GapsDetected)
+	}
+	if got.CompletenessScore != want.CompletenessScore || got.QualityStatus != want.QualityStatus || got.DataFreshness != want.DataFreshness {
+		t.Errorf("raw candles analyzed as %.1f%% %s (%s), want %.1f%% %s (%s) like candles with indicators",
+			got.CompletenessScore, got.QualityStatus, got.DataFreshness, want.CompletenessScore, want.QualityStatus, want.DataFreshness)
+	}
+}
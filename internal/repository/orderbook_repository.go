@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// OrderBookRepository handles database operations for order book snapshots,
+// stored in hourly chunks
+type OrderBookRepository struct {
+	chunksCollection *mongo.Collection
+}
+
+// NewOrderBookRepository creates a new order book repository
+func NewOrderBookRepository(db *Database) *OrderBookRepository {
+	chunksCollection := db.GetCollection("orderbook_chunks")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Chunked storage index: unique on (exchange_id, symbol, hour)
+	chunkIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "hour", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := chunksCollection.Indexes().CreateOne(ctx, chunkIndex); err != nil {
+		log.Printf("[ORDERBOOK_REPO] Warning: Failed to create chunks index: %v", err)
+	}
+
+	// Index for time-based queries on chunks
+	timeIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "start_time", Value: -1},
+		},
+	}
+	if _, err := chunksCollection.Indexes().CreateOne(ctx, timeIndex); err != nil {
+		log.Printf("[ORDERBOOK_REPO] Warning: Failed to create time index: %v", err)
+	}
+
+	return &OrderBookRepository{
+		chunksCollection: chunksCollection,
+	}
+}
+
+// InsertSnapshot appends a snapshot to its hourly chunk, creating the chunk
+// if needed. It returns false when a snapshot with the same timestamp is
+// already stored.
+func (r *OrderBookRepository) InsertSnapshot(ctx context.Context, exchangeID, symbol string, snapshot *models.OrderBookSnapshot) (bool, error) {
+	hour := models.GetHourFromTimestamp(snapshot.Timestamp)
+	ts := time.UnixMilli(snapshot.Timestamp)
+	now := time.Now()
+
+	// The timestamp condition makes an existing chunk that already holds the
+	// snapshot fall through to the upsert, which then fails on the unique index
+	filter := bson.M{
+		"exchange_id":         exchangeID,
+		"symbol":              symbol,
+		"hour":                hour,
+		"snapshots.timestamp": bson.M{"$ne": snapshot.Timestamp},
+	}
+	update := bson.M{
+		"$push":        bson.M{"snapshots": snapshot},
+		"$inc":         bson.M{"snapshots_count": 1},
+		"$min":         bson.M{"start_time": ts},
+		"$max":         bson.M{"end_time": ts},
+		"$set":         bson.M{"updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	_, err := r.chunksCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to store order book snapshot in chunk %s: %w", hour, err)
+	}
+
+	return true, nil
+}
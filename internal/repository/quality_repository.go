@@ -104,6 +104,16 @@ func NewQualityRepository(db *Database) *QualityRepository {
 		log.Printf("[QUALITY_REPO] Warning: Failed to create backfill job index: %v", err)
 	}
 
+	// Index for the backfill jobs of a connector backfill
+	connectorBackfillIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "connector_backfill_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	_, err = backfillCollection.Indexes().CreateOne(ctx, connectorBackfillIndex)
+	if err != nil {
+		log.Printf("[QUALITY_REPO] Warning: Failed to create connector backfill index: %v", err)
+	}
+
 	return &QualityRepository{
 		resultsCollection:  resultsCollection,
 		checksCollection:   checksCollection,
@@ -666,3 +676,43 @@ func (r *QualityRepository) FindRecentBackfillJobsForJob(ctx context.Context, jo
 
 	return jobs, nil
 }
+
+// FindBackfillJobsForConnectorBackfill finds the backfill jobs started by a
+// connector backfill, in the order they were queued
+func (r *QualityRepository) FindBackfillJobsForConnectorBackfill(ctx context.Context, connectorBackfillID primitive.ObjectID) ([]*models.BackfillJob, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.backfillCollection.Find(ctx, bson.M{"connector_backfill_id": connectorBackfillID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connector backfill jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*models.BackfillJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode backfill jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// FindLatestConnectorBackfillID finds the ID of the most recent connector
+// backfill for an exchange, or nil if there is none
+func (r *QualityRepository) FindLatestConnectorBackfillID(ctx context.Context, exchangeID string) (*primitive.ObjectID, error) {
+	filter := bson.M{
+		"exchange_id":           exchangeID,
+		"connector_backfill_id": bson.M{"$exists": true},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var backfillJob models.BackfillJob
+	err := r.backfillCollection.FindOne(ctx, filter, opts).Decode(&backfillJob)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find latest connector backfill: %w", err)
+	}
+
+	return backfillJob.ConnectorBackfillID, nil
+}
@@ -208,8 +208,8 @@ func (r *RetentionRepository) SaveConfig(ctx context.Context, config *models.Ret
 	return nil
 }
 
-// DeleteChunksOlderThan deletes OHLCV chunks older than the specified time
-func (r *RetentionRepository) DeleteChunksOlderThan(ctx context.Context, cutoffTime time.Time, exchangeID, timeframe string) (int64, error) {
+// chunksOlderThanFilter matches chunks whose month is before the cutoff
+func chunksOlderThanFilter(cutoffTime time.Time, exchangeID, timeframe string) bson.M {
 	filter := bson.M{
 		"year_month": bson.M{"$lt": cutoffTime.Format("2006-01")},
 	}
@@ -221,7 +221,12 @@ func (r *RetentionRepository) DeleteChunksOlderThan(ctx context.Context, cutoffT
 		filter["timeframe"] = timeframe
 	}
 
-	result, err := r.ohlcvCollection.DeleteMany(ctx, filter)
+	return filter
+}
+
+// DeleteChunksOlderThan deletes OHLCV chunks older than the specified time
+func (r *RetentionRepository) DeleteChunksOlderThan(ctx context.Context, cutoffTime time.Time, exchangeID, timeframe string) (int64, error) {
+	result, err := r.ohlcvCollection.DeleteMany(ctx, chunksOlderThanFilter(cutoffTime, exchangeID, timeframe))
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old chunks: %w", err)
 	}
@@ -229,6 +234,229 @@ func (r *RetentionRepository) DeleteChunksOlderThan(ctx context.Context, cutoffT
 	return result.DeletedCount, nil
 }
 
+// PreviewChunksOlderThan lists the chunks DeleteChunksOlderThan would delete
+func (r *RetentionRepository) PreviewChunksOlderThan(ctx context.Context, cutoffTime time.Time, exchangeID, timeframe string) ([]models.RetentionChunkPreview, error) {
+	chunks, err := r.findChunkSizes(ctx, chunksOlderThanFilter(cutoffTime, exchangeID, timeframe))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range chunks {
+		chunks[i].Action = models.RetentionActionDelete
+		chunks[i].CandlesToDelete = chunks[i].CandlesCount
+	}
+
+	return chunks, nil
+}
+
+// PlanMaxCandlesTrim works out which chunks must go so that every
+// (exchange, symbol, timeframe) series keeps only its newest maxCandles candles.
+// Chunks entirely beyond the cap are deleted and the chunk straddling the cap
+// is trimmed, so the cap is honored exactly rather than only at month boundaries.
+// Chunks before minYearMonth (if set) are ignored, e.g. because an age-based
+// cleanup removes them first.
+func (r *RetentionRepository) PlanMaxCandlesTrim(ctx context.Context, maxCandles int64, exchangeID, timeframe, minYearMonth string) ([]models.RetentionChunkPreview, error) {
+	filter := bson.M{}
+	if exchangeID != "" {
+		filter["exchange_id"] = exchangeID
+	}
+	if timeframe != "" {
+		filter["timeframe"] = timeframe
+	}
+	if minYearMonth != "" {
+		filter["year_month"] = bson.M{"$gte": minYearMonth}
+	}
+
+	chunks, err := r.findChunkSizes(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []models.RetentionChunkPreview
+	var kept int64
+	seriesKey := ""
+
+	// Chunks are sorted newest month first within each series
+	for _, chunk := range chunks {
+		key := chunk.ExchangeID + "|" + chunk.Symbol + "|" + chunk.Timeframe
+		if key != seriesKey {
+			seriesKey = key
+			kept = 0
+		}
+
+		switch {
+		case kept >= maxCandles:
+			chunk.Action = models.RetentionActionDelete
+			chunk.CandlesToDelete = chunk.CandlesCount
+			plan = append(plan, chunk)
+		case kept+chunk.CandlesCount > maxCandles:
+			chunk.Action = models.RetentionActionTrim
+			chunk.CandlesToDelete = chunk.CandlesCount - (maxCandles - kept)
+			plan = append(plan, chunk)
+			kept = maxCandles
+		default:
+			kept += chunk.CandlesCount
+		}
+	}
+
+	return plan, nil
+}
+
+// TrimChunksToMaxCandles applies the plan computed by PlanMaxCandlesTrim
+func (r *RetentionRepository) TrimChunksToMaxCandles(ctx context.Context, maxCandles int64, exchangeID, timeframe, minYearMonth string) (chunksDeleted int64, candlesDeleted int64, err error) {
+	plan, err := r.PlanMaxCandlesTrim(ctx, maxCandles, exchangeID, timeframe, minYearMonth)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var toDelete []primitive.ObjectID
+	for _, chunk := range plan {
+		if chunk.Action == models.RetentionActionDelete {
+			toDelete = append(toDelete, chunk.ChunkID)
+			candlesDeleted += chunk.CandlesToDelete
+			continue
+		}
+
+		// Candles are stored newest first, so keep the head of the array
+		keep := chunk.CandlesCount - chunk.CandlesToDelete
+		update := bson.A{
+			bson.M{"$set": bson.M{"candles": bson.M{"$slice": bson.A{"$candles", keep}}}},
+			bson.M{"$set": bson.M{
+				"candles_count": bson.M{"$size": "$candles"},
+				"start_time":    bson.M{"$toDate": bson.M{"$arrayElemAt": bson.A{"$candles.timestamp", -1}}},
+				"updated_at":    time.Now(),
+			}},
+		}
+		result, err := r.ohlcvCollection.UpdateOne(ctx, bson.M{"_id": chunk.ChunkID, "candles_blob": bson.M{"$exists": false}}, update)
+		if err != nil {
+			return chunksDeleted, candlesDeleted, fmt.Errorf("failed to trim chunk: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			// Compressed chunks cannot be sliced server-side
+			err := r.rewriteCompressedChunks(ctx, bson.M{"_id": chunk.ChunkID}, func(candles []models.Candle) []models.Candle {
+				if int64(len(candles)) > keep {
+					return candles[:keep]
+				}
+				return candles
+			})
+			if err != nil {
+				return chunksDeleted, candlesDeleted, err
+			}
+		}
+		candlesDeleted += chunk.CandlesToDelete
+	}
+
+	if len(toDelete) > 0 {
+		result, err := r.ohlcvCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": toDelete}})
+		if err != nil {
+			return chunksDeleted, candlesDeleted, fmt.Errorf("failed to delete chunks: %w", err)
+		}
+		chunksDeleted = result.DeletedCount
+	}
+
+	return chunksDeleted, candlesDeleted, nil
+}
+
+// DeleteCandlesBefore removes candles older than beforeMs from a series
+// chunks, trimming the chunk that straddles the boundary and dropping chunks
+// left empty
+func (r *RetentionRepository) DeleteCandlesBefore(ctx context.Context, exchangeID, symbol, timeframe string, beforeMs int64) error {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"timeframe":   timeframe,
+		"year_month":  bson.M{"$lte": models.GetYearMonthFromTimestamp(beforeMs)},
+	}
+
+	update := bson.A{
+		bson.M{"$set": bson.M{"candles": bson.M{"$filter": bson.M{
+			"input": "$candles",
+			"cond":  bson.M{"$gte": bson.A{"$$this.timestamp", beforeMs}},
+		}}}},
+		bson.M{"$set": bson.M{
+			"candles_count": bson.M{"$size": "$candles"},
+			"start_time": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": "$candles"}, 0}},
+				bson.M{"$toDate": bson.M{"$arrayElemAt": bson.A{"$candles.timestamp", -1}}},
+				"$start_time",
+			}},
+			"updated_at": time.Now(),
+		}},
+	}
+
+	plainFilter := bson.M{"candles_blob": bson.M{"$exists": false}}
+	for k, v := range filter {
+		plainFilter[k] = v
+	}
+	if _, err := r.ohlcvCollection.UpdateMany(ctx, plainFilter, update); err != nil {
+		return fmt.Errorf("failed to delete candles: %w", err)
+	}
+
+	// Compressed chunks cannot be filtered server-side
+	err := r.rewriteCompressedChunks(ctx, filter, func(candles []models.Candle) []models.Candle {
+		kept := candles[:0]
+		for _, c := range candles {
+			if c.Timestamp >= beforeMs {
+				kept = append(kept, c)
+			}
+		}
+		return kept
+	})
+	if err != nil {
+		return err
+	}
+
+	filter["candles_count"] = 0
+	if _, err := r.ohlcvCollection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete emptied chunks: %w", err)
+	}
+
+	return nil
+}
+
+// findChunkSizes lists matching chunks with their candle counts, newest month
+// first within each (exchange, symbol, timeframe) series
+func (r *RetentionRepository) findChunkSizes(ctx context.Context, filter bson.M) ([]models.RetentionChunkPreview, error) {
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$project": bson.M{
+			"exchange_id":   1,
+			"symbol":        1,
+			"timeframe":     1,
+			"year_month":    1,
+			"candles_count": chunkCandleCountExpr,
+		}},
+		{"$sort": bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "timeframe", Value: 1},
+			{Key: "year_month", Value: -1},
+		}},
+	}
+
+	cursor, err := r.ohlcvCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chunks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var chunks []models.RetentionChunkPreview
+	if err := cursor.All(ctx, &chunks); err != nil {
+		return nil, fmt.Errorf("failed to decode chunks: %w", err)
+	}
+
+	return chunks, nil
+}
+
+// CountEmptyChunks counts chunks that DeleteEmptyChunks would remove
+func (r *RetentionRepository) CountEmptyChunks(ctx context.Context) (int64, error) {
+	count, err := r.ohlcvCollection.CountDocuments(ctx, emptyChunksFilter())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count empty chunks: %w", err)
+	}
+	return count, nil
+}
+
 // GetDataUsageStats returns storage usage statistics
 func (r *RetentionRepository) GetDataUsageStats(ctx context.Context, exchangeID string) ([]*models.DataUsageStats, error) {
 	matchStage := bson.M{}
@@ -245,7 +473,7 @@ func (r *RetentionRepository) GetDataUsageStats(ctx context.Context, exchangeID
 				"timeframe":   "$timeframe",
 			},
 			"chunk_count":   bson.M{"$sum": 1},
-			"total_candles": bson.M{"$sum": bson.M{"$size": bson.M{"$ifNull": bson.A{"$candles", bson.A{}}}}},
+			"total_candles": bson.M{"$sum": chunkCandleCountExpr},
 			"oldest_data":   bson.M{"$min": "$year_month"},
 			"newest_data":   bson.M{"$max": "$year_month"},
 		}},
@@ -319,7 +547,7 @@ func (r *RetentionRepository) GetTotalDataUsage(ctx context.Context) (chunks int
 	pipeline := []bson.M{
 		{"$group": bson.M{
 			"_id":           nil,
-			"total_candles": bson.M{"$sum": bson.M{"$size": bson.M{"$ifNull": bson.A{"$candles", bson.A{}}}}},
+			"total_candles": bson.M{"$sum": chunkCandleCountExpr},
 		}},
 	}
 
@@ -343,17 +571,70 @@ func (r *RetentionRepository) GetTotalDataUsage(ctx context.Context) (chunks int
 	return chunks, candles, nil
 }
 
-// DeleteEmptyChunks removes chunks that have no candles
-func (r *RetentionRepository) DeleteEmptyChunks(ctx context.Context) (int64, error) {
-	filter := bson.M{
+// emptyChunksFilter matches chunks that have no candles. Compressed chunks
+// have no candles array and are only empty when their count is zero.
+func emptyChunksFilter() bson.M {
+	return bson.M{
 		"$or": []bson.M{
-			{"candles": bson.M{"$size": 0}},
-			{"candles": bson.M{"$exists": false}},
-			{"candles": nil},
+			{"candles": bson.M{"$size": 0}, "candles_blob": bson.M{"$exists": false}},
+			{"candles": bson.M{"$exists": false}, "candles_blob": bson.M{"$exists": false}},
+			{"candles": nil, "candles_blob": bson.M{"$exists": false}},
+			{"candles_blob": bson.M{"$exists": true}, "candles_count": 0},
 		},
 	}
+}
+
+// rewriteCompressedChunks applies keep to the candles of every compressed
+// chunk matching filter and stores the result compressed again. Candles are
+// passed newest first, as stored.
+func (r *RetentionRepository) rewriteCompressedChunks(ctx context.Context, filter bson.M, keep func([]models.Candle) []models.Candle) error {
+	compressedFilter := bson.M{"candles_blob": bson.M{"$exists": true}}
+	for k, v := range filter {
+		compressedFilter[k] = v
+	}
+
+	cursor, err := r.ohlcvCollection.Find(ctx, compressedFilter)
+	if err != nil {
+		return fmt.Errorf("failed to find compressed chunks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var chunks []models.OHLCVChunk
+	if err := cursor.All(ctx, &chunks); err != nil {
+		return fmt.Errorf("failed to decode compressed chunks: %w", err)
+	}
+
+	for _, chunk := range chunks {
+		if err := decodeChunk(&chunk); err != nil {
+			return err
+		}
+
+		kept := keep(chunk.Candles)
+		if len(kept) == len(chunk.Candles) {
+			continue
+		}
+
+		set := bson.M{"candles_count": len(kept), "updated_at": time.Now()}
+		if len(kept) > 0 {
+			blob, _, err := compressCandles(kept)
+			if err != nil {
+				return fmt.Errorf("failed to compress chunk %s: %w", chunk.YearMonth, err)
+			}
+			set["candles_blob"] = blob
+			set["start_time"] = time.UnixMilli(kept[len(kept)-1].Timestamp)
+		}
+
+		if _, err := r.ohlcvCollection.UpdateOne(ctx, bson.M{"_id": chunk.ID}, bson.M{"$set": set}); err != nil {
+			return fmt.Errorf("failed to rewrite chunk %s: %w", chunk.YearMonth, err)
+		}
+	}
 
-	result, err := r.ohlcvCollection.DeleteMany(ctx, filter)
+	return nil
+}
+
+// DeleteEmptyChunks removes chunks that have no candles
+func (r *RetentionRepository) DeleteEmptyChunks(ctx context.Context) (int64, error) {
+	result, err := r.ohlcvCollection.DeleteMany(ctx, emptyChunksFilter())
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete empty chunks: %w", err)
 	}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// TradeRepository handles database operations for trades, stored in hourly chunks
+type TradeRepository struct {
+	chunksCollection *mongo.Collection
+}
+
+// NewTradeRepository creates a new trade repository
+func NewTradeRepository(db *Database) *TradeRepository {
+	chunksCollection := db.GetCollection("trade_chunks")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Chunked storage index: unique on (exchange_id, symbol, hour)
+	chunkIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "hour", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := chunksCollection.Indexes().CreateOne(ctx, chunkIndex); err != nil {
+		log.Printf("[TRADE_REPO] Warning: Failed to create chunks index: %v", err)
+	}
+
+	// Index for time-based queries on chunks
+	timeIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange_id", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "start_time", Value: -1},
+		},
+	}
+	if _, err := chunksCollection.Indexes().CreateOne(ctx, timeIndex); err != nil {
+		log.Printf("[TRADE_REPO] Warning: Failed to create time index: %v", err)
+	}
+
+	return &TradeRepository{
+		chunksCollection: chunksCollection,
+	}
+}
+
+// UpsertTrades inserts trades into their hourly chunks, skipping trades that
+// are already stored. It returns the number of new trades stored.
+func (r *TradeRepository) UpsertTrades(ctx context.Context, exchangeID, symbol string, newTrades []models.Trade) (int, error) {
+	if len(newTrades) == 0 {
+		return 0, nil
+	}
+
+	tradesByHour := make(map[string][]models.Trade)
+	for _, trade := range newTrades {
+		hour := models.GetHourFromTimestamp(trade.Timestamp)
+		tradesByHour[hour] = append(tradesByHour[hour], trade)
+	}
+
+	totalUpserted := 0
+	var firstErr error
+	for hour, trades := range tradesByHour {
+		count, err := r.upsertChunk(ctx, exchangeID, symbol, hour, trades)
+		if err != nil {
+			log.Printf("[TRADE_REPO] Error upserting chunk %s: %v", hour, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		totalUpserted += count
+	}
+
+	log.Printf("[TRADE_REPO] Stored %d new trades for %s-%s across %d chunks", totalUpserted, exchangeID, symbol, len(tradesByHour))
+	return totalUpserted, firstErr
+}
+
+// upsertChunk merges trades into the chunk for one hour
+func (r *TradeRepository) upsertChunk(ctx context.Context, exchangeID, symbol, hour string, trades []models.Trade) (int, error) {
+	filter := bson.M{
+		"exchange_id": exchangeID,
+		"symbol":      symbol,
+		"hour":        hour,
+	}
+
+	now := time.Now()
+
+	var existingChunk models.TradeChunk
+	err := r.chunksCollection.FindOne(ctx, filter).Decode(&existingChunk)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, fmt.Errorf("failed to find chunk: %w", err)
+	}
+	isNew := err == mongo.ErrNoDocuments
+
+	seen := make(map[string]bool, len(existingChunk.Trades)+len(trades))
+	for _, t := range existingChunk.Trades {
+		seen[tradeKey(t)] = true
+	}
+
+	allTrades := existingChunk.Trades
+	added := 0
+	for _, t := range trades {
+		key := tradeKey(t)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		allTrades = append(allTrades, t)
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+
+	sort.SliceStable(allTrades, func(i, j int) bool {
+		return allTrades[i].Timestamp < allTrades[j].Timestamp
+	})
+	startTime := time.UnixMilli(allTrades[0].Timestamp)
+	endTime := time.UnixMilli(allTrades[len(allTrades)-1].Timestamp)
+
+	if isNew {
+		chunk := models.TradeChunk{
+			ID:          primitive.NewObjectID(),
+			ExchangeID:  exchangeID,
+			Symbol:      symbol,
+			Hour:        hour,
+			StartTime:   startTime,
+			EndTime:     endTime,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			TradesCount: len(allTrades),
+			Trades:      allTrades,
+		}
+		if _, err := r.chunksCollection.InsertOne(ctx, chunk); err != nil {
+			return 0, fmt.Errorf("failed to insert chunk %s: %w", hour, err)
+		}
+		return added, nil
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"trades":       allTrades,
+			"trades_count": len(allTrades),
+			"start_time":   startTime,
+			"end_time":     endTime,
+			"updated_at":   now,
+		},
+	}
+	if _, err := r.chunksCollection.UpdateOne(ctx, filter, update); err != nil {
+		return 0, fmt.Errorf("failed to update chunk %s: %w", hour, err)
+	}
+
+	return added, nil
+}
+
+// tradeKey identifies a trade for de-duplication: the exchange trade ID when
+// there is one, otherwise its time, side, price and amount
+func tradeKey(t models.Trade) string {
+	if t.TradeID != "" {
+		return t.TradeID
+	}
+	return fmt.Sprintf("%d|%s|%g|%g", t.Timestamp, t.Side, t.Price, t.Amount)
+}
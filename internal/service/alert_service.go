@@ -3,9 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 )
@@ -35,7 +35,7 @@ func (s *AlertService) CreateAlert(ctx context.Context, alertType models.AlertTy
 	}
 
 	if exists {
-		log.Printf("[ALERT] Alert already exists for %s %s, skipping", source.Type, source.ID)
+		logging.Printf(ctx, "[ALERT] Alert already exists for %s %s, skipping", source.Type, source.ID)
 		return nil
 	}
 
@@ -53,7 +53,7 @@ func (s *AlertService) CreateAlert(ctx context.Context, alertType models.AlertTy
 		return fmt.Errorf("failed to create alert: %w", err)
 	}
 
-	log.Printf("[ALERT] Created %s alert: %s", severity, title)
+	logging.Printf(ctx, "[ALERT] Created %s alert: %s", severity, title)
 	return nil
 }
 
@@ -201,7 +201,7 @@ func (s *AlertService) ResolveJobAlerts(ctx context.Context, jobID string) error
 		return fmt.Errorf("failed to resolve job alerts: %w", err)
 	}
 	if count > 0 {
-		log.Printf("[ALERT] Resolved %d alerts for job %s", count, jobID)
+		logging.Printf(ctx, "[ALERT] Resolved %d alerts for job %s", count, jobID)
 	}
 	return nil
 }
@@ -213,7 +213,7 @@ func (s *AlertService) ResolveConnectorAlerts(ctx context.Context, connectorID s
 		return fmt.Errorf("failed to resolve connector alerts: %w", err)
 	}
 	if count > 0 {
-		log.Printf("[ALERT] Resolved %d alerts for connector %s", count, connectorID)
+		logging.Printf(ctx, "[ALERT] Resolved %d alerts for connector %s", count, connectorID)
 	}
 	return nil
 }
@@ -234,7 +234,7 @@ func (s *AlertService) CheckJobsForAlerts(ctx context.Context) error {
 	for _, job := range jobs {
 		if job.RunState.ConsecutiveFailures >= config.ConsecutiveFailureThreshold {
 			if err := s.AlertConsecutiveFailures(ctx, job, job.RunState.ConsecutiveFailures); err != nil {
-				log.Printf("[ALERT] Failed to create consecutive failures alert: %v", err)
+				logging.Printf(ctx, "[ALERT] Failed to create consecutive failures alert: %v", err)
 			}
 		}
 	}
@@ -249,7 +249,7 @@ func (s *AlertService) CleanupOldAlerts(ctx context.Context, olderThan time.Dura
 		return 0, fmt.Errorf("failed to cleanup old alerts: %w", err)
 	}
 	if count > 0 {
-		log.Printf("[ALERT] Cleaned up %d old resolved alerts", count)
+		logging.Printf(ctx, "[ALERT] Cleaned up %d old resolved alerts", count)
 	}
 	return count, nil
 }
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/exchange"
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// tradesBatchLimit is the number of trades requested per fetchTrades call
+const tradesBatchLimit = 1000
+
+// waitForSlot waits for a rate limit slot when a rate limiter is configured
+func (s *CCXTService) waitForSlot(ctx context.Context, exchangeID string) error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	if err := s.rateLimiter.WaitForSlot(ctx, exchangeID); err != nil {
+		return fmt.Errorf("rate limit wait failed: %w", err)
+	}
+	return nil
+}
+
+// openAdapter waits for a rate limit slot, then creates an adapter for the
+// exchange and loads its markets. The caller must close the adapter.
+func (s *CCXTService) openAdapter(ctx context.Context, exchangeID string, requestTimeoutMs int) (*exchange.CCXTAdapter, error) {
+	if err := s.waitForSlot(ctx, exchangeID); err != nil {
+		return nil, err
+	}
+
+	var adapter *exchange.CCXTAdapter
+	var err error
+	if requestTimeoutMs > 0 {
+		adapter, err = exchange.NewCCXTAdapterWithTimeout(exchangeID, true, requestTimeoutMs)
+	} else {
+		adapter, err = exchange.NewCCXTAdapter(exchangeID, true)
+	}
+	if err != nil {
+		logging.Printf(ctx, "[CCXT] Failed to create adapter for %s: %v", exchangeID, err)
+		return nil, fmt.Errorf("exchange %s not yet supported: %w", exchangeID, err)
+	}
+
+	if err := adapter.LoadMarkets(); err != nil {
+		adapter.Close()
+		logging.Printf(ctx, "[CCXT] Failed to load markets for %s: %v", exchangeID, err)
+		return nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+
+	return adapter, nil
+}
+
+// FetchTrades fetches public trades, oldest first. Without sinceMs it fetches
+// the most recent page of trades; with sinceMs it pages forward from that
+// timestamp until it reaches the present or maxTrades (0 = no cap). Pages
+// overlap on their boundary timestamp, so callers must drop duplicates.
+func (s *CCXTService) FetchTrades(
+	ctx context.Context,
+	exchangeID string,
+	symbol string,
+	sinceMs *int64,
+	maxTrades int,
+	requestTimeoutMs int,
+) ([]models.Trade, error) {
+	adapter, err := s.openAdapter(ctx, exchangeID, requestTimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	defer adapter.Close()
+
+	if sinceMs == nil {
+		logging.Printf(ctx, "[CCXT] First execution - fetching latest trades for %s %s", exchangeID, symbol)
+		if err := s.waitForSlot(ctx, exchangeID); err != nil {
+			return nil, err
+		}
+		trades, err := adapter.FetchTrades(symbol, nil, tradesBatchLimit)
+		if err != nil {
+			return nil, err
+		}
+		logging.Printf(ctx, "[CCXT] Total trades fetched: %d", len(trades))
+		return trades, nil
+	}
+
+	var allTrades []models.Trade
+	currentSince := *sinceMs
+
+	maxIterations := 100
+	if maxTrades > 0 {
+		maxIterations = (maxTrades+tradesBatchLimit-1)/tradesBatchLimit + 1
+	}
+
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		if err := s.waitForSlot(ctx, exchangeID); err != nil {
+			logging.Printf(ctx, "[CCXT] %v", err)
+			if len(allTrades) > 0 {
+				return allTrades, nil
+			}
+			return nil, err
+		}
+
+		since := time.UnixMilli(currentSince)
+		trades, err := adapter.FetchTrades(symbol, &since, tradesBatchLimit)
+		if err != nil {
+			if len(allTrades) > 0 {
+				return allTrades, nil
+			}
+			return nil, err
+		}
+
+		if len(trades) == 0 {
+			break
+		}
+
+		logging.Printf(ctx, "[CCXT] Batch %d: fetched %d trades", iteration, len(trades))
+		allTrades = append(allTrades, trades...)
+
+		if maxTrades > 0 && len(allTrades) >= maxTrades {
+			logging.Printf(ctx, "[CCXT] Reached per-run cap of %d trades after %d pages, resuming next run",
+				maxTrades, iteration)
+			break
+		}
+
+		if len(trades) < tradesBatchLimit {
+			break
+		}
+
+		// A page full of trades sharing one timestamp cannot be paged past
+		lastTimestamp := trades[len(trades)-1].Timestamp
+		if lastTimestamp <= currentSince {
+			logging.Printf(ctx, "[CCXT] Trades page did not advance past %d, resuming next run", currentSince)
+			break
+		}
+		currentSince = lastTimestamp
+
+		if s.rateLimiter == nil {
+			time.Sleep(1 * time.Second) // Safety delay when no rate limiter
+		}
+	}
+
+	logging.Printf(ctx, "[CCXT] Total trades fetched: %d", len(allTrades))
+	return allTrades, nil
+}
+
+// FetchOrderBook fetches an order book snapshot with up to depth levels per side
+func (s *CCXTService) FetchOrderBook(
+	ctx context.Context,
+	exchangeID string,
+	symbol string,
+	depth int,
+	requestTimeoutMs int,
+) (*models.OrderBookSnapshot, error) {
+	adapter, err := s.openAdapter(ctx, exchangeID, requestTimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	defer adapter.Close()
+
+	if err := s.waitForSlot(ctx, exchangeID); err != nil {
+		return nil, err
+	}
+	snapshot, err := adapter.FetchOrderBook(symbol, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Printf(ctx, "[CCXT] Fetched order book for %s %s: %d bids, %d asks",
+		exchangeID, symbol, len(snapshot.Bids), len(snapshot.Asks))
+	return snapshot, nil
+}
+
+// seriesBatchLimit is the number of points requested per funding rate or
+// open interest history call
+const seriesBatchLimit = 500
+
+// pageForward calls fetch with successive since timestamps, starting at
+// sinceMs, until a page comes back short, stops advancing, or maxPoints were
+// fetched (0 = no cap). fetch returns the page size and its last timestamp.
+// Each page after the first waits for a rate limit slot. Once some pages were
+// fetched, a failing page ends paging without an error.
+func (s *CCXTService) pageForward(ctx context.Context, exchangeID string, sinceMs int64, maxPoints int, fetch func(sinceMs int64) (int, int64, error)) error {
+	fetched := 0
+	for iteration := 1; iteration <= 100; iteration++ {
+		if iteration > 1 {
+			if err := s.waitForSlot(ctx, exchangeID); err != nil {
+				logging.Printf(ctx, "[CCXT] %v", err)
+				return nil
+			}
+		}
+
+		count, lastTimestamp, err := fetch(sinceMs)
+		if err != nil {
+			if fetched > 0 {
+				logging.Printf(ctx, "[CCXT] Page %d failed, keeping %d points: %v", iteration, fetched, err)
+				return nil
+			}
+			return err
+		}
+		fetched += count
+
+		if count < seriesBatchLimit || lastTimestamp < sinceMs {
+			break
+		}
+		if maxPoints > 0 && fetched >= maxPoints {
+			logging.Printf(ctx, "[CCXT] Reached per-run cap of %d points after %d pages, resuming next run", maxPoints, iteration)
+			break
+		}
+		sinceMs = lastTimestamp + 1
+	}
+	return nil
+}
+
+// FetchFundingRateHistory fetches the funding rates of a perpetual futures
+// symbol, oldest first. Without sinceMs it fetches the most recent page; with
+// sinceMs it pages forward from that timestamp until it reaches the present
+// or maxPoints (0 = no cap).
+func (s *CCXTService) FetchFundingRateHistory(
+	ctx context.Context,
+	exchangeID string,
+	symbol string,
+	sinceMs *int64,
+	maxPoints int,
+	requestTimeoutMs int,
+) ([]models.FundingRate, error) {
+	adapter, err := s.openAdapter(ctx, exchangeID, requestTimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	defer adapter.Close()
+
+	if err := s.waitForSlot(ctx, exchangeID); err != nil {
+		return nil, err
+	}
+
+	if sinceMs == nil {
+		return adapter.FetchFundingRateHistory(symbol, nil, seriesBatchLimit)
+	}
+
+	var rates []models.FundingRate
+	err = s.pageForward(ctx, exchangeID, *sinceMs, maxPoints, func(since int64) (int, int64, error) {
+		sinceTime := time.UnixMilli(since)
+		page, err := adapter.FetchFundingRateHistory(symbol, &sinceTime, seriesBatchLimit)
+		if err != nil || len(page) == 0 {
+			return 0, 0, err
+		}
+		rates = append(rates, page...)
+		return len(page), page[len(page)-1].Timestamp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Printf(ctx, "[CCXT] Total funding rates fetched for %s %s: %d", exchangeID, symbol, len(rates))
+	return rates, nil
+}
+
+// FetchOpenInterestHistory fetches the open interest of a perpetual futures
+// symbol at the given timeframe, oldest first, paging like
+// FetchFundingRateHistory
+func (s *CCXTService) FetchOpenInterestHistory(
+	ctx context.Context,
+	exchangeID string,
+	symbol string,
+	timeframe string,
+	sinceMs *int64,
+	maxPoints int,
+	requestTimeoutMs int,
+) ([]models.OpenInterest, error) {
+	adapter, err := s.openAdapter(ctx, exchangeID, requestTimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	defer adapter.Close()
+
+	if err := s.waitForSlot(ctx, exchangeID); err != nil {
+		return nil, err
+	}
+
+	if sinceMs == nil {
+		return adapter.FetchOpenInterestHistory(symbol, timeframe, nil, seriesBatchLimit)
+	}
+
+	var points []models.OpenInterest
+	err = s.pageForward(ctx, exchangeID, *sinceMs, maxPoints, func(since int64) (int, int64, error) {
+		sinceTime := time.UnixMilli(since)
+		page, err := adapter.FetchOpenInterestHistory(symbol, timeframe, &sinceTime, seriesBatchLimit)
+		if err != nil || len(page) == 0 {
+			return 0, 0, err
+		}
+		points = append(points, page...)
+		return len(page), page[len(page)-1].Timestamp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Printf(ctx, "[CCXT] Total open interest points fetched for %s %s: %d", exchangeID, symbol, len(points))
+	return points, nil
+}
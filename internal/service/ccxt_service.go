@@ -3,11 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/yourusername/datacollector/internal/exchange"
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 )
 
@@ -49,6 +49,23 @@ func (s *CCXTService) FetchOHLCVDataWithContext(
 	timeframe string,
 	sinceMs *int64,
 ) ([]models.Candle, error) {
+	return s.FetchOHLCVDataWithLimit(ctx, exchangeID, symbol, timeframe, sinceMs, 0, 0)
+}
+
+// FetchOHLCVDataWithLimit behaves like FetchOHLCVDataWithContext, but when
+// fetching since a timestamp it stops paging once maxCandles have been
+// collected (0 = no cap), so a job that fell behind catches up over several
+// runs without exhausting the rate budget. Each request to the exchange times
+// out after requestTimeoutMs (0 = adapter default), independently of ctx.
+func (s *CCXTService) FetchOHLCVDataWithLimit(
+	ctx context.Context,
+	exchangeID string,
+	symbol string,
+	timeframe string,
+	sinceMs *int64,
+	maxCandles int,
+	requestTimeoutMs int,
+) ([]models.Candle, error) {
 
 	// Apply rate limiting before creating adapter (LoadMarkets is an API call)
 	if s.rateLimiter != nil {
@@ -58,22 +75,28 @@ func (s *CCXTService) FetchOHLCVDataWithContext(
 	}
 
 	// Create adapter for the exchange dynamically
-	adapter, err := exchange.NewCCXTAdapter(exchangeID, true)
+	var adapter *exchange.CCXTAdapter
+	var err error
+	if requestTimeoutMs > 0 {
+		adapter, err = exchange.NewCCXTAdapterWithTimeout(exchangeID, true, requestTimeoutMs)
+	} else {
+		adapter, err = exchange.NewCCXTAdapter(exchangeID, true)
+	}
 	if err != nil {
-		log.Printf("[CCXT] Failed to create adapter for %s: %v", exchangeID, err)
+		logging.Printf(ctx, "[CCXT] Failed to create adapter for %s: %v", exchangeID, err)
 		return nil, fmt.Errorf("exchange %s not yet supported: %w", exchangeID, err)
 	}
 	defer adapter.Close()
 
-	log.Printf("[CCXT] Successfully created adapter for %s", exchangeID)
+	logging.Printf(ctx, "[CCXT] Successfully created adapter for %s", exchangeID)
 
 	// Load markets first (this is an API call)
 	if err := adapter.LoadMarkets(); err != nil {
-		log.Printf("[CCXT] Failed to load markets for %s: %v", exchangeID, err)
+		logging.Printf(ctx, "[CCXT] Failed to load markets for %s: %v", exchangeID, err)
 		return nil, fmt.Errorf("failed to load markets: %w", err)
 	}
 
-	log.Printf("[CCXT] Markets loaded for %s", exchangeID)
+	logging.Printf(ctx, "[CCXT] Markets loaded for %s", exchangeID)
 
 	// Get exchange metadata for OHLCV limit
 	metadata, err := exchange.GetExchangeMetadata(exchangeID)
@@ -81,27 +104,27 @@ func (s *CCXTService) FetchOHLCVDataWithContext(
 	if err == nil && metadata.OHLCVLimit > 0 {
 		batchLimit = metadata.OHLCVLimit
 	}
-	log.Printf("[CCXT] Using batch limit of %d for %s", batchLimit, exchangeID)
+	logging.Printf(ctx, "[CCXT] Using batch limit of %d for %s", batchLimit, exchangeID)
 
 	var allCandles []models.Candle
 
 	if sinceMs == nil {
 		// FIRST EXECUTION: Fetch ALL available historical data with pagination
-		log.Printf("[CCXT] First execution - fetching ALL historical data for %s %s %s",
+		logging.Printf(ctx, "[CCXT] First execution - fetching ALL historical data for %s %s %s",
 			exchangeID, symbol, timeframe)
 		allCandles, err = s.fetchAllHistoricalDataWithContext(ctx, adapter, exchangeID, symbol, timeframe, batchLimit)
 	} else {
 		// SUBSEQUENT EXECUTION: Fetch data since timestamp
-		log.Printf("[CCXT] Subsequent execution - fetching data since %d for %s %s %s",
+		logging.Printf(ctx, "[CCXT] Subsequent execution - fetching data since %d for %s %s %s",
 			*sinceMs, exchangeID, symbol, timeframe)
-		allCandles, err = s.fetchDataSinceWithContext(ctx, adapter, exchangeID, symbol, timeframe, *sinceMs, batchLimit)
+		allCandles, err = s.fetchDataSinceWithContext(ctx, adapter, exchangeID, symbol, timeframe, *sinceMs, batchLimit, maxCandles)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("[CCXT] Total candles fetched: %d", len(allCandles))
+	logging.Printf(ctx, "[CCXT] Total candles fetched: %d", len(allCandles))
 
 	// Reverse order so newest candles are at index 0
 	reversed := make([]models.Candle, len(allCandles))
@@ -109,10 +132,44 @@ func (s *CCXTService) FetchOHLCVDataWithContext(
 		reversed[len(allCandles)-1-i] = candle
 	}
 
-	log.Printf("[CCXT] Returning %d candles (newest first)", len(reversed))
+	logging.Printf(ctx, "[CCXT] Returning %d candles (newest first)", len(reversed))
 	return reversed, nil
 }
 
+// ProbeExchange checks that an exchange is reachable with a single lightweight
+// call, going through the rate limiter like any other request. It returns the
+// latency of the call itself, excluding the rate limit wait.
+func (s *CCXTService) ProbeExchange(ctx context.Context, exchangeID string, requestTimeoutMs int) (time.Duration, error) {
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.WaitForSlot(ctx, exchangeID); err != nil {
+			return 0, fmt.Errorf("rate limit wait failed: %w", err)
+		}
+	}
+
+	var adapter *exchange.CCXTAdapter
+	var err error
+	if requestTimeoutMs > 0 {
+		adapter, err = exchange.NewCCXTAdapterWithTimeout(exchangeID, true, requestTimeoutMs)
+	} else {
+		adapter, err = exchange.NewCCXTAdapter(exchangeID, true)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("exchange %s not yet supported: %w", exchangeID, err)
+	}
+	defer adapter.Close()
+
+	start := time.Now()
+	err = adapter.Ping()
+	latency := time.Since(start)
+	if err != nil {
+		logging.Printf(ctx, "[CCXT] Probe of %s failed after %v: %v", exchangeID, latency, err)
+		return latency, err
+	}
+
+	logging.Printf(ctx, "[CCXT] Probe of %s succeeded in %v", exchangeID, latency)
+	return latency, nil
+}
+
 // fetchAllHistoricalData fetches all available historical data using pagination
 // DEPRECATED: Use fetchAllHistoricalDataWithContext instead
 func (s *CCXTService) fetchAllHistoricalData(
@@ -154,18 +211,21 @@ func (s *CCXTService) fetchAllHistoricalDataWithContext(
 	batchLimit int,
 ) ([]models.Candle, error) {
 	// Calculate timeframe duration in milliseconds for pagination
-	tfDuration := getTimeframeDurationMs(timeframe)
+	tfDuration, err := models.GetTimeframeMs(timeframe)
+	if err != nil {
+		return nil, err
+	}
 
 	// Try with progressively shorter date ranges if we hit range limits
 	for fallbackIdx, months := range dateRangeFallbacks {
 		startTime := time.Now().AddDate(0, -months, 0)
-		log.Printf("[CCXT] Attempting historical fetch with %d month range (fallback %d/%d)", months, fallbackIdx+1, len(dateRangeFallbacks))
+		logging.Printf(ctx, "[CCXT] Attempting historical fetch with %d month range (fallback %d/%d)", months, fallbackIdx+1, len(dateRangeFallbacks))
 
 		candles, err := s.fetchHistoricalFromDate(ctx, adapter, exchangeID, symbol, timeframe, startTime, batchLimit, tfDuration)
 
 		if err != nil {
 			if isDateRangeError(err) {
-				log.Printf("[CCXT] Date range error with %d months, trying shorter range: %v", months, err)
+				logging.Printf(ctx, "[CCXT] Date range error with %d months, trying shorter range: %v", months, err)
 				continue // Try next shorter range
 			}
 			// Non-range error, return what we have or the error
@@ -196,7 +256,7 @@ func (s *CCXTService) fetchHistoricalFromDate(
 	var allCandles []models.Candle
 	currentSince := startTime
 
-	log.Printf("[CCXT] Starting historical fetch from %s", startTime.Format("2006-01-02"))
+	logging.Printf(ctx, "[CCXT] Starting historical fetch from %s", startTime.Format("2006-01-02"))
 
 	maxIterations := 1000 // Safety limit to prevent infinite loops
 	iteration := 0
@@ -207,7 +267,7 @@ func (s *CCXTService) fetchHistoricalFromDate(
 		// Check context cancellation
 		select {
 		case <-ctx.Done():
-			log.Printf("[CCXT] Context cancelled, returning %d candles collected so far", len(allCandles))
+			logging.Printf(ctx, "[CCXT] Context cancelled, returning %d candles collected so far", len(allCandles))
 			if len(allCandles) > 0 {
 				return allCandles, nil
 			}
@@ -218,7 +278,7 @@ func (s *CCXTService) fetchHistoricalFromDate(
 		// Apply rate limiting before each API call
 		if s.rateLimiter != nil && exchangeID != "" {
 			if err := s.rateLimiter.WaitForSlot(ctx, exchangeID); err != nil {
-				log.Printf("[CCXT] Rate limit wait failed: %v", err)
+				logging.Printf(ctx, "[CCXT] Rate limit wait failed: %v", err)
 				if len(allCandles) > 0 {
 					return allCandles, nil
 				}
@@ -226,25 +286,25 @@ func (s *CCXTService) fetchHistoricalFromDate(
 			}
 		}
 
-		log.Printf("[CCXT] Fetching batch %d from %s", iteration, currentSince.Format("2006-01-02 15:04:05"))
+		logging.Printf(ctx, "[CCXT] Fetching batch %d from %s", iteration, currentSince.Format("2006-01-02 15:04:05"))
 
 		candles, err := adapter.FetchOHLCV(symbol, timeframe, &currentSince, batchLimit)
 		if err != nil {
-			log.Printf("[CCXT] Error fetching batch %d: %v", iteration, err)
+			logging.Printf(ctx, "[CCXT] Error fetching batch %d: %v", iteration, err)
 			// If we already have some data, return it despite the error
 			if len(allCandles) > 0 {
-				log.Printf("[CCXT] Returning %d candles collected before error", len(allCandles))
+				logging.Printf(ctx, "[CCXT] Returning %d candles collected before error", len(allCandles))
 				return allCandles, nil
 			}
 			return nil, fmt.Errorf("failed to fetch OHLCV: %w", err)
 		}
 
 		if len(candles) == 0 {
-			log.Printf("[CCXT] No more candles returned, stopping pagination")
+			logging.Printf(ctx, "[CCXT] No more candles returned, stopping pagination")
 			break
 		}
 
-		log.Printf("[CCXT] Batch %d: fetched %d candles", iteration, len(candles))
+		logging.Printf(ctx, "[CCXT] Batch %d: fetched %d candles", iteration, len(candles))
 
 		// Append candles (they come in chronological order - oldest first)
 		allCandles = append(allCandles, candles...)
@@ -255,13 +315,13 @@ func (s *CCXTService) fetchHistoricalFromDate(
 
 		// Check if we've reached the present (last candle is recent)
 		if time.Since(lastTimestamp) < time.Duration(tfDuration)*time.Millisecond*2 {
-			log.Printf("[CCXT] Reached present time, stopping pagination")
+			logging.Printf(ctx, "[CCXT] Reached present time, stopping pagination")
 			break
 		}
 
 		// If we got fewer candles than the limit, we've likely reached the end
 		if len(candles) < batchLimit {
-			log.Printf("[CCXT] Received fewer candles than limit (%d < %d), likely at end", len(candles), batchLimit)
+			logging.Printf(ctx, "[CCXT] Received fewer candles than limit (%d < %d), likely at end", len(candles), batchLimit)
 			break
 		}
 
@@ -275,7 +335,7 @@ func (s *CCXTService) fetchHistoricalFromDate(
 		}
 	}
 
-	log.Printf("[CCXT] Historical fetch complete: %d total candles in %d batches", len(allCandles), iteration)
+	logging.Printf(ctx, "[CCXT] Historical fetch complete: %d total candles in %d batches", len(allCandles), iteration)
 	return allCandles, nil
 }
 
@@ -288,10 +348,11 @@ func (s *CCXTService) fetchDataSince(
 	sinceMs int64,
 	batchLimit int,
 ) ([]models.Candle, error) {
-	return s.fetchDataSinceWithContext(context.Background(), adapter, "", symbol, timeframe, sinceMs, batchLimit)
+	return s.fetchDataSinceWithContext(context.Background(), adapter, "", symbol, timeframe, sinceMs, batchLimit, 0)
 }
 
-// fetchDataSinceWithContext fetches data from a specific timestamp with rate limiting
+// fetchDataSinceWithContext fetches data from a specific timestamp with rate limiting,
+// paging forward until it reaches the present or maxCandles (0 = no cap)
 func (s *CCXTService) fetchDataSinceWithContext(
 	ctx context.Context,
 	adapter *exchange.CCXTAdapter,
@@ -300,15 +361,23 @@ func (s *CCXTService) fetchDataSinceWithContext(
 	timeframe string,
 	sinceMs int64,
 	batchLimit int,
+	maxCandles int,
 ) ([]models.Candle, error) {
 	var allCandles []models.Candle
 
-	tfDuration := getTimeframeDurationMs(timeframe)
+	tfDuration, err := models.GetTimeframeMs(timeframe)
+	if err != nil {
+		return nil, err
+	}
 	currentSince := time.UnixMilli(sinceMs)
 
-	log.Printf("[CCXT] Fetching data since %s", currentSince.Format("2006-01-02 15:04:05"))
+	logging.Printf(ctx, "[CCXT] Fetching data since %s", currentSince.Format("2006-01-02 15:04:05"))
 
 	maxIterations := 100 // Fewer iterations needed for incremental updates
+	if maxCandles > 0 {
+		// Enough pages to reach the cap, plus one in case the exchange returns short pages
+		maxIterations = (maxCandles+batchLimit-1)/batchLimit + 1
+	}
 	iteration := 0
 
 	for iteration < maxIterations {
@@ -317,7 +386,7 @@ func (s *CCXTService) fetchDataSinceWithContext(
 		// Check context cancellation
 		select {
 		case <-ctx.Done():
-			log.Printf("[CCXT] Context cancelled, returning %d candles collected so far", len(allCandles))
+			logging.Printf(ctx, "[CCXT] Context cancelled, returning %d candles collected so far", len(allCandles))
 			if len(allCandles) > 0 {
 				return allCandles, nil
 			}
@@ -328,7 +397,7 @@ func (s *CCXTService) fetchDataSinceWithContext(
 		// Apply rate limiting before each API call
 		if s.rateLimiter != nil && exchangeID != "" {
 			if err := s.rateLimiter.WaitForSlot(ctx, exchangeID); err != nil {
-				log.Printf("[CCXT] Rate limit wait failed: %v", err)
+				logging.Printf(ctx, "[CCXT] Rate limit wait failed: %v", err)
 				if len(allCandles) > 0 {
 					return allCandles, nil
 				}
@@ -348,9 +417,17 @@ func (s *CCXTService) fetchDataSinceWithContext(
 			break
 		}
 
-		log.Printf("[CCXT] Batch %d: fetched %d candles", iteration, len(candles))
+		logging.Printf(ctx, "[CCXT] Batch %d: fetched %d candles", iteration, len(candles))
 		allCandles = append(allCandles, candles...)
 
+		// Stop at the per-run cap; the rest is fetched on the next run
+		if maxCandles > 0 && len(allCandles) >= maxCandles {
+			allCandles = allCandles[:maxCandles]
+			logging.Printf(ctx, "[CCXT] Reached per-run cap of %d candles after %d pages, resuming next run",
+				maxCandles, iteration)
+			break
+		}
+
 		// Check if we've reached present
 		lastCandle := candles[len(candles)-1]
 		lastTimestamp := time.UnixMilli(lastCandle.Timestamp)
@@ -372,51 +449,10 @@ func (s *CCXTService) fetchDataSinceWithContext(
 		}
 	}
 
-	log.Printf("[CCXT] Incremental fetch complete: %d candles", len(allCandles))
+	logging.Printf(ctx, "[CCXT] Incremental fetch complete: %d candles in %d pages", len(allCandles), iteration)
 	return allCandles, nil
 }
 
-// getTimeframeDurationMs returns the duration of a timeframe in milliseconds
-func getTimeframeDurationMs(timeframe string) int64 {
-	switch timeframe {
-	case "1s":
-		return 1000
-	case "1m":
-		return 60 * 1000
-	case "3m":
-		return 3 * 60 * 1000
-	case "5m":
-		return 5 * 60 * 1000
-	case "15m":
-		return 15 * 60 * 1000
-	case "30m":
-		return 30 * 60 * 1000
-	case "1h":
-		return 60 * 60 * 1000
-	case "2h":
-		return 2 * 60 * 60 * 1000
-	case "4h":
-		return 4 * 60 * 60 * 1000
-	case "6h":
-		return 6 * 60 * 60 * 1000
-	case "8h":
-		return 8 * 60 * 60 * 1000
-	case "12h":
-		return 12 * 60 * 60 * 1000
-	case "1d":
-		return 24 * 60 * 60 * 1000
-	case "3d":
-		return 3 * 24 * 60 * 60 * 1000
-	case "1w":
-		return 7 * 24 * 60 * 60 * 1000
-	case "1M":
-		return 30 * 24 * 60 * 60 * 1000
-	default:
-		// Default to 1 hour
-		return 60 * 60 * 1000
-	}
-}
-
 // FetchOHLCVRange fetches OHLCV data for a specific time range (used for gap filling)
 func (s *CCXTService) FetchOHLCVRange(
 	ctx context.Context,
@@ -455,11 +491,14 @@ func (s *CCXTService) FetchOHLCVRange(
 	}
 
 	var allCandles []models.Candle
-	tfDuration := getTimeframeDurationMs(timeframe)
+	tfDuration, err := models.GetTimeframeMs(timeframe)
+	if err != nil {
+		return nil, err
+	}
 	currentSince := time.UnixMilli(startMs)
 	endTime := time.UnixMilli(endMs)
 
-	log.Printf("[CCXT] Fetching range %s to %s for %s %s %s",
+	logging.Printf(ctx, "[CCXT] Fetching range %s to %s for %s %s %s",
 		currentSince.Format("2006-01-02 15:04:05"),
 		endTime.Format("2006-01-02 15:04:05"),
 		exchangeID, symbol, timeframe)
@@ -528,7 +567,7 @@ func (s *CCXTService) FetchOHLCVRange(
 	}
 
 done:
-	log.Printf("[CCXT] Range fetch complete: %d candles", len(allCandles))
+	logging.Printf(ctx, "[CCXT] Range fetch complete: %d candles", len(allCandles))
 
 	// Reverse order so newest candles are at index 0
 	reversed := make([]models.Candle, len(allCandles))
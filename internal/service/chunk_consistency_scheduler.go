@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ChunkConsistencyScheduler periodically repairs OHLCV chunks whose candle
+// count or time range no longer matches their candles
+type ChunkConsistencyScheduler struct {
+	retentionService *RetentionService
+	ticker           *time.Ticker
+	stopChan         chan bool
+	interval         time.Duration
+}
+
+// NewChunkConsistencyScheduler creates a new chunk consistency scheduler
+func NewChunkConsistencyScheduler(retentionService *RetentionService, interval time.Duration) *ChunkConsistencyScheduler {
+	if interval <= 0 {
+		interval = 24 * time.Hour // Default to daily
+	}
+
+	return &ChunkConsistencyScheduler{
+		retentionService: retentionService,
+		interval:         interval,
+		stopChan:         make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop
+func (s *ChunkConsistencyScheduler) Start() {
+	log.Printf("Chunk consistency scheduler started - checking chunks every %s", s.interval)
+
+	s.ticker = time.NewTicker(s.interval)
+
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				s.runCheck()
+			case <-s.stopChan:
+				log.Println("Chunk consistency scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler
+func (s *ChunkConsistencyScheduler) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopChan)
+}
+
+// runCheck runs a scheduled consistency check over all chunks
+func (s *ChunkConsistencyScheduler) runCheck() {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
+	defer cancel()
+
+	result, err := s.retentionService.RepairChunkBoundaries(ctx, "", false)
+	if err != nil {
+		log.Printf("[CHUNK_CONSISTENCY] Failed to check chunks: %v", err)
+		return
+	}
+	log.Printf("[CHUNK_CONSISTENCY] Scanned %d chunks: %d mismatched, %d repaired, %d changed during the scan",
+		result.ChunksScanned, result.ChunksMismatched, result.ChunksFixed, result.ChunksChanged)
+}
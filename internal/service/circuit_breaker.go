@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+	"github.com/yourusername/datacollector/internal/repository"
+)
+
+// CircuitBreaker stops job executions against a connector whose exchange keeps
+// failing. After threshold consecutive failures the circuit opens and jobs are
+// skipped for the cooldown; then a single probe execution is let through, which
+// closes the circuit on success or reopens it on failure. State is stored on
+// the connector, so it is shared by every executor and survives restarts.
+type CircuitBreaker struct {
+	connectorRepo *repository.ConnectorRepository
+	threshold     int
+	cooldown      time.Duration
+}
+
+// NewCircuitBreaker creates a circuit breaker. A threshold of 0 disables it.
+func NewCircuitBreaker(connectorRepo *repository.ConnectorRepository, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		connectorRepo: connectorRepo,
+		threshold:     threshold,
+		cooldown:      cooldown,
+	}
+}
+
+// Allow reports whether an execution may call the connector's exchange. When
+// it may not, it also returns when the next probe will be allowed.
+func (b *CircuitBreaker) Allow(ctx context.Context, connector *models.Connector) (bool, time.Time) {
+	if b.threshold <= 0 || !connector.Health.IsCircuitOpen() {
+		return true, time.Time{}
+	}
+
+	openUntil := time.Time{}
+	if connector.Health.CircuitOpenUntil != nil {
+		openUntil = *connector.Health.CircuitOpenUntil
+	}
+	if time.Now().Before(openUntil) {
+		return false, openUntil
+	}
+
+	// Cooldown is over: only one execution gets to probe the exchange
+	probe, err := b.connectorRepo.TryProbeCircuit(ctx, connector.ExchangeID, time.Now().Add(b.cooldown))
+	if err != nil {
+		logging.Printf(ctx, "[CIRCUIT] Failed to probe circuit for %s: %v", connector.ExchangeID, err)
+		return false, time.Now().Add(b.cooldown)
+	}
+	if !probe {
+		return false, time.Now().Add(b.cooldown)
+	}
+
+	logging.Printf(ctx, "[CIRCUIT] Circuit for %s is half-open, probing exchange", connector.ExchangeID)
+	return true, time.Time{}
+}
+
+// RecordSuccess closes the connector's circuit after a successful call
+func (b *CircuitBreaker) RecordSuccess(ctx context.Context, connector *models.Connector) {
+	if !connector.Health.IsCircuitOpen() {
+		return
+	}
+	if err := b.connectorRepo.CloseCircuit(ctx, connector.ExchangeID); err != nil {
+		logging.Printf(ctx, "[CIRCUIT] Failed to close circuit for %s: %v", connector.ExchangeID, err)
+		return
+	}
+	logging.Printf(ctx, "[CIRCUIT] Probe succeeded, circuit for %s closed", connector.ExchangeID)
+}
+
+// RecordFailure opens the connector's circuit once consecutiveFailures reaches
+// the threshold, or reopens it when a probe fails
+func (b *CircuitBreaker) RecordFailure(ctx context.Context, connector *models.Connector, consecutiveFailures int) {
+	if b.threshold <= 0 {
+		return
+	}
+	if consecutiveFailures < b.threshold && !connector.Health.IsCircuitOpen() {
+		return
+	}
+
+	until := time.Now().Add(b.cooldown)
+	if err := b.connectorRepo.OpenCircuit(ctx, connector.ExchangeID, until); err != nil {
+		logging.Printf(ctx, "[CIRCUIT] Failed to open circuit for %s: %v", connector.ExchangeID, err)
+		return
+	}
+	logging.Printf(ctx, "[CIRCUIT] Circuit for %s open after %d consecutive failures, skipping executions until %s",
+		connector.ExchangeID, consecutiveFailures, until.Format(time.RFC3339))
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package service
+
+import "errors"
+
+// diskFreeBytes is not implemented on this platform
+func diskFreeBytes(path string) (int64, error) {
+	return 0, errors.New("free disk space is not available on this platform")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Classes of failed exchange calls. A failed job run's error matches one of
+// them with errors.Is when its cause was recognized, so callers can tell a
+// run worth retrying from a job whose config needs fixing.
+var (
+	ErrRateLimited         = errors.New("rate limited by exchange")
+	ErrSymbolNotFound      = errors.New("symbol not found on exchange")
+	ErrExchangeUnavailable = errors.New("exchange unavailable")
+)
+
+// exchangeErrorPatterns maps each error class to the CCXT error class names
+// and HTTP error phrases that identify it in a lowercased message, checked in
+// this order. Patterns match whole words, and status codes only next to an
+// HTTP word, so IDs, amounts and parameter names containing them don't match.
+var exchangeErrorPatterns = []struct {
+	class   error
+	pattern *regexp.Regexp
+}{
+	{ErrRateLimited, regexp.MustCompile(`\b(?:ratelimitexceeded|ddosprotection|rate limit(?:ed|ing)?|too many requests|(?:http|status|code)\W*429)\b`)},
+	{ErrSymbolNotFound, regexp.MustCompile(`\b(?:badsymbol|invalid symbol|symbol not found|market not found|does not have market symbol)\b`)},
+	{ErrExchangeUnavailable, regexp.MustCompile(`\b(?:` +
+		`exchangenotavailable|onmaintenance|networkerror|requesttimeout|` +
+		`service unavailable|bad gateway|gateway time-?out|(?:http|status|code)\W*50[234]|` +
+		`timeout|timed out|connection reset|connection refused|no such host|network is unreachable` +
+		`)\b`)},
+}
+
+// ExchangeCallError is a failed exchange call of a job, classified by cause
+type ExchangeCallError struct {
+	Class      error // ErrRateLimited, ErrSymbolNotFound, ErrExchangeUnavailable or nil when unrecognized
+	ExchangeID string
+	Symbol     string
+	Err        error
+}
+
+// Error returns the message of the underlying error
+func (e *ExchangeCallError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes both the class and the underlying error to errors.Is
+func (e *ExchangeCallError) Unwrap() []error {
+	if e.Class == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Class, e.Err}
+}
+
+// classifyExchangeError wraps an error from an exchange call with the class
+// its message identifies
+func classifyExchangeError(err error, exchangeID, symbol string) *ExchangeCallError {
+	callErr := &ExchangeCallError{ExchangeID: exchangeID, Symbol: symbol, Err: err}
+
+	msg := strings.ToLower(err.Error())
+	for _, c := range exchangeErrorPatterns {
+		if c.pattern.MatchString(msg) {
+			callErr.Class = c.class
+			return callErr
+		}
+	}
+	return callErr
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyExchangeError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want error
+	}{
+		{"failed to fetch OHLCV: binance RateLimitExceeded: too many requests", ErrRateLimited},
+		{"failed to fetch OHLCV: HTTP 429", ErrRateLimited},
+		{"failed to fetch OHLCV: binance does not have market symbol FOO/BAR", ErrSymbolNotFound},
+		{"failed to fetch trades: BadSymbol: invalid symbol", ErrSymbolNotFound},
+		{"failed to load markets: ExchangeNotAvailable: 503 Service Unavailable", ErrExchangeUnavailable},
+		{"failed to fetch OHLCV: dial tcp: connection refused", ErrExchangeUnavailable},
+		{"failed to fetch OHLCV: HTTP 503", ErrExchangeUnavailable},
+		{"failed to fetch OHLCV: Get \"https://api.binance.com\": dial tcp: i/o timeout", ErrExchangeUnavailable},
+		{"failed to fetch OHLCV: unexpected response", nil},
+		{"failed to fetch order 15030429: order not found", nil},
+		{"failed to fetch trades: invalid parameter timeout_ms", nil},
+		{"failed to withdraw: insufficient balance for network fee", nil},
+	}
+
+	for _, tt := range tests {
+		cause := errors.New(tt.msg)
+		err := classifyExchangeError(fmt.Errorf("wrapped: %w", cause), "binance", "BTC/USDT")
+		if err.Class != tt.want {
+			t.Errorf("%q: class = %v, want %v", tt.msg, err.Class, tt.want)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%q: errors.Is(err, %v) = false", tt.msg, tt.want)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("%q: underlying error not reachable with errors.Is", tt.msg)
+		}
+		if err.Symbol != "BTC/USDT" || err.ExchangeID != "binance" {
+			t.Errorf("%q: source = %s %s", tt.msg, err.ExchangeID, err.Symbol)
+		}
+	}
+
+	if isTransientError(classifyExchangeError(errors.New("BadSymbol"), "binance", "FOO/BAR")) {
+		t.Error("symbol not found should not be transient")
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/exchange"
+)
+
+// ExchangeMetadataScheduler periodically refreshes cached exchange metadata
+// (timeframes, limits) so it doesn't go stale between manual refreshes
+type ExchangeMetadataScheduler struct {
+	ticker   *time.Ticker
+	stopChan chan bool
+	interval time.Duration
+}
+
+// NewExchangeMetadataScheduler creates a new exchange metadata scheduler
+func NewExchangeMetadataScheduler(interval time.Duration) *ExchangeMetadataScheduler {
+	if interval <= 0 {
+		interval = 6 * time.Hour // Default to 6 hours
+	}
+
+	return &ExchangeMetadataScheduler{
+		interval: interval,
+		stopChan: make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop
+func (s *ExchangeMetadataScheduler) Start() {
+	log.Printf("Exchange metadata scheduler started - refreshing metadata every %s", s.interval)
+
+	s.ticker = time.NewTicker(s.interval)
+
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				s.runRefresh()
+			case <-s.stopChan:
+				log.Println("Exchange metadata scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler
+func (s *ExchangeMetadataScheduler) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopChan)
+}
+
+// runRefresh refreshes the metadata of every cached exchange; failed
+// exchanges keep serving their previous metadata
+func (s *ExchangeMetadataScheduler) runRefresh() {
+	refreshed, failed := exchange.RefreshCachedMetadata()
+	log.Printf("[EXCHANGE_METADATA] Refreshed metadata of %d exchanges, %d failed", refreshed, failed)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ExportCleanupScheduler periodically removes expired ML export files
+type ExportCleanupScheduler struct {
+	exportService *MLExportService
+	ticker        *time.Ticker
+	stopChan      chan bool
+	interval      time.Duration
+}
+
+// NewExportCleanupScheduler creates a new export cleanup scheduler
+func NewExportCleanupScheduler(exportService *MLExportService, interval time.Duration) *ExportCleanupScheduler {
+	if interval <= 0 {
+		interval = 1 * time.Hour // Default to 1 hour
+	}
+
+	return &ExportCleanupScheduler{
+		exportService: exportService,
+		interval:      interval,
+		stopChan:      make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop
+func (s *ExportCleanupScheduler) Start() {
+	log.Printf("Export cleanup scheduler started - cleaning up every %s", s.interval)
+
+	// Run initial cleanup after a short delay (don't block startup)
+	go func() {
+		time.Sleep(30 * time.Second)
+		s.runCleanup()
+	}()
+
+	s.ticker = time.NewTicker(s.interval)
+
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				s.runCleanup()
+			case <-s.stopChan:
+				log.Println("Export cleanup scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler
+func (s *ExportCleanupScheduler) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopChan)
+}
+
+// runCleanup runs a scheduled export cleanup
+func (s *ExportCleanupScheduler) runCleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := s.exportService.CleanupExpiredExports(ctx)
+	if err != nil {
+		log.Printf("[EXPORT_CLEANUP] Failed to clean up expired exports: %v", err)
+	}
+	if result != nil {
+		log.Printf("[EXPORT_CLEANUP] Removed %d files and %d records, reconciled %d records with missing files",
+			result.FilesRemoved, result.RecordsRemoved, result.RecordsReconciled)
+	}
+}
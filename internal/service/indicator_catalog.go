@@ -0,0 +1,101 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// IndicatorCategories lists the indicator categories in catalog order
+var IndicatorCategories = []string{"trend", "momentum", "volatility", "volume"}
+
+// indicatorCatalog is built once from the exportable indicator columns, the
+// indicator specs and the built-in IndicatorConfig, so it only lists what the
+// export can produce
+var indicatorCatalog = buildIndicatorCatalog()
+
+// IndicatorCatalog returns the catalog entries of the category, or of all
+// categories when empty, whose name, indicator or description contains search
+// (case-insensitive)
+func IndicatorCatalog(category, search string) []models.IndicatorCatalogEntry {
+	search = strings.ToLower(search)
+	entries := make([]models.IndicatorCatalogEntry, 0, len(indicatorCatalog))
+	for _, entry := range indicatorCatalog {
+		if category != "" && entry.Category != category {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(entry.Name, search) &&
+			!strings.Contains(entry.Indicator, search) &&
+			!strings.Contains(strings.ToLower(entry.Description), search) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// buildIndicatorCatalog describes every exportable indicator column, in
+// export column order, with the indicator spec that produces it and the
+// built-in IndicatorConfig settings
+func buildIndicatorCatalog() []models.IndicatorCatalogEntry {
+	specs := make(map[string]models.IndicatorSpec)
+	for _, spec := range models.IndicatorSpecs() {
+		for _, field := range spec.Fields {
+			specs[field] = spec
+		}
+	}
+	settings := defaultIndicatorSettings()
+
+	var entries []models.IndicatorCatalogEntry
+	for _, field := range storedIndicatorFields {
+		entry := models.IndicatorCatalogEntry{
+			Name:          field.name,
+			Category:      field.category,
+			Description:   featureDescriptions[field.name],
+			Params:        []string{},
+			DefaultParams: map[string]interface{}{},
+			WarmupBars:    indicatorWarmup[field.name].bars,
+		}
+
+		if spec, ok := specs[field.name]; ok {
+			defaults := settings[spec.Category]
+			entry.Indicator = spec.Name
+			entry.EnabledField = spec.Category + "." + spec.Enabled
+			entry.DefaultEnabled, _ = defaults[spec.Enabled].(bool)
+			for _, param := range spec.Params {
+				entry.Params = append(entry.Params, spec.Category+"."+param)
+				entry.DefaultParams[spec.Category+"."+param] = defaults[param]
+			}
+			if period, err := strconv.Atoi(strings.TrimPrefix(field.name, spec.Name)); err == nil {
+				entry.Period = period
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// defaultIndicatorSettings returns the built-in IndicatorConfig settings of
+// each category keyed by config field name, e.g. settings["trend"]["sma_periods"]
+func defaultIndicatorSettings() map[string]map[string]interface{} {
+	settings := make(map[string]map[string]interface{})
+	data, err := json.Marshal(models.DefaultIndicatorConfig())
+	if err != nil {
+		return settings
+	}
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return settings
+	}
+	for _, category := range IndicatorCategories {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(config[category], &fields); err == nil {
+			settings[category] = fields
+		}
+	}
+	return settings
+}
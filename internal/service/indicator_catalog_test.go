@@ -0,0 +1,64 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndicatorCatalogCoversExportableIndicators(t *testing.T) {
+	catalog := IndicatorCatalog("", "")
+	if len(catalog) != len(storedIndicatorFields) {
+		t.Fatalf("catalog has %d entries, want %d", len(catalog), len(storedIndicatorFields))
+	}
+
+	// Every column must map to an indicator spec, so the catalog can't drift
+	// from the IndicatorConfig definitions
+	for _, entry := range catalog {
+		if entry.Indicator == "" || entry.EnabledField == "" {
+			t.Errorf("%s has no indicator spec", entry.Name)
+		}
+		if entry.Description == "" {
+			t.Errorf("%s has no description", entry.Name)
+		}
+	}
+
+	byName := make(map[string]int)
+	for i, entry := range catalog {
+		byName[entry.Name] = i
+	}
+
+	sma20 := catalog[byName["sma20"]]
+	if sma20.Indicator != "sma" || sma20.Period != 20 || sma20.EnabledField != "trend.sma_enabled" {
+		t.Errorf("sma20 = %+v", sma20)
+	}
+	if want := []string{"trend.sma_periods"}; !reflect.DeepEqual(sma20.Params, want) {
+		t.Errorf("sma20 params = %v, want %v", sma20.Params, want)
+	}
+
+	bb := catalog[byName["bb_upper"]]
+	if want := []string{"volatility.bollinger_period", "volatility.bollinger_stddev"}; !reflect.DeepEqual(bb.Params, want) {
+		t.Errorf("bb_upper params = %v, want %v", bb.Params, want)
+	}
+	if atr := catalog[byName["atr"]]; !reflect.DeepEqual(atr.Params, []string{"volatility.atr_period"}) {
+		t.Errorf("atr params = %v", atr.Params)
+	}
+	if di := catalog[byName["plus_di"]]; di.Indicator != "adx" || di.DefaultParams["trend.adx_period"] != float64(14) {
+		t.Errorf("plus_di = %+v", di)
+	}
+}
+
+func TestIndicatorCatalogFilters(t *testing.T) {
+	for _, entry := range IndicatorCatalog("volume", "") {
+		if entry.Category != "volume" {
+			t.Errorf("%s category = %s, want volume", entry.Name, entry.Category)
+		}
+	}
+
+	var names []string
+	for _, entry := range IndicatorCatalog("", "Keltner") {
+		names = append(names, entry.Name)
+	}
+	if want := []string{"keltner_upper", "keltner_middle", "keltner_lower"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("keltner search = %v, want %v", names, want)
+	}
+}
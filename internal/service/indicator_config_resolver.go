@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+	"github.com/yourusername/datacollector/internal/repository"
+)
+
+// IndicatorConfigResolver picks the indicator config a job's candles are
+// computed with: the job's own override, then its connector's config, then the
+// global default config.
+type IndicatorConfigResolver struct {
+	configRepo *repository.IndicatorConfigRepository
+}
+
+// NewIndicatorConfigResolver creates a new indicator config resolver
+func NewIndicatorConfigResolver(configRepo *repository.IndicatorConfigRepository) *IndicatorConfigResolver {
+	return &IndicatorConfigResolver{
+		configRepo: configRepo,
+	}
+}
+
+// Resolve returns the indicator config for a job. Either argument may be nil.
+// A referenced config that no longer exists is skipped with a warning, and if
+// even the global default cannot be loaded the built-in default is returned.
+func (r *IndicatorConfigResolver) Resolve(ctx context.Context, job *models.Job, connector *models.Connector) *models.IndicatorConfig {
+	if job != nil && job.IndicatorConfigID != "" {
+		config, err := r.configRepo.FindByID(ctx, job.IndicatorConfigID)
+		if err == nil {
+			return config
+		}
+		logging.Printf(ctx, "[INDICATORS] Indicator config %s of job %s not found, falling back: %v", job.IndicatorConfigID, job.ID.Hex(), err)
+	}
+
+	if connector != nil && connector.IndicatorConfigID != "" {
+		config, err := r.configRepo.FindByID(ctx, connector.IndicatorConfigID)
+		if err == nil {
+			return config
+		}
+		logging.Printf(ctx, "[INDICATORS] Indicator config %s of connector %s not found, falling back: %v", connector.IndicatorConfigID, connector.ExchangeID, err)
+	}
+
+	config, err := r.configRepo.FindDefault(ctx)
+	if err != nil {
+		logging.Printf(ctx, "[INDICATORS] Failed to load default indicator config, using built-in default: %v", err)
+		return models.DefaultIndicatorConfig()
+	}
+	return config
+}
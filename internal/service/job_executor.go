@@ -2,15 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 	"github.com/yourusername/datacollector/internal/service/indicators"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Retry configuration
@@ -24,33 +27,90 @@ const (
 // JobExecutor handles job execution logic
 type JobExecutor struct {
 	jobRepo          *repository.JobRepository
+	jobRunRepo       *repository.JobRunRepository
 	connectorRepo    *repository.ConnectorRepository
 	ohlcvRepo        *repository.OHLCVRepository
+	tradeRepo        *repository.TradeRepository
+	orderBookRepo    *repository.OrderBookRepository
+	derivativesRepo  *repository.DerivativesRepository
 	config           *config.Config
 	ccxtService      *CCXTService
 	indicatorService *indicators.Service
+	configResolver   *IndicatorConfigResolver
 	rateLimiter      *RateLimiter
+	circuitBreaker   *CircuitBreaker
+	qualityRefresher *QualityRefresher
 }
 
 // NewJobExecutor creates a new job executor
-func NewJobExecutor(jobRepo *repository.JobRepository, connectorRepo *repository.ConnectorRepository, ohlcvRepo *repository.OHLCVRepository, cfg *config.Config) *JobExecutor {
+func NewJobExecutor(jobRepo *repository.JobRepository, jobRunRepo *repository.JobRunRepository, connectorRepo *repository.ConnectorRepository, ohlcvRepo *repository.OHLCVRepository, tradeRepo *repository.TradeRepository, orderBookRepo *repository.OrderBookRepository, derivativesRepo *repository.DerivativesRepository, indicatorConfigRepo *repository.IndicatorConfigRepository, cfg *config.Config) *JobExecutor {
 	// Create rate limiter
 	rateLimiter := NewRateLimiter(connectorRepo)
 
 	return &JobExecutor{
 		jobRepo:          jobRepo,
+		jobRunRepo:       jobRunRepo,
 		connectorRepo:    connectorRepo,
 		ohlcvRepo:        ohlcvRepo,
+		tradeRepo:        tradeRepo,
+		orderBookRepo:    orderBookRepo,
+		derivativesRepo:  derivativesRepo,
 		config:           cfg,
 		ccxtService:      NewCCXTServiceWithRateLimiter(rateLimiter),
 		indicatorService: indicators.NewService(),
+		configResolver:   NewIndicatorConfigResolver(indicatorConfigRepo),
 		rateLimiter:      rateLimiter,
+		circuitBreaker: NewCircuitBreaker(connectorRepo, cfg.Exchange.CircuitBreakerThreshold,
+			time.Duration(cfg.Exchange.CircuitBreakerCooldownSeconds)*time.Second),
 	}
 }
 
-// ExecuteJob executes a job by fetching OHLCV data from the exchange
+// SetQualityRefresher makes the executor queue a quality refresh of each job
+// it stores candles for; nil turns that off
+func (e *JobExecutor) SetQualityRefresher(refresher *QualityRefresher) {
+	e.qualityRefresher = refresher
+}
+
+// ExecuteJob executes a job by fetching its market data from the exchange and
+// appends the outcome to the job's run history
 func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.JobExecutionResult, error) {
 	startTime := time.Now()
+	result, err := e.executeJob(ctx, jobID, startTime)
+	e.recordRun(ctx, jobID, startTime, result, err)
+	return result, err
+}
+
+// recordRun stores the outcome of an execution in the job's run history.
+// Failures are only logged so history can never break job execution.
+func (e *JobExecutor) recordRun(ctx context.Context, jobID string, startTime time.Time, result *models.JobExecutionResult, execErr error) {
+	objectID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return
+	}
+
+	run := &models.JobRun{
+		JobID:      objectID,
+		StartedAt:  startTime,
+		DurationMs: time.Since(startTime).Milliseconds(),
+	}
+	if execErr != nil {
+		errorMsg := execErr.Error()
+		run.Message = "Job execution failed"
+		run.Error = &errorMsg
+	} else if result != nil {
+		run.Success = result.Success
+		run.CandlesFetched = result.RecordsFetched
+		run.Message = result.Message
+		run.Error = result.Error
+	}
+
+	if err := e.jobRunRepo.Record(ctx, run, e.config.Jobs.RunHistorySize); err != nil {
+		logging.Printf(ctx, "[EXEC] Warning: Failed to record run history for job %s: %v", jobID, err)
+	}
+}
+
+// executeJob performs the actual execution
+func (e *JobExecutor) executeJob(ctx context.Context, jobID string, startTime time.Time) (*models.JobExecutionResult, error) {
 
 	// Fetch job
 	job, err := e.jobRepo.FindByID(ctx, jobID)
@@ -62,10 +122,10 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 	if len(job.DependsOn) > 0 {
 		depStatus, err := e.jobRepo.GetDependencyStatus(ctx, jobID, 1*time.Hour)
 		if err != nil {
-			log.Printf("[Job %s] Warning: failed to check dependencies: %v", jobID, err)
+			logging.Printf(ctx, "[Job %s] Warning: failed to check dependencies: %v", jobID, err)
 		} else if !depStatus.AllDepsCompleted {
 			errorMsg := fmt.Sprintf("waiting for dependencies: %v", depStatus.BlockedBy)
-			log.Printf("[Job %s] Blocked by dependencies: %v", jobID, depStatus.BlockedBy)
+			logging.Printf(ctx, "[Job %s] Blocked by dependencies: %v", jobID, depStatus.BlockedBy)
 			return &models.JobExecutionResult{
 				Success:         false,
 				Message:         "Job blocked by dependencies",
@@ -94,6 +154,22 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 		}, nil
 	}
 
+	// Skip the run while the connector's circuit breaker is open, and come
+	// back when the next probe is allowed
+	if allowed, retryAt := e.circuitBreaker.Allow(ctx, connector); !allowed {
+		errorMsg := fmt.Sprintf("circuit breaker open for %s until %s", connector.ExchangeID, retryAt.Format(time.RFC3339))
+		if err := e.jobRepo.Update(ctx, jobID, bson.M{"run_state.next_run_time": retryAt}); err != nil {
+			logging.Printf(ctx, "[EXEC] Warning: Failed to defer job %s: %v", jobID, err)
+		}
+		return &models.JobExecutionResult{
+			Success:         false,
+			Message:         "Connector circuit breaker open",
+			RecordsFetched:  0,
+			ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+			Error:           &errorMsg,
+		}, nil
+	}
+
 	// Note: Rate limiting is now handled by the RateLimiter inside CCXTService
 	// Each API call will wait for a rate limit slot before executing
 	// This provides proper throttling at the API call level, not just at job start
@@ -119,44 +195,50 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 	// Ensure lock is released at the end
 	defer e.jobRepo.ReleaseLock(ctx, jobID)
 
+	switch job.GetJobType() {
+	case models.JobTypeTrades:
+		return e.executeTradesJob(ctx, job, connector, startTime)
+	case models.JobTypeOrderBook:
+		return e.executeOrderBookJob(ctx, job, connector, startTime)
+	case models.JobTypeFunding:
+		return e.executeFundingJob(ctx, job, connector, startTime)
+	case models.JobTypeOpenInterest:
+		return e.executeOpenInterestJob(ctx, job, connector, startTime)
+	}
+
 	// Fetch OHLCV data from exchange
-	log.Printf("[EXEC] About to call FetchOHLCVData for %s", jobID)
+	logging.Printf(ctx, "[EXEC] About to call FetchOHLCVData for %s", jobID)
 	fetchStartTime := time.Now()
-	candles, err := e.FetchOHLCVData(connector, job)
+	candles, err := e.FetchOHLCVData(ctx, connector, job)
 	fetchDuration := time.Since(fetchStartTime).Milliseconds()
-	log.Printf("[EXEC] FetchOHLCVData returned %d candles, err=%v", len(candles), err)
+	logging.Printf(ctx, "[EXEC] FetchOHLCVData returned %d candles, err=%v", len(candles), err)
 	if err != nil {
-		// Record failed API call for health monitoring
-		if healthErr := e.connectorRepo.RecordFailedCall(ctx, connector.ExchangeID, err.Error()); healthErr != nil {
-			log.Printf("[EXEC] Warning: Failed to record failed call for health: %v", healthErr)
-		}
-		// Handle error with retry logic
-		return e.handleExecutionError(ctx, job, err, startTime)
-	}
-
-	// Record successful API call for health monitoring
-	if healthErr := e.connectorRepo.RecordSuccessfulCall(ctx, connector.ExchangeID, fetchDuration); healthErr != nil {
-		log.Printf("[EXEC] Warning: Failed to record successful call for health: %v", healthErr)
+		return e.handleFetchFailure(ctx, connector, job, err, startTime)
 	}
+	e.recordFetchSuccess(ctx, connector, job, fetchDuration)
 
-	// Success - reset consecutive failures
-	if job.RunState.ConsecutiveFailures > 0 {
-		if resetErr := e.jobRepo.ResetConsecutiveFailures(ctx, jobID); resetErr != nil {
-			log.Printf("[EXEC] Warning: Failed to reset consecutive failures: %v", resetErr)
-		}
+	// Leave the still-forming candle for the run after it closes, so its
+	// provisional values are never stored and the cursor stays before it
+	if job.ExcludesUnclosedBar() && len(candles) > 0 && !models.CandleClosed(candles[0], job.Timeframe, time.Now()) {
+		logging.Printf(ctx, "[EXEC] Skipping unclosed candle at %d", candles[0].Timestamp)
+		candles = candles[1:]
 	}
 
-	// Calculate ALL indicators for the fetched candles
-	if len(candles) > 0 {
-		log.Printf("[EXEC] Calculating all indicators for %d candles", len(candles))
+	// Calculate ALL indicators for the fetched candles, unless the job stores
+	// raw OHLCV and leaves indicators to exports
+	if len(candles) > 0 && !job.ComputesIndicatorsOnIngest() {
+		logging.Printf(ctx, "[EXEC] Storing %d candles without indicators", len(candles))
+	} else if len(candles) > 0 {
+		indicatorConfig := e.configResolver.Resolve(ctx, job, connector)
+		logging.Printf(ctx, "[EXEC] Calculating indicators for %d candles with config '%s'", len(candles), indicatorConfig.Name)
 
-		// Calculate all indicators with default periods
-		candles, err = e.indicatorService.CalculateAll(candles)
+		// Calculate indicators with the job's resolved config
+		candles, err = e.indicatorService.CalculateWithConfig(candles, indicatorConfig)
 		if err != nil {
-			log.Printf("[EXEC] Warning: Indicator calculation failed: %v", err)
+			logging.Printf(ctx, "[EXEC] Warning: Indicator calculation failed: %v", err)
 			// Continue with storing candles even if indicator calculation fails
 		} else {
-			log.Printf("[EXEC] All indicators calculated successfully")
+			logging.Printf(ctx, "[EXEC] All indicators calculated successfully")
 		}
 	}
 
@@ -165,20 +247,9 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 	if len(candles) > 0 {
 		recordsStored, err = e.ohlcvRepo.UpsertCandles(ctx, connector.ExchangeID, job.Symbol, job.Timeframe, candles)
 		if err != nil {
-			errorMsg := err.Error()
-			nextRunTime := e.calculateNextRunTime(job)
-			if recErr := e.jobRepo.RecordRun(ctx, jobID, false, &nextRunTime, &errorMsg); recErr != nil {
-				errorMsg = fmt.Sprintf("%s (also failed to record run: %v)", errorMsg, recErr)
-			}
-
-			return &models.JobExecutionResult{
-				Success:         false,
-				Message:         "Failed to store OHLCV data",
-				RecordsFetched:  len(candles),
-				ExecutionTimeMs: time.Since(startTime).Milliseconds(),
-				Error:           &errorMsg,
-			}, nil
+			return e.storeFailed(ctx, job, "Failed to store OHLCV data", len(candles), err, startTime), nil
 		}
+		e.refreshQuality(job, recordsStored)
 
 		// Update cursor with the timestamp of the most recent candle
 		if len(candles) > 0 {
@@ -186,10 +257,74 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 			mostRecentCandle := candles[0]
 			lastCandleTime := time.UnixMilli(mostRecentCandle.Timestamp)
 			_ = e.jobRepo.UpdateCursor(ctx, jobID, lastCandleTime)
-			log.Printf("[EXEC] Updated cursor to most recent candle timestamp: %s", lastCandleTime.Format("2006-01-02 15:04:05"))
+			logging.Printf(ctx, "[EXEC] Updated cursor to most recent candle timestamp: %s", lastCandleTime.Format("2006-01-02 15:04:05"))
 		}
 	}
 
+	return e.completeRun(ctx, job, recordsStored, startTime), nil
+}
+
+// refreshQuality queues a quality refresh of the job when candles were stored
+// and a refresher is set
+func (e *JobExecutor) refreshQuality(job *models.Job, stored int) {
+	if e.qualityRefresher != nil && stored > 0 {
+		e.qualityRefresher.Schedule(job)
+	}
+}
+
+// handleFetchFailure records a failed exchange call for health monitoring and
+// the connector's circuit breaker, then applies the job's retry logic. The
+// result carries the classified cause in Err.
+func (e *JobExecutor) handleFetchFailure(ctx context.Context, connector *models.Connector, job *models.Job, err error, startTime time.Time) (*models.JobExecutionResult, error) {
+	if healthErr := e.connectorRepo.RecordFailedCall(ctx, connector.ExchangeID, err.Error()); healthErr != nil {
+		logging.Printf(ctx, "[EXEC] Warning: Failed to record failed call for health: %v", healthErr)
+	}
+	e.circuitBreaker.RecordFailure(ctx, connector, connector.Health.ConsecutiveFailures+1)
+
+	callErr := classifyExchangeError(err, connector.ExchangeID, job.Symbol)
+	result, execErr := e.handleExecutionError(ctx, job, callErr, startTime)
+	if result != nil {
+		result.Err = callErr
+	}
+	return result, execErr
+}
+
+// recordFetchSuccess records a successful exchange call for health monitoring
+// and the connector's circuit breaker, and resets the job's failure count
+func (e *JobExecutor) recordFetchSuccess(ctx context.Context, connector *models.Connector, job *models.Job, fetchDurationMs int64) {
+	if healthErr := e.connectorRepo.RecordSuccessfulCall(ctx, connector.ExchangeID, fetchDurationMs); healthErr != nil {
+		logging.Printf(ctx, "[EXEC] Warning: Failed to record successful call for health: %v", healthErr)
+	}
+	e.circuitBreaker.RecordSuccess(ctx, connector)
+
+	if job.RunState.ConsecutiveFailures > 0 {
+		if resetErr := e.jobRepo.ResetConsecutiveFailures(ctx, job.ID.Hex()); resetErr != nil {
+			logging.Printf(ctx, "[EXEC] Warning: Failed to reset consecutive failures: %v", resetErr)
+		}
+	}
+}
+
+// storeFailed records a run whose fetched data could not be stored
+func (e *JobExecutor) storeFailed(ctx context.Context, job *models.Job, message string, recordsFetched int, err error, startTime time.Time) *models.JobExecutionResult {
+	errorMsg := err.Error()
+	nextRunTime := e.calculateNextRunTime(job)
+	if recErr := e.jobRepo.RecordRun(ctx, job.ID.Hex(), false, &nextRunTime, &errorMsg); recErr != nil {
+		errorMsg = fmt.Sprintf("%s (also failed to record run: %v)", errorMsg, recErr)
+	}
+
+	return &models.JobExecutionResult{
+		Success:         false,
+		Message:         message,
+		RecordsFetched:  recordsFetched,
+		ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+		Error:           &errorMsg,
+	}
+}
+
+// completeRun records a successful run and schedules the next one
+func (e *JobExecutor) completeRun(ctx context.Context, job *models.Job, recordsStored int, startTime time.Time) *models.JobExecutionResult {
+	jobID := job.ID.Hex()
+
 	// Calculate next run time
 	nextRunTime := e.calculateNextRunTime(job)
 
@@ -206,7 +341,7 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 			ExecutionTimeMs: time.Since(startTime).Milliseconds(),
 			NextRunTime:     nextRunTime,
 			Error:           &errorMsg,
-		}, nil
+		}
 	}
 	fmt.Printf("[DEBUG EXECUTOR] RecordRun succeeded for job %s\n", jobID)
 
@@ -216,13 +351,14 @@ func (e *JobExecutor) ExecuteJob(ctx context.Context, jobID string) (*models.Job
 		RecordsFetched:  recordsStored,
 		ExecutionTimeMs: time.Since(startTime).Milliseconds(),
 		NextRunTime:     nextRunTime,
-	}, nil
+	}
 }
 
-// FetchOHLCVData fetches OHLCV data from the exchange using CCXT
-func (e *JobExecutor) FetchOHLCVData(connector *models.Connector, job *models.Job) ([]models.Candle, error) {
+// FetchOHLCVData fetches OHLCV data from the exchange using CCXT. The fetch
+// isn't bound by ctx's deadline, only its request ID is kept for logging.
+func (e *JobExecutor) FetchOHLCVData(ctx context.Context, connector *models.Connector, job *models.Job) ([]models.Candle, error) {
 	// Use background context with timeout for the fetch operation
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute) // Allow 30 minutes for large historical fetches
+	ctx, cancel := context.WithTimeout(logging.Detach(ctx), 30*time.Minute) // Allow 30 minutes for large historical fetches
 	defer cancel()
 
 	return e.FetchOHLCVDataWithContext(ctx, connector, job)
@@ -230,7 +366,7 @@ func (e *JobExecutor) FetchOHLCVData(connector *models.Connector, job *models.Jo
 
 // FetchOHLCVDataWithContext fetches OHLCV data with context support for cancellation and rate limiting
 func (e *JobExecutor) FetchOHLCVDataWithContext(ctx context.Context, connector *models.Connector, job *models.Job) ([]models.Candle, error) {
-	log.Printf("[FETCH_START] Starting FetchOHLCVData for %s/%s", job.Symbol, job.Timeframe)
+	logging.Printf(ctx, "[FETCH_START] Starting FetchOHLCVData for %s/%s", job.Symbol, job.Timeframe)
 
 	var sinceMs *int64
 	var isFirstExecution bool
@@ -240,27 +376,31 @@ func (e *JobExecutor) FetchOHLCVDataWithContext(ctx context.Context, connector *
 		// FIRST EXECUTION: Fetch ALL available data (no since, no limit)
 		isFirstExecution = true
 		sinceMs = nil
-		log.Printf("[FETCH] First execution for %s/%s - fetching ALL available data (no since, no limit)",
+		logging.Printf(ctx, "[FETCH] First execution for %s/%s - fetching ALL available data (no since, no limit)",
 			job.Symbol, job.Timeframe)
 	} else {
 		// SUBSEQUENT EXECUTION: Fetch only NEW candles from last candle timestamp
 		isFirstExecution = false
-		// Add one timeframe duration to avoid fetching the last candle again
-		timeframeDuration, _ := parseTimeframe(job.Timeframe)
-		nextTimestamp := job.Cursor.LastCandleTime.Add(timeframeDuration)
-		sinceTimestamp := nextTimestamp.UnixMilli()
+		// Start at the next bar to avoid fetching the last candle again
+		if _, err := models.GetTimeframeMs(job.Timeframe); err != nil {
+			return nil, fmt.Errorf("invalid job timeframe: %w", err)
+		}
+		sinceTimestamp := models.NextBarTime(job.Timeframe, job.Cursor.LastCandleTime.UnixMilli())
 		sinceMs = &sinceTimestamp
-		log.Printf("[FETCH] Subsequent execution for %s/%s - fetching from timestamp %d (after last candle, no limit)",
+		logging.Printf(ctx, "[FETCH] Subsequent execution for %s/%s - fetching from timestamp %d (after last candle, no limit)",
 			job.Symbol, job.Timeframe, sinceTimestamp)
 	}
 
-	// Fetch real OHLCV data from exchange using CCXT with rate limiting
-	candles, err := e.ccxtService.FetchOHLCVDataWithContext(
+	// Fetch real OHLCV data from exchange using CCXT with rate limiting.
+	// Subsequent runs page forward to catch up, up to the per-run cap.
+	candles, err := e.ccxtService.FetchOHLCVDataWithLimit(
 		ctx,
 		connector.ExchangeID,
 		job.Symbol,
 		job.Timeframe,
 		sinceMs, // nil for first, timestamp for subsequent
+		e.config.HistoricalData.MaxCandlesPerRun,
+		e.requestTimeoutMs(connector),
 	)
 
 	if err != nil {
@@ -268,48 +408,32 @@ func (e *JobExecutor) FetchOHLCVDataWithContext(ctx context.Context, connector *
 	}
 
 	if isFirstExecution {
-		log.Printf("[FETCH] First execution complete - fetched %d historical candles", len(candles))
+		logging.Printf(ctx, "[FETCH] First execution complete - fetched %d historical candles", len(candles))
 	} else {
-		log.Printf("[FETCH] Subsequent execution complete - fetched %d new candles", len(candles))
+		logging.Printf(ctx, "[FETCH] Subsequent execution complete - fetched %d new candles", len(candles))
 	}
 
 	return candles, nil
 }
 
+// requestTimeoutMs returns the exchange request timeout of a connector,
+// falling back to the global EXCHANGE_REQUEST_TIMEOUT
+func (e *JobExecutor) requestTimeoutMs(connector *models.Connector) int {
+	if connector.RequestTimeoutMs > 0 {
+		return connector.RequestTimeoutMs
+	}
+	return e.config.Exchange.RequestTimeout
+}
+
 // calculateNextRunTime calculates when the job should run next
 func (e *JobExecutor) calculateNextRunTime(job *models.Job) time.Time {
-	// Parse timeframe to duration
-	duration, err := parseTimeframe(job.Timeframe)
+	durationMs, err := models.GetTimeframeMs(job.Timeframe)
 	if err != nil {
-		// Default to 5 minutes if parsing fails
+		// Default to 5 minutes for an unknown timeframe
 		return time.Now().Add(5 * time.Minute)
 	}
 
-	return time.Now().Add(duration)
-}
-
-// parseTimeframe converts a timeframe string to a duration
-func parseTimeframe(timeframe string) (time.Duration, error) {
-	switch timeframe {
-	case "1m":
-		return 1 * time.Minute, nil
-	case "5m":
-		return 5 * time.Minute, nil
-	case "15m":
-		return 15 * time.Minute, nil
-	case "30m":
-		return 30 * time.Minute, nil
-	case "1h":
-		return 1 * time.Hour, nil
-	case "4h":
-		return 4 * time.Hour, nil
-	case "1d":
-		return 24 * time.Hour, nil
-	case "1w":
-		return 7 * 24 * time.Hour, nil
-	default:
-		return 0, fmt.Errorf("unknown timeframe: %s", timeframe)
-	}
+	return time.Now().Add(time.Duration(durationMs) * time.Millisecond)
 }
 
 // handleExecutionError handles execution errors with retry logic
@@ -323,7 +447,7 @@ func (e *JobExecutor) handleExecutionError(ctx context.Context, job *models.Job,
 	// Increment consecutive failures
 	consecutiveFailures := job.RunState.ConsecutiveFailures + 1
 	if incErr := e.jobRepo.IncrementConsecutiveFailures(ctx, jobID); incErr != nil {
-		log.Printf("[EXEC] Warning: Failed to increment consecutive failures: %v", incErr)
+		logging.Printf(ctx, "[EXEC] Warning: Failed to increment consecutive failures: %v", incErr)
 	}
 
 	var nextRunTime time.Time
@@ -335,22 +459,22 @@ func (e *JobExecutor) handleExecutionError(ctx context.Context, job *models.Job,
 		nextRunTime = time.Now().Add(time.Duration(backoffSeconds) * time.Second)
 		message = fmt.Sprintf("Transient error (attempt %d/%d), will retry in %ds",
 			consecutiveFailures, MaxRetryAttempts, backoffSeconds)
-		log.Printf("[EXEC] %s: %s - %s", jobID, message, errorMsg)
+		logging.Printf(ctx, "[EXEC] %s: %s - %s", jobID, message, errorMsg)
 	} else if consecutiveFailures >= MaxRetryAttempts {
 		// Max retries exceeded - use normal schedule but keep error
 		nextRunTime = e.calculateNextRunTime(job)
 		message = fmt.Sprintf("Max retry attempts (%d) exceeded, resuming normal schedule", MaxRetryAttempts)
-		log.Printf("[EXEC] %s: %s", jobID, message)
+		logging.Printf(ctx, "[EXEC] %s: %s", jobID, message)
 
 		// Reset consecutive failures to allow future retries
 		if resetErr := e.jobRepo.ResetConsecutiveFailures(ctx, jobID); resetErr != nil {
-			log.Printf("[EXEC] Warning: Failed to reset consecutive failures: %v", resetErr)
+			logging.Printf(ctx, "[EXEC] Warning: Failed to reset consecutive failures: %v", resetErr)
 		}
 	} else {
 		// Non-transient error - use normal schedule
 		nextRunTime = e.calculateNextRunTime(job)
 		message = "Non-transient error, scheduled for normal retry"
-		log.Printf("[EXEC] %s: %s - %s", jobID, message, errorMsg)
+		logging.Printf(ctx, "[EXEC] %s: %s - %s", jobID, message, errorMsg)
 	}
 
 	// Record the failed run
@@ -375,6 +499,14 @@ func isTransientError(err error) bool {
 		return false
 	}
 
+	// Classified exchange errors
+	switch {
+	case errors.Is(err, ErrSymbolNotFound):
+		return false
+	case errors.Is(err, ErrRateLimited), errors.Is(err, ErrExchangeUnavailable):
+		return true
+	}
+
 	errStr := strings.ToLower(err.Error())
 
 	// Transient errors that should be retried
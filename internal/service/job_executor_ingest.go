@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// IngestCandles stores candles pushed for an OHLCV job from a source other than
+// CCXT under the job's exchange, symbol and timeframe. The candles must already
+// have passed models.ValidateCandle; any indicators they carry are replaced by
+// ones calculated with the job's indicator config, as for fetched candles, or
+// dropped when the job doesn't compute indicators on ingest. Indicators are
+// calculated over the stored candles around the batch too, with up to
+// previewWarmupBars stored bars before it as warm-up, so a short batch gets
+// the same values a recalculation would give it.
+// Candles whose timestamp is already stored are not overwritten; they are
+// skipped and returned as duplicates, so a correction has to delete the range
+// first. Returns the number of candles stored and of duplicates skipped.
+func (e *JobExecutor) IngestCandles(ctx context.Context, job *models.Job, candles []models.Candle) (int, int, error) {
+	if job.GetJobType() != models.JobTypeOHLCV {
+		return 0, 0, fmt.Errorf("job %s is a %s job, not ohlcv", job.ID.Hex(), job.GetJobType())
+	}
+	if len(candles) == 0 {
+		return 0, 0, nil
+	}
+
+	// Candles are stored and their indicators calculated newest first
+	sorted := make([]models.Candle, len(candles))
+	copy(sorted, candles)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp > sorted[j].Timestamp })
+	for i := range sorted {
+		sorted[i].Indicators = models.Indicators{}
+	}
+
+	oldest, newest := sorted[len(sorted)-1].Timestamp, sorted[0].Timestamp
+	warmupStart := oldest
+	if barMs, err := models.GetTimeframeMs(job.Timeframe); err == nil {
+		warmupStart -= previewWarmupBars * barMs
+	}
+	history, err := e.ohlcvRepo.FindCandlesInRange(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, warmupStart, newest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load stored candles: %w", err)
+	}
+	pushed, history := splitIngestBatch(sorted, history, previewWarmupBars)
+	duplicates := len(sorted) - len(pushed)
+	if len(pushed) == 0 {
+		logging.Printf(ctx, "[INGEST] All %d pushed candles for job %s are already stored", duplicates, job.ID.Hex())
+		return 0, duplicates, nil
+	}
+
+	if job.ComputesIndicatorsOnIngest() {
+		// The connector only supplies a fallback indicator config, so ingest
+		// doesn't depend on it being active
+		connector, err := e.connectorRepo.FindByExchangeID(ctx, job.ConnectorExchangeID)
+		if err != nil {
+			connector = nil
+		}
+		indicatorConfig := e.configResolver.Resolve(ctx, job, connector)
+
+		// Stored candles are recalculated alongside, but only pushed ones are kept
+		series := make([]models.Candle, 0, len(pushed)+len(history))
+		series = append(series, pushed...)
+		for _, c := range history {
+			c.Indicators = models.Indicators{}
+			series = append(series, c)
+		}
+		sort.Slice(series, func(i, j int) bool { return series[i].Timestamp > series[j].Timestamp })
+
+		withIndicators, err := e.indicatorService.CalculateWithConfig(series, indicatorConfig)
+		if err != nil {
+			logging.Printf(ctx, "[INGEST] Warning: Indicator calculation failed for job %s: %v", job.ID.Hex(), err)
+		} else {
+			isPushed := make(map[int64]bool, len(pushed))
+			for _, c := range pushed {
+				isPushed[c.Timestamp] = true
+			}
+			pushed = pushed[:0]
+			for _, c := range withIndicators {
+				if isPushed[c.Timestamp] {
+					pushed = append(pushed, c)
+				}
+			}
+		}
+	}
+
+	stored, err := e.ohlcvRepo.UpsertCandles(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, pushed)
+	if err != nil {
+		return 0, duplicates, fmt.Errorf("failed to store candles: %w", err)
+	}
+	e.refreshQuality(job, stored)
+	logging.Printf(ctx, "[INGEST] Stored %d pushed candles for job %s (%s %s), skipped %d already stored", stored, job.ID.Hex(), job.Symbol, job.Timeframe, duplicates)
+	return stored, duplicates, nil
+}
+
+// splitIngestBatch drops the pushed candles whose timestamp is in stored, and
+// trims stored to the candles within the batch's range plus warmupBars before
+// it. Both slices are newest first. Returns the candles to store and the
+// stored ones to calculate their indicators with.
+func splitIngestBatch(pushed, stored []models.Candle, warmupBars int) ([]models.Candle, []models.Candle) {
+	oldest := pushed[len(pushed)-1].Timestamp
+	before := sort.Search(len(stored), func(i int) bool { return stored[i].Timestamp < oldest })
+	stored = stored[:min(len(stored), before+warmupBars)]
+
+	storedAt := make(map[int64]bool, len(stored))
+	for _, c := range stored {
+		storedAt[c.Timestamp] = true
+	}
+	fresh := make([]models.Candle, 0, len(pushed))
+	for _, c := range pushed {
+		if !storedAt[c.Timestamp] {
+			fresh = append(fresh, c)
+		}
+	}
+	return fresh, stored
+}
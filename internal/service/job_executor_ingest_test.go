@@ -0,0 +1,50 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// hourlyCandles returns candles opening at the given hours, newest first
+func hourlyCandles(hours ...int64) []models.Candle {
+	candles := make([]models.Candle, len(hours))
+	for i, h := range hours {
+		candles[i] = models.Candle{Timestamp: h * 3_600_000, Open: 100, High: 101, Low: 99, Close: 100, Volume: 1}
+	}
+	return candles
+}
+
+func timestamps(candles []models.Candle) []int64 {
+	ts := make([]int64, len(candles))
+	for i, c := range candles {
+		ts[i] = c.Timestamp
+	}
+	return ts
+}
+
+func TestSplitIngestBatch(t *testing.T) {
+	// Bars 10-12 are pushed into a gap of a series stored up to bar 11, with
+	// bar 11 already stored
+	pushed := hourlyCandles(12, 11, 10)
+	stored := hourlyCandles(11, 9, 8, 7, 6, 5)
+
+	fresh, history := splitIngestBatch(pushed, stored, 3)
+
+	if want := timestamps(hourlyCandles(12, 10)); !reflect.DeepEqual(timestamps(fresh), want) {
+		t.Errorf("fresh = %v, want %v", timestamps(fresh), want)
+	}
+	// The stored bar inside the batch and the three bars before it
+	if want := timestamps(hourlyCandles(11, 9, 8, 7)); !reflect.DeepEqual(timestamps(history), want) {
+		t.Errorf("history = %v, want %v", timestamps(history), want)
+	}
+}
+
+func TestSplitIngestBatchAllStored(t *testing.T) {
+	pushed := hourlyCandles(3, 2)
+	fresh, _ := splitIngestBatch(pushed, hourlyCandles(3, 2, 1), 10)
+	if len(fresh) != 0 {
+		t.Errorf("fresh = %v, want none for a re-push of stored candles", timestamps(fresh))
+	}
+}
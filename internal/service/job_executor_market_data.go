@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// executeTradesJob fetches the trades made since the job's cursor and stores
+// them in the trades collection
+func (e *JobExecutor) executeTradesJob(ctx context.Context, job *models.Job, connector *models.Connector, startTime time.Time) (*models.JobExecutionResult, error) {
+	// The first run fetches the latest page of trades; later runs page
+	// forward from the last stored trade
+	var sinceMs *int64
+	if job.Cursor.LastCandleTime != nil {
+		since := job.Cursor.LastCandleTime.UnixMilli()
+		sinceMs = &since
+	}
+
+	fetchCtx, cancel := context.WithTimeout(logging.Detach(ctx), 30*time.Minute)
+	defer cancel()
+
+	fetchStartTime := time.Now()
+	trades, err := e.ccxtService.FetchTrades(fetchCtx, connector.ExchangeID, job.Symbol, sinceMs,
+		e.config.HistoricalData.MaxTradesPerRun, e.requestTimeoutMs(connector))
+	fetchDuration := time.Since(fetchStartTime).Milliseconds()
+	logging.Printf(ctx, "[EXEC] FetchTrades returned %d trades for %s, err=%v", len(trades), job.ID.Hex(), err)
+	if err != nil {
+		return e.handleFetchFailure(ctx, connector, job, err, startTime)
+	}
+	e.recordFetchSuccess(ctx, connector, job, fetchDuration)
+
+	recordsStored := 0
+	if len(trades) > 0 {
+		recordsStored, err = e.tradeRepo.UpsertTrades(ctx, connector.ExchangeID, job.Symbol, trades)
+		if err != nil {
+			return e.storeFailed(ctx, job, "Failed to store trades", len(trades), err, startTime), nil
+		}
+
+		lastTradeMs := trades[0].Timestamp
+		for _, trade := range trades {
+			lastTradeMs = max(lastTradeMs, trade.Timestamp)
+		}
+		lastTradeTime := time.UnixMilli(lastTradeMs)
+		_ = e.jobRepo.UpdateCursor(ctx, job.ID.Hex(), lastTradeTime)
+		logging.Printf(ctx, "[EXEC] Updated cursor to most recent trade timestamp: %s", lastTradeTime.Format("2006-01-02 15:04:05"))
+	}
+
+	return e.completeRun(ctx, job, recordsStored, startTime), nil
+}
+
+// executeOrderBookJob takes an order book snapshot and stores it in the
+// order book collection
+func (e *JobExecutor) executeOrderBookJob(ctx context.Context, job *models.Job, connector *models.Connector, startTime time.Time) (*models.JobExecutionResult, error) {
+	fetchCtx, cancel := context.WithTimeout(logging.Detach(ctx), 5*time.Minute)
+	defer cancel()
+
+	fetchStartTime := time.Now()
+	snapshot, err := e.ccxtService.FetchOrderBook(fetchCtx, connector.ExchangeID, job.Symbol,
+		job.GetOrderBookDepth(), e.requestTimeoutMs(connector))
+	fetchDuration := time.Since(fetchStartTime).Milliseconds()
+	if err != nil {
+		logging.Printf(ctx, "[EXEC] FetchOrderBook failed for %s: %v", job.ID.Hex(), err)
+		return e.handleFetchFailure(ctx, connector, job, err, startTime)
+	}
+	e.recordFetchSuccess(ctx, connector, job, fetchDuration)
+
+	stored, err := e.orderBookRepo.InsertSnapshot(ctx, connector.ExchangeID, job.Symbol, snapshot)
+	if err != nil {
+		return e.storeFailed(ctx, job, "Failed to store order book snapshot", 1, err, startTime), nil
+	}
+
+	recordsStored := 0
+	if stored {
+		recordsStored = 1
+		_ = e.jobRepo.UpdateCursor(ctx, job.ID.Hex(), time.UnixMilli(snapshot.Timestamp))
+	} else {
+		logging.Printf(ctx, "[EXEC] Order book snapshot at %d already stored for %s", snapshot.Timestamp, job.ID.Hex())
+	}
+
+	return e.completeRun(ctx, job, recordsStored, startTime), nil
+}
+
+// seriesSince returns the timestamp a funding or open interest job fetches
+// from: just after its cursor, or the historical start date on the first run
+// of a job collecting history. nil fetches the latest page.
+func (e *JobExecutor) seriesSince(job *models.Job) *int64 {
+	if job.Cursor.LastCandleTime != nil {
+		since := job.Cursor.LastCandleTime.UnixMilli() + 1
+		return &since
+	}
+	if job.CollectHistorical {
+		since := e.config.HistoricalData.GetHistoricalStartDate(job.Timeframe).UnixMilli()
+		return &since
+	}
+	return nil
+}
+
+// executeFundingJob fetches the funding rates settled since the job's cursor
+// and stores them in the funding rates collection
+func (e *JobExecutor) executeFundingJob(ctx context.Context, job *models.Job, connector *models.Connector, startTime time.Time) (*models.JobExecutionResult, error) {
+	fetchCtx, cancel := context.WithTimeout(logging.Detach(ctx), 30*time.Minute)
+	defer cancel()
+
+	fetchStartTime := time.Now()
+	rates, err := e.ccxtService.FetchFundingRateHistory(fetchCtx, connector.ExchangeID, job.Symbol, e.seriesSince(job),
+		e.config.HistoricalData.MaxCandlesPerRun, e.requestTimeoutMs(connector))
+	fetchDuration := time.Since(fetchStartTime).Milliseconds()
+	logging.Printf(ctx, "[EXEC] FetchFundingRateHistory returned %d rates for %s, err=%v", len(rates), job.ID.Hex(), err)
+	if err != nil {
+		return e.handleFetchFailure(ctx, connector, job, err, startTime)
+	}
+	e.recordFetchSuccess(ctx, connector, job, fetchDuration)
+
+	recordsStored := 0
+	if len(rates) > 0 {
+		recordsStored, err = e.derivativesRepo.UpsertFundingRates(ctx, connector.ExchangeID, job.Symbol, rates)
+		if err != nil {
+			return e.storeFailed(ctx, job, "Failed to store funding rates", len(rates), err, startTime), nil
+		}
+
+		lastMs := rates[0].Timestamp
+		for _, rate := range rates {
+			lastMs = max(lastMs, rate.Timestamp)
+		}
+		_ = e.jobRepo.UpdateCursor(ctx, job.ID.Hex(), time.UnixMilli(lastMs))
+	}
+
+	return e.completeRun(ctx, job, recordsStored, startTime), nil
+}
+
+// executeOpenInterestJob fetches the open interest at the job's timeframe
+// since its cursor and stores it in the open interest collection
+func (e *JobExecutor) executeOpenInterestJob(ctx context.Context, job *models.Job, connector *models.Connector, startTime time.Time) (*models.JobExecutionResult, error) {
+	fetchCtx, cancel := context.WithTimeout(logging.Detach(ctx), 30*time.Minute)
+	defer cancel()
+
+	fetchStartTime := time.Now()
+	points, err := e.ccxtService.FetchOpenInterestHistory(fetchCtx, connector.ExchangeID, job.Symbol, job.Timeframe,
+		e.seriesSince(job), e.config.HistoricalData.MaxCandlesPerRun, e.requestTimeoutMs(connector))
+	fetchDuration := time.Since(fetchStartTime).Milliseconds()
+	logging.Printf(ctx, "[EXEC] FetchOpenInterestHistory returned %d points for %s, err=%v", len(points), job.ID.Hex(), err)
+	if err != nil {
+		return e.handleFetchFailure(ctx, connector, job, err, startTime)
+	}
+	e.recordFetchSuccess(ctx, connector, job, fetchDuration)
+
+	recordsStored := 0
+	if len(points) > 0 {
+		recordsStored, err = e.derivativesRepo.UpsertOpenInterest(ctx, connector.ExchangeID, job.Symbol, points)
+		if err != nil {
+			return e.storeFailed(ctx, job, "Failed to store open interest", len(points), err, startTime), nil
+		}
+
+		lastMs := points[0].Timestamp
+		for _, point := range points {
+			lastMs = max(lastMs, point.Timestamp)
+		}
+		_ = e.jobRepo.UpdateCursor(ctx, job.ID.Hex(), time.UnixMilli(lastMs))
+	}
+
+	return e.completeRun(ctx, job, recordsStored, startTime), nil
+}
@@ -5,9 +5,15 @@ import (
 	"log"
 	"time"
 
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 )
 
+// DefaultReconcileSpread is the window overdue jobs are spread over when their
+// schedule is reconciled
+const DefaultReconcileSpread = 5 * time.Minute
+
 // JobScheduler handles automatic job execution
 type JobScheduler struct {
 	jobRepo    *repository.JobRepository
@@ -97,3 +103,65 @@ func (s *JobScheduler) checkAndExecuteJobs() {
 		}(job.ID.Hex())
 	}
 }
+
+// ReconcileSchedule reschedules active jobs whose next run time is more than
+// one timeframe in the past, e.g. after the scheduler was down, so they don't
+// all fire at once. Overdue jobs are spread evenly over the next spread
+// (DefaultReconcileSpread when not positive), most overdue first.
+func (s *JobScheduler) ReconcileSchedule(ctx context.Context, spread time.Duration) (*models.ScheduleReconcileResult, error) {
+	if spread <= 0 {
+		spread = DefaultReconcileSpread
+	}
+
+	now := time.Now()
+	jobs, err := s.jobRepo.FindOverdueJobs(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rescheduled := staggerOverdueJobs(jobs, now, spread)
+	adjusted, err := s.jobRepo.RescheduleJobs(ctx, rescheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Printf(ctx, "[SCHEDULER] Rescheduled %d of %d overdue job(s) over %s", adjusted, len(jobs), spread)
+
+	return &models.ScheduleReconcileResult{
+		Checked:       len(jobs),
+		Adjusted:      int(adjusted),
+		SpreadSeconds: int(spread / time.Second),
+		Jobs:          rescheduled,
+	}, nil
+}
+
+// staggerOverdueJobs plans new next run times for the jobs, sorted most
+// overdue first, that are more than one timeframe past due. The i-th of n
+// such jobs runs at now + spread*i/n.
+func staggerOverdueJobs(jobs []*models.Job, now time.Time, spread time.Duration) []models.RescheduledJob {
+	var overdue []*models.Job
+	for _, job := range jobs {
+		if job.RunState.NextRunTime == nil {
+			continue
+		}
+		interval := 5 * time.Minute
+		if durationMs, err := models.GetTimeframeMs(job.Timeframe); err == nil {
+			interval = time.Duration(durationMs) * time.Millisecond
+		}
+		if now.Sub(*job.RunState.NextRunTime) > interval {
+			overdue = append(overdue, job)
+		}
+	}
+
+	rescheduled := make([]models.RescheduledJob, 0, len(overdue))
+	for i, job := range overdue {
+		rescheduled = append(rescheduled, models.RescheduledJob{
+			JobID:               job.ID,
+			Symbol:              job.Symbol,
+			Timeframe:           job.Timeframe,
+			PreviousNextRunTime: *job.RunState.NextRunTime,
+			NextRunTime:         now.Add(spread * time.Duration(i) / time.Duration(len(overdue))),
+		})
+	}
+	return rescheduled
+}
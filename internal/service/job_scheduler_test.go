@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func overdueTestJob(timeframe string, nextRun time.Time) *models.Job {
+	return &models.Job{
+		Symbol:    "BTC/USDT",
+		Timeframe: timeframe,
+		RunState:  models.RunState{NextRunTime: &nextRun},
+	}
+}
+
+func TestStaggerOverdueJobs(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	jobs := []*models.Job{
+		overdueTestJob("1h", now.Add(-3*time.Hour)),
+		overdueTestJob("1m", now.Add(-10*time.Minute)),
+		overdueTestJob("1d", now.Add(-2*time.Hour)),   // Less than one timeframe late
+		overdueTestJob("12h", now.Add(-2*time.Hour)),  // Less than one timeframe late
+		overdueTestJob("5m", now.Add(-5*time.Minute)), // Exactly one timeframe late
+		overdueTestJob("bogus", now.Add(-6*time.Minute)),
+		{Timeframe: "1m"},
+	}
+
+	rescheduled := staggerOverdueJobs(jobs, now, 6*time.Minute)
+	if len(rescheduled) != 3 {
+		t.Fatalf("rescheduled %d jobs, want 3", len(rescheduled))
+	}
+
+	wantTimeframes := []string{"1h", "1m", "bogus"}
+	for i, job := range rescheduled {
+		if job.Timeframe != wantTimeframes[i] {
+			t.Errorf("job %d timeframe = %s, want %s", i, job.Timeframe, wantTimeframes[i])
+		}
+		if want := now.Add(time.Duration(i) * 2 * time.Minute); !job.NextRunTime.Equal(want) {
+			t.Errorf("job %d next run = %s, want %s", i, job.NextRunTime, want)
+		}
+	}
+	if !rescheduled[0].PreviousNextRunTime.Equal(now.Add(-3 * time.Hour)) {
+		t.Errorf("previous next run = %s", rescheduled[0].PreviousNextRunTime)
+	}
+
+	if got := staggerOverdueJobs(nil, now, time.Minute); len(got) != 0 {
+		t.Errorf("no jobs rescheduled %d", len(got))
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// Generated columns always come out in this canonical order, whatever order
+// the feature config lists them in, so column indices only change when the
+// selection does:
+//
+//  1. timestamp, open, high, low, close, volume
+//  2. indicators: trend, momentum, volatility then volume, each in the
+//     order of storedIndicatorFields
+//  3. price, temporal, cross then derivatives features, in
+//     priceFeatureOrder, temporalFeatureOrder, crossFeatureOrder and
+//     derivativesFeatureOrder
+//  4. lagged columns, by the position of the lagged column, then lag period
+//  5. rolling columns, by the position of the rolled column, then window,
+//     then stat in rollingStatOrder
+//  6. auxiliary columns, by auxiliary source, then in the order the source
+//     lists its columns
+//  7. targets, in the order of the target specs
+//
+// MLExportConfig.ColumnOrder moves the columns it lists to the front.
+var (
+	priceFeatureOrder = []string{
+		"returns", "log_returns", "price_change", "volatility", "gaps",
+		"body_ratio", "range_pct", "upper_wick", "lower_wick", "frac_diff",
+	}
+	temporalFeatureOrder = []string{
+		"hour", "hour_sin", "hour_cos", "day_of_week", "dow_sin", "dow_cos",
+		"day_of_month", "month", "month_sin", "month_cos", "is_weekend", "quarter",
+		"market_session", "bar_gap",
+	}
+	crossFeatureOrder = []string{
+		"bb_position", "price_vs_sma20", "price_vs_sma50", "price_vs_sma200",
+		"ma_crossover", "rsi_oversold", "rsi_overbought",
+	}
+	derivativesFeatureOrder = []string{
+		"funding_rate", "open_interest", "open_interest_value",
+	}
+	rollingStatOrder = []string{"mean", "std", "min", "max", "median"}
+)
+
+// canonicalSelection returns the names of order that are in selected, in
+// canonical order. Unknown and repeated names are dropped.
+func canonicalSelection(order, selected []string) []string {
+	want := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		want[name] = true
+	}
+
+	var names []string
+	for _, name := range order {
+		if want[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sortedUnique returns the distinct values in ascending order
+func sortedUnique(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	var unique []int
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
+
+// byColumnPosition returns the names that are matrix columns, ordered by
+// their position in the matrix
+func byColumnPosition(matrix *models.FeatureMatrix, names []string) []string {
+	want := make(map[string]bool, len(names))
+	for _, name := range names {
+		want[name] = true
+	}
+
+	var ordered []string
+	for _, col := range matrix.Columns {
+		if want[col] {
+			ordered = append(ordered, col)
+			delete(want, col)
+		}
+	}
+	return ordered
+}
+
+// applyColumnOrder moves the listed columns to the front of the matrix, in
+// the listed order; the others keep their order after them. Listed columns
+// the matrix doesn't have (e.g. dropped during preprocessing) are ignored.
+func applyColumnOrder(matrix *models.FeatureMatrix, order []string) {
+	if len(order) == 0 {
+		return
+	}
+
+	index := make(map[string]int, len(matrix.Columns))
+	for i, col := range matrix.Columns {
+		index[col] = i
+	}
+
+	positions := make([]int, 0, len(matrix.Columns))
+	placed := make(map[int]bool, len(order))
+	for _, col := range order {
+		if i, ok := index[col]; ok && !placed[i] {
+			positions = append(positions, i)
+			placed[i] = true
+		}
+	}
+	for i := range matrix.Columns {
+		if !placed[i] {
+			positions = append(positions, i)
+		}
+	}
+
+	columns := make([]string, len(positions))
+	for j, i := range positions {
+		columns[j] = matrix.Columns[i]
+	}
+	matrix.Columns = columns
+
+	// Schema is kept per column when present
+	if len(matrix.Schema) == len(positions) {
+		schema := make([]models.FeatureSchema, len(positions))
+		for j, i := range positions {
+			schema[j] = matrix.Schema[i]
+		}
+		matrix.Schema = schema
+	}
+
+	for r, row := range matrix.Data {
+		reordered := make([]float64, len(positions))
+		for j, i := range positions {
+			reordered[j] = row[i]
+		}
+		matrix.Data[r] = reordered
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestFeatureColumnOrderIsCanonical(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	config := models.FeatureConfig{
+		IncludeOHLCV:       true,
+		SpecificIndicators: []string{"rsi14", "sma20"},
+		PriceFeatures:      []string{"log_returns", "returns", "returns"},
+		TemporalFeatures:   []string{"dow_sin", "hour"},
+		CrossFeatures:      []string{"rsi_oversold", "bb_position"},
+		LaggedFeatures:     models.LagConfig{Enabled: true, LagPeriods: []int{5, 1}, LagFeatures: []string{"returns", "close"}},
+		RollingFeatures:    models.RollingConfig{Enabled: true, Windows: []int{10, 5}, Stats: []string{"std", "mean"}},
+	}
+
+	want := []string{
+		"open", "high", "low", "close",
+		"sma20", "rsi14",
+		"returns", "log_returns",
+		"hour", "dow_sin",
+		"bb_position", "rsi_oversold",
+		"close_lag_1", "close_lag_5", "returns_lag_1", "returns_lag_5",
+		"close_roll_5_mean", "close_roll_5_std", "close_roll_10_mean", "close_roll_10_std",
+	}
+
+	// The same selection listed in another order yields the same columns, every run
+	reordered := config
+	reordered.PriceFeatures = []string{"returns", "log_returns"}
+	reordered.TemporalFeatures = []string{"hour", "dow_sin"}
+	reordered.CrossFeatures = []string{"bb_position", "rsi_oversold"}
+	reordered.LaggedFeatures.LagPeriods = []int{1, 5}
+	reordered.LaggedFeatures.LagFeatures = []string{"close", "returns"}
+	reordered.RollingFeatures.Windows = []int{5, 10}
+	reordered.RollingFeatures.Stats = []string{"mean", "std"}
+
+	for run, cfg := range []models.FeatureConfig{config, reordered, config} {
+		matrix, err := engine.GenerateFeatures(context.Background(), storedFeatureCandles(50), cfg, "")
+		if err != nil {
+			t.Fatalf("run %d: unexpected error: %v", run, err)
+		}
+		if !reflect.DeepEqual(matrix.Columns, want) {
+			t.Errorf("run %d: columns =\n%v\nwant\n%v", run, matrix.Columns, want)
+		}
+	}
+}
+
+func TestApplyColumnOrder(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns: []string{"open", "close", "rsi14", "target_direction_1"},
+		Data:    [][]float64{{1, 2, 3, 4}},
+		Schema:  []models.FeatureSchema{{Name: "open"}, {Name: "close"}, {Name: "rsi14"}, {Name: "target_direction_1"}},
+	}
+
+	applyColumnOrder(matrix, []string{"rsi14", "dropped", "close"})
+
+	wantColumns := []string{"rsi14", "close", "open", "target_direction_1"}
+	if !reflect.DeepEqual(matrix.Columns, wantColumns) {
+		t.Errorf("columns = %v, want %v", matrix.Columns, wantColumns)
+	}
+	if !reflect.DeepEqual(matrix.Data[0], []float64{3, 2, 1, 4}) {
+		t.Errorf("row = %v, want [3 2 1 4]", matrix.Data[0])
+	}
+	if matrix.Schema[0].Name != "rsi14" || matrix.Schema[3].Name != "target_direction_1" {
+		t.Errorf("schema not reordered with the columns: %+v", matrix.Schema)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// planCandles is the length of the synthetic series ColumnsForConfig runs the
+// feature engine on; the columns don't depend on it
+const planCandles = 2
+
+// ColumnsForConfig returns the columns an export with the config produces, in
+// export order, without loading any data: the feature engine runs on a short
+// synthetic series, so the names and order are exactly those of a real export.
+// Auxiliary source columns, which depend on the source jobs, are left out, as
+// are the columns preprocessing may drop for their NaN fraction.
+func (e *MLFeatureEngine) ColumnsForConfig(config models.MLExportConfig) ([]string, error) {
+	candles := make([]models.Candle, planCandles)
+	for i := range candles {
+		candles[i] = models.Candle{Timestamp: int64(i) * 60_000, Open: 1, High: 1, Low: 1, Close: 1, Volume: 1}
+	}
+
+	ctx := context.Background()
+	matrix, err := e.GenerateFeatures(ctx, candles, config.Features, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan features: %w", err)
+	}
+	if err := e.GenerateTargets(ctx, matrix, candles, config.Target); err != nil {
+		return nil, fmt.Errorf("failed to plan targets: %w", err)
+	}
+	applyColumnOrder(matrix, config.ColumnOrder)
+	// The split runs after the column order, so shuffle_index always comes last
+	if config.Split.Enabled && config.Split.TimeBased && config.Split.ShuffleIndex {
+		e.addColumn(matrix, "shuffle_index", "float64", "split", make([]float64, planCandles))
+	}
+
+	return matrix.Columns, nil
+}
+
+// diffedSettings are the config sections DiffConfigs compares setting by setting
+var diffedSettings = []struct {
+	name  string
+	value func(c models.MLExportConfig) interface{}
+}{
+	{"preprocessing", func(c models.MLExportConfig) interface{} { return c.Preprocessing }},
+	{"split", func(c models.MLExportConfig) interface{} { return c.Split }},
+	{"target", func(c models.MLExportConfig) interface{} { return c.Target }},
+	{"sequence", func(c models.MLExportConfig) interface{} { return c.Sequence }},
+	{"resample", func(c models.MLExportConfig) interface{} { return c.Resample }},
+}
+
+// DiffConfigs compares the columns two export configs produce and their
+// preprocessing, split, target, sequence and resample settings
+func (s *MLExportService) DiffConfigs(a, b models.MLExportConfig) (*models.ConfigDiff, error) {
+	columnsA, err := s.featureEngine.ColumnsForConfig(a)
+	if err != nil {
+		return nil, fmt.Errorf("config a: %w", err)
+	}
+	columnsB, err := s.featureEngine.ColumnsForConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("config b: %w", err)
+	}
+
+	diff := &models.ConfigDiff{
+		ColumnCountA: len(columnsA),
+		ColumnCountB: len(columnsB),
+		OnlyInA:      columnsMissingFrom(columnsA, columnsB),
+		OnlyInB:      columnsMissingFrom(columnsB, columnsA),
+		Settings:     []models.SettingDiff{},
+	}
+	diff.CommonColumns = len(columnsA) - len(diff.OnlyInA)
+	diff.SameColumnOrder = len(diff.OnlyInA) == 0 && len(diff.OnlyInB) == 0 && reflect.DeepEqual(columnsA, columnsB)
+
+	for _, section := range diffedSettings {
+		settingsA, err := flattenSettings(section.value(a))
+		if err != nil {
+			return nil, err
+		}
+		settingsB, err := flattenSettings(section.value(b))
+		if err != nil {
+			return nil, err
+		}
+		diff.Settings = append(diff.Settings, diffSettings(section.name, settingsA, settingsB)...)
+	}
+
+	return diff, nil
+}
+
+// columnsMissingFrom returns the columns of a that b doesn't have, in a's order
+func columnsMissingFrom(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, col := range b {
+		inB[col] = true
+	}
+	missing := []string{}
+	for _, col := range a {
+		if !inB[col] {
+			missing = append(missing, col)
+		}
+	}
+	return missing
+}
+
+// flattenSettings turns a config section into its JSON fields, keyed by
+// dotted path; arrays are compared whole
+func flattenSettings(section interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+
+	flat := make(map[string]interface{})
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for key, value := range m {
+			if nested, ok := value.(map[string]interface{}); ok {
+				walk(prefix+key+".", nested)
+				continue
+			}
+			flat[prefix+key] = value
+		}
+	}
+	walk("", fields)
+	return flat, nil
+}
+
+// diffSettings lists the settings of a section whose values differ, sorted by
+// field. A setting only one side sets is reported with nil on the other.
+func diffSettings(section string, a, b map[string]interface{}) []models.SettingDiff {
+	var diffs []models.SettingDiff
+	for key, valueA := range a {
+		if valueB, ok := b[key]; !ok || !reflect.DeepEqual(valueA, valueB) {
+			diffs = append(diffs, models.SettingDiff{Field: section + "." + key, A: valueA, B: b[key]})
+		}
+	}
+	for key, valueB := range b {
+		if _, ok := a[key]; !ok {
+			diffs = append(diffs, models.SettingDiff{Field: section + "." + key, B: valueB})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestColumnsForConfigMatchesExport(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	config := models.DefaultMLExportConfig()
+	config.Features.LaggedFeatures = models.LagConfig{Enabled: true, LagPeriods: []int{1, 3}, LagFeatures: []string{"close"}}
+	config.Target.LookaheadPeriods = []int{1, 5}
+	config.ColumnOrder = []string{"close", "open"}
+
+	planned, err := engine.ColumnsForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candles := storedFeatureCandles(50)
+	matrix, err := engine.GenerateFeatures(context.Background(), candles, config.Features, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.GenerateTargets(context.Background(), matrix, candles, config.Target); err != nil {
+		t.Fatal(err)
+	}
+	applyColumnOrder(matrix, config.ColumnOrder)
+
+	if !reflect.DeepEqual(planned, matrix.Columns) {
+		t.Errorf("planned columns =\n%v\nexport columns =\n%v", planned, matrix.Columns)
+	}
+}
+
+func TestDiffConfigs(t *testing.T) {
+	s := &MLExportService{featureEngine: NewMLFeatureEngine()}
+	a := models.DefaultMLExportConfig()
+	a.Features.LaggedFeatures = models.LagConfig{Enabled: true, LagPeriods: []int{1}, LagFeatures: []string{"close"}}
+	a.Features.RollingFeatures = models.RollingConfig{}
+	b := models.DefaultMLExportConfig()
+	b.Features.LaggedFeatures = models.LagConfig{}
+	b.Features.RollingFeatures = models.RollingConfig{Enabled: true, Windows: []int{5}, Stats: []string{"mean"}}
+	b.Split.TrainRatio = a.Split.TrainRatio / 2
+
+	diff, err := s.DiffConfigs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.OnlyInA, []string{"close_lag_1"}) {
+		t.Errorf("only in a = %v, want [close_lag_1]", diff.OnlyInA)
+	}
+	if !reflect.DeepEqual(diff.OnlyInB, []string{"close_roll_5_mean"}) {
+		t.Errorf("only in b = %v, want [close_roll_5_mean]", diff.OnlyInB)
+	}
+	if diff.CommonColumns != diff.ColumnCountA-1 || diff.SameColumnOrder {
+		t.Errorf("common = %d of %d, same order = %v", diff.CommonColumns, diff.ColumnCountA, diff.SameColumnOrder)
+	}
+	if len(diff.Settings) != 1 || diff.Settings[0].Field != "split.train_ratio" {
+		t.Errorf("settings = %+v, want only split.train_ratio", diff.Settings)
+	}
+
+	same, err := s.DiffConfigs(a, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !same.SameColumnOrder || len(same.OnlyInA)+len(same.OnlyInB)+len(same.Settings) != 0 {
+		t.Errorf("diff of a config with itself = %+v", same)
+	}
+}
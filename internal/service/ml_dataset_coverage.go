@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Overlap ratios (common range / combined range) used to classify coverage
+const (
+	coverageFullRatio    = 0.9
+	coveragePartialRatio = 0.5
+)
+
+// AnalyzeCoverage reports each source's time range and how much the sources
+// overlap. Rows from different sources only line up over the common range, so
+// disjoint or sparsely overlapping sources are flagged with warnings.
+func AnalyzeCoverage(sources []models.SourceJobInfo) *models.DatasetCoverage {
+	coverage := &models.DatasetCoverage{
+		Status:  models.CoverageStatusFull,
+		Sources: make([]models.SourceCoverage, len(sources)),
+	}
+	if len(sources) == 0 {
+		return coverage
+	}
+
+	overlapStart, overlapEnd := sources[0].StartTime, sources[0].EndTime
+	unionStart, unionEnd := sources[0].StartTime, sources[0].EndTime
+	for _, src := range sources[1:] {
+		if src.StartTime.After(overlapStart) {
+			overlapStart = src.StartTime
+		}
+		if src.EndTime.Before(overlapEnd) {
+			overlapEnd = src.EndTime
+		}
+		if src.StartTime.Before(unionStart) {
+			unionStart = src.StartTime
+		}
+		if src.EndTime.After(unionEnd) {
+			unionEnd = src.EndTime
+		}
+	}
+
+	overlap := overlapEnd.Sub(overlapStart)
+	if overlap < 0 {
+		overlap = 0
+	} else {
+		coverage.OverlapStart = &overlapStart
+		coverage.OverlapEnd = &overlapEnd
+	}
+	coverage.OverlapRatio = durationRatio(overlap, unionEnd.Sub(unionStart))
+
+	for i, src := range sources {
+		coverage.Sources[i] = models.SourceCoverage{SourceJobInfo: src}
+		if coverage.OverlapStart != nil {
+			coverage.Sources[i].OverlapRatio = durationRatio(overlap, src.EndTime.Sub(src.StartTime))
+		}
+		if coverage.Sources[i].OverlapRatio < coveragePartialRatio {
+			coverage.Warnings = append(coverage.Warnings, fmt.Sprintf(
+				"%s %s (%s) covers %s to %s; only %.0f%% of it overlaps the other sources",
+				src.Symbol, src.Timeframe, src.JobID.Hex(),
+				src.StartTime.UTC().Format(time.RFC3339), src.EndTime.UTC().Format(time.RFC3339),
+				coverage.Sources[i].OverlapRatio*100))
+		}
+	}
+
+	switch {
+	case coverage.OverlapStart == nil:
+		coverage.Status = models.CoverageStatusDisjoint
+		coverage.Warnings = append(coverage.Warnings,
+			"sources have no common time range; the dataset will be separate blocks of data")
+	case coverage.OverlapRatio >= coverageFullRatio:
+		coverage.Status = models.CoverageStatusFull
+	case coverage.OverlapRatio >= coveragePartialRatio:
+		coverage.Status = models.CoverageStatusPartial
+	default:
+		coverage.Status = models.CoverageStatusSparse
+		coverage.Warnings = append(coverage.Warnings, fmt.Sprintf(
+			"sources share only %.0f%% of their combined time range", coverage.OverlapRatio*100))
+	}
+
+	return coverage
+}
+
+// PreviewCoverage analyzes the stored time ranges of the given jobs without
+// loading their candles. Jobs without data are left out and reported as warnings.
+func (s *MLExportService) PreviewCoverage(ctx context.Context, jobIDs []primitive.ObjectID) (*models.DatasetCoverage, error) {
+	var sources []models.SourceJobInfo
+	var empty []string
+
+	for _, jobID := range jobIDs {
+		job, err := s.jobRepo.FindByID(ctx, jobID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find job %s: %w", jobID.Hex(), err)
+		}
+
+		start, end, count, err := s.ohlcvRepo.GetTimeRange(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get time range for job %s: %w", jobID.Hex(), err)
+		}
+		if count == 0 {
+			empty = append(empty, fmt.Sprintf("%s %s (%s)", job.Symbol, job.Timeframe, jobID.Hex()))
+			continue
+		}
+
+		sources = append(sources, models.SourceJobInfo{
+			JobID:      jobID,
+			ExchangeID: job.ConnectorExchangeID,
+			Symbol:     job.Symbol,
+			Timeframe:  job.Timeframe,
+			BarCount:   int64(count),
+			StartTime:  start,
+			EndTime:    end,
+		})
+	}
+
+	coverage := AnalyzeCoverage(sources)
+	for _, name := range empty {
+		coverage.Warnings = append(coverage.Warnings, fmt.Sprintf("%s has no data", name))
+	}
+
+	return coverage, nil
+}
+
+// durationRatio returns part/whole clamped to [0, 1]; a zero whole counts as fully covered
+func durationRatio(part, whole time.Duration) float64 {
+	if whole <= 0 {
+		return 1
+	}
+	ratio := float64(part) / float64(whole)
+	if ratio > 1 {
+		return 1
+	}
+	return ratio
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// alignmentToleranceShare is the default alignment tolerance as a share of
+// the bar duration
+const alignmentToleranceShare = 0.1
+
+// alignCandles snaps candles sorted oldest first onto the bar grid of the
+// timeframe. A candle within toleranceMs of a bar boundary, either side of
+// it, is moved onto it; a candle further off is dropped, as is one landing on
+// a bar an earlier candle already took. toleranceMs <= 0 uses 10% of a bar,
+// and the tolerance never exceeds half a bar, so the nearest boundary is
+// unambiguous. It returns the aligned candles, how many were moved and how
+// many were dropped.
+func alignCandles(candles []models.Candle, timeframe string, toleranceMs int64) ([]models.Candle, int64, int64) {
+	var snapped, unaligned int64
+	aligned := make([]models.Candle, 0, len(candles))
+	for _, c := range candles {
+		start := models.TimeframeBucketStart(timeframe, c.Timestamp)
+		next := models.NextBarTime(timeframe, start)
+
+		tolerance := toleranceMs
+		if tolerance <= 0 {
+			tolerance = int64(float64(next-start) * alignmentToleranceShare)
+		}
+		if tolerance > (next-start)/2 {
+			tolerance = (next - start) / 2
+		}
+
+		var target int64
+		switch {
+		case c.Timestamp-start <= tolerance:
+			target = start
+		case next-c.Timestamp <= tolerance:
+			target = next
+		default:
+			unaligned++
+			continue
+		}
+
+		if n := len(aligned); n > 0 && aligned[n-1].Timestamp >= target {
+			unaligned++
+			continue
+		}
+		if target != c.Timestamp {
+			c.Timestamp = target
+			snapped++
+		}
+		aligned = append(aligned, c)
+	}
+	return aligned, snapped, unaligned
+}
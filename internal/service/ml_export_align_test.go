@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestAlignCandles(t *testing.T) {
+	const hour = int64(3_600_000)
+	base := int64(1_704_067_200_000) // 2024-01-01 00:00 UTC
+
+	candles := []models.Candle{
+		{Timestamp: base, Close: 1},                   // On the grid
+		{Timestamp: base + hour + 2_000, Close: 2},    // 2s late
+		{Timestamp: base + 3*hour - 1_500, Close: 3},  // 1.5s early
+		{Timestamp: base + 3*hour + 1_000, Close: 4},  // Lands on the bar taken by the previous candle
+		{Timestamp: base + 4*hour + hour/2, Close: 5}, // Half way between bars
+	}
+
+	aligned, snapped, unaligned := alignCandles(candles, "1h", 5_000)
+	want := []int64{base, base + hour, base + 3*hour}
+	if len(aligned) != len(want) {
+		t.Fatalf("got %d aligned candles, want %d: %+v", len(aligned), len(want), aligned)
+	}
+	for i, ts := range want {
+		if aligned[i].Timestamp != ts {
+			t.Errorf("candle %d at %d, want %d", i, aligned[i].Timestamp, ts)
+		}
+	}
+	if snapped != 2 || unaligned != 2 {
+		t.Errorf("snapped = %d, unaligned = %d, want 2 and 2", snapped, unaligned)
+	}
+
+	// The default tolerance is a tenth of a bar
+	if _, _, unaligned := alignCandles([]models.Candle{{Timestamp: base + 5*60_000}}, "1h", 0); unaligned != 0 {
+		t.Error("5 minutes off a 1h bar should be within the default tolerance")
+	}
+	if _, _, unaligned := alignCandles([]models.Candle{{Timestamp: base + 7*60_000}}, "1h", 0); unaligned != 1 {
+		t.Error("7 minutes off a 1h bar should be beyond the default tolerance")
+	}
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// auxCandleColumns are the columns an OHLCV auxiliary source provides besides
+// its stored indicators
+var auxCandleColumns = []string{"open", "high", "low", "close", "volume", "returns", "log_returns"}
+
+// auxSeriesColumns are the columns funding and open interest auxiliary
+// sources provide
+var auxSeriesColumns = map[string][]string{
+	models.JobTypeFunding:      {"funding_rate"},
+	models.JobTypeOpenInterest: {"open_interest", "open_interest_value"},
+}
+
+var auxPrefixCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+// auxSeries is an auxiliary source loaded for joining. Point i becomes known
+// at availableAt[i] and has values[c][i] for its c-th column.
+type auxSeries struct {
+	info        models.AuxSourceInfo
+	columns     []string // Source column of each joined column
+	availableAt []int64
+	values      [][]float64
+}
+
+// auxColumnPrefix is the prefix of an auxiliary source's columns
+func auxColumnPrefix(src models.AuxSource, symbol string) string {
+	if src.Prefix != "" {
+		return src.Prefix
+	}
+	return strings.Trim(auxPrefixCleaner.ReplaceAllString(strings.ToLower(symbol), "_"), "_")
+}
+
+// validAuxColumn reports whether a job of the given type provides the column
+func validAuxColumn(jobType, column string) bool {
+	if jobType == models.JobTypeOHLCV {
+		for _, name := range auxCandleColumns {
+			if name == column {
+				return true
+			}
+		}
+		for _, field := range storedIndicatorFields {
+			if field.name == column {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range auxSeriesColumns[jobType] {
+		if name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAuxiliarySources checks that every auxiliary source names an
+// existing OHLCV, funding or open interest job and columns that job provides
+func (s *MLExportService) ValidateAuxiliarySources(ctx context.Context, sources []models.AuxSource) error {
+	for i, src := range sources {
+		job, err := s.jobRepo.FindByID(ctx, src.JobID.Hex())
+		if err != nil {
+			return fmt.Errorf("invalid auxiliary source %d: job %s not found", i, src.JobID.Hex())
+		}
+		jobType := job.GetJobType()
+		if jobType != models.JobTypeOHLCV && auxSeriesColumns[jobType] == nil {
+			return fmt.Errorf("invalid auxiliary source %d: %s jobs can't be joined, only %s, %s and %s jobs",
+				i, jobType, models.JobTypeOHLCV, models.JobTypeFunding, models.JobTypeOpenInterest)
+		}
+		if len(src.Columns) == 0 {
+			return fmt.Errorf("invalid auxiliary source %d: no columns selected", i)
+		}
+		for _, column := range src.Columns {
+			if !validAuxColumn(jobType, column) {
+				return fmt.Errorf("invalid auxiliary source %d: %s jobs have no column %q", i, jobType, column)
+			}
+		}
+	}
+	return nil
+}
+
+// joinAuxiliarySources joins the columns of each auxiliary source onto the
+// matrix rows, whose bars span baseTimeframe. It returns what was joined, for
+// the export metadata.
+func (s *MLExportService) joinAuxiliarySources(ctx context.Context, matrix *models.FeatureMatrix, sources []models.AuxSource, baseTimeframe string) ([]models.AuxSourceInfo, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	baseDurationMs, err := models.GetTimeframeMs(baseTimeframe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join auxiliary sources: %w", err)
+	}
+	infos := make([]models.AuxSourceInfo, 0, len(sources))
+	for _, src := range sources {
+		series, err := s.loadAuxSeries(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.joinAuxSeries(matrix, series, baseDurationMs); err != nil {
+			return nil, err
+		}
+		infos = append(infos, series.info)
+	}
+	return infos, nil
+}
+
+// loadAuxSeries loads an auxiliary source's points, oldest first. Candles
+// become known when they close, funding rates and open interest at their
+// timestamp.
+func (s *MLExportService) loadAuxSeries(ctx context.Context, src models.AuxSource) (*auxSeries, error) {
+	job, err := s.jobRepo.FindByID(ctx, src.JobID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find auxiliary job %s: %w", src.JobID.Hex(), err)
+	}
+
+	prefix := auxColumnPrefix(src, job.Symbol)
+	series := &auxSeries{
+		info: models.AuxSourceInfo{
+			JobID:      src.JobID,
+			JobType:    job.GetJobType(),
+			ExchangeID: job.ConnectorExchangeID,
+			Symbol:     job.Symbol,
+			Timeframe:  job.Timeframe,
+		},
+		columns: src.Columns,
+		values:  make([][]float64, len(src.Columns)),
+	}
+	for _, column := range src.Columns {
+		series.info.Columns = append(series.info.Columns, prefix+"_"+column)
+	}
+
+	switch job.GetJobType() {
+	case models.JobTypeOHLCV:
+		doc, err := s.ohlcvRepo.FindByJob(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load candles for auxiliary job %s: %w", src.JobID.Hex(), err)
+		}
+		if doc != nil {
+			candles := doc.Candles
+			sort.Slice(candles, func(i, j int) bool {
+				return candles[i].Timestamp < candles[j].Timestamp
+			})
+			durationMs, err := models.GetTimeframeMs(job.Timeframe)
+			if err != nil {
+				return nil, fmt.Errorf("auxiliary job %s: %w", src.JobID.Hex(), err)
+			}
+			for _, c := range candles {
+				series.availableAt = append(series.availableAt, c.Timestamp+durationMs)
+			}
+			for i, column := range src.Columns {
+				series.values[i] = auxCandleValues(candles, column)
+			}
+		}
+
+	case models.JobTypeFunding:
+		rates, err := s.derivativesRepo.FindFundingRates(ctx, job.ConnectorExchangeID, job.Symbol, 0, math.MaxInt64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auxiliary job %s: %w", src.JobID.Hex(), err)
+		}
+		for _, rate := range rates {
+			series.availableAt = append(series.availableAt, rate.Timestamp)
+			series.values[0] = append(series.values[0], rate.FundingRate)
+		}
+
+	case models.JobTypeOpenInterest:
+		points, err := s.derivativesRepo.FindOpenInterest(ctx, job.ConnectorExchangeID, job.Symbol, 0, math.MaxInt64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auxiliary job %s: %w", src.JobID.Hex(), err)
+		}
+		for _, point := range points {
+			series.availableAt = append(series.availableAt, point.Timestamp)
+			for i, column := range src.Columns {
+				v := point.OpenInterest
+				if column == "open_interest_value" {
+					v = point.OpenInterestValue
+					if v == 0 {
+						v = math.NaN()
+					}
+				}
+				series.values[i] = append(series.values[i], v)
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("auxiliary job %s is a %s job, which can't be joined", src.JobID.Hex(), job.GetJobType())
+	}
+
+	series.info.PointCount = int64(len(series.availableAt))
+	if n := len(series.availableAt); n > 0 {
+		series.info.StartTime = time.UnixMilli(series.availableAt[0])
+		series.info.EndTime = time.UnixMilli(series.availableAt[n-1])
+	}
+	return series, nil
+}
+
+// auxCandleValues extracts one column from candles sorted oldest first.
+// Returns are computed within the auxiliary series.
+func auxCandleValues(candles []models.Candle, column string) []float64 {
+	values := make([]float64, len(candles))
+	for i := range candles {
+		c := &candles[i]
+		switch column {
+		case "open":
+			values[i] = c.Open
+		case "high":
+			values[i] = c.High
+		case "low":
+			values[i] = c.Low
+		case "close":
+			values[i] = c.Close
+		case "volume":
+			values[i] = c.Volume
+		case "returns", "log_returns":
+			values[i] = math.NaN()
+			if i > 0 && candles[i-1].Close > 0 {
+				values[i] = c.Close/candles[i-1].Close - 1
+				if column == "log_returns" {
+					values[i] = math.Log(c.Close / candles[i-1].Close)
+				}
+			}
+		default:
+			values[i] = math.NaN()
+			ind := reflect.ValueOf(&c.Indicators).Elem()
+			for _, field := range storedIndicatorFields {
+				if field.name == column {
+					if ptr := ind.Field(field.index); !ptr.IsNil() {
+						values[i] = ptr.Elem().Float()
+					}
+					break
+				}
+			}
+		}
+	}
+	return values
+}
+
+// joinAuxSeries appends the series' columns to the matrix. Each row takes the
+// last point known by the time its bar closes, so lower-frequency series are
+// forward-filled and a coarser candle only appears once it has closed. Rows
+// before the first point get NaN.
+func (s *MLExportService) joinAuxSeries(matrix *models.FeatureMatrix, series *auxSeries, baseDurationMs int64) error {
+	existing := make(map[string]bool, len(matrix.Columns))
+	for _, name := range matrix.Columns {
+		existing[name] = true
+	}
+	for _, name := range series.info.Columns {
+		if existing[name] {
+			return fmt.Errorf("auxiliary column %s duplicates an existing column, set a different prefix", name)
+		}
+		existing[name] = true
+	}
+
+	// Index of the point each row takes, -1 when none is known yet
+	points := make([]int, len(matrix.Timestamps))
+	for r, ts := range matrix.Timestamps {
+		closesAt := ts + baseDurationMs
+		points[r] = sort.Search(len(series.availableAt), func(i int) bool {
+			return series.availableAt[i] > closesAt
+		}) - 1
+	}
+
+	for c, name := range series.info.Columns {
+		values := make([]float64, len(points))
+		for r, p := range points {
+			values[r] = math.NaN()
+			if p >= 0 {
+				values[r] = series.values[c][p]
+			}
+		}
+		s.featureEngine.addColumn(matrix, name, "float64", "auxiliary", values)
+		matrix.Schema[len(matrix.Schema)-1].Description = describeAuxColumn(series.info, series.columns[c])
+	}
+	return nil
+}
+
+// describeAuxColumn describes a joined auxiliary column by its source column
+// and the series it was joined from
+func describeAuxColumn(info models.AuxSourceInfo, column string) string {
+	desc := DescribeFeature(column)
+	if desc == "" {
+		desc = column
+	}
+	source := info.Symbol
+	if info.Timeframe != "" {
+		source += " " + info.Timeframe
+	}
+	return fmt.Sprintf("%s, from %s on %s (last value known at the row's close)", desc, source, info.ExchangeID)
+}
+
+// finestTimeframe returns the shortest timeframe of the sources, so auxiliary
+// points are only joined onto rows once every source's bar has closed
+func finestTimeframe(sources []models.SourceJobInfo) string {
+	finest := ""
+	for _, src := range sources {
+		if finest == "" || models.GetTimeframeDurationMinutes(src.Timeframe) < models.GetTimeframeDurationMinutes(finest) {
+			finest = src.Timeframe
+		}
+	}
+	return finest
+}
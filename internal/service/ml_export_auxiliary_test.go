@@ -0,0 +1,76 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestJoinAuxSeries(t *testing.T) {
+	s := &MLExportService{featureEngine: NewMLFeatureEngine()}
+
+	// Four 1m rows joined with a 2m series known at 2m and 4m
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{1}, {2}, {3}, {4}},
+		Timestamps: []int64{0, 60000, 120000, 180000},
+		RowCount:   4,
+	}
+	series := &auxSeries{
+		info:        models.AuxSourceInfo{Symbol: "ETH/USDT", Timeframe: "2m", ExchangeID: "binance", Columns: []string{"eth_usdt_close"}},
+		columns:     []string{"close"},
+		availableAt: []int64{120000, 240000},
+		values:      [][]float64{{10, 20}},
+	}
+
+	if err := s.joinAuxSeries(matrix, series, 60000); err != nil {
+		t.Fatal(err)
+	}
+	if matrix.Columns[1] != "eth_usdt_close" {
+		t.Fatalf("columns = %v", matrix.Columns)
+	}
+	if want := "Closing price of the candle, from ETH/USDT 2m on binance (last value known at the row's close)"; matrix.Schema[0].Description != want {
+		t.Errorf("description = %q, want %q", matrix.Schema[0].Description, want)
+	}
+	want := []float64{math.NaN(), 10, 10, 20}
+	for i, w := range want {
+		got := matrix.Data[i][1]
+		if got != w && !(math.IsNaN(got) && math.IsNaN(w)) {
+			t.Errorf("row %d = %v, want %v", i, got, w)
+		}
+	}
+
+	// Joining the same column again needs a different prefix
+	if err := s.joinAuxSeries(matrix, series, 60000); err == nil {
+		t.Error("expected an error for a duplicate column")
+	}
+}
+
+func TestAuxCandleValues(t *testing.T) {
+	candles := []models.Candle{{Close: 100}, {Close: 110}, {Close: 99}}
+
+	returns := auxCandleValues(candles, "returns")
+	if !math.IsNaN(returns[0]) || math.Abs(returns[1]-0.1) > 1e-9 || math.Abs(returns[2]+0.1) > 1e-9 {
+		t.Errorf("returns = %v", returns)
+	}
+
+	rsi := 55.0
+	candles[1].Indicators.RSI14 = &rsi
+	values := auxCandleValues(candles, "rsi14")
+	if !math.IsNaN(values[0]) || values[1] != 55 {
+		t.Errorf("rsi14 = %v", values)
+	}
+}
+
+func TestAuxColumnPrefix(t *testing.T) {
+	if got := auxColumnPrefix(models.AuxSource{}, "BTC/USDT:USDT"); got != "btc_usdt_usdt" {
+		t.Errorf("prefix = %q", got)
+	}
+	if got := auxColumnPrefix(models.AuxSource{Prefix: "mkt"}, "BTC/USDT"); got != "mkt" {
+		t.Errorf("prefix = %q", got)
+	}
+	if !validAuxColumn(models.JobTypeOHLCV, "rsi14") || validAuxColumn(models.JobTypeFunding, "close") {
+		t.Error("unexpected column validity")
+	}
+}
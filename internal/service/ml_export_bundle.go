@@ -0,0 +1,257 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bundleManifestName is the name of the manifest at the root of a bundle
+const bundleManifestName = "manifest.json"
+
+// bundleProgressSpan is the share of the overall progress the sub-exports
+// cover; bundling the files covers the rest
+const bundleProgressSpan = 90
+
+// runSeparateExports runs the export pipeline once per source job and bundles
+// the outputs into one .zip. Progress is reported across all sub-exports, so
+// the job moves from 0 to 100 once. With the skip min-bars action, jobs with
+// too few bars are left out of the bundle instead of failing the export.
+func (s *MLExportService) runSeparateExports(ctx context.Context, exportJob *models.MLExportJob) (*exportOutput, error) {
+	var outputs []*exportOutput
+	var skipped []models.SkippedSourceInfo
+
+	// Sub-export files are only kept until they are bundled
+	defer func() {
+		for _, output := range outputs {
+			for _, path := range append([]string{output.outputPath}, output.outputFiles...) {
+				os.Remove(path)
+			}
+		}
+	}()
+
+	span := float64(bundleProgressSpan) / float64(len(exportJob.JobIDs))
+	var records int64
+	for i, jobID := range exportJob.JobIDs {
+		sub := *exportJob
+		sub.JobIDs = []primitive.ObjectID{jobID}
+
+		tracker := &exportTracker{s: s, job: exportJob, base: float64(i) * span, span: span, records: records}
+		output, err := s.runExportPipeline(ctx, &sub, tracker)
+		if output != nil {
+			outputs = append(outputs, output)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			if exportJob.Config.MinBars.Action == models.MinBarsActionSkip && strings.Contains(err.Error(), "have fewer than") {
+				logging.Printf(ctx, "[ML_EXPORT] Leaving job %s out of bundle: %v", jobID.Hex(), err)
+				skipped = append(skipped, models.SkippedSourceInfo{
+					JobID:        jobID,
+					RequiredBars: RequiredBars(exportJob.Config),
+				})
+				skippedID := jobID
+				tracker.warn(models.ExportWarning{
+					Code:      models.ExportWarningTooFewBars,
+					Message:   fmt.Sprintf("job %s was left out of the bundle: %v", jobID.Hex(), err),
+					JobID:     &skippedID,
+					RequestID: exportJob.RequestID,
+					At:        time.Now(),
+				})
+				continue
+			}
+			return nil, fmt.Errorf("job %s: %w", jobID.Hex(), err)
+		}
+		records += output.processedRecords
+	}
+
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("failed to load data: all source jobs have fewer than the %d bars required by the export config", RequiredBars(exportJob.Config))
+	}
+
+	exportJob.Progress = bundleProgressSpan
+	exportJob.CurrentPhase = "bundling"
+	s.exportRepo.UpdateExportJob(ctx, exportJob)
+
+	outputPath, err := s.writeBundle(exportJob, outputs, skipped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %v", err)
+	}
+
+	checksum, size, err := FileSHA256(outputPath)
+	if err != nil {
+		os.Remove(outputPath)
+		return nil, fmt.Errorf("failed to hash bundle: %v", err)
+	}
+
+	bundle := &exportOutput{
+		outputPath:   outputPath,
+		fileSize:     size,
+		checksum:     checksum,
+		featureCount: outputs[0].featureCount,
+		columns:      outputs[0].columns,
+		metadata:     mergeBundleMetadata(outputs, skipped),
+	}
+	for _, output := range outputs {
+		bundle.rowCount += output.rowCount
+		bundle.processedRecords += output.processedRecords
+	}
+	return bundle, nil
+}
+
+// writeBundle zips the sub-export outputs, each under a directory named after
+// its source job, together with a manifest mapping the files to their jobs.
+// The zip is written to a temp file and renamed into place when complete.
+func (s *MLExportService) writeBundle(exportJob *models.MLExportJob, outputs []*exportOutput, skipped []models.SkippedSourceInfo) (string, error) {
+	manifest := models.BundleManifest{
+		ExportJobID:    exportJob.ID.Hex(),
+		Format:         exportJob.Config.Format,
+		SkippedSources: skipped,
+		CreatedAt:      time.Now(),
+	}
+
+	filename := fmt.Sprintf("ml_export_%s_%s.zip", exportJob.ID.Hex(), time.Now().Format("20060102_150405"))
+	outputPath := filepath.Join(s.exportDir, filename)
+	tempPath := outputPath + tempFileSuffix
+
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tempPath)
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, output := range outputs {
+		source := output.metadata.SourceJobs[0]
+		job := models.BundleManifestJob{
+			JobID:               source.JobID.Hex(),
+			ExchangeID:          source.ExchangeID,
+			Symbol:              source.Symbol,
+			Timeframe:           source.Timeframe,
+			RowCount:            output.rowCount,
+			Columns:             output.columns,
+			NormalizationParams: output.metadata.NormalizationParams,
+		}
+
+		for _, src := range append([]string{output.outputPath}, output.outputFiles...) {
+			name := path.Join(job.JobID, filepath.Base(src))
+			if err := addZipFile(zw, name, src); err != nil {
+				return "", err
+			}
+			checksum, size, err := FileSHA256(src)
+			if err != nil {
+				return "", fmt.Errorf("failed to hash %s: %w", name, err)
+			}
+			job.Files = append(job.Files, models.BundleManifestFile{File: name, SizeBytes: size, Checksum: checksum})
+		}
+		manifest.Jobs = append(manifest.Jobs, job)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+	w, err := zw.Create(bundleManifestName)
+	if err != nil {
+		return "", fmt.Errorf("failed to add bundle manifest: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to add bundle manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to finish zip: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return "", fmt.Errorf("failed to sync file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+	if err := os.Rename(tempPath, outputPath); err != nil {
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
+	return outputPath, nil
+}
+
+// addZipFile copies the file at src into the zip as name
+func addZipFile(zw *zip.Writer, name, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := io.Copy(w, in); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	return nil
+}
+
+// mergeBundleMetadata combines the sub-exports' metadata into the metadata
+// of the bundle. Normalization params differ per job, so they are only kept
+// in the bundle manifest.
+func mergeBundleMetadata(outputs []*exportOutput, skipped []models.SkippedSourceInfo) models.MLExportMetadata {
+	first := outputs[0].metadata
+	metadata := models.MLExportMetadata{
+		Version:          first.Version,
+		ExportedAt:       time.Now(),
+		FeatureSchema:    first.FeatureSchema,
+		Targets:          first.Targets,
+		AuxiliarySources: first.AuxiliarySources,
+		SkippedSources:   skipped,
+	}
+
+	symbols := make(map[string]bool)
+	timeframes := make(map[string]bool)
+	exchanges := make(map[string]bool)
+	for i, output := range outputs {
+		m := output.metadata
+		metadata.SourceJobs = append(metadata.SourceJobs, m.SourceJobs...)
+		metadata.SkippedSources = append(metadata.SkippedSources, m.SkippedSources...)
+		metadata.RecalculatedJobs = append(metadata.RecalculatedJobs, m.RecalculatedJobs...)
+
+		r := m.DataRange
+		if i == 0 || r.StartTime.Before(metadata.DataRange.StartTime) {
+			metadata.DataRange.StartTime = r.StartTime
+		}
+		if r.EndTime.After(metadata.DataRange.EndTime) {
+			metadata.DataRange.EndTime = r.EndTime
+		}
+		metadata.DataRange.TotalBars += r.TotalBars
+		for _, v := range r.Symbols {
+			symbols[v] = true
+		}
+		for _, v := range r.Timeframes {
+			timeframes[v] = true
+		}
+		for _, v := range r.Exchanges {
+			exchanges[v] = true
+		}
+	}
+	metadata.DataRange.Symbols = mapKeys(symbols)
+	metadata.DataRange.Timeframes = mapKeys(timeframes)
+	metadata.DataRange.Exchanges = mapKeys(exchanges)
+
+	if len(metadata.SourceJobs) > 1 {
+		metadata.Coverage = AnalyzeCoverage(metadata.SourceJobs)
+	}
+	return metadata
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWriteBundle(t *testing.T) {
+	dir := t.TempDir()
+	s := &MLExportService{exportDir: dir}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var outputs []*exportOutput
+	for i, symbol := range []string{"BTC/USDT", "ETH/USDT"} {
+		jobID := primitive.NewObjectID()
+		path := filepath.Join(dir, "ml_export_"+jobID.Hex()+".csv")
+		if err := os.WriteFile(path, []byte("timestamp,close\n1,2\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, &exportOutput{
+			outputPath: path,
+			rowCount:   int64(10 * (i + 1)),
+			columns:    []string{"timestamp", "close"},
+			metadata: models.MLExportMetadata{
+				SourceJobs: []models.SourceJobInfo{{JobID: jobID, ExchangeID: "binance", Symbol: symbol, Timeframe: "1h"}},
+				DataRange: models.DataRange{
+					StartTime: start.Add(time.Duration(i) * time.Hour),
+					EndTime:   start.Add(time.Duration(i+10) * time.Hour),
+					TotalBars: int64(10 * (i + 1)),
+					Symbols:   []string{symbol},
+				},
+			},
+		})
+	}
+
+	exportJob := &models.MLExportJob{ID: primitive.NewObjectID(), Config: models.MLExportConfig{Format: models.MLExportFormatCSV}}
+	bundlePath, err := s.writeBundle(exportJob, outputs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Ext(bundlePath) != ".zip" {
+		t.Errorf("bundle path = %s, want a .zip", bundlePath)
+	}
+	if _, err := os.Stat(bundlePath + tempFileSuffix); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+
+	zr, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+
+	entries := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	manifestFile, ok := entries[bundleManifestName]
+	if !ok {
+		t.Fatalf("bundle has no %s", bundleManifestName)
+	}
+	rc, err := manifestFile.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest models.BundleManifest
+	err = json.NewDecoder(rc).Decode(&manifest)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(manifest.Jobs) != 2 {
+		t.Fatalf("manifest has %d jobs, want 2", len(manifest.Jobs))
+	}
+	for i, job := range manifest.Jobs {
+		source := outputs[i].metadata.SourceJobs[0]
+		if job.JobID != source.JobID.Hex() || job.Symbol != source.Symbol {
+			t.Errorf("job %d = %s %s, want %s %s", i, job.JobID, job.Symbol, source.JobID.Hex(), source.Symbol)
+		}
+		if job.RowCount != outputs[i].rowCount {
+			t.Errorf("job %d row count = %d, want %d", i, job.RowCount, outputs[i].rowCount)
+		}
+		if len(job.Files) != 1 {
+			t.Fatalf("job %d has %d files, want 1", i, len(job.Files))
+		}
+		want := source.JobID.Hex() + "/" + filepath.Base(outputs[i].outputPath)
+		if job.Files[0].File != want {
+			t.Errorf("job %d file = %s, want %s", i, job.Files[0].File, want)
+		}
+		if _, ok := entries[want]; !ok {
+			t.Errorf("bundle is missing %s", want)
+		}
+		if job.Files[0].Checksum == "" {
+			t.Errorf("job %d file has no checksum", i)
+		}
+	}
+
+	metadata := mergeBundleMetadata(outputs, nil)
+	if len(metadata.SourceJobs) != 2 {
+		t.Errorf("merged source jobs = %d, want 2", len(metadata.SourceJobs))
+	}
+	if metadata.DataRange.TotalBars != 30 {
+		t.Errorf("merged total bars = %d, want 30", metadata.DataRange.TotalBars)
+	}
+	if !metadata.DataRange.StartTime.Equal(start) || !metadata.DataRange.EndTime.Equal(start.Add(11*time.Hour)) {
+		t.Errorf("merged range = %v - %v", metadata.DataRange.StartTime, metadata.DataRange.EndTime)
+	}
+	if len(metadata.DataRange.Symbols) != 2 {
+		t.Errorf("merged symbols = %v, want 2", metadata.DataRange.Symbols)
+	}
+}
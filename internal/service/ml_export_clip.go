@@ -0,0 +1,112 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// trainingRows returns how many leading rows of an n-row matrix a time-based
+// split will put in training, as applySplit computes it. Other splits mix the
+// training rows into the rest, so all rows are used. Rows removed after
+// preprocessing can shift the split by those rows.
+func trainingRows(n int, split models.SplitConfig) int {
+	if !split.Enabled || !split.TimeBased {
+		return n
+	}
+	if train := int(float64(n) * split.TrainRatio); train > 0 {
+		return train
+	}
+	return n
+}
+
+// clipPercentiles clips each feature column to its lowerPct and upperPct
+// percentiles (0-100) over the first fitRows rows, leaving target, protected
+// and categorical columns alone. Fitting on the training rows keeps validation
+// and test values out of the bounds.
+func clipPercentiles(matrix *models.FeatureMatrix, lowerPct, upperPct float64, fitRows int, protect []string) {
+	if fitRows > len(matrix.Data) {
+		fitRows = len(matrix.Data)
+	}
+
+	for colIdx := range matrix.Columns {
+		if isExcludedFromPreprocessing(matrix, colIdx, protect) {
+			continue
+		}
+		if colIdx < len(matrix.Schema) && len(matrix.Schema[colIdx].Categories) > 0 {
+			continue
+		}
+
+		values := make([]float64, 0, fitRows)
+		for _, row := range matrix.Data[:fitRows] {
+			if !math.IsNaN(row[colIdx]) {
+				values = append(values, row[colIdx])
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		sort.Float64s(values)
+
+		lower := percentileOf(values, lowerPct)
+		upper := percentileOf(values, upperPct)
+		setClipBounds(matrix, colIdx, models.ClipBounds{
+			Method:  models.ClipMethodPercentile,
+			Lower:   lower,
+			Upper:   upper,
+			FitRows: fitRows,
+			Clipped: clipColumn(matrix, colIdx, lower, upper),
+		})
+	}
+}
+
+// percentileOf returns the pct percentile (0-100) of sorted values,
+// interpolating linearly between the closest ranks
+func percentileOf(sorted []float64, pct float64) float64 {
+	pos := pct / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo < 0 {
+		return sorted[0]
+	}
+	if hi >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// clipColumn moves the column's values outside [lower, upper] onto the nearest
+// bound and returns how many it moved
+func clipColumn(matrix *models.FeatureMatrix, colIdx int, lower, upper float64) int {
+	clipped := 0
+	for rowIdx := range matrix.Data {
+		val := matrix.Data[rowIdx][colIdx]
+		if val < lower {
+			matrix.Data[rowIdx][colIdx] = lower
+			clipped++
+		} else if val > upper {
+			matrix.Data[rowIdx][colIdx] = upper
+			clipped++
+		}
+	}
+	return clipped
+}
+
+// applyClipBounds clips columns to bounds recorded by an earlier export, so
+// new rows are clipped like the ones the export was trained on
+func applyClipBounds(matrix *models.FeatureMatrix, bounds map[string]models.ClipBounds) {
+	for colIdx, colName := range matrix.Columns {
+		if b, ok := bounds[colName]; ok {
+			clipColumn(matrix, colIdx, b.Lower, b.Upper)
+		}
+	}
+}
+
+// setClipBounds records the bounds a column was clipped to
+func setClipBounds(matrix *models.FeatureMatrix, colIdx int, bounds models.ClipBounds) {
+	if matrix.ClipBounds == nil {
+		matrix.ClipBounds = make(map[string]models.ClipBounds)
+	}
+	matrix.ClipBounds[matrix.Columns[colIdx]] = bounds
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestPercentileOf(t *testing.T) {
+	sorted := []float64{0, 10, 20, 30, 40}
+	cases := map[float64]float64{0: 0, 25: 10, 50: 20, 90: 36, 100: 40}
+	for pct, want := range cases {
+		if got := percentileOf(sorted, pct); math.Abs(got-want) > 1e-9 {
+			t.Errorf("percentile %g = %g, want %g", pct, got, want)
+		}
+	}
+}
+
+func TestClipPercentiles(t *testing.T) {
+	// 0..99 with an outlier in the training rows and one after them
+	matrix := &models.FeatureMatrix{
+		Columns: []string{"returns", "target_return_1"},
+		Schema:  []models.FeatureSchema{{Name: "returns"}, {Name: "target_return_1"}},
+	}
+	for i := 0; i < 100; i++ {
+		matrix.Data = append(matrix.Data, []float64{float64(i), float64(i)})
+	}
+	matrix.Data[10][0] = 1e6
+	matrix.Data[90][0] = -1e6
+	matrix.Data[20][0] = math.NaN()
+	matrix.RowCount = len(matrix.Data)
+
+	split := models.SplitConfig{Enabled: true, TimeBased: true, TrainRatio: 0.8}
+	fitRows := trainingRows(matrix.RowCount, split)
+	if fitRows != 80 {
+		t.Fatalf("training rows = %d, want 80", fitRows)
+	}
+	clipPercentiles(matrix, 5, 95, fitRows, nil)
+
+	bounds, ok := matrix.ClipBounds["returns"]
+	if !ok {
+		t.Fatal("no clip bounds recorded for returns")
+	}
+	if bounds.Method != models.ClipMethodPercentile || bounds.FitRows != 80 {
+		t.Errorf("bounds = %+v", bounds)
+	}
+	// The outlier only moves the top percentile by one rank
+	if bounds.Lower < 3 || bounds.Lower > 5 || bounds.Upper < 75 || bounds.Upper > 78 {
+		t.Errorf("bounds %g to %g, want about 4 to 76", bounds.Lower, bounds.Upper)
+	}
+	if matrix.Data[10][0] != bounds.Upper || matrix.Data[90][0] != bounds.Lower {
+		t.Errorf("outliers not clipped: %g, %g", matrix.Data[10][0], matrix.Data[90][0])
+	}
+	if !math.IsNaN(matrix.Data[20][0]) {
+		t.Error("NaN was clipped")
+	}
+	if matrix.Data[50][0] != 50 {
+		t.Errorf("value inside the bounds changed to %g", matrix.Data[50][0])
+	}
+
+	// Targets are left alone
+	if _, ok := matrix.ClipBounds["target_return_1"]; ok || matrix.Data[99][1] != 99 {
+		t.Error("target column was clipped")
+	}
+}
+
+func TestTrainingRows(t *testing.T) {
+	if got := trainingRows(100, models.SplitConfig{}); got != 100 {
+		t.Errorf("no split: %d rows, want 100", got)
+	}
+	if got := trainingRows(100, models.SplitConfig{Enabled: true, Shuffle: true, TrainRatio: 0.7}); got != 100 {
+		t.Errorf("shuffled split: %d rows, want 100", got)
+	}
+	if got := trainingRows(100, models.SplitConfig{Enabled: true, TimeBased: true, TrainRatio: 0.7}); got != 70 {
+		t.Errorf("time-based split: %d rows, want 70", got)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// joinDerivatives loads the funding rates and open interest stored for the
+// symbol over the candles' range and joins them onto the candles (sorted
+// oldest first). It does nothing unless derivatives features are selected.
+func (s *MLExportService) joinDerivatives(ctx context.Context, exchangeID, symbol string, candles []models.Candle, features []string) error {
+	selected := canonicalSelection(derivativesFeatureOrder, features)
+	if len(selected) == 0 || len(candles) == 0 || s.derivativesRepo == nil {
+		return nil
+	}
+
+	// Selections are in canonical order, so funding_rate can only come first
+	// and any other name is an open interest column
+	startMs, endMs := candles[0].Timestamp, candles[len(candles)-1].Timestamp
+
+	var funding []models.FundingRate
+	var openInterest []models.OpenInterest
+	var err error
+	if selected[0] == "funding_rate" {
+		funding, err = s.derivativesRepo.FindFundingRates(ctx, exchangeID, symbol, startMs, endMs)
+		if err != nil {
+			return fmt.Errorf("failed to load derivatives data for %s %s: %w", exchangeID, symbol, err)
+		}
+	}
+	if selected[len(selected)-1] != "funding_rate" {
+		openInterest, err = s.derivativesRepo.FindOpenInterest(ctx, exchangeID, symbol, startMs, endMs)
+		if err != nil {
+			return fmt.Errorf("failed to load derivatives data for %s %s: %w", exchangeID, symbol, err)
+		}
+	}
+
+	alignDerivatives(candles, funding, openInterest)
+	return nil
+}
+
+// alignDerivatives sets each candle's derivatives data to the last funding
+// rate and open interest at or before its open time, so no value from inside
+// or after the candle leaks into it. All inputs must be sorted oldest first.
+func alignDerivatives(candles []models.Candle, funding []models.FundingRate, openInterest []models.OpenInterest) {
+	f, o := -1, -1
+	for i := range candles {
+		ts := candles[i].Timestamp
+		for f+1 < len(funding) && funding[f+1].Timestamp <= ts {
+			f++
+		}
+		for o+1 < len(openInterest) && openInterest[o+1].Timestamp <= ts {
+			o++
+		}
+
+		data := &models.DerivativesData{}
+		if f >= 0 {
+			rate := funding[f].FundingRate
+			data.FundingRate = &rate
+		}
+		if o >= 0 {
+			amount := openInterest[o].OpenInterest
+			data.OpenInterest = &amount
+			if openInterest[o].OpenInterestValue != 0 {
+				value := openInterest[o].OpenInterestValue
+				data.OpenInterestValue = &value
+			}
+		}
+		candles[i].Derivatives = data
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestAlignDerivatives(t *testing.T) {
+	candles := tailTestCandles(4) // opens at 0, 60000, 120000, 180000
+	funding := []models.FundingRate{
+		{Timestamp: 30000, FundingRate: 0.0001},
+		{Timestamp: 120000, FundingRate: 0.0002},
+	}
+	openInterest := []models.OpenInterest{
+		{Timestamp: 0, OpenInterest: 10},
+		{Timestamp: 150000, OpenInterest: 20, OpenInterestValue: 2000},
+	}
+
+	alignDerivatives(candles, funding, openInterest)
+
+	// A rate settled inside a candle only applies from the next one
+	if candles[0].Derivatives.FundingRate != nil {
+		t.Errorf("candle 0 funding rate = %v, want none", *candles[0].Derivatives.FundingRate)
+	}
+	wantFunding := []float64{0.0001, 0.0002, 0.0002}
+	for i, want := range wantFunding {
+		got := candles[i+1].Derivatives.FundingRate
+		if got == nil || *got != want {
+			t.Errorf("candle %d funding rate = %v, want %v", i+1, got, want)
+		}
+	}
+
+	wantOI := []float64{10, 10, 10, 20}
+	for i, want := range wantOI {
+		got := candles[i].Derivatives.OpenInterest
+		if got == nil || *got != want {
+			t.Errorf("candle %d open interest = %v, want %v", i, got, want)
+		}
+	}
+	if candles[2].Derivatives.OpenInterestValue != nil {
+		t.Errorf("candle 2 open interest value set without a reported value")
+	}
+	if v := candles[3].Derivatives.OpenInterestValue; v == nil || *v != 2000 {
+		t.Errorf("candle 3 open interest value = %v, want 2000", v)
+	}
+}
+
+func TestAddDerivativesFeatures(t *testing.T) {
+	candles := tailTestCandles(3)
+	alignDerivatives(candles, []models.FundingRate{{Timestamp: 60000, FundingRate: 0.0003}}, nil)
+
+	engine := NewMLFeatureEngine()
+	matrix := &models.FeatureMatrix{Data: make([][]float64, len(candles))}
+	engine.addDerivativesFeatures(matrix, candles, []string{"open_interest", "funding_rate", "unknown"})
+
+	if len(matrix.Columns) != 2 || matrix.Columns[0] != "funding_rate" || matrix.Columns[1] != "open_interest" {
+		t.Fatalf("columns = %v, want funding_rate then open_interest", matrix.Columns)
+	}
+	if !math.IsNaN(matrix.Data[0][0]) || matrix.Data[1][0] != 0.0003 || matrix.Data[2][0] != 0.0003 {
+		t.Errorf("funding rate column = %v %v %v", matrix.Data[0][0], matrix.Data[1][0], matrix.Data[2][0])
+	}
+	for i := range candles {
+		if !math.IsNaN(matrix.Data[i][1]) {
+			t.Errorf("row %d open interest = %v, want NaN", i, matrix.Data[i][1])
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func fillTestMatrix(values ...float64) *models.FeatureMatrix {
+	matrix := &models.FeatureMatrix{Columns: []string{"x"}}
+	for _, v := range values {
+		matrix.Data = append(matrix.Data, []float64{v})
+	}
+	return matrix
+}
+
+func assertColumn(t *testing.T, matrix *models.FeatureMatrix, want ...float64) {
+	t.Helper()
+	for i, row := range matrix.Data {
+		got := row[0]
+		if math.IsNaN(want[i]) != math.IsNaN(got) || (!math.IsNaN(got) && got != want[i]) {
+			t.Fatalf("row %d = %v, want %v (column %v)", i, got, want[i], matrix.Data)
+		}
+	}
+}
+
+func TestHandleNaNForwardFillLimit(t *testing.T) {
+	s := &MLExportService{}
+	nan := math.NaN()
+
+	matrix := fillTestMatrix(nan, 1, nan, nan, nan, 2, nan)
+	s.handleNaN(matrix, models.NaNHandlingForwardFill, 2)
+	assertColumn(t, matrix, nan, 1, 1, 1, nan, 2, 2)
+
+	// 0 keeps the unlimited fill, including the leading zero fill
+	matrix = fillTestMatrix(nan, 1, nan, nan, nan, 2, nan)
+	s.handleNaN(matrix, models.NaNHandlingForwardFill, 0)
+	assertColumn(t, matrix, 0, 1, 1, 1, 1, 2, 2)
+}
+
+func TestHandleNaNBackwardFillLimit(t *testing.T) {
+	s := &MLExportService{}
+	nan := math.NaN()
+
+	matrix := fillTestMatrix(nan, 1, nan, nan, nan, 2, nan)
+	s.handleNaN(matrix, models.NaNHandlingBackwardFill, 2)
+	assertColumn(t, matrix, 1, 1, nan, 2, 2, 2, nan)
+}
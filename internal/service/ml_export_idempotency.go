@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// Idempotency key scopes, so the same key sent to different endpoints doesn't collide
+const (
+	IdempotencyScopeExport  = "export"
+	IdempotencyScopeDataset = "dataset"
+)
+
+// Reasons a request with an idempotency key is rejected. Errors returned for
+// them match with errors.Is, so callers can tell them from storage failures.
+var (
+	ErrIdempotencyKeyMismatch   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key is in use by a request that is still in progress")
+)
+
+// StartExportIdempotent starts an export like StartExport, unless one was
+// already started with the same key and request within the key TTL; that job
+// is then returned with replayed set. An empty key always starts a new export.
+// A key whose first request failed, or whose job could not be recorded, is
+// released so the client can retry.
+func (s *MLExportService) StartExportIdempotent(ctx context.Context, scope, key string, config models.MLExportConfig, jobIDs []primitive.ObjectID) (*models.MLExportJob, bool, error) {
+	if key == "" || s.idempotencyRepo == nil || s.idempotencyTTL <= 0 {
+		exportJob, err := s.StartExport(ctx, config, jobIDs)
+		return exportJob, false, err
+	}
+
+	requestHash, err := idempotencyRequestHash(config, jobIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	existing, err := s.idempotencyRepo.Reserve(ctx, &models.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.idempotencyTTL),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing != nil {
+		if existing.RequestHash != requestHash {
+			return nil, false, fmt.Errorf("%w: %q", ErrIdempotencyKeyMismatch, key)
+		}
+		if existing.ExportJobID == nil {
+			return nil, false, fmt.Errorf("%w: %q", ErrIdempotencyKeyInProgress, key)
+		}
+		exportJob, err := s.exportRepo.FindExportJobByID(ctx, *existing.ExportJobID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find the export job started with key %q: %w", key, err)
+		}
+		logging.Printf(ctx, "[ML_EXPORT] Idempotency key %q replayed export job %s", key, exportJob.ID.Hex())
+		return exportJob, true, nil
+	}
+
+	exportJob, err := s.StartExport(ctx, config, jobIDs)
+	if err != nil {
+		if releaseErr := s.idempotencyRepo.Release(ctx, scope, key); releaseErr != nil {
+			logging.Printf(ctx, "[ML_EXPORT] %v", releaseErr)
+		}
+		return nil, false, err
+	}
+
+	// A key left reserved without its job would answer every retry with
+	// "in progress" until it expires, so it is released instead; a retry then
+	// starts a new export rather than replaying this one
+	if err := s.idempotencyRepo.SetExportJob(ctx, scope, key, exportJob.ID); err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] %v", err)
+		if releaseErr := s.idempotencyRepo.Release(ctx, scope, key); releaseErr != nil {
+			logging.Printf(ctx, "[ML_EXPORT] %v", releaseErr)
+		}
+	}
+
+	return exportJob, false, nil
+}
+
+// idempotencyRequestHash identifies the request an idempotency key was sent with
+func idempotencyRequestHash(config models.MLExportConfig, jobIDs []primitive.ObjectID) (string, error) {
+	data, err := json.Marshal(struct {
+		Config models.MLExportConfig `json:"config"`
+		JobIDs []primitive.ObjectID  `json:"job_ids"`
+	}{config, jobIDs})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
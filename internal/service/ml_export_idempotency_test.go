@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestIdempotencyRequestHash(t *testing.T) {
+	config := models.DefaultMLExportConfig()
+	jobIDs := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+
+	first, err := idempotencyRequestHash(config, jobIDs)
+	if err != nil {
+		t.Fatalf("idempotencyRequestHash() error = %v", err)
+	}
+	retry, _ := idempotencyRequestHash(config, jobIDs)
+	if first != retry {
+		t.Errorf("retried request hashed to %s, want %s", retry, first)
+	}
+
+	otherJobs, _ := idempotencyRequestHash(config, jobIDs[:1])
+	config.Format = models.MLExportFormatParquet
+	otherConfig, _ := idempotencyRequestHash(config, jobIDs)
+	if otherJobs == first || otherConfig == first {
+		t.Error("different requests should not share a hash")
+	}
+}
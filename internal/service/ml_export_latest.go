@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// MaxLatestFeatureRows caps the rows LatestFeatures returns
+const MaxLatestFeatureRows = 500
+
+// LatestFeatures generates the feature rows of a job's most recent candles
+// for serving a trained model. Only as many candles as the feature warm-up
+// needs are loaded, and targets, splits and sequences are never built. Nothing
+// is fitted on the live data: when trainedOn is set, the rows are projected
+// onto that export's feature columns, in its order, and its normalization
+// params are applied, so they match what the model was trained on.
+func (s *MLExportService) LatestFeatures(ctx context.Context, jobID primitive.ObjectID, config models.MLExportConfig, trainedOn *models.MLExportJob, rows int) (*models.LatestFeatures, error) {
+	job, err := s.jobRepo.FindByID(ctx, jobID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job %s: %w", jobID.Hex(), err)
+	}
+
+	// Warm-up is counted in output bars; a resampled bar spans several stored ones
+	bars := rows + FeatureWarmup(config.Features)
+	timeframe := job.Timeframe
+	if config.Resample.Enabled && config.Resample.Timeframe != "" && config.Resample.Timeframe != job.Timeframe {
+		sourceMinutes := models.GetTimeframeDurationMinutes(job.Timeframe)
+		targetMinutes := models.GetTimeframeDurationMinutes(config.Resample.Timeframe)
+		if sourceMinutes > 0 && targetMinutes > 0 {
+			ratio := int(targetMinutes / sourceMinutes)
+			bars = (bars + 1) * max(ratio, 1)
+		}
+		timeframe = config.Resample.Timeframe
+	}
+	if config.Features.ComputeMissingIndicators {
+		bars += previewWarmupBars
+	}
+
+	candles, err := s.ohlcvRepo.GetRecentCandles(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, bars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load candles for job %s: %w", jobID.Hex(), err)
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no data for job %s", jobID.Hex())
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp < candles[j].Timestamp
+	})
+
+	if config.Resample.Enabled {
+		candles, err = ResampleCandles(candles, job.Timeframe, config.Resample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resample candles for job %s: %w", jobID.Hex(), err)
+		}
+		if len(candles) == 0 {
+			return nil, fmt.Errorf("no data for job %s", jobID.Hex())
+		}
+	}
+	if config.ExcludesUnclosedBar() {
+		candles = dropUnclosedBar(candles, timeframe, time.Now())
+		if len(candles) == 0 {
+			return nil, fmt.Errorf("no closed bars for job %s", jobID.Hex())
+		}
+	}
+
+	if config.Features.ComputeMissingIndicators {
+		indicatorConfig, err := s.jobIndicatorConfig(ctx, jobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load indicator config: %w", err)
+		}
+		if err := s.fillMissingIndicators(candles, indicatorConfig); err != nil {
+			return nil, fmt.Errorf("failed to compute indicators for job %s: %w", jobID.Hex(), err)
+		}
+	}
+
+	if err := s.joinDerivatives(ctx, job.ConnectorExchangeID, job.Symbol, candles, config.Features.DerivativesFeatures); err != nil {
+		return nil, err
+	}
+
+	matrix, err := s.featureEngine.GenerateFeatures(ctx, candles, config.Features, timeframe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate features: %w", err)
+	}
+
+	if _, err := s.joinAuxiliarySources(ctx, matrix, config.AuxiliarySources, timeframe); err != nil {
+		return nil, fmt.Errorf("failed to join auxiliary sources: %w", err)
+	}
+
+	result := &models.LatestFeatures{
+		JobID:     jobID,
+		Symbol:    job.Symbol,
+		Timeframe: timeframe,
+		Columns:   matrix.Columns,
+	}
+
+	if trainedOn != nil {
+		columns := featureColumns(trainedOn.ColumnNames)
+		if err := projectMatrix(matrix, columns); err != nil {
+			return nil, fmt.Errorf("config does not produce the features of export %s: %w", trainedOn.ID.Hex(), err)
+		}
+		result.Columns = columns
+		result.ExportJobID = trainedOn.ID.Hex()
+
+		applyClipBounds(matrix, trainedOn.Metadata.ClipBounds)
+		if len(trainedOn.Metadata.NormalizationParams) > 0 {
+			applyNormParams(matrix, trainedOn.Metadata.NormalizationParams)
+			result.Normalized = true
+		}
+	} else {
+		applyColumnOrder(matrix, config.ColumnOrder)
+		result.Columns = matrix.Columns
+	}
+
+	start := max(matrix.RowCount-rows, 0)
+	result.Rows = make([]models.FeatureRow, 0, matrix.RowCount-start)
+	for i := start; i < matrix.RowCount; i++ {
+		values := make([]*float64, len(matrix.Data[i]))
+		for j, v := range matrix.Data[i] {
+			// JSON has no NaN or Inf, features without a value are null
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				values[j] = &v
+			}
+		}
+		result.Rows = append(result.Rows, models.FeatureRow{
+			Timestamp: time.UnixMilli(matrix.Timestamps[i]).UTC(),
+			Values:    values,
+		})
+	}
+
+	return result, nil
+}
+
+// jobIndicatorConfig returns the indicator config a job's stored candles are
+// computed with, so indicators filled in live match the stored ones
+func (s *MLExportService) jobIndicatorConfig(ctx context.Context, jobID primitive.ObjectID) (*models.IndicatorConfig, error) {
+	if s.recalcService == nil {
+		return s.indicatorConfigRepo.FindDefault(ctx)
+	}
+	_, config, err := s.recalcService.IndicatorConfigForJob(ctx, jobID.Hex())
+	return config, err
+}
+
+// Columns an export adds for splitting its rows rather than as features
+var splitColumns = map[string]bool{
+	"shuffle_index": true,
+}
+
+// featureColumns drops the target and split columns of an export's column names
+func featureColumns(columns []string) []string {
+	features := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if !strings.HasPrefix(col, "target_") && !splitColumns[col] {
+			features = append(features, col)
+		}
+	}
+	return features
+}
+
+// projectMatrix keeps only columns, in the given order
+func projectMatrix(matrix *models.FeatureMatrix, columns []string) error {
+	index := make(map[string]int, len(matrix.Columns))
+	for i, col := range matrix.Columns {
+		index[col] = i
+	}
+
+	positions := make([]int, len(columns))
+	var missing []string
+	for i, col := range columns {
+		pos, ok := index[col]
+		if !ok {
+			missing = append(missing, col)
+			continue
+		}
+		positions[i] = pos
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing columns: %s", strings.Join(missing, ", "))
+	}
+
+	for r, row := range matrix.Data {
+		projected := make([]float64, len(positions))
+		for i, pos := range positions {
+			projected[i] = row[pos]
+		}
+		matrix.Data[r] = projected
+	}
+	matrix.Columns = columns
+	matrix.ColumnCount = len(columns)
+
+	return nil
+}
+
+// applyNormParams scales columns with previously fitted normalization params,
+// the same way normalize does when it fits them
+func applyNormParams(matrix *models.FeatureMatrix, params map[string]models.NormParams) {
+	for colIdx, colName := range matrix.Columns {
+		param, ok := params[colName]
+		if !ok {
+			continue
+		}
+
+		var offset, scale float64
+		switch models.NormalizationType(param.Method) {
+		case models.NormalizationMinMax:
+			offset, scale = param.Min, param.Max-param.Min
+		case models.NormalizationZScore:
+			offset, scale = param.Mean, param.Std
+		case models.NormalizationRobust:
+			offset, scale = param.Median, param.IQR
+		default:
+			continue
+		}
+		if scale <= 0 {
+			continue
+		}
+
+		for rowIdx := range matrix.Data {
+			if !math.IsNaN(matrix.Data[rowIdx][colIdx]) {
+				matrix.Data[rowIdx][colIdx] = (matrix.Data[rowIdx][colIdx] - offset) / scale
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestProjectMatrix(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:     []string{"close", "rsi14", "target_direction_1", "sma20"},
+		Data:        [][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}},
+		RowCount:    2,
+		ColumnCount: 4,
+	}
+
+	columns := featureColumns([]string{"sma20", "target_direction_1", "close"})
+	if err := projectMatrix(matrix, columns); err != nil {
+		t.Fatalf("projectMatrix() error = %v", err)
+	}
+	want := [][]float64{{4, 1}, {8, 5}}
+	if !reflect.DeepEqual(matrix.Data, want) || matrix.ColumnCount != 2 {
+		t.Errorf("projectMatrix() = %v, want %v", matrix.Data, want)
+	}
+
+	if err := projectMatrix(matrix, []string{"close", "atr"}); err == nil {
+		t.Error("projectMatrix() with a column the matrix lacks should fail")
+	}
+}
+
+// An export split with a shuffle seed stores shuffle_index among its columns;
+// latest rows are generated without splits and must still project onto it
+func TestProjectMatrixOntoShuffledExport(t *testing.T) {
+	s := &MLExportService{featureEngine: NewMLFeatureEngine()}
+	config := models.DefaultMLExportConfig()
+	candles := storedFeatureCandles(50)
+
+	exported, err := s.featureEngine.GenerateFeatures(context.Background(), candles, config.Features, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.featureEngine.GenerateTargets(context.Background(), exported, candles, config.Target); err != nil {
+		t.Fatal(err)
+	}
+	s.addShuffleIndex(exported, 30, 42)
+	trainedOn := &models.MLExportJob{ColumnNames: exported.Columns}
+
+	latest, err := s.featureEngine.GenerateFeatures(context.Background(), candles, config.Features, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := featureColumns(trainedOn.ColumnNames)
+	if err := projectMatrix(latest, columns); err != nil {
+		t.Fatalf("projectMatrix() onto a shuffled export error = %v", err)
+	}
+	for _, col := range latest.Columns {
+		if col == "shuffle_index" {
+			t.Error("shuffle_index should not be served as a feature")
+		}
+	}
+}
+
+func TestApplyNormParams(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns: []string{"a", "b", "c", "d"},
+		Data:    [][]float64{{15, 7, 3, math.NaN()}},
+	}
+
+	applyNormParams(matrix, map[string]models.NormParams{
+		"a": {Method: string(models.NormalizationMinMax), Min: 10, Max: 20},
+		"b": {Method: string(models.NormalizationZScore), Mean: 5, Std: 2},
+		"c": {Method: string(models.NormalizationRobust), Median: 3, IQR: 0}, // zero IQR is left unscaled
+		"d": {Method: string(models.NormalizationZScore), Mean: 1, Std: 1},
+	})
+
+	row := matrix.Data[0]
+	if row[0] != 0.5 || row[1] != 1 || row[2] != 3 || !math.IsNaN(row[3]) {
+		t.Errorf("applyNormParams() = %v, want [0.5 1 3 NaN]", row)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// fakeSourceRepo serves the jobs and candles of an export's sources, waiting
+// a fixed round trip on every query like a MongoDB read would
+type fakeSourceRepo struct {
+	latency time.Duration
+	jobs    map[string]*models.Job
+	candles map[string][]models.Candle
+}
+
+func newFakeSourceRepo(jobCount, bars int, latency time.Duration) (*fakeSourceRepo, []primitive.ObjectID) {
+	repo := &fakeSourceRepo{
+		latency: latency,
+		jobs:    make(map[string]*models.Job, jobCount),
+		candles: make(map[string][]models.Candle, jobCount),
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	ids := make([]primitive.ObjectID, jobCount)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+		job := &models.Job{ID: ids[i], ConnectorExchangeID: "binance", Symbol: fmt.Sprintf("SYM%d/USDT", i), Timeframe: "1h"}
+		repo.jobs[ids[i].Hex()] = job
+
+		// Stored newest first, like chunks are read back
+		candles := make([]models.Candle, bars)
+		for j := range candles {
+			candles[bars-1-j] = models.Candle{Timestamp: start + int64(j)*3_600_000, Open: 100, High: 101, Low: 99, Close: 100.5, Volume: 10}
+		}
+		repo.candles[job.Symbol] = candles
+	}
+	return repo, ids
+}
+
+func (r *fakeSourceRepo) FindByID(ctx context.Context, id string) (*models.Job, error) {
+	time.Sleep(r.latency)
+	return r.jobs[id], nil
+}
+
+func (r *fakeSourceRepo) FindByJob(ctx context.Context, exchangeID, symbol, timeframe string) (*models.OHLCVDocument, error) {
+	time.Sleep(r.latency)
+	return &models.OHLCVDocument{Candles: append([]models.Candle(nil), r.candles[symbol]...)}, nil
+}
+
+// BenchmarkLoadSources loads a 20-job export from a repository with a 5ms
+// round trip, comparing one job at a time with the concurrency limits an
+// operator would configure
+func BenchmarkLoadSources(b *testing.B) {
+	repo, ids := newFakeSourceRepo(20, 8760, 5*time.Millisecond)
+
+	load := func(ctx context.Context, jobID primitive.ObjectID) ([]models.Candle, error) {
+		job, err := repo.FindByID(ctx, jobID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		doc, err := repo.FindByJob(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			return nil, err
+		}
+		candles := doc.Candles
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].Timestamp < candles[j].Timestamp
+		})
+		return candles, nil
+	}
+
+	for _, limit := range []int{1, 4, 8, 20} {
+		b.Run(fmt.Sprintf("concurrency=%d", limit), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				results, err := loadSources(context.Background(), ids, limit, load)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(results) != len(ids) {
+					b.Fatalf("loaded %d sources, want %d", len(results), len(ids))
+				}
+			}
+		})
+	}
+}
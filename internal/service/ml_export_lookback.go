@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/datacollector/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// indicatorWarmup is the number of leading bars each indicator needs before it
+// produces a value, using the IndicatorConfig default periods
+var indicatorWarmup = map[string]struct {
+	category string
+	bars     int
+}{
+	// Trend
+	"sma20":             {"trend", 19},
+	"sma50":             {"trend", 49},
+	"sma200":            {"trend", 199},
+	"ema12":             {"trend", 11},
+	"ema26":             {"trend", 25},
+	"ema50":             {"trend", 49},
+	"dema":              {"trend", 38},
+	"tema":              {"trend", 57},
+	"wma":               {"trend", 19},
+	"hma":               {"trend", 10},
+	"vwma":              {"trend", 19},
+	"ichimoku_tenkan":   {"trend", 8},
+	"ichimoku_kijun":    {"trend", 25},
+	"ichimoku_senkou_a": {"trend", 25},
+	"ichimoku_senkou_b": {"trend", 51},
+	"adx":               {"trend", 27},
+	"plus_di":           {"trend", 14},
+	"minus_di":          {"trend", 14},
+	"supertrend":        {"trend", 10},
+
+	// Momentum
+	"rsi6":        {"momentum", 6},
+	"rsi14":       {"momentum", 14},
+	"rsi24":       {"momentum", 24},
+	"stoch_k":     {"momentum", 13},
+	"stoch_d":     {"momentum", 15},
+	"macd":        {"momentum", 25},
+	"macd_signal": {"momentum", 33},
+	"macd_hist":   {"momentum", 33},
+	"roc":         {"momentum", 12},
+	"cci":         {"momentum", 19},
+	"williams_r":  {"momentum", 13},
+	"momentum":    {"momentum", 10},
+
+	// Volatility
+	"bb_upper":        {"volatility", 19},
+	"bb_middle":       {"volatility", 19},
+	"bb_lower":        {"volatility", 19},
+	"bb_bandwidth":    {"volatility", 19},
+	"bb_percent_b":    {"volatility", 19},
+	"atr":             {"volatility", 14},
+	"keltner_upper":   {"volatility", 19},
+	"keltner_middle":  {"volatility", 19},
+	"keltner_lower":   {"volatility", 19},
+	"donchian_upper":  {"volatility", 19},
+	"donchian_middle": {"volatility", 19},
+	"donchian_lower":  {"volatility", 19},
+	"stddev":          {"volatility", 19},
+
+	// Volume
+	"obv":        {"volume", 0},
+	"vwap":       {"volume", 0},
+	"mfi":        {"volume", 14},
+	"cmf":        {"volume", 19},
+	"volume_sma": {"volume", 19},
+}
+
+// crossFeatureWarmup is the warm-up of each cross feature (that of the indicators it reads)
+var crossFeatureWarmup = map[string]int{
+	"bb_position":     19,
+	"price_vs_sma20":  19,
+	"price_vs_sma50":  49,
+	"price_vs_sma200": 199,
+	"ma_crossover":    25,
+	"rsi_oversold":    14,
+	"rsi_overbought":  14,
+}
+
+// FeatureWarmup returns the number of leading bars the feature config needs
+// before every selected feature has a value
+func FeatureWarmup(config models.FeatureConfig) int {
+	warmup := 0
+
+	for name, ind := range indicatorWarmup {
+		if ind.bars > warmup && includeIndicator(config, name, ind.category) {
+			warmup = ind.bars
+		}
+	}
+
+	for _, name := range config.CrossFeatures {
+		if crossFeatureWarmup[name] > warmup {
+			warmup = crossFeatureWarmup[name]
+		}
+	}
+
+	// Price features other than intra-bar ones compare against the previous close
+	if len(config.PriceFeatures) > 0 && warmup < 1 {
+		warmup = 1
+	}
+	for _, name := range config.PriceFeatures {
+		if name == "frac_diff" {
+			if width := len(config.FracDiff.Weights()); width-1 > warmup {
+				warmup = width - 1
+			}
+		}
+	}
+
+	// Lags and rolling windows stack on top of the base features
+	if config.LaggedFeatures.Enabled {
+		warmup += maxInt(config.LaggedFeatures.LagPeriods)
+	}
+	if config.RollingFeatures.Enabled {
+		if window := maxInt(config.RollingFeatures.Windows); window > 1 {
+			warmup += window - 1
+		}
+	}
+
+	return warmup
+}
+
+// RequiredBars returns the minimum bars a source job needs to yield at least one
+// complete row: the feature warm-up, the target lookahead and one sequence
+func RequiredBars(config models.MLExportConfig) int {
+	if config.MinBars.MinBars > 0 {
+		return config.MinBars.MinBars
+	}
+
+	warmup := FeatureWarmup(config.Features)
+	if trim := WarmupTrimRows(config); trim > warmup {
+		warmup = trim
+	}
+	required := warmup + TargetLookahead(config.Target)
+
+	if config.Sequence.Enabled && config.Sequence.Length > 1 {
+		required += config.Sequence.Length
+	} else {
+		required++
+	}
+
+	return required
+}
+
+// WarmupTrimRows returns the leading rows TrimWarmup drops, 0 when it is off
+func WarmupTrimRows(config models.MLExportConfig) int {
+	if !config.Preprocessing.TrimWarmup {
+		return 0
+	}
+	if config.Preprocessing.WarmupRows > 0 {
+		return config.Preprocessing.WarmupRows
+	}
+	return FeatureWarmup(config.Features)
+}
+
+// TargetLookahead returns the trailing bars the largest target lookahead needs
+func TargetLookahead(config models.TargetConfig) int {
+	if !config.Enabled {
+		return 0
+	}
+	lookahead := 0
+	for _, spec := range config.Specs() {
+		if period := maxInt(spec.LookaheadPeriods); period > lookahead {
+			lookahead = period
+		}
+	}
+	return lookahead
+}
+
+// Preflight checks, without loading candles, that every source job has enough
+// bars for the config's largest window, lag, lookahead and sequence. Bar counts
+// of resampled exports are estimated from the timeframe ratio.
+func (s *MLExportService) Preflight(ctx context.Context, config models.MLExportConfig, jobIDs []primitive.ObjectID) (*models.ExportPreflight, error) {
+	required := RequiredBars(config)
+	preflight := &models.ExportPreflight{
+		RequiredBars:  required,
+		FeatureWarmup: FeatureWarmup(config.Features),
+		Lookahead:     TargetLookahead(config.Target),
+		Sources:       make([]models.PreflightSource, 0, len(jobIDs)),
+	}
+
+	var short []models.SkippedSourceInfo
+	for _, jobID := range jobIDs {
+		job, err := s.jobRepo.FindByID(ctx, jobID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find job %s: %w", jobID.Hex(), err)
+		}
+
+		_, _, count, err := s.ohlcvRepo.GetTimeRange(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count candles for job %s: %w", jobID.Hex(), err)
+		}
+
+		source := models.PreflightSource{
+			JobID:     jobID,
+			Symbol:    job.Symbol,
+			Timeframe: job.Timeframe,
+			BarCount:  int64(count),
+		}
+
+		if config.Resample.Enabled && config.Resample.Timeframe != "" && config.Resample.Timeframe != job.Timeframe {
+			sourceMinutes := models.GetTimeframeDurationMinutes(job.Timeframe)
+			targetMinutes := models.GetTimeframeDurationMinutes(config.Resample.Timeframe)
+			if sourceMinutes > 0 && targetMinutes > 0 {
+				source.BarCount = int64(count) * sourceMinutes / targetMinutes
+				source.Estimated = true
+			}
+		}
+
+		source.Sufficient = source.BarCount >= int64(required)
+		if !source.Sufficient {
+			source.Shortfall = int64(required) - source.BarCount
+			short = append(short, models.SkippedSourceInfo{
+				JobID:        jobID,
+				Symbol:       job.Symbol,
+				Timeframe:    job.Timeframe,
+				BarCount:     source.BarCount,
+				RequiredBars: required,
+			})
+		}
+		preflight.Sources = append(preflight.Sources, source)
+	}
+
+	switch {
+	case len(short) == 0:
+		preflight.OK = true
+	case len(short) == len(jobIDs):
+		preflight.Errors = append(preflight.Errors, fmt.Sprintf(
+			"all source jobs have fewer than the %d bars required by the export config: %s",
+			required, describeShortSources(short)))
+	case config.MinBars.Action == models.MinBarsActionSkip:
+		// Short sources will be left out of the export
+		preflight.OK = true
+	default:
+		preflight.Errors = append(preflight.Errors, fmt.Sprintf(
+			"source jobs have fewer than the %d bars required by the export config: %s",
+			required, describeShortSources(short)))
+	}
+
+	return preflight, nil
+}
+
+// maxInt returns the largest value, or 0 for an empty slice
+func maxInt(values []int) int {
+	largest := 0
+	for _, v := range values {
+		if v > largest {
+			largest = v
+		}
+	}
+	return largest
+}
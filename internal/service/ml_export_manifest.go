@@ -0,0 +1,81 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// splitLabels are the split file labels in manifest order
+var splitLabels = []string{"train", "validation", "test"}
+
+// writeSplitManifest writes <basePath>_manifest.json describing the split
+// files <basePath>_<label><ext> the writer produced. It returns the manifest
+// path and the split file paths; no manifest is written when the writer
+// produced no split files (e.g. NumPy, which keeps splits in one file).
+func writeSplitManifest(exportJob *models.MLExportJob, columns []string, basePath, ext string, splitInfo *models.SplitInfo) (string, []string, error) {
+	manifest := models.SplitManifest{
+		ExportJobID: exportJob.ID.Hex(),
+		Format:      exportJob.Config.Format,
+		Columns:     columns,
+		CreatedAt:   time.Now(),
+	}
+
+	var splitPaths []string
+	for _, label := range splitLabels {
+		path := fmt.Sprintf("%s_%s%s", basePath, label, ext)
+		checksum, size, err := FileSHA256(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to hash %s split file: %w", label, err)
+		}
+
+		file := models.SplitManifestFile{
+			Split:     label,
+			File:      filepath.Base(path),
+			SizeBytes: size,
+			Checksum:  checksum,
+		}
+		if splitInfo != nil {
+			file.RowCount, file.StartTime, file.EndTime = splitRange(splitInfo, label)
+		}
+
+		manifest.Files = append(manifest.Files, file)
+		splitPaths = append(splitPaths, path)
+	}
+
+	if len(splitPaths) == 0 {
+		return "", nil, nil
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode split manifest: %w", err)
+	}
+
+	manifestPath := basePath + "_manifest.json"
+	if err := writeFileAtomic(manifestPath, data); err != nil {
+		return "", nil, fmt.Errorf("failed to write split manifest: %w", err)
+	}
+
+	return manifestPath, splitPaths, nil
+}
+
+// splitRange returns the row count and time range SplitInfo records for a split
+func splitRange(info *models.SplitInfo, label string) (int64, time.Time, time.Time) {
+	switch label {
+	case "train":
+		return info.TrainRows, info.TrainStart, info.TrainEnd
+	case "validation":
+		return info.ValRows, info.ValStart, info.ValEnd
+	case "test":
+		return info.TestRows, info.TestStart, info.TestEnd
+	}
+	return 0, time.Time{}, time.Time{}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestWriteSplitManifest(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "ml_export_test")
+
+	matrix := &models.FeatureMatrix{
+		Columns:     []string{"close"},
+		Data:        [][]float64{{1}, {2}, {3}, {4}},
+		Timestamps:  []int64{1000, 2000, 3000, 4000},
+		SplitLabels: []string{"train", "train", "validation", "test"},
+		RowCount:    4,
+		ColumnCount: 1,
+	}
+	options := DefaultWriterOptions()
+	options.SplitByLabel = true
+	writer := &CSVExportWriter{options: options}
+	if err := writer.Write(matrix, basePath+".csv"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	splitInfo := &models.SplitInfo{
+		TrainStart: time.UnixMilli(1000), TrainEnd: time.UnixMilli(2000), TrainRows: 2,
+		ValStart: time.UnixMilli(3000), ValEnd: time.UnixMilli(3000), ValRows: 1,
+		TestStart: time.UnixMilli(4000), TestEnd: time.UnixMilli(4000), TestRows: 1,
+	}
+	exportJob := &models.MLExportJob{ID: primitive.NewObjectID()}
+	exportJob.Config.Format = models.MLExportFormatCSV
+
+	manifestPath, splitPaths, err := writeSplitManifest(exportJob, matrix.Columns, basePath, ".csv", splitInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if manifestPath != basePath+"_manifest.json" {
+		t.Errorf("manifest path = %s", manifestPath)
+	}
+	if len(splitPaths) != 3 {
+		t.Fatalf("expected 3 split files, got %v", splitPaths)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var manifest models.SplitManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+
+	if manifest.ExportJobID != exportJob.ID.Hex() || len(manifest.Files) != 3 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for i, want := range []struct {
+		split string
+		rows  int64
+		start int64
+	}{{"train", 2, 1000}, {"validation", 1, 3000}, {"test", 1, 4000}} {
+		file := manifest.Files[i]
+		if file.Split != want.split || file.RowCount != want.rows || file.StartTime.UnixMilli() != want.start {
+			t.Errorf("file %d = %+v, want %s with %d rows from %d", i, file, want.split, want.rows, want.start)
+		}
+		if file.File != "ml_export_test_"+want.split+".csv" {
+			t.Errorf("file %d name = %s", i, file.File)
+		}
+
+		checksum, size, err := FileSHA256(splitPaths[i])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if file.Checksum != checksum || file.SizeBytes != size {
+			t.Errorf("file %d checksum/size = %s/%d, want %s/%d", i, file.Checksum, file.SizeBytes, checksum, size)
+		}
+	}
+}
+
+func TestWriteSplitManifestWithoutSplitFiles(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "ml_export_test")
+	exportJob := &models.MLExportJob{ID: primitive.NewObjectID()}
+
+	manifestPath, splitPaths, err := writeSplitManifest(exportJob, nil, basePath, ".npz", nil)
+	if err != nil || manifestPath != "" || splitPaths != nil {
+		t.Fatalf("expected no manifest, got %q %v %v", manifestPath, splitPaths, err)
+	}
+}
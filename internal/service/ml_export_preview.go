@@ -0,0 +1,495 @@
+package service
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// Row counts of export previews
+const (
+	DefaultPreviewRows = 10
+	MaxPreviewRows     = 100
+)
+
+// filePreview is what previewing one output file yields
+type filePreview struct {
+	columns []string
+	head    []models.MLExportPreviewRow
+	tail    []models.MLExportPreviewRow
+}
+
+// previewCollector keeps the first and last n rows of a stream of rows
+type previewCollector struct {
+	n     int
+	count int
+	head  []models.MLExportPreviewRow
+	tail  []models.MLExportPreviewRow
+}
+
+// add records the next row
+func (p *previewCollector) add(row models.MLExportPreviewRow) {
+	p.count++
+	if len(p.head) < p.n {
+		p.head = append(p.head, row)
+	}
+	p.tail = append(p.tail, row)
+	if len(p.tail) > p.n {
+		p.tail = p.tail[1:]
+	}
+}
+
+// PreviewExport returns the first and last n rows of a completed export,
+// read from its output file. Text formats are read through once; Parquet and
+// NumPy outputs are parsed only where the previewed rows are. Split outputs
+// preview the head of the first split file and the tail of the last.
+func (s *MLExportService) PreviewExport(ctx context.Context, id string, n int) (*models.MLExportPreview, error) {
+	exportJob, err := s.GetExportJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exportJob.Status != models.MLExportStatusCompleted {
+		return nil, fmt.Errorf("export job is not completed")
+	}
+
+	files := exportJob.OutputFiles
+	if len(files) == 0 {
+		files = []string{exportJob.OutputPath}
+	}
+
+	first, err := s.previewFile(ctx, files[0], n, exportJob.ColumnNames)
+	if err != nil {
+		return nil, err
+	}
+	last := first
+	if len(files) > 1 {
+		if last, err = s.previewFile(ctx, files[len(files)-1], n, exportJob.ColumnNames); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.MLExportPreview{
+		ExportJobID: exportJob.ID.Hex(),
+		Format:      exportJob.Config.Format,
+		Columns:     first.columns,
+		RowCount:    exportJob.RowCount,
+		Head:        first.head,
+		Tail:        last.tail,
+	}, nil
+}
+
+// previewFile reads the first and last n rows of one output file, picking
+// the parser from its extension
+func (s *MLExportService) previewFile(ctx context.Context, location string, n int, columns []string) (*filePreview, error) {
+	file, err := s.OpenExportFile(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	path := strings.TrimSuffix(location, ".gz")
+	var reader io.Reader = file
+	if path != location {
+		gzReader, err := gzip.NewReader(bufio.NewReader(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open compressed export: %w", err)
+		}
+		defer gzReader.Close()
+		reader = gzReader
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return previewCSV(reader, n)
+	case strings.HasSuffix(path, ".jsonl"):
+		return previewJSONL(reader, n, columns)
+	case strings.HasSuffix(path, ".json"):
+		return previewJSON(reader, n)
+	case strings.HasSuffix(path, ".parquet"):
+		return previewParquet(reader, n)
+	case strings.HasSuffix(path, ".npz"):
+		return previewNumpy(reader, n, columns)
+	}
+	return nil, fmt.Errorf("preview is not supported for %s", filepath.Base(path))
+}
+
+// previewValue maps a value to its preview form: NaN and infinities are null
+func previewValue(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+// previewTime converts a millisecond timestamp to a UTC time
+func previewTime(ms int64) *time.Time {
+	t := time.UnixMilli(ms).UTC()
+	return &t
+}
+
+// previewCSV reads the header row for the columns; the index, timestamp and
+// split columns are reported on the rows instead
+func previewCSV(in io.Reader, n int) (*filePreview, error) {
+	reader := csv.NewReader(in)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &filePreview{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	preview := &filePreview{}
+	tsCol, splitCol := -1, -1
+	var valueCols []int
+	for i, name := range header {
+		switch name {
+		case "index":
+		case "timestamp":
+			tsCol = i
+		case "split":
+			splitCol = i
+		default:
+			preview.columns = append(preview.columns, name)
+			valueCols = append(valueCols, i)
+		}
+	}
+
+	collector := previewCollector{n: n}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read row %d: %w", collector.count, err)
+		}
+
+		row := models.MLExportPreviewRow{Values: make([]*float64, len(valueCols))}
+		if tsCol >= 0 && tsCol < len(record) {
+			if ms, err := strconv.ParseInt(record[tsCol], 10, 64); err == nil {
+				row.Timestamp = previewTime(ms)
+			}
+		}
+		if splitCol >= 0 && splitCol < len(record) {
+			row.Split = record[splitCol]
+		}
+		for j, col := range valueCols {
+			if col >= len(record) {
+				continue
+			}
+			if v, err := strconv.ParseFloat(record[col], 64); err == nil {
+				row.Values[j] = previewValue(v)
+			}
+		}
+		collector.add(row)
+	}
+
+	preview.head, preview.tail = collector.head, collector.tail
+	return preview, nil
+}
+
+// previewJSONL decodes each line's object, taking values in the job's
+// column order
+func previewJSONL(in io.Reader, n int, columns []string) (*filePreview, error) {
+	dec := json.NewDecoder(in)
+	collector := previewCollector{n: n}
+	for {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read row %d: %w", collector.count, err)
+		}
+
+		row := models.MLExportPreviewRow{Values: make([]*float64, len(columns))}
+		if ts, ok := record["timestamp"].(float64); ok {
+			row.Timestamp = previewTime(int64(ts))
+		}
+		if split, ok := record["_split"].(string); ok {
+			row.Split = split
+		}
+		for j, col := range columns {
+			// Infinities are written as strings and NaN as null
+			if v, ok := record[col].(float64); ok {
+				row.Values[j] = previewValue(v)
+			}
+		}
+		collector.add(row)
+	}
+
+	return &filePreview{columns: columns, head: collector.head, tail: collector.tail}, nil
+}
+
+// previewJSON streams the document's rows array, then picks the timestamps
+// and split labels of the previewed rows from the arrays that follow it
+func previewJSON(in io.Reader, n int) (*filePreview, error) {
+	dec := json.NewDecoder(in)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("export is not a JSON object")
+	}
+
+	preview := &filePreview{}
+	collector := previewCollector{n: n}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "columns":
+			if err := dec.Decode(&preview.columns); err != nil {
+				return nil, fmt.Errorf("failed to read columns: %w", err)
+			}
+		case "rows":
+			err = readJSONArray(dec, func(i int) error {
+				var values []interface{}
+				if err := dec.Decode(&values); err != nil {
+					return err
+				}
+				// Infinities are written as strings and NaN as null
+				row := models.MLExportPreviewRow{Values: make([]*float64, len(values))}
+				for j, v := range values {
+					if f, ok := v.(float64); ok {
+						row.Values[j] = previewValue(f)
+					}
+				}
+				collector.add(row)
+				return nil
+			})
+		case "timestamps":
+			err = readJSONArray(dec, func(i int) error {
+				var ms int64
+				if err := dec.Decode(&ms); err != nil {
+					return err
+				}
+				for _, row := range collector.rowsAt(i) {
+					row.Timestamp = previewTime(ms)
+				}
+				return nil
+			})
+		case "splits":
+			err = readJSONArray(dec, func(i int) error {
+				var split string
+				if err := dec.Decode(&split); err != nil {
+					return err
+				}
+				for _, row := range collector.rowsAt(i) {
+					row.Split = split
+				}
+				return nil
+			})
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v: %w", key, err)
+		}
+	}
+
+	preview.head, preview.tail = collector.head, collector.tail
+	return preview, nil
+}
+
+// rowsAt returns the kept rows that are row i of the stream: its head entry,
+// its tail entry, or both
+func (p *previewCollector) rowsAt(i int) []*models.MLExportPreviewRow {
+	var rows []*models.MLExportPreviewRow
+	if i < len(p.head) {
+		rows = append(rows, &p.head[i])
+	}
+	if tailStart := p.count - len(p.tail); i >= tailStart {
+		rows = append(rows, &p.tail[i-tailStart])
+	}
+	return rows
+}
+
+// readJSONArray reads a JSON array, calling element for each element with
+// the decoder positioned on it
+func readJSONArray(dec *json.Decoder, element func(i int) error) error {
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("not an array")
+	}
+	for i := 0; dec.More(); i++ {
+		if err := element(i); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// previewParquet reads the header and column names, then only the head and
+// tail values of the timestamp section and of each column
+func previewParquet(in io.Reader, n int) (*filePreview, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(in, magic); err != nil || string(magic) != "PAR1" {
+		return nil, fmt.Errorf("missing leading PAR1 magic")
+	}
+	var header parquetHeader
+	if err := binary.Read(in, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	preview := &filePreview{columns: make([]string, header.NumCols)}
+	for i := range preview.columns {
+		var nameLen int32
+		if err := binary.Read(in, binary.LittleEndian, &nameLen); err != nil {
+			return nil, fmt.Errorf("failed to read column %d name: %w", i, err)
+		}
+		name := make([]byte, nameLen)
+		if _, err := io.ReadFull(in, name); err != nil {
+			return nil, fmt.Errorf("failed to read column %d name: %w", i, err)
+		}
+		preview.columns[i] = string(name)
+	}
+
+	rows := int(header.NumRows)
+	headCount, tailStart := min(n, rows), max(rows-n, 0)
+	preview.head = make([]models.MLExportPreviewRow, headCount)
+	preview.tail = make([]models.MLExportPreviewRow, rows-tailStart)
+	for _, part := range [][]models.MLExportPreviewRow{preview.head, preview.tail} {
+		for i := range part {
+			part[i].Values = make([]*float64, header.NumCols)
+		}
+	}
+
+	if header.HasTimestamp {
+		head, tail, err := readSection[int64](in, rows, 1, headCount, tailStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read timestamps: %w", err)
+		}
+		for i, ms := range head {
+			preview.head[i].Timestamp = previewTime(ms)
+		}
+		for i, ms := range tail {
+			preview.tail[i].Timestamp = previewTime(ms)
+		}
+	}
+
+	for col := range preview.columns {
+		head, tail, err := readSection[float64](in, rows, 1, headCount, tailStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read column %s: %w", preview.columns[col], err)
+		}
+		for i, v := range head {
+			preview.head[i].Values[col] = previewValue(v)
+		}
+		for i, v := range tail {
+			preview.tail[i].Values[col] = previewValue(v)
+		}
+	}
+
+	return preview, nil
+}
+
+// previewNumpy reads the NPY header for the shape, then only the head and
+// tail rows. The file has no column names or timestamps, so the job's
+// columns are used.
+func previewNumpy(in io.Reader, n int, columns []string) (*filePreview, error) {
+	prefix := make([]byte, 10)
+	if _, err := io.ReadFull(in, prefix); err != nil || string(prefix[:6]) != "\x93NUMPY" {
+		return nil, fmt.Errorf("missing NPY magic")
+	}
+	header := make([]byte, binary.LittleEndian.Uint16(prefix[8:]))
+	if _, err := io.ReadFull(in, header); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	match := npyShape.FindSubmatch(header)
+	if match == nil {
+		return nil, fmt.Errorf("header has no shape")
+	}
+	var dims []int
+	for _, field := range strings.Split(string(match[1]), ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		dim, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shape %q", match[1])
+		}
+		dims = append(dims, dim)
+	}
+	if len(dims) != 2 {
+		return nil, fmt.Errorf("preview is only supported for 2-D arrays, the export has shape (%s)", match[1])
+	}
+	rows, cols := dims[0], dims[1]
+	if cols != len(columns) {
+		return nil, fmt.Errorf("array has %d columns, the export job lists %d", cols, len(columns))
+	}
+
+	headCount, tailStart := min(n, rows), max(rows-n, 0)
+	head, tail, err := readSection[float64](in, rows, cols, headCount, tailStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	toRows := func(values []float64) []models.MLExportPreviewRow {
+		result := make([]models.MLExportPreviewRow, len(values)/cols)
+		for i := range result {
+			result[i].Values = make([]*float64, cols)
+			for j := range cols {
+				result[i].Values[j] = previewValue(values[i*cols+j])
+			}
+		}
+		return result
+	}
+	return &filePreview{columns: columns, head: toRows(head), tail: toRows(tail)}, nil
+}
+
+// readSection reads the head and tail entries of a section of rows entries
+// of width little-endian values each, skipping the entries in between. The
+// reader is left at the end of the section.
+func readSection[T int64 | float64](in io.Reader, rows, width, headCount, tailStart int) ([]T, []T, error) {
+	head := make([]T, headCount*width)
+	if err := binary.Read(in, binary.LittleEndian, head); err != nil {
+		return nil, nil, err
+	}
+
+	if tailStart < headCount {
+		// The head and tail overlap: read the rest and take the tail from both
+		rest := make([]T, (rows-headCount)*width)
+		if err := binary.Read(in, binary.LittleEndian, rest); err != nil {
+			return nil, nil, err
+		}
+		tail := append(append([]T{}, head[tailStart*width:]...), rest...)
+		return head, tail, nil
+	}
+
+	if err := skipBytes(in, int64(tailStart-headCount)*int64(width)*8); err != nil {
+		return nil, nil, err
+	}
+	tail := make([]T, (rows-tailStart)*width)
+	if err := binary.Read(in, binary.LittleEndian, tail); err != nil {
+		return nil, nil, err
+	}
+	return head, tail, nil
+}
+
+// skipBytes advances the reader by n bytes, seeking when it can
+func skipBytes(in io.Reader, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	if seeker, ok := in.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, in, n)
+	return err
+}
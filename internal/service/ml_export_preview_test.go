@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// previewMatrix has rows i with close 100+i, NaN returns in the first row
+// and +Inf returns in the last
+func previewMatrix(rows int) *models.FeatureMatrix {
+	matrix := &models.FeatureMatrix{Columns: []string{"close", "returns"}, RowCount: rows, ColumnCount: 2}
+	for i := 0; i < rows; i++ {
+		returns := float64(i) / 100
+		switch i {
+		case 0:
+			returns = math.NaN()
+		case rows - 1:
+			returns = math.Inf(1)
+		}
+		matrix.Data = append(matrix.Data, []float64{100 + float64(i), returns})
+		matrix.Timestamps = append(matrix.Timestamps, int64(i+1)*60_000)
+	}
+	return matrix
+}
+
+func TestPreviewFileAllFormats(t *testing.T) {
+	matrix := previewMatrix(25)
+	s := &MLExportService{sink: LocalExportSink{}, localSink: LocalExportSink{}}
+	dir := t.TempDir()
+
+	for _, format := range GetSupportedFormats() {
+		for _, compress := range []bool{false, true} {
+			if compress && (format == models.MLExportFormatParquet || format == models.MLExportFormatNumpy) {
+				continue
+			}
+			options := DefaultWriterOptions()
+			options.Compress = compress
+			writer, err := NewExportWriter(format, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, string(format)+writer.Extension())
+			if _, _, err := writeHashedFile(writer, matrix, path); err != nil {
+				t.Fatal(err)
+			}
+
+			preview, err := s.previewFile(context.Background(), path, 3, matrix.Columns)
+			if err != nil {
+				t.Fatalf("%s (compress=%v): %v", format, compress, err)
+			}
+			if !reflect.DeepEqual(preview.columns, matrix.Columns) {
+				t.Errorf("%s: columns = %v, want %v", format, preview.columns, matrix.Columns)
+			}
+			if len(preview.head) != 3 || len(preview.tail) != 3 {
+				t.Fatalf("%s: %d head and %d tail rows, want 3 each", format, len(preview.head), len(preview.tail))
+			}
+
+			first, last := preview.head[0], preview.tail[2]
+			if first.Values[0] == nil || *first.Values[0] != 100 || first.Values[1] != nil {
+				t.Errorf("%s: first row = %v, want close 100 and null returns", format, first.Values)
+			}
+			if last.Values[0] == nil || *last.Values[0] != 124 || last.Values[1] != nil {
+				t.Errorf("%s: last row = %v, want close 124 and null returns", format, last.Values)
+			}
+			if mid := preview.tail[0]; mid.Values[1] == nil || *mid.Values[1] != 0.22 {
+				t.Errorf("%s: row 22 = %v, want returns 0.22", format, mid.Values)
+			}
+
+			// NumPy outputs carry no timestamps
+			if format != models.MLExportFormatNumpy {
+				if first.Timestamp == nil || first.Timestamp.UnixMilli() != 60_000 {
+					t.Errorf("%s: first timestamp = %v, want 60000ms", format, first.Timestamp)
+				}
+				if last.Timestamp == nil || last.Timestamp.UnixMilli() != 25*60_000 {
+					t.Errorf("%s: last timestamp = %v, want %dms", format, last.Timestamp, 25*60_000)
+				}
+			}
+		}
+	}
+}
+
+func TestPreviewFileShortOutputOverlaps(t *testing.T) {
+	matrix := previewMatrix(4)
+	matrix.SplitLabels = []string{"train", "train", "train", "test"}
+	s := &MLExportService{sink: LocalExportSink{}, localSink: LocalExportSink{}}
+	dir := t.TempDir()
+
+	for _, format := range []models.MLExportFormat{models.MLExportFormatParquet, models.MLExportFormatJSON} {
+		writer, err := NewExportWriter(format, DefaultWriterOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "short"+writer.Extension())
+		if _, _, err := writeHashedFile(writer, matrix, path); err != nil {
+			t.Fatal(err)
+		}
+
+		preview, err := s.previewFile(context.Background(), path, 10, matrix.Columns)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if len(preview.head) != 4 || len(preview.tail) != 4 {
+			t.Fatalf("%s: %d head and %d tail rows, want all 4 in both", format, len(preview.head), len(preview.tail))
+		}
+		for i := range preview.head {
+			if *preview.head[i].Values[0] != *preview.tail[i].Values[0] || !preview.head[i].Timestamp.Equal(*preview.tail[i].Timestamp) {
+				t.Errorf("%s: head and tail disagree on row %d", format, i)
+			}
+		}
+		if format == models.MLExportFormatJSON && (preview.head[0].Split != "train" || preview.tail[3].Split != "test") {
+			t.Errorf("%s: splits = %q, %q, want train, test", format, preview.head[0].Split, preview.tail[3].Split)
+		}
+	}
+}
+
+func TestPreviewFileRejectsSequences(t *testing.T) {
+	matrix := &models.FeatureMatrix{Columns: []string{"close"}, Sequences: [][][]float64{{{1}, {2}}}}
+	path := filepath.Join(t.TempDir(), "seq.npz")
+	if _, _, err := writeHashedFile(&NumpyExportWriter{}, matrix, path); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &MLExportService{sink: LocalExportSink{}, localSink: LocalExportSink{}}
+	if _, err := s.previewFile(context.Background(), path, 5, matrix.Columns); err == nil {
+		t.Error("expected sequence outputs to be rejected")
+	}
+}
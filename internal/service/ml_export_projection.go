@@ -0,0 +1,208 @@
+package service
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// Columns every projected row keeps so it can still be aligned and split
+var projectionMetaColumns = map[string]bool{
+	"index":     true,
+	"timestamp": true,
+	"split":     true,
+	"_index":    true,
+	"_split":    true,
+}
+
+// ValidateProjectionColumns checks requested columns against the export's stored column names
+func ValidateProjectionColumns(exportJob *models.MLExportJob, columns []string) error {
+	known := make(map[string]bool, len(exportJob.ColumnNames))
+	for _, col := range exportJob.ColumnNames {
+		known[col] = true
+	}
+
+	var unknown []string
+	for _, col := range columns {
+		if !known[col] && !projectionMetaColumns[col] {
+			unknown = append(unknown, col)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown columns: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// SupportsProjection returns true if the export file can be column-projected while streaming
+func SupportsProjection(outputPath string) bool {
+	path := strings.TrimSuffix(outputPath, ".gz")
+	return strings.HasSuffix(path, ".csv") || strings.HasSuffix(path, ".jsonl")
+}
+
+// StreamProjectedExport reads the export file and writes only the requested
+// columns (plus index/timestamp/split) to out. Gzip-compressed files are
+// re-compressed so the response keeps the original encoding.
+func (s *MLExportService) StreamProjectedExport(ctx context.Context, exportJob *models.MLExportJob, columns []string, out io.Writer) error {
+	if err := ValidateProjectionColumns(exportJob, columns); err != nil {
+		return err
+	}
+	if !SupportsProjection(exportJob.OutputPath) {
+		return fmt.Errorf("column selection is only supported for CSV and JSONL exports")
+	}
+
+	file, err := s.OpenExportFile(ctx, exportJob.OutputPath)
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer file.Close()
+
+	keep := make(map[string]bool, len(columns)+len(projectionMetaColumns))
+	for col := range projectionMetaColumns {
+		keep[col] = true
+	}
+	for _, col := range columns {
+		keep[col] = true
+	}
+
+	project := projectJSONL
+	if strings.HasSuffix(strings.TrimSuffix(exportJob.OutputPath, ".gz"), ".csv") {
+		project = projectCSV
+	}
+
+	var reader io.Reader = bufio.NewReader(file)
+	if !strings.HasSuffix(exportJob.OutputPath, ".gz") {
+		return project(reader, out, keep)
+	}
+
+	gzReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("failed to open compressed export: %w", err)
+	}
+	defer gzReader.Close()
+
+	gzWriter := gzip.NewWriter(out)
+	if err := project(gzReader, gzWriter, keep); err != nil {
+		gzWriter.Close()
+		return err
+	}
+	// Closing writes the gzip footer, so a failure here truncates the response
+	if err := gzWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish compressed output: %w", err)
+	}
+	return nil
+}
+
+// projectCSV copies the kept columns of a CSV export, using its header row to locate them
+func projectCSV(in io.Reader, out io.Writer, keep map[string]bool) error {
+	csvReader := csv.NewReader(in)
+	csvWriter := csv.NewWriter(out)
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+
+	indices := make([]int, 0, len(header))
+	projected := make([]string, 0, len(header))
+	for i, col := range header {
+		if keep[col] {
+			indices = append(indices, i)
+			projected = append(projected, col)
+		}
+	}
+	if err := csvWriter.Write(projected); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	record := make([]string, len(indices))
+	for row := 1; ; row++ {
+		values, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read row %d: %w", row, err)
+		}
+		for j, idx := range indices {
+			record[j] = values[idx]
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", row, err)
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// projectJSONL copies the kept keys of each JSON Lines record. Records are
+// read token by token so kept keys stay in their original order and values
+// are copied byte for byte.
+func projectJSONL(in io.Reader, out io.Writer, keep map[string]bool) error {
+	decoder := json.NewDecoder(in)
+	bw := bufio.NewWriter(out)
+
+	for row := 0; ; row++ {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read row %d: %w", row, err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+			return fmt.Errorf("failed to read row %d: record is not a JSON object", row)
+		}
+
+		bw.WriteByte('{')
+		first := true
+		for decoder.More() {
+			tok, err := decoder.Token()
+			if err != nil {
+				return fmt.Errorf("failed to read row %d: %w", row, err)
+			}
+			key, _ := tok.(string)
+
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return fmt.Errorf("failed to read row %d: %w", row, err)
+			}
+			if !keep[key] {
+				continue
+			}
+
+			if !first {
+				bw.WriteByte(',')
+			}
+			first = false
+			name, _ := json.Marshal(key)
+			bw.Write(name)
+			bw.WriteByte(':')
+			bw.Write(value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("failed to read row %d: %w", row, err)
+		}
+		bw.WriteString("}\n")
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
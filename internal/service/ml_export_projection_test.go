@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func projectionKeep(columns ...string) map[string]bool {
+	keep := map[string]bool{}
+	for col := range projectionMetaColumns {
+		keep[col] = true
+	}
+	for _, col := range columns {
+		keep[col] = true
+	}
+	return keep
+}
+
+func TestProjectCSVKeepsHeaderOrder(t *testing.T) {
+	in := "timestamp,close,rsi_14,returns\n60000,100.5,55.25,0.01\n120000,101,60,0.02\n"
+
+	var out bytes.Buffer
+	if err := projectCSV(strings.NewReader(in), &out, projectionKeep("returns", "close")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "timestamp,close,returns\n60000,100.5,0.01\n120000,101,0.02\n"
+	if out.String() != want {
+		t.Errorf("projected CSV = %q, want %q", out.String(), want)
+	}
+}
+
+func TestProjectJSONLKeepsKeyOrderAndNumbers(t *testing.T) {
+	in := `{"timestamp":60000,"z_score":1.50000000,"drop":3,"close":100.12345678901234567}` + "\n" +
+		`{"timestamp":120000,"z_score":null,"drop":4,"close":101}` + "\n"
+
+	var out bytes.Buffer
+	if err := projectJSONL(strings.NewReader(in), &out, projectionKeep("z_score", "close")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"timestamp":60000,"z_score":1.50000000,"close":100.12345678901234567}` + "\n" +
+		`{"timestamp":120000,"z_score":null,"close":101}` + "\n"
+	if out.String() != want {
+		t.Errorf("projected JSONL = %q, want %q", out.String(), want)
+	}
+}
+
+// closedConn rejects every write, like a client that hung up
+type closedConn struct{}
+
+func (closedConn) Write(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestProjectReportsWriteErrors(t *testing.T) {
+	csvIn := "timestamp,close\n60000,100\n"
+	if err := projectCSV(strings.NewReader(csvIn), closedConn{}, projectionKeep("close")); err == nil {
+		t.Error("projectCSV returned nil for a failing writer")
+	}
+
+	jsonlIn := `{"timestamp":60000,"close":100}` + "\n"
+	if err := projectJSONL(strings.NewReader(jsonlIn), closedConn{}, projectionKeep("close")); err == nil {
+		t.Error("projectJSONL returned nil for a failing writer")
+	}
+}
+
+func TestStreamProjectedExportGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.jsonl.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzWriter := gzip.NewWriter(file)
+	gzWriter.Write([]byte(`{"timestamp":60000,"close":100,"volume":5}` + "\n"))
+	if err := gzWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	s := &MLExportService{sink: LocalExportSink{}, localSink: LocalExportSink{}}
+	exportJob := &models.MLExportJob{OutputPath: path, ColumnNames: []string{"timestamp", "close", "volume"}}
+
+	var out bytes.Buffer
+	if err := s.StreamProjectedExport(context.Background(), exportJob, []string{"volume"}, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	gzReader, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	data, err := io.ReadAll(gzReader)
+	if err != nil {
+		t.Fatalf("output gzip stream is incomplete: %v", err)
+	}
+	if want := `{"timestamp":60000,"volume":5}` + "\n"; string(data) != want {
+		t.Errorf("projected output = %q, want %q", data, want)
+	}
+}
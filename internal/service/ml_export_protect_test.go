@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// protectMatrix has a feature, a protected key, and a target known only by its
+// schema source, each with an outlier and a mostly-NaN tail
+func protectMatrix() *models.FeatureMatrix {
+	matrix := &models.FeatureMatrix{
+		Columns: []string{"returns", "fold", "next_move", "target_return_1"},
+		Schema: []models.FeatureSchema{
+			{Name: "returns", Source: "price_feature"},
+			{Name: "fold", Source: "external"},
+			{Name: "next_move", Source: "target"},
+			{Name: "target_return_1"},
+		},
+	}
+	for i := 0; i < 100; i++ {
+		v := float64(i % 10)
+		matrix.Data = append(matrix.Data, []float64{v, v, v, v})
+	}
+	for _, row := range matrix.Data[40:] {
+		for j := range row {
+			row[j] = math.NaN()
+		}
+	}
+	for j := range matrix.Columns {
+		matrix.Data[0][j] = 1e6
+	}
+	matrix.RowCount = len(matrix.Data)
+	matrix.ColumnCount = len(matrix.Columns)
+	return matrix
+}
+
+func TestPreprocessingLeavesTargetsAndProtectedColumns(t *testing.T) {
+	configs := map[string]models.PreprocessConfig{
+		"stddev clip": {ClipOutliers: true, OutlierStdDev: 2, Normalization: models.NormalizationZScore, MaxColNaNFraction: 0.5},
+		"percentile clip": {ClipOutliers: true, ClipMethod: models.ClipMethodPercentile, LowerPct: 5, UpperPct: 95,
+			Normalization: models.NormalizationMinMax, MaxColNaNFraction: 0.5},
+	}
+
+	for name, config := range configs {
+		config.ProtectColumns = []string{"fold"}
+		matrix := protectMatrix()
+		want := protectMatrix()
+
+		s := &MLExportService{}
+		params, err := s.applyPreprocessing(context.Background(), matrix, config, models.SplitConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// returns is dropped for its NaN fraction; the rest survive untouched
+		if !reflect.DeepEqual(matrix.Columns, []string{"fold", "next_move", "target_return_1"}) {
+			t.Fatalf("%s: columns = %v", name, matrix.Columns)
+		}
+		for i, row := range matrix.Data {
+			for j, v := range row {
+				orig := want.Data[i][j+1]
+				if v != orig && !(math.IsNaN(v) && math.IsNaN(orig)) {
+					t.Fatalf("%s: %s row %d changed from %g to %g", name, matrix.Columns[j], i, orig, v)
+				}
+			}
+		}
+		if len(params) != 0 || len(matrix.ClipBounds) != 0 {
+			t.Errorf("%s: fitted %d normalizations and %d clip bounds, want none", name, len(params), len(matrix.ClipBounds))
+		}
+	}
+}
+
+func TestPreprocessingStillAppliesToFeatures(t *testing.T) {
+	matrix := protectMatrix()
+	config := models.PreprocessConfig{ClipOutliers: true, OutlierStdDev: 2, Normalization: models.NormalizationZScore}
+
+	s := &MLExportService{}
+	params, err := s.applyPreprocessing(context.Background(), matrix, config, models.SplitConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["returns"]; !ok {
+		t.Error("returns was not normalized")
+	}
+	if _, ok := params["fold"]; !ok {
+		t.Error("fold was not normalized without being protected")
+	}
+	for _, target := range []string{"next_move", "target_return_1"} {
+		if _, ok := params[target]; ok {
+			t.Errorf("target %s was normalized", target)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// recalculateMissingIndicators recalculates and stores the indicators of
+// source jobs whose latest candle lacks indicators the export requests, so
+// exports read stored values instead of computing them every time. Only
+// indicators the job's own indicator config produces are considered, and at
+// most maxAutoRecalculations jobs are recalculated; the rest are reported as
+// skipped. Failures are reported, not fatal: the export goes on with the data
+// that is stored.
+func (s *MLExportService) recalculateMissingIndicators(ctx context.Context, exportJob *models.MLExportJob) []models.RecalculatedJobInfo {
+	features := exportJob.Config.Features
+	if !features.AutoRecalculateMissing || s.recalcService == nil {
+		return nil
+	}
+
+	requested := requestedIndicators(features)
+	if len(requested) == 0 {
+		return nil
+	}
+
+	var results []models.RecalculatedJobInfo
+	recalculated := 0
+	for _, jobID := range exportJob.JobIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		job, indicatorConfig, err := s.recalcService.IndicatorConfigForJob(ctx, jobID.Hex())
+		if err != nil {
+			logging.Printf(ctx, "[ML_EXPORT] Skipping indicator check for job %s: %v", jobID.Hex(), err)
+			continue
+		}
+
+		latest, err := s.ohlcvRepo.GetLastCandle(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			logging.Printf(ctx, "[ML_EXPORT] Skipping indicator check for job %s: %v", jobID.Hex(), err)
+			continue
+		}
+		if latest == nil {
+			continue
+		}
+
+		missing := missingIndicators(requested, indicatorConfig.ExpectedIndicators(), latest.Indicators.Populated())
+		if len(missing) == 0 {
+			continue
+		}
+
+		info := models.RecalculatedJobInfo{
+			JobID:     jobID,
+			Symbol:    job.Symbol,
+			Timeframe: job.Timeframe,
+			Missing:   missing,
+		}
+		if recalculated >= s.maxAutoRecalculations {
+			info.Skipped = true
+			results = append(results, info)
+			continue
+		}
+		recalculated++
+
+		logging.Printf(ctx, "[ML_EXPORT] Recalculating indicators for job %s, missing %v", jobID.Hex(), missing)
+		info.CandlesUpdated, err = s.recalcService.RecalculateJobWithConfig(ctx, job, indicatorConfig)
+		if err != nil {
+			logging.Printf(ctx, "[ML_EXPORT] Failed to recalculate indicators for job %s: %v", jobID.Hex(), err)
+			info.Error = err.Error()
+		}
+		results = append(results, info)
+	}
+
+	return results
+}
+
+// requestedIndicators returns the stored indicator fields the feature config selects
+func requestedIndicators(config models.FeatureConfig) []string {
+	var names []string
+	for _, spec := range models.IndicatorSpecs() {
+		for _, field := range spec.Fields {
+			if includeIndicator(config, field, spec.Category) {
+				names = append(names, field)
+			}
+		}
+	}
+	return names
+}
+
+// missingIndicators returns the requested indicators the config computes but
+// that are not populated
+func missingIndicators(requested, expected, populated []string) []string {
+	computed := make(map[string]bool, len(expected))
+	for _, name := range expected {
+		computed[name] = true
+	}
+	stored := make(map[string]bool, len(populated))
+	for _, name := range populated {
+		stored[name] = true
+	}
+
+	var missing []string
+	for _, name := range requested {
+		if computed[name] && !stored[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestRequestedIndicators(t *testing.T) {
+	config := models.FeatureConfig{
+		IndicatorCategories: []string{"volume"},
+		SpecificIndicators:  []string{"rsi14"},
+	}
+
+	got := requestedIndicators(config)
+	want := []string{"rsi14", "obv", "vwap", "mfi", "cmf", "volume_sma"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requestedIndicators() = %v, want %v", got, want)
+	}
+
+	if got := requestedIndicators(models.FeatureConfig{IncludeOHLCV: true}); len(got) != 0 {
+		t.Errorf("requestedIndicators() without indicators = %v, want none", got)
+	}
+}
+
+func TestMissingIndicators(t *testing.T) {
+	requested := []string{"sma20", "rsi14", "atr", "obv"}
+	expected := []string{"sma20", "rsi14", "atr"}
+	populated := []string{"sma20", "obv"}
+
+	// obv is not computed by the config, so recalculating would not add it
+	got := missingIndicators(requested, expected, populated)
+	want := []string{"rsi14", "atr"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingIndicators() = %v, want %v", got, want)
+	}
+
+	if got := missingIndicators(requested, expected, expected); len(got) != 0 {
+		t.Errorf("missingIndicators() with everything stored = %v, want none", got)
+	}
+}
@@ -3,38 +3,67 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
 	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
+	"github.com/yourusername/datacollector/internal/service/indicators"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
 )
 
 // MLExportService handles ML data export operations
 type MLExportService struct {
-	ohlcvRepo    *repository.OHLCVRepository
-	jobRepo      *repository.JobRepository
-	exportRepo   *repository.MLExportRepository
-	featureEngine *MLFeatureEngine
+	ohlcvRepo           *repository.OHLCVRepository
+	derivativesRepo     *repository.DerivativesRepository
+	jobRepo             *repository.JobRepository
+	exportRepo          *repository.MLExportRepository
+	indicatorConfigRepo *repository.IndicatorConfigRepository
+	featureCacheRepo    *repository.MLFeatureCacheRepository
+	idempotencyRepo     *repository.IdempotencyRepository
+	recalcService       *RecalculatorService
+	sink                ExportSink
+	localSink           ExportSink
+	featureEngine       *MLFeatureEngine
+	indicatorService    *indicators.Service
 
 	// Background job management
 	activeJobs   map[string]context.CancelFunc
 	activeJobsMu sync.RWMutex
 
 	// Configuration
-	exportDir string
+	exportDir             string
+	defaultRetentionHours int
+	maxDiskBytes          int64 // 0 = unlimited
+	minFreeDiskBytes      int64 // 0 = free space not checked
+	loadConcurrency       int
+	featureCacheTTL       time.Duration // 0 = feature caching disabled
+	maxAutoRecalculations int
+	idempotencyTTL        time.Duration // 0 = idempotency keys ignored
 }
 
 // NewMLExportService creates a new ML export service
 func NewMLExportService(
 	ohlcvRepo *repository.OHLCVRepository,
+	derivativesRepo *repository.DerivativesRepository,
 	jobRepo *repository.JobRepository,
 	exportRepo *repository.MLExportRepository,
+	indicatorConfigRepo *repository.IndicatorConfigRepository,
+	featureCacheRepo *repository.MLFeatureCacheRepository,
+	idempotencyRepo *repository.IdempotencyRepository,
+	recalcService *RecalculatorService,
+	cfg *config.Config,
 ) *MLExportService {
 	// Default export directory
 	exportDir := os.Getenv("ML_EXPORT_DIR")
@@ -42,16 +71,41 @@ func NewMLExportService(
 		exportDir = "/tmp/ml_exports"
 	}
 
-	// Ensure directory exists
-	os.MkdirAll(exportDir, 0755)
+	// Fail early and clearly if exports can't be written, instead of deep in writeOutput
+	if err := checkExportDir(exportDir); err != nil {
+		log.Printf("[ML_EXPORT] WARNING: exports unavailable: %v", err)
+	}
+
+	loadConcurrency := cfg.MLExport.LoadConcurrency
+	if loadConcurrency < 1 {
+		loadConcurrency = 1
+	}
 
 	return &MLExportService{
 		ohlcvRepo:     ohlcvRepo,
 		jobRepo:       jobRepo,
 		exportRepo:    exportRepo,
 		featureEngine: NewMLFeatureEngine(),
+
+		derivativesRepo:     derivativesRepo,
+		indicatorConfigRepo: indicatorConfigRepo,
+		featureCacheRepo:    featureCacheRepo,
+		idempotencyRepo:     idempotencyRepo,
+		recalcService:       recalcService,
+		sink:                NewExportSink(cfg.MLExport.S3),
+		localSink:           LocalExportSink{},
+		indicatorService:    indicators.NewService(),
+
 		activeJobs:    make(map[string]context.CancelFunc),
 		exportDir:     exportDir,
+
+		defaultRetentionHours: cfg.MLExport.DefaultRetentionHours,
+		maxDiskBytes:          cfg.MLExport.MaxDiskBytes,
+		minFreeDiskBytes:      cfg.MLExport.MinFreeDiskBytes,
+		loadConcurrency:       loadConcurrency,
+		featureCacheTTL:       time.Duration(cfg.MLExport.FeatureCacheTTLHours) * time.Hour,
+		maxAutoRecalculations: cfg.MLExport.MaxAutoRecalculations,
+		idempotencyTTL:        time.Duration(cfg.MLExport.IdempotencyKeyTTLHours) * time.Hour,
 	}
 }
 
@@ -61,6 +115,45 @@ func (s *MLExportService) StartExport(ctx context.Context, config models.MLExpor
 	if len(jobIDs) == 0 {
 		return nil, fmt.Errorf("no job IDs provided")
 	}
+	if config.RetentionHours != nil && *config.RetentionHours < 0 {
+		return nil, fmt.Errorf("retention_hours must be >= 0")
+	}
+	if err := s.ValidateAuxiliarySources(ctx, config.AuxiliarySources); err != nil {
+		return nil, err
+	}
+	if err := config.Features.MarketSessions.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Features.FracDiff.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.FloatFormat.Valid() {
+		return nil, fmt.Errorf("invalid float format %q: must be fixed, general or scientific", config.FloatFormat)
+	}
+	if config.FloatPrecision < 0 {
+		return nil, fmt.Errorf("invalid float format: float_precision must be >= 0")
+	}
+	if config.Preprocessing.WarmupRows < 0 {
+		return nil, fmt.Errorf("invalid preprocessing: warmup_rows must be >= 0")
+	}
+
+	if err := checkExportDir(s.exportDir); err != nil {
+		return nil, fmt.Errorf("exports unavailable: %w", err)
+	}
+
+	// Make sure a new export won't push the export directory over its limit
+	if err := s.ensureDiskCapacity(ctx); err != nil {
+		return nil, err
+	}
+
+	// Fail fast instead of producing an empty file from too-short sources
+	preflight, err := s.Preflight(ctx, config, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !preflight.OK {
+		return nil, fmt.Errorf("insufficient data: %s", strings.Join(preflight.Errors, "; "))
+	}
 
 	// Create export job record
 	exportJob := &models.MLExportJob{
@@ -69,6 +162,7 @@ func (s *MLExportService) StartExport(ctx context.Context, config models.MLExpor
 		Config:       config,
 		Progress:     0,
 		CurrentPhase: "initializing",
+		RequestID:    logging.RequestID(ctx),
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -79,14 +173,105 @@ func (s *MLExportService) StartExport(ctx context.Context, config models.MLExpor
 	}
 
 	// Start background processing
-	go s.processExportJob(exportJob.ID.Hex())
+	go s.processExportJob(exportJob.ID.Hex(), exportJob.RequestID)
 
 	return exportJob, nil
 }
 
-// processExportJob handles the background export processing
-func (s *MLExportService) processExportJob(exportJobID string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+// CheckExportDir reports whether the export directory can currently be written
+func (s *MLExportService) CheckExportDir() error {
+	return checkExportDir(s.exportDir)
+}
+
+// RemoveStrayTempFiles deletes the temp files of exports interrupted mid-write
+// by a crash or restart. It must run before any export starts, as running
+// exports write to temp files too.
+func (s *MLExportService) RemoveStrayTempFiles(ctx context.Context) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(s.exportDir, "*"+tempFileSuffix))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logging.Printf(ctx, "[ML_EXPORT] Failed to remove stray temp file %s: %v", path, err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		logging.Printf(ctx, "[ML_EXPORT] Removed %d stray temp files of interrupted exports", removed)
+	}
+	return removed, nil
+}
+
+// CheckHealth reports whether the export directory is writable, how much
+// space its filesystem has left and how many exports are running. Low disk
+// space or an export directory over its limit is a warning: exports may
+// still succeed, but are at risk.
+func (s *MLExportService) CheckHealth() *models.MLExportHealth {
+	s.activeJobsMu.RLock()
+	running := len(s.activeJobs)
+	s.activeJobsMu.RUnlock()
+
+	health := &models.MLExportHealth{
+		Status:       "available",
+		ExportDir:    s.exportDir,
+		MinFreeBytes: s.minFreeDiskBytes,
+		RunningJobs:  running,
+	}
+
+	if err := checkExportDir(s.exportDir); err != nil {
+		health.Status = "unavailable"
+		health.Error = err.Error()
+		return health
+	}
+	health.Writable = true
+
+	if free, err := diskFreeBytes(s.exportDir); err == nil {
+		health.FreeBytes = &free
+		if s.minFreeDiskBytes > 0 && free < s.minFreeDiskBytes {
+			health.LowDisk = true
+			health.Warnings = append(health.Warnings, fmt.Sprintf(
+				"only %d bytes free on the export filesystem, below the %d byte minimum", free, s.minFreeDiskBytes))
+		}
+	}
+
+	if s.maxDiskBytes > 0 {
+		if usage, err := s.GetDiskUsage(); err == nil && usage.OverLimit {
+			health.Warnings = append(health.Warnings, fmt.Sprintf(
+				"export directory uses %d of its %d byte limit", usage.UsedBytes, s.maxDiskBytes))
+		}
+	}
+
+	if len(health.Warnings) > 0 {
+		health.Status = "warning"
+	}
+	return health
+}
+
+// checkExportDir creates the export directory if needed and probes that files
+// can be created in it
+func checkExportDir(exportDir string) error {
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return fmt.Errorf("cannot create export directory %s: %w", exportDir, err)
+	}
+
+	probe, err := os.CreateTemp(exportDir, ".write_probe_*")
+	if err != nil {
+		return fmt.Errorf("export directory %s is not writable: %w", exportDir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return nil
+}
+
+// processExportJob handles the background export processing. Its logs carry
+// the ID of the request that started the export.
+func (s *MLExportService) processExportJob(exportJobID, requestID string) {
+	ctx, cancel := context.WithTimeout(logging.WithRequestID(context.Background(), requestID), 30*time.Minute)
 	defer cancel()
 
 	// Track active job
@@ -115,47 +300,171 @@ func (s *MLExportService) processExportJob(exportJobID string) {
 	exportJob.CurrentPhase = "loading"
 	s.exportRepo.UpdateExportJob(ctx, exportJob)
 
-	// Load candle data from all source jobs
-	allCandles, sourceInfos, err := s.loadCandleData(ctx, exportJob)
+	var output *exportOutput
+	if exportJob.Config.SeparateFiles && len(exportJob.JobIDs) > 1 {
+		output, err = s.runSeparateExports(ctx, exportJob)
+	} else {
+		output, err = s.runExportPipeline(ctx, exportJob, &exportTracker{s: s, job: exportJob, span: 100})
+	}
+	if s.exportInterrupted(ctx, objID) {
+		// A cancel while writing leaves no output behind
+		if output != nil {
+			cleanupCtx, cleanupCancel := context.WithTimeout(logging.Detach(ctx), time.Minute)
+			defer cleanupCancel()
+			for _, path := range append([]string{output.outputPath}, output.outputFiles...) {
+				s.removeExportFile(cleanupCtx, path)
+			}
+		}
+		return
+	}
 	if err != nil {
-		s.failExportJob(ctx, objID, fmt.Sprintf("failed to load data: %v", err))
+		s.failExportJob(ctx, objID, err.Error())
 		return
 	}
 
-	exportJob.TotalRecords = int64(len(allCandles))
-	exportJob.Progress = 10
-	exportJob.CurrentPhase = "features"
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
-
-	// Generate features
-	matrix, err := s.featureEngine.GenerateFeatures(allCandles, exportJob.Config.Features)
+	// Move the output to the configured sink (no-op for local storage)
+	outputPath, outputFiles, err := s.storeOutput(ctx, output.outputPath, output.outputFiles)
 	if err != nil {
-		s.failExportJob(ctx, objID, fmt.Sprintf("failed to generate features: %v", err))
+		s.failExportJob(ctx, objID, fmt.Sprintf("failed to store output: %v", err))
 		return
 	}
 
-	exportJob.Progress = 30
+	exportJob.Metadata = output.metadata
+	exportJob.OutputPath = outputPath
+	exportJob.OutputFiles = outputFiles
+	exportJob.FileSizeBytes = output.fileSize
+	exportJob.Checksum = output.checksum
+	exportJob.FeatureCount = output.featureCount
+	exportJob.ColumnNames = output.columns
+	exportJob.RowCount = output.rowCount
+	exportJob.ProcessedRecords = output.processedRecords
+
+	// Complete the job
+	completedAt := time.Now()
+	exportJob.Status = models.MLExportStatusCompleted
+	exportJob.CompletedAt = &completedAt
+	exportJob.ExpiresAt = exportJob.Config.ExpiresAt(completedAt, s.defaultRetentionHours)
+	exportJob.Progress = 100
+	exportJob.CurrentPhase = "completed"
+
 	s.exportRepo.UpdateExportJob(ctx, exportJob)
+}
+
+// exportOutput is what one run of the export pipeline wrote
+type exportOutput struct {
+	outputPath       string
+	outputFiles      []string
+	fileSize         int64
+	checksum         string
+	featureCount     int
+	columns          []string
+	rowCount         int64
+	processedRecords int64
+	metadata         models.MLExportMetadata
+}
+
+// exportTracker records pipeline progress on the export job. A pipeline
+// reports progress from 0 to 100, which is mapped onto the job's range
+// [base, base+span], so sub-exports can share one overall progress bar.
+type exportTracker struct {
+	s          *MLExportService
+	job        *models.MLExportJob
+	base, span float64
+	records    int64 // Records loaded by earlier sub-exports
+}
+
+// loaded records the number of candles the pipeline loaded
+func (t *exportTracker) loaded(records int64) {
+	t.job.TotalRecords = t.records + records
+}
+
+// report stores the progress; an empty phase keeps the current one
+func (t *exportTracker) report(ctx context.Context, progress float64, phase string) {
+	t.job.Progress = t.base + progress*t.span/100
+	if phase != "" {
+		t.job.CurrentPhase = phase
+	}
+	t.s.exportRepo.UpdateExportJob(ctx, t.job)
+}
+
+// runExportPipeline loads the export job's sources, builds the feature matrix
+// and writes it to the export directory. An interrupted pipeline returns the
+// context's error, along with the output if it was already written.
+func (s *MLExportService) runExportPipeline(ctx context.Context, exportJob *models.MLExportJob, tracker *exportTracker) (*exportOutput, error) {
+	// Store indicators the source jobs are missing, when requested
+	recalculatedJobs := s.recalculateMissingIndicators(ctx, exportJob)
+
+	// Load candle data from all source jobs
+	allCandles, sourceInfos, skippedSources, err := s.loadCandleData(ctx, exportJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %v", err)
+	}
+
+	tracker.warn(sourceWarnings(exportJob.JobIDs, sourceInfos, skippedSources, exportJob.RequestID, time.Now())...)
+	tracker.loaded(int64(len(allCandles)))
+	tracker.report(ctx, 10, "features")
+
+	// Generate features, reusing a cached matrix when the data is unchanged
+	matrix, err := s.generateFeaturesCached(ctx, allCandles, exportJob, sourceInfos)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate features: %v", err)
+	}
+
+	// Join auxiliary series onto the rows
+	auxInfos, err := s.joinAuxiliarySources(ctx, matrix, exportJob.Config.AuxiliarySources, finestTimeframe(sourceInfos))
+	if err != nil {
+		return nil, fmt.Errorf("failed to join auxiliary sources: %v", err)
+	}
+
+	tracker.report(ctx, 30, "")
 
 	// Generate targets
-	if err := s.featureEngine.GenerateTargets(matrix, allCandles, exportJob.Config.Target); err != nil {
-		s.failExportJob(ctx, objID, fmt.Sprintf("failed to generate targets: %v", err))
-		return
+	err = s.featureEngine.GenerateTargets(ctx, matrix, allCandles, exportJob.Config.Target)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate targets: %v", err)
 	}
 
-	exportJob.Progress = 40
-	exportJob.CurrentPhase = "preprocessing"
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
+	// Drop the warm-up rows loaded ahead of the tail
+	var tailInfo *models.TailInfo
+	if cutoff, ok := tailCutoff(sourceInfos); ok {
+		tailInfo = &models.TailInfo{
+			TailBars:   exportJob.Config.TailBars,
+			LoadedBars: int64(len(allCandles)),
+			WarmupRows: dropRowsBefore(matrix, cutoff),
+		}
+		tailInfo.EffectiveBars = int64(matrix.RowCount)
+	}
+
+	// Drop the leading rows features are still warming up in; a tail has
+	// already dropped them
+	var trimmedWarmup int
+	var trimmedStart int64
+	if tailInfo == nil {
+		trimmedWarmup = dropLeadingRows(matrix, WarmupTrimRows(exportJob.Config))
+		if trimmedWarmup > 0 && matrix.RowCount > 0 {
+			trimmedStart = matrix.Timestamps[0]
+		}
+	}
+
+	tracker.report(ctx, 40, "preprocessing")
 
 	// Apply preprocessing
-	normParams, err := s.applyPreprocessing(matrix, exportJob.Config.Preprocessing)
+	normParams, err := s.applyPreprocessing(ctx, matrix, exportJob.Config.Preprocessing, exportJob.Config.Split)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
-		s.failExportJob(ctx, objID, fmt.Sprintf("failed to apply preprocessing: %v", err))
-		return
+		return nil, fmt.Errorf("failed to apply preprocessing: %v", err)
 	}
+	applyColumnOrder(matrix, exportJob.Config.ColumnOrder)
 
-	exportJob.Progress = 50
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
+	tracker.report(ctx, 50, "")
 
 	// Apply split if enabled
 	var splitInfo *models.SplitInfo
@@ -163,8 +472,7 @@ func (s *MLExportService) processExportJob(exportJobID string) {
 		splitInfo = s.applySplit(matrix, exportJob.Config.Split)
 	}
 
-	exportJob.Progress = 60
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
+	tracker.report(ctx, 60, "")
 
 	// Generate sequences if enabled
 	var seqInfo *models.SequenceInfo
@@ -172,80 +480,145 @@ func (s *MLExportService) processExportJob(exportJobID string) {
 		seqInfo = s.generateSequences(matrix, exportJob.Config.Sequence)
 	}
 
-	exportJob.Progress = 70
-	exportJob.CurrentPhase = "writing"
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	tracker.report(ctx, 70, "writing")
 
 	// Write output file
-	outputPath, fileSize, err := s.writeOutput(matrix, exportJob)
+	outputPath, outputFiles, fileSize, checksum, err := s.writeOutput(matrix, exportJob, splitInfo)
 	if err != nil {
-		s.failExportJob(ctx, objID, fmt.Sprintf("failed to write output: %v", err))
-		return
+		return nil, fmt.Errorf("failed to write output: %v", err)
 	}
 
 	// Build metadata
-	exportJob.Metadata = s.buildMetadata(matrix, allCandles, sourceInfos, normParams, splitInfo, seqInfo)
-	exportJob.OutputPath = outputPath
-	exportJob.FileSizeBytes = fileSize
-	exportJob.FeatureCount = matrix.ColumnCount
-	exportJob.ColumnNames = matrix.Columns
-	exportJob.RowCount = int64(matrix.RowCount)
-	exportJob.ProcessedRecords = int64(len(allCandles))
+	metadata := s.buildMetadata(matrix, allCandles, sourceInfos, normParams, splitInfo, seqInfo)
+	metadata.SkippedSources = skippedSources
+	metadata.RecalculatedJobs = recalculatedJobs
+	metadata.Tail = tailInfo
+	metadata.TrimmedWarmupRows = trimmedWarmup
+	if trimmedStart > 0 {
+		metadata.DataRange.StartTime = time.UnixMilli(trimmedStart)
+	}
+	metadata.AuxiliarySources = auxInfos
+	if len(sourceInfos) > 1 {
+		metadata.Coverage = AnalyzeCoverage(sourceInfos)
+	}
 
-	// Complete the job
-	completedAt := time.Now()
-	expiresAt := completedAt.Add(24 * time.Hour) // Files expire after 24 hours
-	exportJob.Status = models.MLExportStatusCompleted
-	exportJob.CompletedAt = &completedAt
-	exportJob.ExpiresAt = &expiresAt
-	exportJob.Progress = 100
-	exportJob.CurrentPhase = "completed"
+	output := &exportOutput{
+		outputPath:       outputPath,
+		outputFiles:      outputFiles,
+		fileSize:         fileSize,
+		checksum:         checksum,
+		featureCount:     matrix.ColumnCount,
+		columns:          matrix.Columns,
+		rowCount:         int64(matrix.RowCount),
+		processedRecords: int64(len(allCandles)),
+		metadata:         metadata,
+	}
+	return output, ctx.Err()
+}
 
-	s.exportRepo.UpdateExportJob(ctx, exportJob)
+// exportInterrupted reports whether the export's context is done. A cancelled
+// export was already marked cancelled by CancelExportJob, so only a timeout
+// is recorded, as a failure, using a fresh context since ctx is no longer usable.
+func (s *MLExportService) exportInterrupted(ctx context.Context, jobID primitive.ObjectID) bool {
+	switch ctx.Err() {
+	case nil:
+		return false
+	case context.DeadlineExceeded:
+		logging.Printf(ctx, "[ML_EXPORT] Export job %s timed out", jobID.Hex())
+		failCtx, cancel := context.WithTimeout(logging.Detach(ctx), 10*time.Second)
+		defer cancel()
+		s.failExportJob(failCtx, jobID, "export timed out")
+	default:
+		logging.Printf(ctx, "[ML_EXPORT] Export job %s cancelled", jobID.Hex())
+	}
+	return true
 }
 
-// loadCandleData loads candle data from source jobs
-func (s *MLExportService) loadCandleData(ctx context.Context, exportJob *models.MLExportJob) ([]models.Candle, []models.SourceJobInfo, error) {
-	var allCandles []models.Candle
-	var sourceInfos []models.SourceJobInfo
+// loadCandleData loads candle data from source jobs.
+// Jobs are independent reads, so they are loaded concurrently (bounded by
+// loadConcurrency); the first error cancels the remaining loads.
+// Source jobs with fewer bars than the config needs fail the export, or are
+// skipped and returned separately when the min-bars action is skip.
+func (s *MLExportService) loadCandleData(ctx context.Context, exportJob *models.MLExportJob) ([]models.Candle, []models.SourceJobInfo, []models.SkippedSourceInfo, error) {
+	type jobCandles struct {
+		candles []models.Candle
+		info    *models.SourceJobInfo
+	}
 
-	for _, jobID := range exportJob.JobIDs {
-		// Get job info
-		job, err := s.jobRepo.FindByID(ctx, jobID.Hex())
+	// Indicators missing from storage are computed with the active config
+	var indicatorConfig *models.IndicatorConfig
+	if exportJob.Config.Features.ComputeMissingIndicators {
+		active, err := s.indicatorConfigRepo.FindDefault(ctx)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to find job %s: %w", jobID.Hex(), err)
+			return nil, nil, nil, fmt.Errorf("failed to load indicator config: %w", err)
 		}
+		indicatorConfig = active
+	}
+
+	tailBars, warmup := exportJob.Config.TailBars, FeatureWarmup(exportJob.Config.Features)
 
-		// Load OHLCV data using job's exchange, symbol, and timeframe
-		doc, err := s.ohlcvRepo.FindByJob(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	results, err := loadSources(ctx, exportJob.JobIDs, s.loadConcurrency, func(ctx context.Context, jobID primitive.ObjectID) (jobCandles, error) {
+		candles, info, err := s.loadJobCandles(ctx, jobID, exportJob.Config, indicatorConfig)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to load candles for job %s: %w", jobID.Hex(), err)
+			return jobCandles{}, err
 		}
-
-		if doc == nil || len(doc.Candles) == 0 {
-			continue
+		// Trimmed after indicators are computed, so they see the full history
+		if info != nil && tailBars > 0 {
+			var tailStart int64
+			candles, tailStart = tailCandles(candles, tailBars, warmup)
+			start := time.UnixMilli(tailStart)
+			info.BarCount = int64(len(candles))
+			info.StartTime = time.UnixMilli(candles[0].Timestamp)
+			info.TailStart = &start
 		}
+		if info != nil {
+			if err := s.joinDerivatives(ctx, info.ExchangeID, info.Symbol, candles, exportJob.Config.Features.DerivativesFeatures); err != nil {
+				return jobCandles{}, err
+			}
+		}
+		return jobCandles{candles: candles, info: info}, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		candles := doc.Candles
-
-		// Sort candles
-		sort.Slice(candles, func(i, j int) bool {
-			return candles[i].Timestamp < candles[j].Timestamp
-		})
+	requiredBars := RequiredBars(exportJob.Config)
 
-		// Track source info
-		sourceInfo := models.SourceJobInfo{
-			JobID:      jobID,
-			ExchangeID: job.ConnectorExchangeID,
-			Symbol:     job.Symbol,
-			Timeframe:  job.Timeframe,
-			BarCount:   int64(len(candles)),
-			StartTime:  time.UnixMilli(candles[0].Timestamp),
-			EndTime:    time.UnixMilli(candles[len(candles)-1].Timestamp),
+	var allCandles []models.Candle
+	var sourceInfos []models.SourceJobInfo
+	var shortSources []models.SkippedSourceInfo
+	for _, r := range results {
+		if r.info == nil {
+			continue
 		}
-		sourceInfos = append(sourceInfos, sourceInfo)
+		if r.info.BarCount < int64(requiredBars) {
+			shortSources = append(shortSources, models.SkippedSourceInfo{
+				JobID:        r.info.JobID,
+				Symbol:       r.info.Symbol,
+				Timeframe:    r.info.Timeframe,
+				BarCount:     r.info.BarCount,
+				RequiredBars: requiredBars,
+			})
+			continue
+		}
+		sourceInfos = append(sourceInfos, *r.info)
+		allCandles = append(allCandles, r.candles...)
+	}
 
-		allCandles = append(allCandles, candles...)
+	if len(shortSources) > 0 {
+		if exportJob.Config.MinBars.Action != models.MinBarsActionSkip {
+			return nil, nil, nil, fmt.Errorf("source jobs have fewer than the %d bars required by the export config: %s",
+				requiredBars, describeShortSources(shortSources))
+		}
+		logging.Printf(ctx, "[ML_EXPORT] Skipping %d source job(s) with fewer than %d bars: %s",
+			len(shortSources), requiredBars, describeShortSources(shortSources))
+		if len(sourceInfos) == 0 {
+			return nil, nil, nil, fmt.Errorf("all source jobs have fewer than the %d bars required by the export config", requiredBars)
+		}
 	}
 
 	// Sort all candles by timestamp
@@ -253,39 +626,227 @@ func (s *MLExportService) loadCandleData(ctx context.Context, exportJob *models.
 		return allCandles[i].Timestamp < allCandles[j].Timestamp
 	})
 
-	return allCandles, sourceInfos, nil
+	return allCandles, sourceInfos, shortSources, nil
+}
+
+// describeShortSources lists under-sized sources as "symbol timeframe (job): N bars"
+func describeShortSources(sources []models.SkippedSourceInfo) string {
+	parts := make([]string, len(sources))
+	for i, src := range sources {
+		parts[i] = fmt.Sprintf("%s %s (%s): %d bars", src.Symbol, src.Timeframe, src.JobID.Hex(), src.BarCount)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dropUnclosedBar drops the last of candles sorted oldest first if it hadn't
+// closed at now
+func dropUnclosedBar(candles []models.Candle, timeframe string, now time.Time) []models.Candle {
+	if len(candles) > 0 && !models.CandleClosed(candles[len(candles)-1], timeframe, now) {
+		return candles[:len(candles)-1]
+	}
+	return candles
+}
+
+// loadSources runs load for every source job, at most limit at a time, and
+// returns the results in job order. The first error cancels the remaining loads.
+func loadSources[T any](ctx context.Context, jobIDs []primitive.ObjectID, limit int, load func(ctx context.Context, jobID primitive.ObjectID) (T, error)) ([]T, error) {
+	// Results are stored by position so source info keeps the job order
+	results := make([]T, len(jobIDs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+
+	for i, jobID := range jobIDs {
+		g.Go(func() error {
+			result, err := load(gctx, jobID)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// loadJobCandles loads (and optionally resamples) the candles of a single source job.
+// If indicatorConfig is set, indicators missing from the candles are computed with it.
+// The config's alignment, resampling and unclosed bar settings apply.
+// Returns nil info when the job has no data.
+func (s *MLExportService) loadJobCandles(ctx context.Context, jobID primitive.ObjectID, config models.MLExportConfig, indicatorConfig *models.IndicatorConfig) ([]models.Candle, *models.SourceJobInfo, error) {
+	// Get job info
+	job, err := s.jobRepo.FindByID(ctx, jobID.Hex())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find job %s: %w", jobID.Hex(), err)
+	}
+
+	// Load OHLCV data using job's exchange, symbol, and timeframe
+	doc, err := s.ohlcvRepo.FindByJob(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load candles for job %s: %w", jobID.Hex(), err)
+	}
+
+	if doc == nil || len(doc.Candles) == 0 {
+		return nil, nil, nil
+	}
+
+	candles := doc.Candles
+
+	// Sort candles
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp < candles[j].Timestamp
+	})
+
+	// Snap candles onto the bar grid so sources from different exchanges
+	// share timestamps
+	var snapped, unaligned int64
+	if config.Alignment.Enabled {
+		candles, snapped, unaligned = alignCandles(candles, job.Timeframe, config.Alignment.ToleranceMs)
+		if unaligned > 0 {
+			logging.Printf(ctx, "[ML_EXPORT] Dropped %d candles of job %s too far off the %s bar grid", unaligned, jobID.Hex(), job.Timeframe)
+		}
+		if len(candles) == 0 {
+			return nil, nil, nil
+		}
+	}
+
+	// Resample to a coarser timeframe if requested
+	timeframe := job.Timeframe
+	if config.Resample.Enabled {
+		candles, err = ResampleCandles(candles, job.Timeframe, config.Resample)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resample candles for job %s: %w", jobID.Hex(), err)
+		}
+		if len(candles) == 0 {
+			return nil, nil, nil
+		}
+		timeframe = config.Resample.Timeframe
+	}
+
+	if config.ExcludesUnclosedBar() {
+		candles = dropUnclosedBar(candles, timeframe, time.Now())
+		if len(candles) == 0 {
+			return nil, nil, nil
+		}
+	}
+
+	// Computed per job so indicators never span two different series
+	if indicatorConfig != nil {
+		if err := s.fillMissingIndicators(candles, indicatorConfig); err != nil {
+			return nil, nil, fmt.Errorf("failed to compute indicators for job %s: %w", jobID.Hex(), err)
+		}
+	}
+
+	// Track source info
+	sourceInfo := &models.SourceJobInfo{
+		JobID:      jobID,
+		ExchangeID: job.ConnectorExchangeID,
+		Symbol:     job.Symbol,
+		Timeframe:  timeframe,
+		BarCount:   int64(len(candles)),
+		StartTime:  time.UnixMilli(candles[0].Timestamp),
+		EndTime:    time.UnixMilli(candles[len(candles)-1].Timestamp),
+
+		SnappedBars:   snapped,
+		UnalignedBars: unaligned,
+	}
+
+	return candles, sourceInfo, nil
+}
+
+// fillMissingIndicators computes indicators from the raw candles (sorted oldest
+// first) and fills in any indicator value that is not stored on a candle.
+// Stored values are kept as is.
+func (s *MLExportService) fillMissingIndicators(candles []models.Candle, indicatorConfig *models.IndicatorConfig) error {
+	// The indicator service expects newest first and overwrites its input
+	computed := make([]models.Candle, len(candles))
+	for i := range candles {
+		computed[len(candles)-1-i] = candles[i]
+		computed[len(candles)-1-i].Indicators = models.Indicators{}
+	}
+
+	computed, err := s.indicatorService.CalculateWithConfig(computed, indicatorConfig)
+	if err != nil {
+		return err
+	}
+
+	for i := range candles {
+		candles[i].Indicators.FillMissing(computed[len(candles)-1-i].Indicators)
+	}
+
+	return nil
 }
 
-// applyPreprocessing applies preprocessing steps to the feature matrix
-func (s *MLExportService) applyPreprocessing(matrix *models.FeatureMatrix, config models.PreprocessConfig) (map[string]models.NormParams, error) {
+// applyPreprocessing applies preprocessing steps to the feature matrix. The
+// split config tells percentile clipping which rows will be the training rows.
+// Cancellation of ctx is checked between steps.
+func (s *MLExportService) applyPreprocessing(ctx context.Context, matrix *models.FeatureMatrix, config models.PreprocessConfig, split models.SplitConfig) (map[string]models.NormParams, error) {
 	normParams := make(map[string]models.NormParams)
 
+	// Drop sparse columns before filling hides their NaNs
+	if config.MaxColNaNFraction > 0 {
+		s.dropNaNColumns(matrix, config.MaxColNaNFraction, config.ProtectColumns)
+	}
+
 	// Handle NaN values first
-	s.handleNaN(matrix, config.NaNHandling)
+	s.handleNaN(matrix, config.NaNHandling, config.ForwardFillLimit)
 
 	// Handle Inf values
 	s.handleInf(matrix, config.InfHandling)
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Clip outliers if enabled
-	if config.ClipOutliers && config.OutlierStdDev > 0 {
-		s.clipOutliers(matrix, config.OutlierStdDev)
+	if config.ClipOutliers {
+		if config.ClipMethod == models.ClipMethodPercentile {
+			lower, upper := config.ClipPercentiles()
+			clipPercentiles(matrix, lower, upper, trainingRows(matrix.RowCount, split), config.ProtectColumns)
+		} else if config.OutlierStdDev > 0 {
+			s.clipOutliers(matrix, config.OutlierStdDev, config.ProtectColumns)
+		}
 	}
 
 	// Apply normalization
 	if config.Normalization != models.NormalizationNone {
-		normParams = s.normalize(matrix, config.Normalization)
+		normParams = s.normalize(matrix, config.Normalization, config.ProtectColumns)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
 	// Remove NaN rows if requested (after all preprocessing)
 	if config.RemoveNaNRows {
-		s.removeNaNRows(matrix)
+		s.removeNaNRows(matrix, config.MaxNaNFraction)
 	}
 
 	return normParams, nil
 }
 
+// isTargetColumn reports whether the column at colIdx is a target, by its
+// schema source or, without a schema, its target_ prefix
+func isTargetColumn(matrix *models.FeatureMatrix, colIdx int) bool {
+	if colIdx < len(matrix.Schema) && matrix.Schema[colIdx].Source == "target" {
+		return true
+	}
+	return strings.HasPrefix(matrix.Columns[colIdx], "target_")
+}
+
+// isExcludedFromPreprocessing reports whether the preprocessing steps that
+// fit values to the data (clipping, normalization, NaN column dropping) must
+// leave the column at colIdx alone: targets and the protected columns
+func isExcludedFromPreprocessing(matrix *models.FeatureMatrix, colIdx int, protect []string) bool {
+	return isTargetColumn(matrix, colIdx) || slices.Contains(protect, matrix.Columns[colIdx])
+}
+
 // handleNaN handles NaN values based on strategy
-func (s *MLExportService) handleNaN(matrix *models.FeatureMatrix, handling models.NaNHandlingType) {
+func (s *MLExportService) handleNaN(matrix *models.FeatureMatrix, handling models.NaNHandlingType, fillLimit int) {
 	for colIdx := range matrix.Columns {
 		switch handling {
 		case models.NaNHandlingZero:
@@ -296,6 +857,10 @@ func (s *MLExportService) handleNaN(matrix *models.FeatureMatrix, handling model
 			}
 
 		case models.NaNHandlingForwardFill:
+			if fillLimit > 0 {
+				fillLimited(matrix, colIdx, fillLimit, false)
+				continue
+			}
 			lastValid := 0.0
 			for rowIdx := range matrix.Data {
 				if math.IsNaN(matrix.Data[rowIdx][colIdx]) {
@@ -306,6 +871,10 @@ func (s *MLExportService) handleNaN(matrix *models.FeatureMatrix, handling model
 			}
 
 		case models.NaNHandlingBackwardFill:
+			if fillLimit > 0 {
+				fillLimited(matrix, colIdx, fillLimit, true)
+				continue
+			}
 			nextValid := 0.0
 			for rowIdx := len(matrix.Data) - 1; rowIdx >= 0; rowIdx-- {
 				if math.IsNaN(matrix.Data[rowIdx][colIdx]) {
@@ -353,6 +922,33 @@ func (s *MLExportService) handleNaN(matrix *models.FeatureMatrix, handling model
 	}
 }
 
+// fillLimited carries the last valid value of a column into at most limit
+// consecutive NaN rows, walking backwards for backward fill. Rows before the
+// first valid value, and rows past the limit, are left NaN.
+func fillLimited(matrix *models.FeatureMatrix, colIdx, limit int, backward bool) {
+	n := len(matrix.Data)
+	carried := math.NaN()
+	run := 0
+	for i := 0; i < n; i++ {
+		rowIdx := i
+		if backward {
+			rowIdx = n - 1 - i
+		}
+
+		val := matrix.Data[rowIdx][colIdx]
+		if !math.IsNaN(val) {
+			carried = val
+			run = 0
+			continue
+		}
+
+		run++
+		if run <= limit {
+			matrix.Data[rowIdx][colIdx] = carried
+		}
+	}
+}
+
 // handleInf handles infinite values
 func (s *MLExportService) handleInf(matrix *models.FeatureMatrix, handling string) {
 	if handling == "" {
@@ -380,9 +976,14 @@ func (s *MLExportService) handleInf(matrix *models.FeatureMatrix, handling strin
 	}
 }
 
-// clipOutliers clips values beyond N standard deviations
-func (s *MLExportService) clipOutliers(matrix *models.FeatureMatrix, nStdDev float64) {
+// clipOutliers clips values beyond N standard deviations, leaving target and
+// protected columns alone
+func (s *MLExportService) clipOutliers(matrix *models.FeatureMatrix, nStdDev float64, protect []string) {
 	for colIdx := range matrix.Columns {
+		if isExcludedFromPreprocessing(matrix, colIdx, protect) {
+			continue
+		}
+
 		// Calculate mean and std for column
 		sum := 0.0
 		count := 0
@@ -409,26 +1010,27 @@ func (s *MLExportService) clipOutliers(matrix *models.FeatureMatrix, nStdDev flo
 		// Clip values
 		lower := mean - nStdDev*std
 		upper := mean + nStdDev*std
-
-		for rowIdx := range matrix.Data {
-			if !math.IsNaN(matrix.Data[rowIdx][colIdx]) {
-				if matrix.Data[rowIdx][colIdx] < lower {
-					matrix.Data[rowIdx][colIdx] = lower
-				} else if matrix.Data[rowIdx][colIdx] > upper {
-					matrix.Data[rowIdx][colIdx] = upper
-				}
-			}
-		}
+		setClipBounds(matrix, colIdx, models.ClipBounds{
+			Method:  models.ClipMethodStdDev,
+			Lower:   lower,
+			Upper:   upper,
+			FitRows: len(matrix.Data),
+			Clipped: clipColumn(matrix, colIdx, lower, upper),
+		})
 	}
 }
 
-// normalize applies normalization to the feature matrix
-func (s *MLExportService) normalize(matrix *models.FeatureMatrix, method models.NormalizationType) map[string]models.NormParams {
+// normalize applies normalization to the feature matrix, leaving target and
+// protected columns alone
+func (s *MLExportService) normalize(matrix *models.FeatureMatrix, method models.NormalizationType, protect []string) map[string]models.NormParams {
 	params := make(map[string]models.NormParams)
 
 	for colIdx, colName := range matrix.Columns {
-		// Skip target columns
-		if len(colName) > 7 && colName[:7] == "target_" {
+		if isExcludedFromPreprocessing(matrix, colIdx, protect) {
+			continue
+		}
+		// Skip categorical columns, whose values index their legend
+		if colIdx < len(matrix.Schema) && len(matrix.Schema[colIdx].Categories) > 0 {
 			continue
 		}
 
@@ -518,8 +1120,68 @@ func (s *MLExportService) normalize(matrix *models.FeatureMatrix, method models.
 	return params
 }
 
-// removeNaNRows removes rows with any NaN values
-func (s *MLExportService) removeNaNRows(matrix *models.FeatureMatrix) {
+// dropNaNColumns removes feature columns whose fraction of NaN values is above
+// maxFraction, recording each in matrix.DroppedColumns. Target and protected
+// columns are kept.
+func (s *MLExportService) dropNaNColumns(matrix *models.FeatureMatrix, maxFraction float64, protect []string) {
+	if len(matrix.Data) == 0 {
+		return
+	}
+
+	keep := make([]int, 0, len(matrix.Columns))
+	for colIdx, colName := range matrix.Columns {
+		if isExcludedFromPreprocessing(matrix, colIdx, protect) {
+			keep = append(keep, colIdx)
+			continue
+		}
+
+		nanCount := 0
+		for _, row := range matrix.Data {
+			if math.IsNaN(row[colIdx]) {
+				nanCount++
+			}
+		}
+
+		fraction := float64(nanCount) / float64(len(matrix.Data))
+		if fraction > maxFraction {
+			matrix.DroppedColumns = append(matrix.DroppedColumns, models.DroppedColumnInfo{
+				Name:        colName,
+				NaNFraction: fraction,
+				Reason:      fmt.Sprintf("%.1f%% NaN exceeds max_col_nan_fraction %.2f", fraction*100, maxFraction),
+			})
+			continue
+		}
+		keep = append(keep, colIdx)
+	}
+
+	if len(keep) == len(matrix.Columns) {
+		return
+	}
+
+	columns := make([]string, len(keep))
+	schema := make([]models.FeatureSchema, 0, len(keep))
+	for i, colIdx := range keep {
+		columns[i] = matrix.Columns[colIdx]
+		if colIdx < len(matrix.Schema) {
+			schema = append(schema, matrix.Schema[colIdx])
+		}
+	}
+	for rowIdx, row := range matrix.Data {
+		kept := make([]float64, len(keep))
+		for i, colIdx := range keep {
+			kept[i] = row[colIdx]
+		}
+		matrix.Data[rowIdx] = kept
+	}
+
+	matrix.Columns = columns
+	matrix.Schema = schema
+	matrix.ColumnCount = len(columns)
+}
+
+// removeNaNRows removes rows whose fraction of NaN values is above maxFraction
+// (0 removes any row with a NaN), recording the removal in matrix.DroppedRows
+func (s *MLExportService) removeNaNRows(matrix *models.FeatureMatrix, maxFraction float64) {
 	newData := make([][]float64, 0, len(matrix.Data))
 	newTimestamps := make([]int64, 0, len(matrix.Timestamps))
 	var newSplitLabels []string
@@ -527,16 +1189,16 @@ func (s *MLExportService) removeNaNRows(matrix *models.FeatureMatrix) {
 		newSplitLabels = make([]string, 0, len(matrix.SplitLabels))
 	}
 
+	dropped := &models.DroppedRowsInfo{MaxNaNFraction: maxFraction}
 	for i, row := range matrix.Data {
-		hasNaN := false
+		nanCount := 0
 		for _, val := range row {
 			if math.IsNaN(val) {
-				hasNaN = true
-				break
+				nanCount++
 			}
 		}
 
-		if !hasNaN {
+		if len(row) == 0 || float64(nanCount)/float64(len(row)) <= maxFraction {
 			newData = append(newData, row)
 			if i < len(matrix.Timestamps) {
 				newTimestamps = append(newTimestamps, matrix.Timestamps[i])
@@ -544,7 +1206,25 @@ func (s *MLExportService) removeNaNRows(matrix *models.FeatureMatrix) {
 			if len(matrix.SplitLabels) > i {
 				newSplitLabels = append(newSplitLabels, matrix.SplitLabels[i])
 			}
+			continue
+		}
+
+		dropped.Count++
+		if i < len(matrix.Timestamps) {
+			if dropped.FirstTimestamp == 0 {
+				dropped.FirstTimestamp = matrix.Timestamps[i]
+			}
+			dropped.LastTimestamp = matrix.Timestamps[i]
+		}
+	}
+
+	if dropped.Count > 0 {
+		if maxFraction > 0 {
+			dropped.Reason = fmt.Sprintf("more than %.0f%% of values were NaN", maxFraction*100)
+		} else {
+			dropped.Reason = "row contained NaN values"
 		}
+		matrix.DroppedRows = dropped
 	}
 
 	matrix.Data = newData
@@ -599,6 +1279,12 @@ func (s *MLExportService) applySplit(matrix *models.FeatureMatrix, config models
 			splitInfo.TestEnd = time.UnixMilli(matrix.Timestamps[n-1])
 		}
 
+		if config.ShuffleIndex {
+			s.addShuffleIndex(matrix, trainEnd, config.ShuffleSeed)
+			seed := config.ShuffleSeed
+			splitInfo.ShuffleSeed = &seed
+		}
+
 	} else if config.Shuffle {
 		// Random shuffle split
 		indices := make([]int, n)
@@ -642,6 +1328,25 @@ func (s *MLExportService) applySplit(matrix *models.FeatureMatrix, config models
 	return splitInfo
 }
 
+// addShuffleIndex appends a shuffle_index column without reordering rows.
+// Training rows get their position in a seeded permutation, so sorting the
+// training split by shuffle_index yields a reproducible shuffled batch order;
+// validation and test rows get -1. The column is float64 like every matrix
+// value, since the writers emit it in the export's float format.
+func (s *MLExportService) addShuffleIndex(matrix *models.FeatureMatrix, trainRows int, seed int64) {
+	values := make([]float64, len(matrix.Data))
+	for i := range values {
+		values[i] = -1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for pos, row := range rng.Perm(trainRows) {
+		values[row] = float64(pos)
+	}
+
+	s.featureEngine.addColumn(matrix, "shuffle_index", "float64", "split", values)
+}
+
 // generateSequences creates sequences for RNN/LSTM/Transformer models
 func (s *MLExportService) generateSequences(matrix *models.FeatureMatrix, config models.SequenceConfig) *models.SequenceInfo {
 	if config.Length <= 0 || len(matrix.Data) < config.Length {
@@ -694,37 +1399,91 @@ func (s *MLExportService) generateSequences(matrix *models.FeatureMatrix, config
 	return seqInfo
 }
 
+// writerOptions returns the default writer options with the config's float
+// format and precision applied
+func writerOptions(config models.MLExportConfig) WriterOptions {
+	options := DefaultWriterOptions()
+	options.FloatFormat = config.FloatFormat
+	if config.FloatPrecision > 0 {
+		options.Precision = config.FloatPrecision
+	}
+	return options
+}
+
 // writeOutput writes the feature matrix to the output file
-func (s *MLExportService) writeOutput(matrix *models.FeatureMatrix, exportJob *models.MLExportJob) (string, int64, error) {
+// Returns the output path, the split files of split outputs, and the file size
+// and SHA-256 checksum of the output file. The output file of split outputs
+// is the manifest listing the split files.
+func (s *MLExportService) writeOutput(matrix *models.FeatureMatrix, exportJob *models.MLExportJob, splitInfo *models.SplitInfo) (string, []string, int64, string, error) {
 	// Create writer
-	options := DefaultWriterOptions()
+	options := writerOptions(exportJob.Config)
 	options.SplitByLabel = exportJob.Config.Split.Enabled
 
 	writer, err := NewExportWriter(exportJob.Config.Format, options)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to create writer: %w", err)
+		return "", nil, 0, "", fmt.Errorf("failed to create writer: %w", err)
 	}
 
-	// Generate output filename
+	// Generate output filename; the per-job outputs of a separate-files
+	// export also carry their source job ID
+	name := exportJob.ID.Hex()
+	if exportJob.Config.SeparateFiles && len(exportJob.JobIDs) == 1 {
+		name += "_" + exportJob.JobIDs[0].Hex()
+	}
 	filename := fmt.Sprintf("ml_export_%s_%s%s",
-		exportJob.ID.Hex(),
+		name,
 		time.Now().Format("20060102_150405"),
 		writer.Extension(),
 	)
 	outputPath := filepath.Join(s.exportDir, filename)
 
-	// Write file
-	if err := writer.Write(matrix, outputPath); err != nil {
-		return "", 0, fmt.Errorf("failed to write file: %w", err)
+	// Split outputs are written as several files by the writer itself
+	if options.SplitByLabel && len(matrix.SplitLabels) > 0 {
+		if err := writer.Write(matrix, outputPath); err != nil {
+			return "", nil, 0, "", fmt.Errorf("failed to write file: %w", err)
+		}
+
+		basePath := strings.TrimSuffix(outputPath, writer.Extension())
+		manifestPath, splitPaths, err := writeSplitManifest(exportJob, matrix.Columns, basePath, writer.Extension(), splitInfo)
+		if err != nil {
+			return "", nil, 0, "", err
+		}
+		if manifestPath != "" {
+			err = verifySplitRows(writer, matrix, basePath, writer.Extension())
+			outputPath = manifestPath
+		} else {
+			err = verifyRowCount(writer, outputPath, expectedOutputRows(writer, matrix))
+		}
+		if err != nil {
+			return "", nil, 0, "", err
+		}
+
+		checksum, size, err := FileSHA256(outputPath)
+		if err != nil {
+			return "", nil, 0, "", fmt.Errorf("failed to hash output file: %w", err)
+		}
+		return outputPath, splitPaths, size, checksum, nil
+	}
+
+	// Write file, hashing the bytes as they are written
+	checksum, written, err := writeHashedFile(writer, matrix, outputPath)
+	if err != nil {
+		return "", nil, 0, "", fmt.Errorf("failed to write file: %w", err)
 	}
 
-	// Get file size
+	// Verify the file on disk matches what was written (catches truncated writes)
 	fileInfo, err := os.Stat(outputPath)
 	if err != nil {
-		return outputPath, 0, nil
+		return "", nil, 0, "", fmt.Errorf("failed to stat output file: %w", err)
+	}
+	if fileInfo.Size() != written {
+		return "", nil, 0, "", fmt.Errorf("output file size mismatch: wrote %d bytes, found %d on disk", written, fileInfo.Size())
+	}
+	if err := verifyRowCount(writer, outputPath, expectedOutputRows(writer, matrix)); err != nil {
+		return "", nil, 0, "", err
 	}
 
-	return outputPath, fileInfo.Size(), nil
+	return outputPath, nil, fileInfo.Size(), checksum, nil
 }
 
 // buildMetadata creates export metadata
@@ -769,6 +1528,11 @@ func (s *MLExportService) buildMetadata(
 		metadata.NormalizationParams = normParams
 	}
 
+	metadata.Targets = matrix.Targets
+	metadata.DroppedColumns = matrix.DroppedColumns
+	metadata.DroppedRows = matrix.DroppedRows
+	metadata.ClipBounds = matrix.ClipBounds
+
 	if splitInfo != nil {
 		metadata.SplitInfo = splitInfo
 	}
@@ -789,7 +1553,11 @@ func (s *MLExportService) failExportJob(ctx context.Context, jobID primitive.Obj
 
 	exportJob.Status = models.MLExportStatusFailed
 	exportJob.LastError = errMsg
-	exportJob.Errors = append(exportJob.Errors, errMsg)
+	if exportJob.RequestID != "" {
+		exportJob.Errors = append(exportJob.Errors, fmt.Sprintf("[%s] %s", exportJob.RequestID, errMsg))
+	} else {
+		exportJob.Errors = append(exportJob.Errors, errMsg)
+	}
 	now := time.Now()
 	exportJob.CompletedAt = &now
 	exportJob.UpdatedAt = now
@@ -811,6 +1579,55 @@ func (s *MLExportService) ListExportJobs(ctx context.Context, limit, offset int6
 	return s.exportRepo.FindExportJobs(ctx, limit, offset)
 }
 
+// VerifyExportJob re-hashes an export file on disk and compares it with the
+// checksum and size recorded when the export completed
+func (s *MLExportService) VerifyExportJob(ctx context.Context, id string) (*models.MLExportVerification, error) {
+	exportJob, err := s.GetExportJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exportJob.Status != models.MLExportStatusCompleted {
+		return nil, fmt.Errorf("export job is not completed")
+	}
+
+	result := &models.MLExportVerification{
+		ExportJobID:      exportJob.ID.Hex(),
+		OutputPath:       exportJob.OutputPath,
+		ExpectedChecksum: exportJob.Checksum,
+		ExpectedSize:     exportJob.FileSizeBytes,
+	}
+
+	actualChecksum, actualSize, err := s.exportFileSHA256(ctx, exportJob.OutputPath)
+	if os.IsNotExist(err) {
+		result.Message = "export file has expired or been deleted"
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash export file: %w", err)
+	}
+
+	result.FileExists = true
+	result.ActualChecksum = actualChecksum
+	result.ActualSize = actualSize
+	result.SizeMatch = actualSize == exportJob.FileSizeBytes
+
+	if exportJob.Checksum == "" {
+		// Exports written before checksums were recorded can only be size-checked
+		result.ChecksumMatch = true
+		result.Message = "no checksum recorded for this export, verified size only"
+	} else {
+		result.ChecksumMatch = actualChecksum == exportJob.Checksum
+	}
+
+	result.Valid = result.SizeMatch && result.ChecksumMatch
+	if !result.Valid {
+		result.Message = "export file does not match the recorded checksum or size"
+	}
+
+	return result, nil
+}
+
 // CancelExportJob cancels a running export job
 func (s *MLExportService) CancelExportJob(ctx context.Context, id string) error {
 	s.activeJobsMu.RLock()
@@ -852,15 +1669,60 @@ func (s *MLExportService) DeleteExportJob(ctx context.Context, id string) error
 		return err
 	}
 
-	// Delete output file if exists
-	if exportJob.OutputPath != "" {
-		os.Remove(exportJob.OutputPath)
+	// Delete output files if they exist
+	for _, path := range append([]string{exportJob.OutputPath}, exportJob.OutputFiles...) {
+		if path != "" {
+			s.removeExportFile(ctx, path)
+		}
 	}
 
 	// Delete from database
 	return s.exportRepo.DeleteExportJob(ctx, objID)
 }
 
+// DeleteExportJobsByStatus deletes all export jobs in a terminal status along with their files
+func (s *MLExportService) DeleteExportJobsByStatus(ctx context.Context, status models.MLExportStatus) (*models.MLExportBulkDeleteResult, error) {
+	if !status.IsTerminal() {
+		return nil, fmt.Errorf("cannot bulk delete export jobs with non-terminal status: %s", status)
+	}
+
+	jobs, err := s.exportRepo.FindExportJobsByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find export jobs: %w", err)
+	}
+
+	result := &models.MLExportBulkDeleteResult{Status: status}
+	ids := make([]primitive.ObjectID, 0, len(jobs))
+
+	for _, job := range jobs {
+		paths := job.OutputFiles
+		if job.OutputPath != "" {
+			paths = append([]string{job.OutputPath}, paths...)
+		}
+		for _, path := range paths {
+			size, err := s.removeExportFile(ctx, path)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					logging.Printf(ctx, "[ML_EXPORT] Failed to remove export file %s: %v", path, err)
+				}
+				continue
+			}
+			result.FilesRemoved++
+			result.BytesRemoved += size
+		}
+		ids = append(ids, job.ID)
+	}
+
+	// Delete only the jobs we cleaned up, so a job reaching this status meanwhile keeps its file
+	deleted, err := s.exportRepo.DeleteExportJobsByIDs(ctx, ids)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete export jobs: %w", err)
+	}
+	result.JobsDeleted = deleted
+
+	return result, nil
+}
+
 // StreamExport performs a streaming export (for smaller datasets)
 func (s *MLExportService) StreamExport(ctx context.Context, config models.MLExportConfig, jobID primitive.ObjectID, writer *os.File) error {
 	// Get job info first
@@ -880,23 +1742,59 @@ func (s *MLExportService) StreamExport(ctx context.Context, config models.MLExpo
 	}
 
 	candles := doc.Candles
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp < candles[j].Timestamp
+	})
+	if config.ExcludesUnclosedBar() {
+		candles = dropUnclosedBar(candles, job.Timeframe, time.Now())
+		if len(candles) == 0 {
+			return fmt.Errorf("no candle data found for job")
+		}
+	}
+
+	if config.Features.ComputeMissingIndicators {
+		indicatorConfig, err := s.indicatorConfigRepo.FindDefault(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load indicator config: %w", err)
+		}
+		if err := s.fillMissingIndicators(candles, indicatorConfig); err != nil {
+			return fmt.Errorf("failed to compute indicators: %w", err)
+		}
+	}
+
+	candles, tailStart := tailCandles(candles, config.TailBars, FeatureWarmup(config.Features))
+
+	if err := s.joinDerivatives(ctx, job.ConnectorExchangeID, job.Symbol, candles, config.Features.DerivativesFeatures); err != nil {
+		return err
+	}
 
 	// Generate features
-	matrix, err := s.featureEngine.GenerateFeatures(candles, config.Features)
+	matrix, err := s.featureEngine.GenerateFeatures(ctx, candles, config.Features, job.Timeframe)
 	if err != nil {
 		return fmt.Errorf("failed to generate features: %w", err)
 	}
 
+	if _, err := s.joinAuxiliarySources(ctx, matrix, config.AuxiliarySources, job.Timeframe); err != nil {
+		return fmt.Errorf("failed to join auxiliary sources: %w", err)
+	}
+
 	// Generate targets
-	if err := s.featureEngine.GenerateTargets(matrix, candles, config.Target); err != nil {
+	if err := s.featureEngine.GenerateTargets(ctx, matrix, candles, config.Target); err != nil {
 		return fmt.Errorf("failed to generate targets: %w", err)
 	}
 
+	if config.TailBars > 0 {
+		dropRowsBefore(matrix, tailStart)
+	} else {
+		dropLeadingRows(matrix, WarmupTrimRows(config))
+	}
+
 	// Apply preprocessing
-	_, err = s.applyPreprocessing(matrix, config.Preprocessing)
+	_, err = s.applyPreprocessing(ctx, matrix, config.Preprocessing, config.Split)
 	if err != nil {
 		return fmt.Errorf("failed to apply preprocessing: %w", err)
 	}
+	applyColumnOrder(matrix, config.ColumnOrder)
 
 	// Apply split if enabled
 	if config.Split.Enabled {
@@ -909,7 +1807,7 @@ func (s *MLExportService) StreamExport(ctx context.Context, config models.MLExpo
 	}
 
 	// Create export writer
-	options := DefaultWriterOptions()
+	options := writerOptions(config)
 	exportWriter, err := NewExportWriter(config.Format, options)
 	if err != nil {
 		return fmt.Errorf("failed to create writer: %w", err)
@@ -933,11 +1831,82 @@ func (s *MLExportService) GetConfig(ctx context.Context, id string) (*models.MLE
 	return s.exportRepo.FindConfigByID(ctx, objID)
 }
 
+// FindProfile finds an export profile by ID, or a built-in preset by name
+func (s *MLExportService) FindProfile(ctx context.Context, id string) (*models.MLExportConfig, error) {
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		return s.exportRepo.FindConfigByID(ctx, objID)
+	}
+	for _, preset := range models.GetBuiltinPresets() {
+		if preset.Name == id {
+			return &preset, nil
+		}
+	}
+	return nil, fmt.Errorf("config not found")
+}
+
 // CreateConfig creates a new export configuration
 func (s *MLExportService) CreateConfig(ctx context.Context, config *models.MLExportConfig) error {
 	return s.exportRepo.CreateConfig(ctx, config)
 }
 
+// maxCloneNameAttempts bounds the " (copy N)" suffixes tried for a clone name
+const maxCloneNameAttempts = 100
+
+// CloneConfig copies an export configuration into a new, non-preset one. id is
+// a config ID, or the name of a built-in preset. An empty name gives the clone
+// the source's name with the first free " (copy)" / " (copy N)" suffix; a
+// given name must not be taken.
+func (s *MLExportService) CloneConfig(ctx context.Context, id, name, description string) (*models.MLExportConfig, error) {
+	source, err := s.FindProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		for i := 1; i <= maxCloneNameAttempts && name == ""; i++ {
+			candidate := source.Name + " (copy)"
+			if i > 1 {
+				candidate = fmt.Sprintf("%s (copy %d)", source.Name, i)
+			}
+			existing, err := s.exportRepo.FindConfigByName(ctx, candidate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check config name: %w", err)
+			}
+			if existing == nil {
+				name = candidate
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("config with name '%s (copy)' already exists", source.Name)
+		}
+	} else {
+		existing, err := s.exportRepo.FindConfigByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check config name: %w", err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("config with name '%s' already exists", name)
+		}
+	}
+
+	clone := *source
+	clone.ID = primitive.NilObjectID
+	clone.Name = name
+	if description != "" {
+		clone.Description = description
+	}
+	clone.IsPreset = false
+	clone.IsDefault = false
+
+	// The unique name index still catches a clone racing another create
+	if err := s.exportRepo.CreateConfig(ctx, &clone); err != nil {
+		return nil, err
+	}
+
+	logging.Printf(ctx, "[ML_EXPORT] Cloned config %q into %q", source.Name, clone.Name)
+	return &clone, nil
+}
+
 // UpdateConfig updates an export configuration
 func (s *MLExportService) UpdateConfig(ctx context.Context, config *models.MLExportConfig) error {
 	return s.exportRepo.UpdateConfig(ctx, config)
@@ -957,28 +1926,182 @@ func (s *MLExportService) ListConfigs(ctx context.Context) ([]*models.MLExportCo
 	return s.exportRepo.FindConfigs(ctx)
 }
 
-// CleanupExpiredExports removes expired export files
-func (s *MLExportService) CleanupExpiredExports(ctx context.Context) error {
-	jobs, _, err := s.exportRepo.FindExportJobs(ctx, 1000, 0)
+// CleanupExpiredExports removes expired export files and their job records.
+// Expiry is stored per job at completion, so exports without an ExpiresAt are kept.
+// Completed jobs whose file has already disappeared are marked expired so their
+// status matches what DownloadExport can actually serve.
+func (s *MLExportService) CleanupExpiredExports(ctx context.Context) (*models.MLExportCleanupResult, error) {
+	result := &models.MLExportCleanupResult{}
+
+	jobs, err := s.exportRepo.FindExpiredExportJobs(ctx)
 	if err != nil {
-		return err
+		return result, fmt.Errorf("failed to find expired exports: %w", err)
 	}
 
-	now := time.Now()
 	for _, job := range jobs {
-		if job.ExpiresAt != nil && job.ExpiresAt.Before(now) {
-			// Remove file
-			if job.OutputPath != "" {
-				os.Remove(job.OutputPath)
+		// Remove file
+		if job.OutputPath != "" {
+			if _, err := s.removeExportFile(ctx, job.OutputPath); err == nil {
+				result.FilesRemoved++
+			} else if !os.IsNotExist(err) {
+				logging.Printf(ctx, "[ML_EXPORT] Failed to remove expired export file %s: %v", job.OutputPath, err)
+				continue
+			}
+		}
+		for _, path := range job.OutputFiles {
+			s.removeExportFile(ctx, path)
+		}
+		// Delete job record
+		if err := s.exportRepo.DeleteExportJob(ctx, job.ID); err != nil {
+			logging.Printf(ctx, "[ML_EXPORT] Failed to delete expired export job %s: %v", job.ID.Hex(), err)
+			continue
+		}
+		result.RecordsRemoved++
+	}
+
+	// Reconcile completed jobs whose files were removed outside of this cleanup
+	completed, err := s.exportRepo.FindExportJobsByStatus(ctx, models.MLExportStatusCompleted)
+	if err != nil {
+		return result, fmt.Errorf("failed to find completed exports: %w", err)
+	}
+
+	for _, job := range completed {
+		// Split outputs keep their data in one file per split; older split
+		// jobs never wrote anything at OutputPath
+		paths := []string{job.OutputPath}
+		if job.Config.Split.Enabled {
+			paths = job.OutputFiles
+		}
+
+		missing := false
+		for _, path := range paths {
+			if path == "" {
+				continue
+			}
+			if _, err := s.StatExportFile(ctx, path); os.IsNotExist(err) {
+				missing = true
+				break
 			}
-			// Delete job record
-			s.exportRepo.DeleteExportJob(ctx, job.ID)
 		}
+		if !missing {
+			continue
+		}
+		if err := s.MarkExportFileMissing(ctx, job.ID); err != nil {
+			logging.Printf(ctx, "[ML_EXPORT] Failed to reconcile export job %s: %v", job.ID.Hex(), err)
+			continue
+		}
+		result.RecordsReconciled++
+	}
+
+	return result, nil
+}
+
+// GetDiskUsage returns how much space export files currently take in the export directory
+func (s *MLExportService) GetDiskUsage() (*models.MLExportDiskUsage, error) {
+	usage := &models.MLExportDiskUsage{
+		ExportDir: s.exportDir,
+		MaxBytes:  s.maxDiskBytes,
 	}
 
+	err := filepath.WalkDir(s.exportDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			// File removed while walking
+			return nil
+		}
+		usage.UsedBytes += info.Size()
+		usage.FileCount++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan export directory: %w", err)
+	}
+
+	if s.maxDiskBytes > 0 {
+		usage.UsagePercent = float64(usage.UsedBytes) / float64(s.maxDiskBytes) * 100
+		usage.AvailableBytes = s.maxDiskBytes - usage.UsedBytes
+		if usage.AvailableBytes < 0 {
+			usage.AvailableBytes = 0
+		}
+		usage.OverLimit = usage.UsedBytes >= s.maxDiskBytes
+	}
+
+	return usage, nil
+}
+
+// ensureDiskCapacity checks the export directory against MaxDiskBytes.
+// When over the limit, expired exports are evicted oldest first; if that
+// doesn't free enough space the export is rejected.
+func (s *MLExportService) ensureDiskCapacity(ctx context.Context) error {
+	if s.maxDiskBytes <= 0 {
+		return nil
+	}
+
+	usage, err := s.GetDiskUsage()
+	if err != nil {
+		return err
+	}
+	if !usage.OverLimit {
+		return nil
+	}
+
+	expired, err := s.exportRepo.FindExpiredExportJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find expired exports: %w", err)
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt.Before(*expired[j].ExpiresAt)
+	})
+
+	used := usage.UsedBytes
+	for _, job := range expired {
+		if used < s.maxDiskBytes {
+			break
+		}
+		// Objects in the bucket don't take disk space; cleanup removes them
+		if IsRemoteExport(job.OutputPath) {
+			continue
+		}
+		paths := job.OutputFiles
+		if job.OutputPath != "" {
+			paths = append([]string{job.OutputPath}, paths...)
+		}
+		evicted := true
+		for _, path := range paths {
+			size, err := s.removeExportFile(ctx, path)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					logging.Printf(ctx, "[ML_EXPORT] Failed to evict export file %s: %v", path, err)
+					evicted = false
+				}
+				continue
+			}
+			used -= size
+		}
+		if !evicted {
+			continue
+		}
+		s.exportRepo.DeleteExportJob(ctx, job.ID)
+		logging.Printf(ctx, "[ML_EXPORT] Evicted expired export %s to free disk space", job.ID.Hex())
+	}
+
+	if used >= s.maxDiskBytes {
+		return fmt.Errorf("export disk limit reached: %d of %d bytes used", used, s.maxDiskBytes)
+	}
 	return nil
 }
 
+// MarkExportFileMissing flags a completed export whose output file no longer exists
+func (s *MLExportService) MarkExportFileMissing(ctx context.Context, id primitive.ObjectID) error {
+	return s.exportRepo.UpdateExportJobStatus(ctx, id, models.MLExportStatusExpired, "export file no longer exists on disk")
+}
+
 // Helper function to get map keys
 func mapKeys(m map[string]bool) []string {
 	keys := make([]string, 0, len(m))
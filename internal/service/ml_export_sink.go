@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/config"
+)
+
+// ExportSink stores finished export files. Exports are always written to the
+// local export directory first; the sink then takes over the file and hands
+// back the location recorded as the job's output path.
+type ExportSink interface {
+	// Store moves a file from the local export directory into the sink
+	Store(ctx context.Context, localPath string) (string, error)
+
+	// Open reads a stored file
+	Open(ctx context.Context, location string) (io.ReadCloser, error)
+
+	// Stat describes a stored file. Missing files return an error for which
+	// os.IsNotExist is true, as do Open and Remove.
+	Stat(ctx context.Context, location string) (ExportFileInfo, error)
+
+	// Remove deletes a stored file
+	Remove(ctx context.Context, location string) error
+
+	// PresignURL returns a temporary URL the file can be downloaded from
+	// without going through the API, or "" when the sink has none
+	PresignURL(location string) (string, error)
+
+	// Owns reports whether location was stored by this sink
+	Owns(location string) bool
+}
+
+// ExportFileInfo describes a stored export file
+type ExportFileInfo struct {
+	Size    int64
+	ModTime time.Time
+}
+
+// NewExportSink returns the S3 sink when a bucket is configured, the local
+// export directory otherwise
+func NewExportSink(cfg config.S3Config) ExportSink {
+	if cfg.Bucket == "" {
+		return LocalExportSink{}
+	}
+	return NewS3ExportSink(cfg)
+}
+
+// LocalExportSink keeps export files in the local export directory
+type LocalExportSink struct{}
+
+// Store leaves the file where it was written
+func (LocalExportSink) Store(ctx context.Context, localPath string) (string, error) {
+	return localPath, nil
+}
+
+// Open opens the file
+func (LocalExportSink) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	return os.Open(location)
+}
+
+// Stat returns the file's size and modification time
+func (LocalExportSink) Stat(ctx context.Context, location string) (ExportFileInfo, error) {
+	info, err := os.Stat(location)
+	if err != nil {
+		return ExportFileInfo{}, err
+	}
+	return ExportFileInfo{Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Remove deletes the file
+func (LocalExportSink) Remove(ctx context.Context, location string) error {
+	return os.Remove(location)
+}
+
+// PresignURL returns "": local files are served by the API
+func (LocalExportSink) PresignURL(location string) (string, error) {
+	return "", nil
+}
+
+// Owns reports true for any path that isn't an object store URL
+func (LocalExportSink) Owns(location string) bool {
+	return !isS3Location(location)
+}
+
+// sinkFor returns the sink holding location. Exports written before a
+// bucket was configured stay readable from the local export directory.
+func (s *MLExportService) sinkFor(location string) ExportSink {
+	if s.sink.Owns(location) {
+		return s.sink
+	}
+	return s.localSink
+}
+
+// storeOutput hands a written export and its split files to the sink and
+// returns their stored locations
+func (s *MLExportService) storeOutput(ctx context.Context, outputPath string, splitPaths []string) (string, []string, error) {
+	if _, local := s.sink.(LocalExportSink); local {
+		return outputPath, splitPaths, nil
+	}
+
+	location, err := s.sink.Store(ctx, outputPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var outputFiles []string
+	for _, path := range splitPaths {
+		stored, err := s.sink.Store(ctx, path)
+		if err != nil {
+			return "", nil, err
+		}
+		outputFiles = append(outputFiles, stored)
+	}
+
+	return location, outputFiles, nil
+}
+
+// IsRemoteExport reports whether an export's output lives in an object store
+func IsRemoteExport(location string) bool {
+	return isS3Location(location)
+}
+
+// StatExportFile describes an export's output file wherever it is stored
+func (s *MLExportService) StatExportFile(ctx context.Context, location string) (ExportFileInfo, error) {
+	return s.sinkFor(location).Stat(ctx, location)
+}
+
+// OpenExportFile reads an export's output file wherever it is stored
+func (s *MLExportService) OpenExportFile(ctx context.Context, location string) (io.ReadCloser, error) {
+	return s.sinkFor(location).Open(ctx, location)
+}
+
+// ExportDownloadURL returns a presigned URL to download the export from, or
+// "" when it has to be streamed through the API
+func (s *MLExportService) ExportDownloadURL(location string) (string, error) {
+	return s.sinkFor(location).PresignURL(location)
+}
+
+// exportFileSHA256 hashes an export file wherever it is stored
+func (s *MLExportService) exportFileSHA256(ctx context.Context, location string) (string, int64, error) {
+	if !IsRemoteExport(location) {
+		return FileSHA256(location)
+	}
+
+	reader, err := s.OpenExportFile(ctx, location)
+	if err != nil {
+		return "", 0, err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, reader)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
+// removeExportFile deletes an export file and returns its size. Missing
+// files return an error for which os.IsNotExist is true.
+func (s *MLExportService) removeExportFile(ctx context.Context, location string) (int64, error) {
+	sink := s.sinkFor(location)
+	info, err := sink.Stat(ctx, location)
+	if err != nil {
+		return 0, err
+	}
+	if err := sink.Remove(ctx, location); err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/config"
+	"github.com/yourusername/datacollector/internal/logging"
+)
+
+// s3Scheme prefixes the locations of files stored in an object store
+const s3Scheme = "s3://"
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3ExportSink stores export files in an S3-compatible bucket (AWS, MinIO).
+// Requests are signed with AWS Signature Version 4. Files are uploaded with
+// a single PUT, which S3 limits to 5 GB per object.
+type S3ExportSink struct {
+	endpoint      *url.URL
+	region        string
+	bucket        string
+	prefix        string
+	accessKey     string
+	secretKey     string
+	pathStyle     bool
+	presign       bool
+	presignExpiry time.Duration
+	client        *http.Client
+}
+
+// NewS3ExportSink creates an S3 sink from the export config
+func NewS3ExportSink(cfg config.S3Config) *S3ExportSink {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		log.Printf("[ML_EXPORT] WARNING: invalid S3 endpoint %q, using https://s3.amazonaws.com", cfg.Endpoint)
+		endpoint, _ = url.Parse("https://s3.amazonaws.com")
+	}
+
+	expiry := time.Duration(cfg.PresignExpiryMinutes) * time.Minute
+	if expiry <= 0 {
+		expiry = 15 * time.Minute
+	}
+
+	return &S3ExportSink{
+		endpoint:      endpoint,
+		region:        cfg.Region,
+		bucket:        cfg.Bucket,
+		prefix:        strings.Trim(cfg.Prefix, "/"),
+		accessKey:     cfg.AccessKey,
+		secretKey:     cfg.SecretKey,
+		pathStyle:     cfg.PathStyle,
+		presign:       cfg.PresignDownloads,
+		presignExpiry: expiry,
+		client:        &http.Client{},
+	}
+}
+
+// isS3Location reports whether location is an object store URL
+func isS3Location(location string) bool {
+	return strings.HasPrefix(location, s3Scheme)
+}
+
+// Owns reports whether location is in this sink's bucket
+func (s *S3ExportSink) Owns(location string) bool {
+	return strings.HasPrefix(location, s3Scheme+s.bucket+"/")
+}
+
+// Store uploads the file to the bucket and removes the local copy
+func (s *S3ExportSink) Store(ctx context.Context, localPath string) (string, error) {
+	key := filepath.Base(localPath)
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+
+	// Hash first so the upload is signed over its content
+	payloadHash, size, err := FileSHA256(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", localPath, err)
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	resp, err := s.do(ctx, http.MethodPut, key, file, size, payloadHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	resp.Body.Close()
+
+	location := s3Scheme + s.bucket + "/" + key
+	if err := os.Remove(localPath); err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Failed to remove local copy of %s: %v", location, err)
+	}
+
+	logging.Printf(ctx, "[ML_EXPORT] Stored %s (%d bytes)", location, size)
+	return location, nil
+}
+
+// Open streams the object from the bucket
+func (s *S3ExportSink) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	key, err := s.key(location)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Stat returns the object's size and modification time
+func (s *S3ExportSink) Stat(ctx context.Context, location string) (ExportFileInfo, error) {
+	key, err := s.key(location)
+	if err != nil {
+		return ExportFileInfo{}, err
+	}
+	resp, err := s.do(ctx, http.MethodHead, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return ExportFileInfo{}, err
+	}
+	resp.Body.Close()
+
+	info := ExportFileInfo{Size: resp.ContentLength}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modTime
+	}
+	return info, nil
+}
+
+// Remove deletes the object. S3 doesn't report whether the object existed,
+// so it is looked up first.
+func (s *S3ExportSink) Remove(ctx context.Context, location string) error {
+	if _, err := s.Stat(ctx, location); err != nil {
+		return err
+	}
+	key, err := s.key(location)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// PresignURL returns a presigned GET URL for the object, or "" when
+// downloads are streamed through the API
+func (s *S3ExportSink) PresignURL(location string) (string, error) {
+	if !s.presign {
+		return "", nil
+	}
+	key, err := s.key(location)
+	if err != nil {
+		return "", err
+	}
+	return s.presignGet(key, time.Now().UTC(), s.presignExpiry), nil
+}
+
+// key returns the object key of a location in this sink's bucket
+func (s *S3ExportSink) key(location string) (string, error) {
+	if !s.Owns(location) {
+		return "", fmt.Errorf("%s is not in bucket %s", location, s.bucket)
+	}
+	return strings.TrimPrefix(location, s3Scheme+s.bucket+"/"), nil
+}
+
+// do sends a signed request for an object and turns error statuses into
+// errors; a missing object is reported as os.ErrNotExist
+func (s *S3ExportSink) do(ctx context.Context, method, key string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	s.sign(req, payloadHash, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, &os.PathError{Op: strings.ToLower(method), Path: s3Scheme + s.bucket + "/" + key, Err: os.ErrNotExist}
+	}
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", method, key, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
+
+// objectURL returns the URL of an object, path-style or virtual-hosted
+func (s *S3ExportSink) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	return &u
+}
+
+// sign adds AWS Signature Version 4 headers to req
+func (s *S3ExportSink) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	canonicalHeaders, signedHeaders := s3CanonicalHeaders(headers)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// presignGet returns a GET URL for key signed in the query string
+func (s *S3ExportSink) presignGet(key string, now time.Time, expiry time.Duration) string {
+	u := s.objectURL(key)
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.accessKey + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := s3CanonicalQuery(query)
+	canonicalHeaders, signedHeaders := s3CanonicalHeaders(map[string]string{"host": u.Host})
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		s3EscapePath(u.Path),
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+// scope returns the credential scope of a request made at now
+func (s *S3ExportSink) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature signs a canonical request with the key derived for its date and region
+func (s *S3ExportSink) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3CanonicalHeaders returns the canonical header block and the signed header list
+func s3CanonicalHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+// s3CanonicalQuery returns the sorted, URI-encoded query string
+func s3CanonicalQuery(query map[string]string) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, s3Escape(name, true)+"="+s3Escape(query[name], true))
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath URI-encodes a path the way SigV4 expects, keeping slashes
+func s3EscapePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape URI-encodes every byte except the unreserved characters, and
+// slashes unless encodeSlash is set
+func s3Escape(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
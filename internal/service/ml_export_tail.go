@@ -0,0 +1,62 @@
+package service
+
+import (
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// tailCandles keeps the most recent tailBars candles (sorted oldest first),
+// plus up to warmup candles before them so windowed features are valid from
+// the first kept bar. It returns the kept candles and the timestamp of the
+// first of the tailBars candles. tailBars <= 0 keeps all candles.
+func tailCandles(candles []models.Candle, tailBars, warmup int) ([]models.Candle, int64) {
+	if tailBars <= 0 || len(candles) == 0 {
+		return candles, 0
+	}
+
+	tailStart := max(len(candles)-tailBars, 0)
+	keepFrom := max(tailStart-warmup, 0)
+	return candles[keepFrom:], candles[tailStart].Timestamp
+}
+
+// tailCutoff returns the earliest tail start of the sources, so no source
+// loses bars of its tail. ok is false when no source was trimmed to a tail.
+func tailCutoff(sources []models.SourceJobInfo) (cutoff int64, ok bool) {
+	for _, src := range sources {
+		if src.TailStart == nil {
+			continue
+		}
+		ts := src.TailStart.UnixMilli()
+		if !ok || ts < cutoff {
+			cutoff, ok = ts, true
+		}
+	}
+	return cutoff, ok
+}
+
+// dropRowsBefore removes the warm-up rows loaded ahead of a tail, i.e. rows
+// timestamped before cutoff, and returns how many were removed
+func dropRowsBefore(matrix *models.FeatureMatrix, cutoff int64) int {
+	start := 0
+	for start < len(matrix.Timestamps) && matrix.Timestamps[start] < cutoff {
+		start++
+	}
+	return dropLeadingRows(matrix, start)
+}
+
+// dropLeadingRows removes the first n rows of the matrix, or all of them when
+// it has fewer, and returns how many were removed
+func dropLeadingRows(matrix *models.FeatureMatrix, n int) int {
+	start := min(n, len(matrix.Data))
+	if start <= 0 {
+		return 0
+	}
+
+	matrix.Data = matrix.Data[start:]
+	matrix.Timestamps = matrix.Timestamps[start:]
+	if len(matrix.SplitLabels) > start {
+		matrix.SplitLabels = matrix.SplitLabels[start:]
+	}
+	matrix.RowCount = len(matrix.Data)
+
+	return start
+}
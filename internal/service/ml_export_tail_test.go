@@ -0,0 +1,108 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func tailTestCandles(n int) []models.Candle {
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		candles[i] = models.Candle{Timestamp: int64(i) * 60000, Close: float64(i)}
+	}
+	return candles
+}
+
+func TestTailCandles(t *testing.T) {
+	candles := tailTestCandles(100)
+
+	kept, tailStart := tailCandles(candles, 10, 5)
+	if len(kept) != 15 || kept[0].Timestamp != 85*60000 {
+		t.Errorf("kept %d candles from %d, want 15 from %d", len(kept), kept[0].Timestamp, 85*60000)
+	}
+	if tailStart != 90*60000 {
+		t.Errorf("tail start = %d, want %d", tailStart, 90*60000)
+	}
+
+	// Short histories keep everything
+	kept, tailStart = tailCandles(candles, 98, 5)
+	if len(kept) != 100 || tailStart != 2*60000 {
+		t.Errorf("kept %d candles, tail start %d", len(kept), tailStart)
+	}
+	kept, tailStart = tailCandles(candles, 500, 5)
+	if len(kept) != 100 || tailStart != 0 {
+		t.Errorf("kept %d candles, tail start %d", len(kept), tailStart)
+	}
+
+	if kept, _ := tailCandles(candles, 0, 5); len(kept) != 100 {
+		t.Errorf("tail bars 0 kept %d candles, want all", len(kept))
+	}
+}
+
+func TestTailCutoffAndDropRows(t *testing.T) {
+	later, earlier := time.UnixMilli(3000), time.UnixMilli(2000)
+	cutoff, ok := tailCutoff([]models.SourceJobInfo{{TailStart: &later}, {}, {TailStart: &earlier}})
+	if !ok || cutoff != 2000 {
+		t.Fatalf("cutoff = %d, %v; want 2000", cutoff, ok)
+	}
+	if _, ok := tailCutoff([]models.SourceJobInfo{{}}); ok {
+		t.Fatal("expected no cutoff without tail starts")
+	}
+
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{1}, {2}, {3}, {4}},
+		Timestamps: []int64{1000, 2000, 3000, 4000},
+		RowCount:   4,
+	}
+	if dropped := dropRowsBefore(matrix, cutoff); dropped != 1 {
+		t.Errorf("dropped %d rows, want 1", dropped)
+	}
+	if matrix.RowCount != 3 || matrix.Timestamps[0] != 2000 || matrix.Data[0][0] != 2 {
+		t.Errorf("unexpected matrix after drop: %+v", matrix)
+	}
+}
+
+func TestWarmupTrim(t *testing.T) {
+	config := models.MLExportConfig{
+		Features: models.FeatureConfig{
+			LaggedFeatures: models.LagConfig{Enabled: true, LagPeriods: []int{1, 3}},
+		},
+	}
+	if rows := WarmupTrimRows(config); rows != 0 {
+		t.Errorf("trim off: rows = %d, want 0", rows)
+	}
+
+	config.Preprocessing.TrimWarmup = true
+	if rows, want := WarmupTrimRows(config), FeatureWarmup(config.Features); rows != want || rows == 0 {
+		t.Errorf("default rows = %d, want the feature warm-up %d", rows, want)
+	}
+
+	config.Preprocessing.WarmupRows = 50
+	if rows := WarmupTrimRows(config); rows != 50 {
+		t.Errorf("explicit rows = %d, want 50", rows)
+	}
+	if required := RequiredBars(config); required < 51 {
+		t.Errorf("required bars = %d, want at least the trimmed rows plus one", required)
+	}
+
+	// Trimming is by position, whatever the rows contain
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{1}, {2}, {math.NaN()}, {4}},
+		Timestamps: []int64{1000, 2000, 3000, 4000},
+		RowCount:   4,
+	}
+	if dropped := dropLeadingRows(matrix, 2); dropped != 2 {
+		t.Errorf("dropped %d rows, want 2", dropped)
+	}
+	if matrix.RowCount != 2 || matrix.Timestamps[0] != 3000 || !math.IsNaN(matrix.Data[0][0]) {
+		t.Errorf("unexpected matrix after trim: %+v", matrix)
+	}
+	if dropped := dropLeadingRows(matrix, 10); dropped != 2 || matrix.RowCount != 0 {
+		t.Errorf("dropped %d rows leaving %d, want all 2", dropped, matrix.RowCount)
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// verifyRowCount reads back an output file and fails if it doesn't hold
+// exactly expected rows, so a writer bug can't leave a short file behind a
+// job record that looks complete
+func verifyRowCount(writer MLExportWriter, path string, expected int) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open output for verification: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := writer.CountRows(bufio.NewReader(file))
+	if err != nil {
+		return fmt.Errorf("failed to verify output: %w", err)
+	}
+	if rows != expected {
+		return fmt.Errorf("output row count mismatch: expected %d rows, found %d in %s", expected, rows, path)
+	}
+	return nil
+}
+
+// verifySplitRows checks each split file written next to basePath against
+// the number of rows carrying its split label
+func verifySplitRows(writer MLExportWriter, matrix *models.FeatureMatrix, basePath, ext string) error {
+	counts := make(map[string]int)
+	for _, label := range matrix.SplitLabels {
+		counts[label]++
+	}
+	for label, expected := range counts {
+		path := fmt.Sprintf("%s_%s%s", basePath, label, ext)
+		if err := verifyRowCount(writer, path, expected); err != nil {
+			return fmt.Errorf("%s split: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// expectedOutputRows returns how many rows the writer writes for the matrix:
+// NumPy writes one entry per sequence when the matrix has sequences
+func expectedOutputRows(writer MLExportWriter, matrix *models.FeatureMatrix) int {
+	if _, ok := writer.(*NumpyExportWriter); ok && len(matrix.Sequences) > 0 {
+		return len(matrix.Sequences)
+	}
+	return matrix.RowCount
+}
+
+// decompressed unwraps gzip when the writer compressed its output
+func decompressed(in io.Reader, compressed bool) (io.Reader, error) {
+	if !compressed {
+		return in, nil
+	}
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	return gz, nil
+}
+
+// CountRows counts the CSV records, less the header row
+func (w *CSVExportWriter) CountRows(in io.Reader) (int, error) {
+	r, err := decompressed(in, w.options.Compress)
+	if err != nil {
+		return 0, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	rows := 0
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read record %d: %w", rows+1, err)
+		}
+		rows++
+	}
+
+	if w.options.IncludeHeader && rows > 0 {
+		rows--
+	}
+	return rows, nil
+}
+
+// CountRows reads the row count from the header and checks the file is
+// exactly as long as a file of that many rows
+func (w *ParquetExportWriter) CountRows(in io.Reader) (int, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(in, magic); err != nil || string(magic) != "PAR1" {
+		return 0, fmt.Errorf("missing leading PAR1 magic")
+	}
+
+	var header parquetHeader
+	if err := binary.Read(in, binary.LittleEndian, &header); err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+	if header.NumRows < 0 || header.NumCols < 0 {
+		return 0, fmt.Errorf("invalid header: %d rows, %d columns", header.NumRows, header.NumCols)
+	}
+
+	for i := int64(0); i < header.NumCols; i++ {
+		var nameLen int32
+		if err := binary.Read(in, binary.LittleEndian, &nameLen); err != nil {
+			return 0, fmt.Errorf("failed to read column %d name: %w", i, err)
+		}
+		if _, err := io.CopyN(io.Discard, in, int64(nameLen)); err != nil {
+			return 0, fmt.Errorf("failed to read column %d name: %w", i, err)
+		}
+	}
+
+	values := header.NumRows * header.NumCols
+	if header.HasTimestamp {
+		values += header.NumRows
+	}
+	body, err := io.Copy(io.Discard, in)
+	if err != nil {
+		return 0, err
+	}
+	if want := values*8 + int64(len(magic)); body != want {
+		return 0, fmt.Errorf("header declares %d rows but the data section is %d bytes, want %d", header.NumRows, body, want)
+	}
+
+	return int(header.NumRows), nil
+}
+
+// npyShape matches the shape tuple of an NPY header
+var npyShape = regexp.MustCompile(`'shape': \(([^)]*)\)`)
+
+// CountRows reads the first dimension of the NPY header shape and checks the
+// data is exactly as long as the shape says
+func (w *NumpyExportWriter) CountRows(in io.Reader) (int, error) {
+	prefix := make([]byte, 10)
+	if _, err := io.ReadFull(in, prefix); err != nil || !bytes.Equal(prefix[:6], []byte("\x93NUMPY")) {
+		return 0, fmt.Errorf("missing NPY magic")
+	}
+	header := make([]byte, binary.LittleEndian.Uint16(prefix[8:]))
+	if _, err := io.ReadFull(in, header); err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	match := npyShape.FindSubmatch(header)
+	if match == nil {
+		return 0, fmt.Errorf("header has no shape")
+	}
+	var dims []int64
+	for _, field := range strings.Split(string(match[1]), ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		dim, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid shape %q", match[1])
+		}
+		dims = append(dims, dim)
+	}
+	if len(dims) == 0 {
+		return 0, fmt.Errorf("invalid shape %q", match[1])
+	}
+
+	values := int64(1)
+	for _, dim := range dims {
+		values *= dim
+	}
+	body, err := io.Copy(io.Discard, in)
+	if err != nil {
+		return 0, err
+	}
+	if body != values*8 {
+		return 0, fmt.Errorf("shape %s needs %d data bytes, found %d", match[1], values*8, body)
+	}
+
+	return int(dims[0]), nil
+}
+
+// CountRows counts the newline-terminated JSON objects
+func (w *JSONLExportWriter) CountRows(in io.Reader) (int, error) {
+	r, err := decompressed(in, w.options.Compress)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		rows += bytes.Count(buf[:n], []byte{'\n'})
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return rows, nil
+}
+
+// CountRows counts the elements of the document's rows array without
+// decoding the rows themselves
+func (w *JSONExportWriter) CountRows(in io.Reader) (int, error) {
+	r, err := decompressed(in, w.options.Compress)
+	if err != nil {
+		return 0, err
+	}
+
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, fmt.Errorf("output is not a JSON object")
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return 0, err
+		}
+		if key != "rows" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, fmt.Errorf("failed to read %v: %w", key, err)
+			}
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return 0, fmt.Errorf("rows is not an array")
+		}
+		rows := 0
+		for dec.More() {
+			var row json.RawMessage
+			if err := dec.Decode(&row); err != nil {
+				return 0, fmt.Errorf("failed to read row %d: %w", rows, err)
+			}
+			rows++
+		}
+		if _, err := dec.Token(); err != nil {
+			return 0, fmt.Errorf("rows array is not closed: %w", err)
+		}
+		return rows, nil
+	}
+	return 0, fmt.Errorf("output has no rows array")
+}
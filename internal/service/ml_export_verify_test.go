@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestCountRowsMatchesWrittenRows(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:     []string{"close", "returns"},
+		Data:        [][]float64{{100, math.NaN()}, {101, math.Inf(1)}, {99, 0.5}},
+		Timestamps:  []int64{1000, 2000, 3000},
+		SplitLabels: []string{"train", "train", "test"},
+		RowCount:    3,
+		ColumnCount: 2,
+	}
+
+	for _, format := range GetSupportedFormats() {
+		for _, compress := range []bool{false, true} {
+			options := DefaultWriterOptions()
+			options.Compress = compress
+			writer, err := NewExportWriter(format, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := writer.WriteStream(matrix, &buf); err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			rows, err := writer.CountRows(&buf)
+			if err != nil {
+				t.Errorf("%s (compress=%v): %v", format, compress, err)
+			} else if rows != 3 {
+				t.Errorf("%s (compress=%v): counted %d rows, want 3", format, compress, rows)
+			}
+		}
+	}
+}
+
+func TestCountRowsNumpySequences(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:   []string{"close"},
+		Sequences: [][][]float64{{{1}, {2}}, {{2}, {3}}},
+	}
+
+	writer := &NumpyExportWriter{}
+	var buf bytes.Buffer
+	if err := writer.WriteStream(matrix, &buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := writer.CountRows(&buf)
+	if err != nil || rows != 2 {
+		t.Errorf("CountRows = %d, %v, want 2 sequences", rows, err)
+	}
+	if expected := expectedOutputRows(writer, matrix); expected != 2 {
+		t.Errorf("expectedOutputRows = %d, want 2", expected)
+	}
+}
+
+func TestCountRowsRejectsTruncatedBinaryOutput(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{1}, {2}, {3}},
+		Timestamps: []int64{1000, 2000, 3000},
+	}
+
+	for _, writer := range []MLExportWriter{&ParquetExportWriter{}, &NumpyExportWriter{}} {
+		var buf bytes.Buffer
+		if err := writer.WriteStream(matrix, &buf); err != nil {
+			t.Fatal(err)
+		}
+		truncated := buf.Bytes()[:buf.Len()-8]
+		if _, err := writer.CountRows(bytes.NewReader(truncated)); err == nil {
+			t.Errorf("%T accepted a truncated output", writer)
+		}
+	}
+}
+
+func TestVerifyRowCount(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{1}, {2}},
+		Timestamps: []int64{1000, 2000},
+		RowCount:   2,
+	}
+	writer := &CSVExportWriter{options: DefaultWriterOptions()}
+	path := filepath.Join(t.TempDir(), "export.csv")
+	if _, _, err := writeHashedFile(writer, matrix, path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyRowCount(writer, path, 2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// Metadata claiming more rows than the file holds fails the export
+	if err := verifyRowCount(writer, path, 3); err == nil {
+		t.Error("expected a row count mismatch")
+	}
+}
+
+func TestVerifySplitRows(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:     []string{"close"},
+		Data:        [][]float64{{1}, {2}, {3}},
+		Timestamps:  []int64{1000, 2000, 3000},
+		SplitLabels: []string{"train", "train", "test"},
+		RowCount:    3,
+	}
+	options := DefaultWriterOptions()
+	options.SplitByLabel = true
+	writer := &JSONLExportWriter{options: options}
+
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "export.jsonl")
+	if err := writer.Write(matrix, outputPath); err != nil {
+		t.Fatal(err)
+	}
+	basePath := filepath.Join(dir, "export")
+	if err := verifySplitRows(writer, matrix, basePath, writer.Extension()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(basePath+"_test.jsonl", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifySplitRows(writer, matrix, basePath, writer.Extension()); err == nil {
+		t.Error("expected an emptied split file to fail verification")
+	}
+}
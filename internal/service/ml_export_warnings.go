@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// warn records non-fatal issues on the export job; they are stored with the
+// next progress report
+func (t *exportTracker) warn(warnings ...models.ExportWarning) {
+	t.job.Warnings = append(t.job.Warnings, warnings...)
+}
+
+// sourceWarnings explains source jobs that contributed fewer rows than they
+// might have: jobs without any candles, jobs skipped for having too few bars,
+// jobs with bars dropped by alignment, and, when several jobs are combined,
+// jobs whose data starts later or ends earlier than the others' by more than
+// a bar
+func sourceWarnings(jobIDs []primitive.ObjectID, sources []models.SourceJobInfo, skipped []models.SkippedSourceInfo, requestID string, now time.Time) []models.ExportWarning {
+	var warnings []models.ExportWarning
+	add := func(code string, jobID primitive.ObjectID, format string, args ...interface{}) {
+		id := jobID
+		warnings = append(warnings, models.ExportWarning{
+			Code:      code,
+			Message:   fmt.Sprintf(format, args...),
+			JobID:     &id,
+			RequestID: requestID,
+			At:        now,
+		})
+	}
+
+	loaded := make(map[primitive.ObjectID]bool, len(sources)+len(skipped))
+	for _, src := range sources {
+		loaded[src.JobID] = true
+	}
+	for _, src := range skipped {
+		loaded[src.JobID] = true
+		add(models.ExportWarningTooFewBars, src.JobID, "job %s (%s %s) was skipped: it has %d bars, the export config needs %d",
+			src.JobID.Hex(), src.Symbol, src.Timeframe, src.BarCount, src.RequiredBars)
+	}
+	for _, jobID := range jobIDs {
+		if !loaded[jobID] {
+			add(models.ExportWarningNoData, jobID, "job %s has no data and was left out", jobID.Hex())
+		}
+	}
+	for _, src := range sources {
+		if src.UnalignedBars > 0 {
+			add(models.ExportWarningUnaligned, src.JobID, "job %s (%s %s): %d bars were too far off the bar grid to be aligned and were dropped",
+				src.JobID.Hex(), src.Symbol, src.Timeframe, src.UnalignedBars)
+		}
+	}
+
+	if len(sources) < 2 {
+		return warnings
+	}
+	start, end := sources[0].StartTime, sources[0].EndTime
+	for _, src := range sources[1:] {
+		if src.StartTime.Before(start) {
+			start = src.StartTime
+		}
+		if src.EndTime.After(end) {
+			end = src.EndTime
+		}
+	}
+	for _, src := range sources {
+		bar := time.Duration(models.GetTimeframeDurationMinutes(src.Timeframe)) * time.Minute
+		if src.StartTime.Sub(start) > bar || end.Sub(src.EndTime) > bar {
+			add(models.ExportWarningPartialRange, src.JobID, "job %s (%s %s) covers %s to %s, the export spans %s to %s",
+				src.JobID.Hex(), src.Symbol, src.Timeframe,
+				src.StartTime.UTC().Format(time.RFC3339), src.EndTime.UTC().Format(time.RFC3339),
+				start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+		}
+	}
+	return warnings
+}
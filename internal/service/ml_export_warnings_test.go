@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestSourceWarnings(t *testing.T) {
+	full, late, empty, short := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(100 * time.Hour)
+
+	sources := []models.SourceJobInfo{
+		{JobID: full, Symbol: "BTC/USDT", Timeframe: "1h", StartTime: start, EndTime: end},
+		{JobID: late, Symbol: "ETH/USDT", Timeframe: "1h", StartTime: start.Add(48 * time.Hour), EndTime: end},
+	}
+	skipped := []models.SkippedSourceInfo{{JobID: short, Symbol: "SOL/USDT", Timeframe: "1h", BarCount: 10, RequiredBars: 50}}
+
+	now := time.Now()
+	warnings := sourceWarnings([]primitive.ObjectID{full, late, empty, short}, sources, skipped, "req-1", now)
+
+	want := map[primitive.ObjectID]string{
+		short: models.ExportWarningTooFewBars,
+		empty: models.ExportWarningNoData,
+		late:  models.ExportWarningPartialRange,
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %+v", len(warnings), len(want), warnings)
+	}
+	for _, w := range warnings {
+		if w.JobID == nil || want[*w.JobID] != w.Code {
+			t.Errorf("unexpected warning %+v", w)
+		}
+		if w.RequestID != "req-1" || !w.At.Equal(now) {
+			t.Errorf("warning not tagged with the request: %+v", w)
+		}
+	}
+
+	// A source starting within a bar of the others isn't partial
+	sources[1].StartTime = start.Add(30 * time.Minute)
+	if warnings := sourceWarnings([]primitive.ObjectID{full, late}, sources, nil, "", now); len(warnings) != 0 {
+		t.Errorf("unexpected warnings %+v", warnings)
+	}
+}
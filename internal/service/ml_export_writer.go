@@ -1,12 +1,16 @@
 package service
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"math"
 	"os"
@@ -21,19 +25,21 @@ import (
 type MLExportWriter interface {
 	Write(matrix *models.FeatureMatrix, outputPath string) error
 	WriteStream(matrix *models.FeatureMatrix, w io.Writer) error
+	CountRows(r io.Reader) (int, error) // Reads back a WriteStream output and counts its rows
 	Extension() string
 	MimeType() string
 }
 
 // WriterOptions configures export writer behavior
 type WriterOptions struct {
-	Compress      bool    // Enable gzip compression (for CSV, JSONL)
-	Precision     int     // Float precision (default 8)
-	IncludeHeader bool    // Include header row (CSV)
-	IncludeIndex  bool    // Include row index
-	NaNValue      string  // NaN representation (default "NaN")
-	InfValue      string  // Inf representation (default "Inf")
-	SplitByLabel  bool    // Create separate files for train/val/test
+	Compress      bool               // Enable gzip compression (for CSV, JSONL, JSON)
+	Precision     int                // Float precision (default 8): decimals in fixed and scientific format, significant digits in general
+	FloatFormat   models.FloatFormat // fixed (default), general or scientific (CSV, JSONL)
+	IncludeHeader bool               // Include header row (CSV)
+	IncludeIndex  bool               // Include row index
+	NaNValue      string             // NaN representation (default "NaN")
+	InfValue      string             // Inf representation (default "Inf")
+	SplitByLabel  bool               // Create separate files for train/val/test
 }
 
 // DefaultWriterOptions returns default writer options
@@ -60,6 +66,8 @@ func NewExportWriter(format models.MLExportFormat, options WriterOptions) (MLExp
 		return &NumpyExportWriter{options: options}, nil
 	case models.MLExportFormatJSONL:
 		return &JSONLExportWriter{options: options}, nil
+	case models.MLExportFormatJSON:
+		return &JSONExportWriter{options: options}, nil
 	default:
 		return nil, fmt.Errorf("unsupported export format: %s", format)
 	}
@@ -231,7 +239,21 @@ func (w *CSVExportWriter) formatFloat(val float64, precision int) string {
 	if math.IsInf(val, -1) {
 		return "-" + w.options.InfValue
 	}
-	return strconv.FormatFloat(val, 'f', precision, 64)
+	return formatFloatValue(val, w.options.FloatFormat, precision)
+}
+
+// formatFloatValue formats a finite float in the float format: precision is
+// the number of decimals in fixed and scientific format and of significant
+// digits in general format
+func formatFloatValue(val float64, format models.FloatFormat, precision int) string {
+	switch format {
+	case models.FloatFormatGeneral:
+		return strconv.FormatFloat(val, 'g', precision, 64)
+	case models.FloatFormatScientific:
+		return strconv.FormatFloat(val, 'e', precision, 64)
+	default:
+		return strconv.FormatFloat(val, 'f', precision, 64)
+	}
 }
 
 // ============================================================================
@@ -357,10 +379,12 @@ func (w *ParquetExportWriter) WriteStream(matrix *models.FeatureMatrix, out io.W
 		}
 	}
 
-	// Write data column by column (columnar format)
+	// Write data column by column (columnar format). Values are written as
+	// raw IEEE 754 doubles, so NaN and ±Inf survive unchanged; cells missing
+	// from short rows are NaN, never 0, so missing stays distinct from zero.
 	for colIdx := range matrix.Columns {
 		for rowIdx := range matrix.Data {
-			val := 0.0
+			val := math.NaN()
 			if colIdx < len(matrix.Data[rowIdx]) {
 				val = matrix.Data[rowIdx][colIdx]
 			}
@@ -674,6 +698,11 @@ func (w *JSONLExportWriter) WriteStream(matrix *models.FeatureMatrix, out io.Wri
 
 	encoder := json.NewEncoder(writer)
 
+	precision := w.options.Precision
+	if precision <= 0 {
+		precision = 8
+	}
+
 	// Write each row as a JSON object
 	for i, row := range matrix.Data {
 		record := make(map[string]interface{})
@@ -691,17 +720,7 @@ func (w *JSONLExportWriter) WriteStream(matrix *models.FeatureMatrix, out io.Wri
 		// Add features
 		for j, col := range matrix.Columns {
 			if j < len(row) {
-				val := row[j]
-				// Handle special values
-				if math.IsNaN(val) {
-					record[col] = nil // JSON doesn't support NaN, use null
-				} else if math.IsInf(val, 1) {
-					record[col] = "Infinity"
-				} else if math.IsInf(val, -1) {
-					record[col] = "-Infinity"
-				} else {
-					record[col] = val
-				}
+				record[col] = w.jsonFloat(row[j], precision)
 			}
 		}
 
@@ -718,10 +737,285 @@ func (w *JSONLExportWriter) WriteStream(matrix *models.FeatureMatrix, out io.Wri
 	return nil
 }
 
+// jsonFloat formats a finite value in the configured float format as a JSON
+// number. Without a float format values keep their full precision.
+func (w *JSONLExportWriter) jsonFloat(val float64, precision int) interface{} {
+	if w.options.FloatFormat == "" || math.IsNaN(val) || math.IsInf(val, 0) {
+		return jsonValue(val)
+	}
+	return json.Number(formatFloatValue(val, w.options.FloatFormat, precision))
+}
+
+// jsonValue maps the special float values JSON can't represent: NaN becomes
+// null and infinities the strings "Infinity" and "-Infinity"
+func jsonValue(val float64) interface{} {
+	switch {
+	case math.IsNaN(val):
+		return nil
+	case math.IsInf(val, 1):
+		return "Infinity"
+	case math.IsInf(val, -1):
+		return "-Infinity"
+	default:
+		return val
+	}
+}
+
+// ============================================================================
+// JSON Export Writer
+// ============================================================================
+
+// JSONExportWriter writes data as a single JSON document:
+// {"schema": [...], "columns": [...], "rows": [[...]], "timestamps": [...]}
+// plus "splits" when rows carry split labels
+type JSONExportWriter struct {
+	options WriterOptions
+}
+
+// Extension returns the file extension
+func (w *JSONExportWriter) Extension() string {
+	if w.options.Compress {
+		return ".json.gz"
+	}
+	return ".json"
+}
+
+// MimeType returns the MIME type
+func (w *JSONExportWriter) MimeType() string {
+	return "application/json"
+}
+
+// Write writes the feature matrix to a file
+func (w *JSONExportWriter) Write(matrix *models.FeatureMatrix, outputPath string) error {
+	// Handle split files
+	if w.options.SplitByLabel && len(matrix.SplitLabels) > 0 {
+		return w.writeSplitFiles(matrix, outputPath)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	return w.WriteStream(matrix, file)
+}
+
+// writeSplitFiles writes separate JSON files for each split
+func (w *JSONExportWriter) writeSplitFiles(matrix *models.FeatureMatrix, outputPath string) error {
+	ext := filepath.Ext(outputPath)
+	if w.options.Compress {
+		ext = ".json.gz"
+	}
+	basePath := strings.TrimSuffix(outputPath, ext)
+
+	// Group rows by split label
+	splitData := make(map[string]*models.FeatureMatrix)
+	for i, label := range matrix.SplitLabels {
+		if _, exists := splitData[label]; !exists {
+			splitData[label] = &models.FeatureMatrix{
+				Columns:    matrix.Columns,
+				Data:       make([][]float64, 0),
+				Timestamps: make([]int64, 0),
+				Schema:     matrix.Schema,
+			}
+		}
+		splitData[label].Data = append(splitData[label].Data, matrix.Data[i])
+		splitData[label].Timestamps = append(splitData[label].Timestamps, matrix.Timestamps[i])
+	}
+
+	// Write each split
+	for label, data := range splitData {
+		data.RowCount = len(data.Data)
+		data.ColumnCount = len(data.Columns)
+
+		splitPath := fmt.Sprintf("%s_%s%s", basePath, label, ext)
+		file, err := os.Create(splitPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s split file: %w", label, err)
+		}
+
+		if err := w.WriteStream(data, file); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write %s split: %w", label, err)
+		}
+		file.Close()
+	}
+
+	return nil
+}
+
+// WriteStream writes the feature matrix as one JSON object. Rows are encoded
+// one at a time so the whole document is never held in memory.
+func (w *JSONExportWriter) WriteStream(matrix *models.FeatureMatrix, out io.Writer) error {
+	var writer io.Writer = out
+
+	// Wrap with gzip if compression enabled
+	if w.options.Compress {
+		gzWriter := gzip.NewWriter(out)
+		defer gzWriter.Close()
+		writer = gzWriter
+	}
+
+	bw := bufio.NewWriter(writer)
+
+	// Schema stats that aren't finite can't be encoded and are omitted
+	schema := make([]models.FeatureSchema, len(matrix.Schema))
+	for i, col := range matrix.Schema {
+		for _, stat := range []*float64{&col.Min, &col.Max, &col.Mean, &col.Std} {
+			if math.IsNaN(*stat) || math.IsInf(*stat, 0) {
+				*stat = 0
+			}
+		}
+		schema[i] = col
+	}
+
+	if err := writeJSONField(bw, "{", "schema", schema); err != nil {
+		return err
+	}
+	if err := writeJSONField(bw, ",", "columns", matrix.Columns); err != nil {
+		return err
+	}
+
+	bw.WriteString(`,"rows":[`)
+	values := make([]interface{}, len(matrix.Columns))
+	for i, row := range matrix.Data {
+		for j := range values {
+			values[j] = nil
+			if j < len(row) {
+				values[j] = jsonValue(row[j])
+			}
+		}
+		data, err := json.Marshal(values)
+		if err != nil {
+			return fmt.Errorf("failed to write row %d: %w", i, err)
+		}
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		bw.Write(data)
+	}
+	bw.WriteByte(']')
+
+	timestamps := matrix.Timestamps
+	if timestamps == nil {
+		timestamps = []int64{}
+	}
+	if err := writeJSONField(bw, ",", "timestamps", timestamps); err != nil {
+		return err
+	}
+	if len(matrix.SplitLabels) > 0 {
+		if err := writeJSONField(bw, ",", "splits", matrix.SplitLabels); err != nil {
+			return err
+		}
+	}
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// writeJSONField writes sep followed by "name": and the JSON encoding of value
+func writeJSONField(w *bufio.Writer, sep, name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	fmt.Fprintf(w, "%s%q:", sep, name)
+	_, err = w.Write(data)
+	return err
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
 
+// hashingWriter passes writes through while computing a SHA-256 and byte count
+type hashingWriter struct {
+	w       io.Writer
+	hash    hash.Hash
+	written int64
+}
+
+// Write writes to the underlying writer and hashes the bytes actually written
+func (h *hashingWriter) Write(p []byte) (int, error) {
+	n, err := h.w.Write(p)
+	h.hash.Write(p[:n])
+	h.written += int64(n)
+	return n, err
+}
+
+// writeHashedFile streams the matrix to a temp file, renames it to outputPath
+// once fully written and returns the SHA-256 (hex) and number of bytes written
+func writeHashedFile(writer MLExportWriter, matrix *models.FeatureMatrix, outputPath string) (string, int64, error) {
+	tmpPath := outputPath + tempFileSuffix
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	hw := &hashingWriter{w: file, hash: sha256.New()}
+	if err := writer.WriteStream(matrix, hw); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return "", 0, err
+	}
+
+	// Surface flush errors (e.g. disk full) instead of losing them in a deferred Close
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return "", 0, fmt.Errorf("failed to sync file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", 0, fmt.Errorf("failed to close file: %w", err)
+	}
+
+	// Only a complete file ever appears under the final name
+	if err := os.Rename(tmpPath, outputPath); err != nil {
+		os.Remove(tmpPath)
+		return "", 0, fmt.Errorf("failed to rename file: %w", err)
+	}
+
+	return hex.EncodeToString(hw.hash.Sum(nil)), hw.written, nil
+}
+
+// tempFileSuffix marks an export file still being written. It is renamed to
+// its final name once complete, so a crash mid-write never leaves a truncated
+// file that looks finished.
+const tempFileSuffix = ".tmp"
+
+// writeFileAtomic writes data to a temp file next to path and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + tempFileSuffix
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// FileSHA256 computes the SHA-256 (hex) and size of a file on disk
+func FileSHA256(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
 // GetSupportedFormats returns all supported export formats
 func GetSupportedFormats() []models.MLExportFormat {
 	return []models.MLExportFormat{
@@ -729,6 +1023,7 @@ func GetSupportedFormats() []models.MLExportFormat {
 		models.MLExportFormatParquet,
 		models.MLExportFormatNumpy,
 		models.MLExportFormatJSONL,
+		models.MLExportFormatJSON,
 	}
 }
 
@@ -763,6 +1058,13 @@ func GetFormatInfo(format models.MLExportFormat) map[string]string {
 			"mime":        "application/x-jsonlines",
 			"compression": "optional gzip",
 		},
+		models.MLExportFormatJSON: {
+			"name":        "JSON",
+			"description": "Single JSON document with schema, columns, rows and timestamps",
+			"extension":   ".json",
+			"mime":        "application/json",
+			"compression": "optional gzip",
+		},
 	}
 
 	if i, ok := info[format]; ok {
@@ -779,6 +1081,7 @@ func CalculateExportSize(rowCount, colCount int, format models.MLExportFormat, c
 		models.MLExportFormatParquet: 8,  // 8 bytes per float64
 		models.MLExportFormatNumpy:   8,  // 8 bytes per float64
 		models.MLExportFormatJSONL:   20, // key + value
+		models.MLExportFormatJSON:    12, // value only, keys are in columns
 	}
 
 	bpv := bytesPerValue[format]
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestJSONExportWriterWritesSingleDocument(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns: []string{"close", "returns"},
+		Data: [][]float64{
+			{100, math.NaN()},
+			{101, math.Inf(1)},
+			{99, math.Inf(-1)},
+		},
+		Timestamps:  []int64{1000, 2000, 3000},
+		SplitLabels: []string{"train", "train", "test"},
+		Schema: []models.FeatureSchema{
+			{Name: "close", Type: "float64", Source: "ohlcv", Min: 99, Max: 101},
+			{Name: "returns", Type: "float64", Source: "price_feature", Min: math.Inf(-1), Max: math.Inf(1), Mean: math.NaN()},
+		},
+		RowCount:    3,
+		ColumnCount: 2,
+	}
+
+	writer, err := NewExportWriter(models.MLExportFormatJSON, DefaultWriterOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if writer.Extension() != ".json" {
+		t.Errorf("extension = %q, want .json", writer.Extension())
+	}
+
+	var buf bytes.Buffer
+	if err := writer.WriteStream(matrix, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Schema     []models.FeatureSchema `json:"schema"`
+		Columns    []string               `json:"columns"`
+		Rows       [][]interface{}        `json:"rows"`
+		Timestamps []int64                `json:"timestamps"`
+		Splits     []string               `json:"splits"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if !reflect.DeepEqual(doc.Columns, matrix.Columns) {
+		t.Errorf("columns = %v, want %v", doc.Columns, matrix.Columns)
+	}
+	if !reflect.DeepEqual(doc.Timestamps, matrix.Timestamps) {
+		t.Errorf("timestamps = %v, want %v", doc.Timestamps, matrix.Timestamps)
+	}
+	if !reflect.DeepEqual(doc.Splits, matrix.SplitLabels) {
+		t.Errorf("splits = %v, want %v", doc.Splits, matrix.SplitLabels)
+	}
+	if len(doc.Schema) != 2 || doc.Schema[1].Name != "returns" || doc.Schema[1].Min != 0 {
+		t.Errorf("unexpected schema: %+v", doc.Schema)
+	}
+
+	want := [][]interface{}{
+		{100.0, nil},
+		{101.0, "Infinity"},
+		{99.0, "-Infinity"},
+	}
+	if !reflect.DeepEqual(doc.Rows, want) {
+		t.Errorf("rows = %v, want %v", doc.Rows, want)
+	}
+}
+
+func TestJSONExportWriterEmptyMatrix(t *testing.T) {
+	writer := &JSONExportWriter{options: DefaultWriterOptions()}
+
+	var buf bytes.Buffer
+	if err := writer.WriteStream(&models.FeatureMatrix{Columns: []string{"close"}}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"schema":[],"columns":["close"],"rows":[],"timestamps":[]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("output = %s, want %s", buf.String(), want)
+	}
+}
+
+func TestFloatFormats(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close"},
+		Data:       [][]float64{{0.000012345678912}, {math.NaN()}},
+		Timestamps: []int64{1000, 2000},
+	}
+
+	cases := map[models.FloatFormat]string{
+		"":                           "1000,0.00001235\n",
+		models.FloatFormatFixed:      "1000,0.00001235\n",
+		models.FloatFormatGeneral:    "1000,1.2345679e-05\n",
+		models.FloatFormatScientific: "1000,1.23456789e-05\n",
+	}
+	for format, want := range cases {
+		options := DefaultWriterOptions()
+		options.IncludeHeader = false
+		options.FloatFormat = format
+
+		var buf bytes.Buffer
+		if err := (&CSVExportWriter{options: options}).WriteStream(matrix, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want+"2000,NaN\n" {
+			t.Errorf("%q: csv = %q, want %q", format, got, want+"2000,NaN\n")
+		}
+	}
+
+	options := DefaultWriterOptions()
+	options.FloatFormat = models.FloatFormatGeneral
+	options.Precision = 3
+	var buf bytes.Buffer
+	if err := (&JSONLExportWriter{options: options}).WriteStream(matrix, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"close\":1.23e-05,\"timestamp\":1000}\n{\"close\":null,\"timestamp\":2000}\n"
+	if buf.String() != want {
+		t.Errorf("jsonl = %q, want %q", buf.String(), want)
+	}
+}
+
+// failingWriter writes part of a row and then fails, like a crash mid-export
+type failingWriter struct{ CSVExportWriter }
+
+func (w *failingWriter) WriteStream(matrix *models.FeatureMatrix, out io.Writer) error {
+	out.Write([]byte("timestamp,close\n1000,"))
+	return errors.New("disk full")
+}
+
+// readParquetStream reads back the values ParquetExportWriter wrote, row-major
+func readParquetStream(t *testing.T, data []byte) [][]float64 {
+	t.Helper()
+	r := bytes.NewReader(data[4:])
+
+	var header parquetHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		t.Fatal(err)
+	}
+	for i := int64(0); i < header.NumCols; i++ {
+		var n int32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			t.Fatal(err)
+		}
+		r.Seek(int64(n), io.SeekCurrent)
+	}
+	if header.HasTimestamp {
+		r.Seek(8*header.NumRows, io.SeekCurrent)
+	}
+
+	rows := make([][]float64, header.NumRows)
+	for i := range rows {
+		rows[i] = make([]float64, header.NumCols)
+	}
+	for col := int64(0); col < header.NumCols; col++ {
+		for row := int64(0); row < header.NumRows; row++ {
+			if err := binary.Read(r, binary.LittleEndian, &rows[row][col]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return rows
+}
+
+func TestParquetWriterKeepsNaNAndInf(t *testing.T) {
+	matrix := &models.FeatureMatrix{
+		Columns:    []string{"close", "rsi", "ratio"},
+		Timestamps: []int64{1000, 2000},
+		Data: [][]float64{
+			{100, math.NaN(), math.Inf(1)},
+			{101, 0}, // short row: ratio is missing
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&ParquetExportWriter{}).WriteStream(matrix, &buf); err != nil {
+		t.Fatal(err)
+	}
+	rows := readParquetStream(t, buf.Bytes())
+
+	if !math.IsNaN(rows[0][1]) {
+		t.Errorf("NaN cell read back as %v", rows[0][1])
+	}
+	if !math.IsInf(rows[0][2], 1) {
+		t.Errorf("+Inf cell read back as %v", rows[0][2])
+	}
+	if rows[1][1] != 0 || math.Signbit(rows[1][1]) {
+		t.Errorf("zero cell read back as %v", rows[1][1])
+	}
+	if !math.IsNaN(rows[1][2]) {
+		t.Errorf("missing cell read back as %v, want NaN", rows[1][2])
+	}
+}
+
+func TestWriteHashedFileIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	matrix := &models.FeatureMatrix{Columns: []string{"close"}, Data: [][]float64{{1}}, Timestamps: []int64{1000}}
+
+	path := filepath.Join(dir, "export.csv")
+	if _, _, err := writeHashedFile(&failingWriter{}, matrix, path); err == nil {
+		t.Fatal("expected the write to fail")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("failed write left %d files behind", len(entries))
+	}
+
+	checksum, written, err := writeHashedFile(&CSVExportWriter{options: DefaultWriterOptions()}, matrix, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onDisk, size, err := FileSHA256(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onDisk != checksum || size != written {
+		t.Errorf("file on disk = %s (%d bytes), want %s (%d bytes)", onDisk, size, checksum, written)
+	}
+	if _, err := os.Stat(path + tempFileSuffix); !os.IsNotExist(err) {
+		t.Errorf("temp file still present: %v", err)
+	}
+}
+
+func TestRemoveStrayTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.csv.tmp", "b.parquet.tmp", "done.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &MLExportService{exportDir: dir}
+	removed, err := s.RemoveStrayTempFiles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 || entries[0].Name() != "done.csv" {
+		t.Errorf("remaining files = %v, want done.csv", entries)
+	}
+}
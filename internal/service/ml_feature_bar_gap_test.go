@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestBarGapFeature(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	// Bars at hours 0, 1, 4 and 5: two bars missing before hour 4
+	var candles []models.Candle
+	for _, hour := range []int{0, 1, 4, 5} {
+		candles = append(candles, models.Candle{Timestamp: start.Add(time.Duration(hour) * time.Hour).UnixMilli(), Close: 1})
+	}
+
+	engine := NewMLFeatureEngine()
+	matrix, err := engine.GenerateFeatures(context.Background(), candles, models.FeatureConfig{TemporalFeatures: []string{"bar_gap"}}, "1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gaps []float64
+	for _, row := range matrix.Data {
+		gaps = append(gaps, row[0])
+	}
+	if want := []float64{0, 0, 2, 0}; !reflect.DeepEqual(gaps, want) {
+		t.Errorf("gaps = %v, want %v", gaps, want)
+	}
+
+	// A coarser timeframe than the data spacing never reports gaps
+	if got := calculateBarGaps(matrix.Timestamps, "1d"); !reflect.DeepEqual(got, []float64{0, 0, 0, 0}) {
+		t.Errorf("1d gaps = %v", got)
+	}
+
+	// An unknown timeframe infers the bar spacing from the rows
+	if got := calculateBarGaps(matrix.Timestamps, ""); !reflect.DeepEqual(got, []float64{0, 0, 2, 0}) {
+		t.Errorf("inferred gaps = %v", got)
+	}
+}
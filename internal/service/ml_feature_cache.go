@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// maxFeatureCacheBytes keeps cache entries under MongoDB's 16MB document limit
+const maxFeatureCacheBytes = 15 << 20
+
+// generateFeaturesCached returns the feature matrix for the export, reusing a
+// cached matrix when the feature config and source data range match a previous
+// export and none of the source series was written since. Cache failures are
+// logged and fall back to generating the features. Exports joining derivatives
+// data are not cached, as writes to it don't mark the source series updated.
+func (s *MLExportService) generateFeaturesCached(ctx context.Context, candles []models.Candle, exportJob *models.MLExportJob, sources []models.SourceJobInfo) (*models.FeatureMatrix, error) {
+	if s.featureCacheRepo == nil || s.featureCacheTTL <= 0 || len(exportJob.Config.Features.DerivativesFeatures) > 0 {
+		return s.featureEngine.GenerateFeatures(ctx, candles, exportJob.Config.Features, finestTimeframe(sources))
+	}
+
+	key, err := s.featureCacheKey(ctx, exportJob.Config, sources)
+	if err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Feature cache disabled for job %s: %v", exportJob.ID.Hex(), err)
+		return s.featureEngine.GenerateFeatures(ctx, candles, exportJob.Config.Features, finestTimeframe(sources))
+	}
+
+	dataUpdatedAt, err := s.sourcesUpdatedAt(ctx, sources)
+	if err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Feature cache disabled for job %s: %v", exportJob.ID.Hex(), err)
+		return s.featureEngine.GenerateFeatures(ctx, candles, exportJob.Config.Features, finestTimeframe(sources))
+	}
+
+	entry, err := s.featureCacheRepo.FindByKey(ctx, key)
+	if err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Feature cache lookup failed: %v", err)
+	}
+	if entry != nil && !entry.DataUpdatedAt.Before(dataUpdatedAt) {
+		matrix, err := decodeFeatureMatrix(entry.Matrix)
+		if err == nil {
+			logging.Printf(ctx, "[ML_EXPORT] Feature cache hit for job %s (%d rows, %d columns)",
+				exportJob.ID.Hex(), matrix.RowCount, matrix.ColumnCount)
+			return matrix, nil
+		}
+		logging.Printf(ctx, "[ML_EXPORT] Ignoring unreadable feature cache entry: %v", err)
+	}
+
+	matrix, err := s.featureEngine.GenerateFeatures(ctx, candles, exportJob.Config.Features, finestTimeframe(sources))
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := encodeFeatureMatrix(matrix)
+	if err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Failed to encode features for cache: %v", err)
+		return matrix, nil
+	}
+	if len(encoded) > maxFeatureCacheBytes {
+		logging.Printf(ctx, "[ML_EXPORT] Feature matrix too large to cache (%d bytes)", len(encoded))
+		return matrix, nil
+	}
+
+	now := time.Now()
+	err = s.featureCacheRepo.Save(ctx, &models.MLFeatureCacheEntry{
+		Key:           key,
+		JobIDs:        exportJob.JobIDs,
+		DataUpdatedAt: dataUpdatedAt,
+		Matrix:        encoded,
+		RowCount:      matrix.RowCount,
+		ColumnCount:   matrix.ColumnCount,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(s.featureCacheTTL),
+	})
+	if err != nil {
+		logging.Printf(ctx, "[ML_EXPORT] Failed to cache features: %v", err)
+	}
+
+	return matrix, nil
+}
+
+// featureCacheKey hashes everything that determines the feature matrix: the
+// feature and resample config, the source data range and, when missing
+// indicators are computed, the indicator config used
+func (s *MLExportService) featureCacheKey(ctx context.Context, config models.MLExportConfig, sources []models.SourceJobInfo) (string, error) {
+	keyed := struct {
+		Features        models.FeatureConfig    `json:"features"`
+		Resample        models.ResampleConfig   `json:"resample"`
+		Alignment       models.AlignmentConfig  `json:"alignment"`
+		Sources         []models.SourceJobInfo  `json:"sources"`
+		IndicatorConfig *models.IndicatorConfig `json:"indicator_config,omitempty"`
+	}{
+		Features:  config.Features,
+		Resample:  config.Resample,
+		Alignment: config.Alignment,
+		Sources:   sources,
+	}
+
+	if config.Features.ComputeMissingIndicators {
+		indicatorConfig, err := s.indicatorConfigRepo.FindDefault(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to load indicator config: %w", err)
+		}
+		keyed.IndicatorConfig = indicatorConfig
+	}
+
+	data, err := json.Marshal(keyed)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cache key: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sourcesUpdatedAt returns the latest time any source series was written
+func (s *MLExportService) sourcesUpdatedAt(ctx context.Context, sources []models.SourceJobInfo) (time.Time, error) {
+	var latest time.Time
+	for _, src := range sources {
+		updatedAt, err := s.ohlcvRepo.GetLastUpdated(ctx, src.ExchangeID, src.Symbol, src.Timeframe)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to get last update of %s %s: %w", src.Symbol, src.Timeframe, err)
+		}
+		if updatedAt.After(latest) {
+			latest = updatedAt
+		}
+	}
+	return latest, nil
+}
+
+// encodeFeatureMatrix serializes a matrix as gzip-compressed gob, which keeps NaN values
+func encodeFeatureMatrix(matrix *models.FeatureMatrix) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := gob.NewEncoder(gz).Encode(matrix); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeFeatureMatrix reverses encodeFeatureMatrix
+func decodeFeatureMatrix(data []byte) (*models.FeatureMatrix, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var matrix models.FeatureMatrix
+	if err := gob.NewDecoder(gz).Decode(&matrix); err != nil {
+		return nil, err
+	}
+	return &matrix, nil
+}
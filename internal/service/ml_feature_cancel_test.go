@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// rollingFeatureConfig rolls every stored column, so generation checks for
+// cancellation many times
+func rollingFeatureConfig() models.FeatureConfig {
+	config := storedFeatureConfig()
+	config.RollingFeatures = models.RollingConfig{
+		Enabled:         true,
+		Windows:         []int{5, 20},
+		Stats:           []string{"median", "std"},
+		RollingFeatures: []string{"open", "high", "low", "close", "volume"},
+	}
+	return config
+}
+
+// cancelAfterContext reports itself cancelled from its nth Err check on, so a
+// test can cancel generation part way through without racing a timer
+type cancelAfterContext struct {
+	context.Context
+	n      int
+	checks int
+}
+
+func (c *cancelAfterContext) Err() error {
+	c.checks++
+	if c.checks >= c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestGenerateFeaturesCancelledContext(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := engine.GenerateFeatures(ctx, storedFeatureCandles(100), rollingFeatureConfig(), ""); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGenerateFeaturesStopsOnCancel(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	candles := storedFeatureCandles(500)
+	config := rollingFeatureConfig()
+
+	baseline := &cancelAfterContext{Context: context.Background(), n: math.MaxInt}
+	if _, err := engine.GenerateFeatures(baseline, candles, config, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Cancelled half way through the checks an uncancelled run makes
+	ctx := &cancelAfterContext{Context: context.Background(), n: baseline.checks / 2}
+	_, err := engine.GenerateFeatures(ctx, candles, config, "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if ctx.checks != ctx.n {
+		t.Errorf("generation went on for %d more checks after the cancel", ctx.checks-ctx.n)
+	}
+}
+
+func TestGenerateTargetsCancelledContext(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	candles := storedFeatureCandles(100)
+	matrix, err := engine.GenerateFeatures(context.Background(), candles, storedFeatureConfig(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	target := models.TargetConfig{
+		Enabled:          true,
+		Type:             models.TargetTypeFutureReturns,
+		LookaheadPeriods: []int{1, 5},
+	}
+	if err := engine.GenerateTargets(ctx, matrix, candles, target); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// featureDescriptions holds the human-readable description of every base feature
+// the engine can generate. Indicator periods are the IndicatorConfig defaults.
+var featureDescriptions = map[string]string{
+	// OHLCV
+	"timestamp": "Candle open time in Unix milliseconds",
+	"open":      "Opening price of the candle",
+	"high":      "Highest traded price of the candle",
+	"low":       "Lowest traded price of the candle",
+	"close":     "Closing price of the candle",
+	"volume":    "Traded base volume of the candle",
+
+	// Trend indicators
+	"sma20":             "20-period simple moving average of close",
+	"sma50":             "50-period simple moving average of close",
+	"sma200":            "200-period simple moving average of close",
+	"ema12":             "12-period exponential moving average of close",
+	"ema26":             "26-period exponential moving average of close",
+	"ema50":             "50-period exponential moving average of close",
+	"dema":              "Double exponential moving average of close (default 20-period)",
+	"tema":              "Triple exponential moving average of close (default 20-period)",
+	"wma":               "Weighted moving average of close (default 20-period)",
+	"hma":               "Hull moving average of close (default 9-period)",
+	"vwma":              "Volume-weighted moving average of close (default 20-period)",
+	"ichimoku_tenkan":   "Ichimoku conversion line (9-period high/low midpoint)",
+	"ichimoku_kijun":    "Ichimoku base line (26-period high/low midpoint)",
+	"ichimoku_senkou_a": "Ichimoku leading span A (midpoint of conversion and base lines)",
+	"ichimoku_senkou_b": "Ichimoku leading span B (52-period high/low midpoint)",
+	"adx":               "Average directional index trend strength (default 14-period)",
+	"plus_di":           "Positive directional indicator +DI (default 14-period)",
+	"minus_di":          "Negative directional indicator -DI (default 14-period)",
+	"supertrend":        "SuperTrend trailing stop line (default 10-period, 3x ATR)",
+
+	// Momentum indicators
+	"rsi6":        "6-period RSI momentum oscillator",
+	"rsi14":       "14-period RSI momentum oscillator",
+	"rsi24":       "24-period RSI momentum oscillator",
+	"stoch_k":     "Stochastic oscillator %K line",
+	"stoch_d":     "Stochastic oscillator %D signal line",
+	"macd":        "MACD line (12-period EMA minus 26-period EMA)",
+	"macd_signal": "MACD signal line (9-period EMA of MACD)",
+	"macd_hist":   "MACD histogram (MACD minus signal line)",
+	"roc":         "Rate of change of close in percent (default 12-period)",
+	"cci":         "Commodity channel index (default 20-period)",
+	"williams_r":  "Williams %R oscillator (default 14-period)",
+	"momentum":    "Close minus close N periods ago (default 10-period)",
+
+	// Volatility indicators
+	"bb_upper":        "Upper Bollinger band (default 20-period, 2 std)",
+	"bb_middle":       "Middle Bollinger band (default 20-period SMA)",
+	"bb_lower":        "Lower Bollinger band (default 20-period, 2 std)",
+	"bb_bandwidth":    "Bollinger bandwidth (band width relative to middle band)",
+	"bb_percent_b":    "Bollinger %B (close position within the bands)",
+	"atr":             "Average true range volatility (default 14-period)",
+	"keltner_upper":   "Upper Keltner channel (default 20-period EMA + 2x ATR)",
+	"keltner_middle":  "Middle Keltner channel (default 20-period EMA)",
+	"keltner_lower":   "Lower Keltner channel (default 20-period EMA - 2x ATR)",
+	"donchian_upper":  "Upper Donchian channel (highest high, default 20-period)",
+	"donchian_middle": "Middle Donchian channel (midpoint of upper and lower)",
+	"donchian_lower":  "Lower Donchian channel (lowest low, default 20-period)",
+	"stddev":          "Standard deviation of close (default 20-period)",
+
+	// Volume indicators
+	"obv":        "On-balance volume",
+	"vwap":       "Volume-weighted average price",
+	"mfi":        "Money flow index (default 14-period)",
+	"cmf":        "Chaikin money flow (default 20-period)",
+	"volume_sma": "Simple moving average of volume (default 20-period)",
+
+	// Price features
+	"returns":      "Simple return of close vs previous close",
+	"log_returns":  "Log return of close vs previous close",
+	"price_change": "Absolute change of close vs previous close",
+	"volatility":   "Candle range (high minus low)",
+	"gaps":         "Open minus previous close",
+	"body_ratio":   "Candle body (close minus open) as a fraction of the range",
+	"range_pct":    "Candle range (high minus low) relative to close",
+	"upper_wick":   "High minus the top of the candle body",
+	"lower_wick":   "Bottom of the candle body minus low",
+	"frac_diff":    "Fixed-width window fractional difference of the log close (order d from features.frac_diff), stationary while keeping memory of the price level",
+
+	// Temporal features
+	"hour":         "Hour of day (UTC, 0-23)",
+	"hour_sin":     "Sine encoding of hour of day",
+	"hour_cos":     "Cosine encoding of hour of day",
+	"day_of_week":  "Day of week (UTC, 0 = Sunday)",
+	"dow_sin":      "Sine encoding of day of week",
+	"dow_cos":      "Cosine encoding of day of week",
+	"day_of_month": "Day of month (UTC, 1-31)",
+	"month":        "Month of year (1-12)",
+	"month_sin":    "Sine encoding of month of year",
+	"month_cos":    "Cosine encoding of month of year",
+	"is_weekend":   "1 if the candle falls on Saturday or Sunday (UTC), else 0",
+	"quarter":      "Quarter of year (1-4)",
+
+	"market_session":           "Market session of the candle's UTC hour: 0 off hours, 1 Asian, 2 European, 3 US, 4 overlap",
+	"market_session_off_hours": "1 if no market session is open at the candle's UTC hour, else 0",
+	"market_session_asian":     "1 if only the Asian session is open at the candle's UTC hour, else 0",
+	"market_session_european":  "1 if only the European session is open at the candle's UTC hour, else 0",
+	"market_session_us":        "1 if only the US session is open at the candle's UTC hour, else 0",
+	"market_session_overlap":   "1 if more than one market session is open at the candle's UTC hour, else 0",
+	"bar_gap":                  "Number of bars missing between the candle and the previous one (0 when contiguous)",
+
+	// Cross features
+	"bb_position":     "Position of close between the lower (0) and upper (1) Bollinger bands",
+	"price_vs_sma20":  "Relative distance of close from the 20-period SMA",
+	"price_vs_sma50":  "Relative distance of close from the 50-period SMA",
+	"price_vs_sma200": "Relative distance of close from the 200-period SMA",
+	"ma_crossover":    "1 if EMA12 is above EMA26, -1 if below, 0 if equal",
+	"rsi_oversold":    "1 if RSI14 is below 30, else 0",
+	"rsi_overbought":  "1 if RSI14 is above 70, else 0",
+
+	// Derivatives features
+	"funding_rate":        "Last perpetual funding rate settled at or before the candle open",
+	"open_interest":       "Open interest at or before the candle open, in contracts or base currency",
+	"open_interest_value": "Open interest at or before the candle open, in quote currency",
+
+	// Split helpers
+	"shuffle_index": "Random permutation index over training rows (-1 outside the train split)",
+}
+
+var rollingStatDescriptions = map[string]string{
+	"mean":   "mean",
+	"std":    "standard deviation",
+	"min":    "minimum",
+	"max":    "maximum",
+	"median": "median",
+}
+
+var (
+	lagFeaturePattern     = regexp.MustCompile(`^(.+)_lag_(\d+)$`)
+	rollingFeaturePattern = regexp.MustCompile(`^(.+)_roll_(\d+)_([a-z]+)$`)
+	targetFeaturePattern  = regexp.MustCompile(`^target_(future_returns|future_direction|future_class|future_volatility)_(\d+)$`)
+)
+
+// DescribeFeature returns a human-readable description for a generated feature
+// column, or an empty string if the feature is unknown
+func DescribeFeature(name string) string {
+	if desc, ok := featureDescriptions[name]; ok {
+		return desc
+	}
+
+	if m := targetFeaturePattern.FindStringSubmatch(name); m != nil {
+		period, _ := strconv.Atoi(m[2])
+		switch m[1] {
+		case "future_returns":
+			return fmt.Sprintf("Target: return of close %d periods ahead", period)
+		case "future_direction":
+			return fmt.Sprintf("Target: 1 if close %d periods ahead is higher, else 0", period)
+		case "future_class":
+			return fmt.Sprintf("Target: bin class of the return %d periods ahead", period)
+		case "future_volatility":
+			return fmt.Sprintf("Target: average true range over the next %d periods", period)
+		}
+	}
+
+	if m := rollingFeaturePattern.FindStringSubmatch(name); m != nil {
+		if stat, ok := rollingStatDescriptions[m[3]]; ok {
+			return fmt.Sprintf("Rolling %s-period %s of %s", m[2], stat, m[1])
+		}
+	}
+
+	if m := lagFeaturePattern.FindStringSubmatch(name); m != nil {
+		return fmt.Sprintf("%s lagged by %s periods", m[1], m[2])
+	}
+
+	return ""
+}
+
+// FeatureDescriptions returns the descriptions of all base features keyed by name
+func FeatureDescriptions() map[string]string {
+	descriptions := make(map[string]string, len(featureDescriptions))
+	for name, desc := range featureDescriptions {
+		descriptions[name] = desc
+	}
+	return descriptions
+}
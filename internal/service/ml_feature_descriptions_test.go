@@ -0,0 +1,31 @@
+package service
+
+import "testing"
+
+func TestEveryFeatureIsDescribed(t *testing.T) {
+	names := []string{"timestamp", "open", "high", "low", "close", "volume", "shuffle_index"}
+	for _, order := range [][]string{priceFeatureOrder, temporalFeatureOrder, crossFeatureOrder, derivativesFeatureOrder} {
+		names = append(names, order...)
+	}
+	for _, field := range storedIndicatorFields {
+		names = append(names, field.name)
+	}
+
+	for _, name := range names {
+		if DescribeFeature(name) == "" {
+			t.Errorf("%s has no description", name)
+		}
+	}
+
+	derived := map[string]string{
+		"rsi14_lag_5":                "rsi14 lagged by 5 periods",
+		"close_roll_20_std":          "Rolling 20-period standard deviation of close",
+		"target_future_returns_5":    "Target: return of close 5 periods ahead",
+		"target_future_direction_10": "Target: 1 if close 10 periods ahead is higher, else 0",
+	}
+	for name, want := range derived {
+		if got := DescribeFeature(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
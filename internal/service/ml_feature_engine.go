@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -17,11 +18,16 @@ func NewMLFeatureEngine() *MLFeatureEngine {
 	return &MLFeatureEngine{}
 }
 
-// GenerateFeatures generates all requested features from candle data
-func (e *MLFeatureEngine) GenerateFeatures(candles []models.Candle, config models.FeatureConfig) (*models.FeatureMatrix, error) {
+// GenerateFeatures generates all requested features from candle data of the
+// given timeframe. Cancellation of ctx is checked between features and returns
+// ctx.Err().
+func (e *MLFeatureEngine) GenerateFeatures(ctx context.Context, candles []models.Candle, config models.FeatureConfig, timeframe string) (*models.FeatureMatrix, error) {
 	if len(candles) == 0 {
 		return nil, fmt.Errorf("no candles provided")
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Sort candles by timestamp ascending (oldest first)
 	sortedCandles := make([]models.Candle, len(candles))
@@ -30,6 +36,16 @@ func (e *MLFeatureEngine) GenerateFeatures(candles []models.Candle, config model
 		return sortedCandles[i].Timestamp < sortedCandles[j].Timestamp
 	})
 
+	// Exports of stored columns only skip the per-feature extraction
+	if storedColumnsOnly(config) {
+		return e.projectStoredColumns(sortedCandles, config), nil
+	}
+
+	return e.buildFeatures(ctx, sortedCandles, config, timeframe)
+}
+
+// buildFeatures adds every requested feature column by column to a new matrix
+func (e *MLFeatureEngine) buildFeatures(ctx context.Context, sortedCandles []models.Candle, config models.FeatureConfig, timeframe string) (*models.FeatureMatrix, error) {
 	// Initialize feature matrix
 	matrix := &models.FeatureMatrix{
 		Columns:     []string{},
@@ -73,29 +89,42 @@ func (e *MLFeatureEngine) GenerateFeatures(candles []models.Candle, config model
 	e.addIndicatorFeatures(matrix, sortedCandles, config)
 
 	// Add price-based features
-	e.addPriceFeatures(matrix, sortedCandles, config.PriceFeatures)
+	e.addPriceFeatures(matrix, sortedCandles, config.PriceFeatures, config.FracDiff)
 
 	// Add temporal features
-	e.addTemporalFeatures(matrix, sortedCandles, config.TemporalFeatures)
+	e.addTemporalFeatures(matrix, sortedCandles, config.TemporalFeatures, config.MarketSessions, timeframe)
 
 	// Add cross-indicator features
 	e.addCrossFeatures(matrix, sortedCandles, config.CrossFeatures)
 
+	// Add derivatives features
+	e.addDerivativesFeatures(matrix, sortedCandles, config.DerivativesFeatures)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Add lagged features (after all base features are added)
 	if config.LaggedFeatures.Enabled {
-		e.addLaggedFeatures(matrix, config.LaggedFeatures)
+		if err := e.addLaggedFeatures(ctx, matrix, config.LaggedFeatures); err != nil {
+			return nil, err
+		}
 	}
 
 	// Add rolling features
 	if config.RollingFeatures.Enabled {
-		e.addRollingFeatures(matrix, config.RollingFeatures)
+		if err := e.addRollingFeatures(ctx, matrix, config.RollingFeatures); err != nil {
+			return nil, err
+		}
 	}
 
 	return matrix, nil
 }
 
-// GenerateTargets generates target variables
-func (e *MLFeatureEngine) GenerateTargets(matrix *models.FeatureMatrix, candles []models.Candle, config models.TargetConfig) error {
+// GenerateTargets generates target variables for every target spec. Each
+// classification spec is binned independently and the edges it used are
+// recorded in matrix.Targets. Cancellation of ctx is checked between targets.
+func (e *MLFeatureEngine) GenerateTargets(ctx context.Context, matrix *models.FeatureMatrix, candles []models.Candle, config models.TargetConfig) error {
 	if !config.Enabled {
 		return nil
 	}
@@ -109,31 +138,96 @@ func (e *MLFeatureEngine) GenerateTargets(matrix *models.FeatureMatrix, candles
 
 	closes := extractFloats(sortedCandles, func(c models.Candle) float64 { return c.Close })
 
-	for _, period := range config.LookaheadPeriods {
-		switch config.Type {
-		case models.TargetTypeFutureReturns:
-			targets := e.calculateFutureReturns(closes, period)
-			e.addColumn(matrix, fmt.Sprintf("target_future_returns_%d", period), "float64", "target", targets)
-
-		case models.TargetTypeFutureDirection:
-			targets := e.calculateFutureDirection(closes, period)
-			e.addColumn(matrix, fmt.Sprintf("target_future_direction_%d", period), "int64", "target", targets)
-
-		case models.TargetTypeFutureClass:
-			targets := e.calculateFutureClass(closes, period, config.ClassificationBins)
-			e.addColumn(matrix, fmt.Sprintf("target_future_class_%d", period), "int64", "target", targets)
-
-		case models.TargetTypeFutureVolatility:
-			highs := extractFloats(sortedCandles, func(c models.Candle) float64 { return c.High })
-			lows := extractFloats(sortedCandles, func(c models.Candle) float64 { return c.Low })
-			targets := e.calculateFutureVolatility(highs, lows, closes, period)
-			e.addColumn(matrix, fmt.Sprintf("target_future_volatility_%d", period), "float64", "target", targets)
+	seen := make(map[string]bool)
+	for _, spec := range config.Specs() {
+		prefix := spec.Name
+		if prefix == "" {
+			prefix = string(spec.Type)
+		}
+
+		for _, period := range spec.LookaheadPeriods {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			name := fmt.Sprintf("target_%s_%d", prefix, period)
+			if seen[name] {
+				return fmt.Errorf("duplicate target column %s: give the target a distinct name", name)
+			}
+			seen[name] = true
+
+			info := models.TargetInfo{
+				Column:          name,
+				Type:            spec.Type,
+				LookaheadPeriod: period,
+			}
+
+			var targets []float64
+			dtype := "float64"
+			switch spec.Type {
+			case models.TargetTypeFutureReturns:
+				targets = e.calculateFutureReturns(closes, period)
+
+			case models.TargetTypeFutureDirection:
+				targets = e.calculateFutureDirection(closes, period)
+				dtype = "int64"
+
+			case models.TargetTypeFutureClass:
+				bins := spec.ClassificationBins
+				info.BinMode = models.TargetBinModeFixed
+				if spec.BinMode == models.TargetBinModeQuantile {
+					bins = quantileEdges(e.calculateFutureReturns(closes, period), spec.NumClasses)
+					info.BinMode = models.TargetBinModeQuantile
+					info.QuantileEdges = bins
+				}
+				targets = e.calculateFutureClass(closes, period, bins)
+				info.ClassificationBins = bins
+				info.NumClasses = len(bins) + 1
+				dtype = "int64"
+
+			case models.TargetTypeFutureVolatility:
+				highs := extractFloats(sortedCandles, func(c models.Candle) float64 { return c.High })
+				lows := extractFloats(sortedCandles, func(c models.Candle) float64 { return c.Low })
+				targets = e.calculateFutureVolatility(highs, lows, closes, period)
+
+			default:
+				return fmt.Errorf("unknown target type: %s", spec.Type)
+			}
+
+			e.addColumn(matrix, name, dtype, "target", targets)
+			if spec.Name != "" {
+				// Custom names are not in the description table; describe by type
+				matrix.Schema[len(matrix.Schema)-1].Description = DescribeFeature(fmt.Sprintf("target_%s_%d", spec.Type, period))
+			}
+			matrix.Targets = append(matrix.Targets, info)
 		}
 	}
 
 	return nil
 }
 
+// quantileEdges returns the numClasses-1 edges that split the non-NaN values
+// into equally populated classes. Edges are computed over the whole dataset,
+// so they carry information from the validation and test rows.
+func quantileEdges(values []float64, numClasses int) []float64 {
+	valid := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			valid = append(valid, v)
+		}
+	}
+	if len(valid) == 0 || numClasses < 2 {
+		return nil
+	}
+	sort.Float64s(valid)
+
+	edges := make([]float64, numClasses-1)
+	for k := 1; k < numClasses; k++ {
+		edges[k-1] = valid[k*len(valid)/numClasses]
+	}
+	return edges
+}
+
 // addColumn adds a column to the feature matrix
 func (e *MLFeatureEngine) addColumn(matrix *models.FeatureMatrix, name, dtype, source string, values []float64) {
 	matrix.Columns = append(matrix.Columns, name)
@@ -149,19 +243,25 @@ func (e *MLFeatureEngine) addColumn(matrix *models.FeatureMatrix, name, dtype, s
 	}
 
 	// Add schema
+	matrix.Schema = append(matrix.Schema, columnSchema(name, dtype, source, values))
+}
+
+// columnSchema describes a column and its NaN count and value statistics
+func columnSchema(name, dtype, source string, values []float64) models.FeatureSchema {
 	nanCount := countNaN(values)
 	stats := calculateStats(values)
-	matrix.Schema = append(matrix.Schema, models.FeatureSchema{
-		Name:       name,
-		Type:       dtype,
-		Source:     source,
-		NaNCount:   int64(nanCount),
-		NaNPercent: float64(nanCount) / float64(len(values)) * 100,
-		Min:        stats.min,
-		Max:        stats.max,
-		Mean:       stats.mean,
-		Std:        stats.std,
-	})
+	return models.FeatureSchema{
+		Name:        name,
+		Type:        dtype,
+		Source:      source,
+		Description: DescribeFeature(name),
+		NaNCount:    int64(nanCount),
+		NaNPercent:  float64(nanCount) / float64(len(values)) * 100,
+		Min:         stats.min,
+		Max:         stats.max,
+		Mean:        stats.mean,
+		Std:         stats.std,
+	}
 }
 
 // addIndicatorFeatures adds technical indicator features
@@ -240,38 +340,9 @@ func (e *MLFeatureEngine) addIndicatorFeatures(matrix *models.FeatureMatrix, can
 		{"volume_sma", func(ind models.Indicators) *float64 { return ind.VolumeSMA }},
 	}
 
-	// Helper to check if indicator should be included
-	shouldInclude := func(name, category string) bool {
-		if config.IncludeAllIndicators {
-			// Check exclusions
-			for _, exc := range config.ExcludeIndicators {
-				if exc == name {
-					return false
-				}
-			}
-			return true
-		}
-
-		// Check specific indicators
-		for _, inc := range config.SpecificIndicators {
-			if inc == name {
-				return true
-			}
-		}
-
-		// Check categories
-		for _, cat := range config.IndicatorCategories {
-			if cat == category {
-				return true
-			}
-		}
-
-		return false
-	}
-
 	// Add trend indicators
 	for _, ind := range trendIndicators {
-		if shouldInclude(ind.name, "trend") {
+		if includeIndicator(config, ind.name, "trend") {
 			values := extractIndicator(candles, ind.getter)
 			e.addColumn(matrix, ind.name, "float64", "indicator", values)
 		}
@@ -279,7 +350,7 @@ func (e *MLFeatureEngine) addIndicatorFeatures(matrix *models.FeatureMatrix, can
 
 	// Add momentum indicators
 	for _, ind := range momentumIndicators {
-		if shouldInclude(ind.name, "momentum") {
+		if includeIndicator(config, ind.name, "momentum") {
 			values := extractIndicator(candles, ind.getter)
 			e.addColumn(matrix, ind.name, "float64", "indicator", values)
 		}
@@ -287,7 +358,7 @@ func (e *MLFeatureEngine) addIndicatorFeatures(matrix *models.FeatureMatrix, can
 
 	// Add volatility indicators
 	for _, ind := range volatilityIndicators {
-		if shouldInclude(ind.name, "volatility") {
+		if includeIndicator(config, ind.name, "volatility") {
 			values := extractIndicator(candles, ind.getter)
 			e.addColumn(matrix, ind.name, "float64", "indicator", values)
 		}
@@ -295,15 +366,44 @@ func (e *MLFeatureEngine) addIndicatorFeatures(matrix *models.FeatureMatrix, can
 
 	// Add volume indicators
 	for _, ind := range volumeIndicators {
-		if shouldInclude(ind.name, "volume") {
+		if includeIndicator(config, ind.name, "volume") {
 			values := extractIndicator(candles, ind.getter)
 			e.addColumn(matrix, ind.name, "float64", "indicator", values)
 		}
 	}
 }
 
+// includeIndicator checks if an indicator is selected by the feature config
+func includeIndicator(config models.FeatureConfig, name, category string) bool {
+	if config.IncludeAllIndicators {
+		// Check exclusions
+		for _, exc := range config.ExcludeIndicators {
+			if exc == name {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Check specific indicators
+	for _, inc := range config.SpecificIndicators {
+		if inc == name {
+			return true
+		}
+	}
+
+	// Check categories
+	for _, cat := range config.IndicatorCategories {
+		if cat == category {
+			return true
+		}
+	}
+
+	return false
+}
+
 // addPriceFeatures adds price-based features
-func (e *MLFeatureEngine) addPriceFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string) {
+func (e *MLFeatureEngine) addPriceFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string, fracDiff *models.FracDiffConfig) {
 	if len(features) == 0 {
 		return
 	}
@@ -313,7 +413,7 @@ func (e *MLFeatureEngine) addPriceFeatures(matrix *models.FeatureMatrix, candles
 	lows := extractFloats(candles, func(c models.Candle) float64 { return c.Low })
 	closes := extractFloats(candles, func(c models.Candle) float64 { return c.Close })
 
-	for _, feature := range features {
+	for _, feature := range canonicalSelection(priceFeatureOrder, features) {
 		switch feature {
 		case "returns":
 			values := e.calculateReturns(closes)
@@ -350,17 +450,30 @@ func (e *MLFeatureEngine) addPriceFeatures(matrix *models.FeatureMatrix, candles
 		case "lower_wick":
 			values := e.calculateLowerWick(opens, lows, closes)
 			e.addColumn(matrix, "lower_wick", "float64", "price_feature", values)
+
+		case "frac_diff":
+			values := e.calculateFracDiff(closes, fracDiff.Weights())
+			e.addColumn(matrix, "frac_diff", "float64", "price_feature", values)
 		}
 	}
 }
 
 // addTemporalFeatures adds time-based features
-func (e *MLFeatureEngine) addTemporalFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string) {
+func (e *MLFeatureEngine) addTemporalFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string, sessions *models.MarketSessionConfig, timeframe string) {
 	if len(features) == 0 {
 		return
 	}
 
-	for _, feature := range features {
+	for _, feature := range canonicalSelection(temporalFeatureOrder, features) {
+		switch feature {
+		case "market_session":
+			e.addMarketSession(matrix, candles, sessions)
+			continue
+		case "bar_gap":
+			e.addColumn(matrix, "bar_gap", "int64", "temporal", calculateBarGaps(matrix.Timestamps, timeframe))
+			continue
+		}
+
 		values := make([]float64, len(candles))
 
 		for i, c := range candles {
@@ -402,6 +515,61 @@ func (e *MLFeatureEngine) addTemporalFeatures(matrix *models.FeatureMatrix, cand
 	}
 }
 
+// addMarketSession adds the market session of each candle's UTC hour, as an
+// integer code whose legend is recorded in the column schema, or one-hot
+func (e *MLFeatureEngine) addMarketSession(matrix *models.FeatureMatrix, candles []models.Candle, sessions *models.MarketSessionConfig) {
+	codes := make([]int, len(candles))
+	for i, c := range candles {
+		codes[i] = sessions.SessionAt(time.UnixMilli(c.Timestamp).UTC().Hour())
+	}
+
+	if sessions != nil && sessions.OneHot {
+		for code, name := range models.MarketSessionLegend {
+			values := make([]float64, len(codes))
+			for i, c := range codes {
+				if c == code {
+					values[i] = 1
+				}
+			}
+			e.addColumn(matrix, "market_session_"+name, "int64", "temporal", values)
+		}
+		return
+	}
+
+	values := make([]float64, len(codes))
+	for i, c := range codes {
+		values[i] = float64(c)
+	}
+	e.addColumn(matrix, "market_session", "int64", "temporal", values)
+	matrix.Schema[len(matrix.Schema)-1].Categories = models.MarketSessionLegend
+}
+
+// calculateBarGaps returns, for each row, the number of bars missing between
+// it and the previous row: 0 when contiguous and for the first row. An unknown
+// timeframe falls back to the smallest spacing between rows.
+func calculateBarGaps(timestamps []int64, timeframe string) []float64 {
+	result := make([]float64, len(timestamps))
+
+	interval, err := models.GetTimeframeMs(timeframe)
+	if err != nil {
+		for i := 1; i < len(timestamps); i++ {
+			if delta := timestamps[i] - timestamps[i-1]; delta > 0 && (interval <= 0 || delta < interval) {
+				interval = delta
+			}
+		}
+		if interval <= 0 {
+			return result
+		}
+	}
+
+	for i := 1; i < len(timestamps); i++ {
+		if missing := (timestamps[i]-timestamps[i-1])/interval - 1; missing > 0 {
+			result[i] = float64(missing)
+		}
+	}
+	return result
+}
+
 // addCrossFeatures adds cross-indicator features
 func (e *MLFeatureEngine) addCrossFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string) {
 	if len(features) == 0 {
@@ -410,7 +578,7 @@ func (e *MLFeatureEngine) addCrossFeatures(matrix *models.FeatureMatrix, candles
 
 	closes := extractFloats(candles, func(c models.Candle) float64 { return c.Close })
 
-	for _, feature := range features {
+	for _, feature := range canonicalSelection(crossFeatureOrder, features) {
 		switch feature {
 		case "bb_position":
 			bbUpper := extractIndicator(candles, func(ind models.Indicators) *float64 { return ind.BollingerUpper })
@@ -452,10 +620,37 @@ func (e *MLFeatureEngine) addCrossFeatures(matrix *models.FeatureMatrix, candles
 	}
 }
 
+// addDerivativesFeatures adds the funding rate and open interest joined onto
+// the candles. Candles without a joined value get NaN.
+func (e *MLFeatureEngine) addDerivativesFeatures(matrix *models.FeatureMatrix, candles []models.Candle, features []string) {
+	for _, feature := range canonicalSelection(derivativesFeatureOrder, features) {
+		var field func(d *models.DerivativesData) *float64
+		switch feature {
+		case "funding_rate":
+			field = func(d *models.DerivativesData) *float64 { return d.FundingRate }
+		case "open_interest":
+			field = func(d *models.DerivativesData) *float64 { return d.OpenInterest }
+		case "open_interest_value":
+			field = func(d *models.DerivativesData) *float64 { return d.OpenInterestValue }
+		}
+
+		values := make([]float64, len(candles))
+		for i := range candles {
+			values[i] = math.NaN()
+			if d := candles[i].Derivatives; d != nil {
+				if v := field(d); v != nil {
+					values[i] = *v
+				}
+			}
+		}
+		e.addColumn(matrix, feature, "float64", "derivatives", values)
+	}
+}
+
 // addLaggedFeatures adds lagged versions of features
-func (e *MLFeatureEngine) addLaggedFeatures(matrix *models.FeatureMatrix, config models.LagConfig) {
+func (e *MLFeatureEngine) addLaggedFeatures(ctx context.Context, matrix *models.FeatureMatrix, config models.LagConfig) error {
 	if len(config.LagPeriods) == 0 {
-		return
+		return nil
 	}
 
 	// Determine which columns to lag
@@ -475,12 +670,13 @@ func (e *MLFeatureEngine) addLaggedFeatures(matrix *models.FeatureMatrix, config
 		colIndices[col] = i
 	}
 
-	// Add lagged features
-	for _, col := range columnsToLag {
-		idx, ok := colIndices[col]
-		if !ok {
-			continue
+	// Add lagged features, in column order so the config's list order doesn't matter
+	lagPeriods := sortedUnique(config.LagPeriods)
+	for _, col := range byColumnPosition(matrix, columnsToLag) {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		idx := colIndices[col]
 
 		// Extract column values
 		values := make([]float64, len(matrix.Data))
@@ -489,17 +685,19 @@ func (e *MLFeatureEngine) addLaggedFeatures(matrix *models.FeatureMatrix, config
 		}
 
 		// Create lagged versions
-		for _, lag := range config.LagPeriods {
+		for _, lag := range lagPeriods {
 			laggedValues := e.lagSeries(values, lag)
 			e.addColumn(matrix, fmt.Sprintf("%s_lag_%d", col, lag), "float64", "lagged", laggedValues)
 		}
 	}
+
+	return nil
 }
 
 // addRollingFeatures adds rolling statistics
-func (e *MLFeatureEngine) addRollingFeatures(matrix *models.FeatureMatrix, config models.RollingConfig) {
+func (e *MLFeatureEngine) addRollingFeatures(ctx context.Context, matrix *models.FeatureMatrix, config models.RollingConfig) error {
 	if len(config.Windows) == 0 || len(config.Stats) == 0 {
-		return
+		return nil
 	}
 
 	// Determine which columns to use
@@ -515,12 +713,11 @@ func (e *MLFeatureEngine) addRollingFeatures(matrix *models.FeatureMatrix, confi
 		colIndices[col] = i
 	}
 
-	// Add rolling features
-	for _, col := range columnsToRoll {
-		idx, ok := colIndices[col]
-		if !ok {
-			continue
-		}
+	// Add rolling features, in canonical order so the config's list order doesn't matter
+	windows := sortedUnique(config.Windows)
+	stats := canonicalSelection(rollingStatOrder, config.Stats)
+	for _, col := range byColumnPosition(matrix, columnsToRoll) {
+		idx := colIndices[col]
 
 		// Extract column values
 		values := make([]float64, len(matrix.Data))
@@ -529,8 +726,13 @@ func (e *MLFeatureEngine) addRollingFeatures(matrix *models.FeatureMatrix, confi
 		}
 
 		// Create rolling stats for each window
-		for _, window := range config.Windows {
-			for _, stat := range config.Stats {
+		for _, window := range windows {
+			for _, stat := range stats {
+				// Rolling stats over long series are the slowest features
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
 				var rolledValues []float64
 
 				switch stat {
@@ -552,6 +754,8 @@ func (e *MLFeatureEngine) addRollingFeatures(matrix *models.FeatureMatrix, confi
 			}
 		}
 	}
+
+	return nil
 }
 
 // ==================== Price Feature Calculations ====================
@@ -584,6 +788,33 @@ func (e *MLFeatureEngine) calculateLogReturns(closes []float64) []float64 {
 	return result
 }
 
+// calculateFracDiff applies the fixed-width window fractional difference to the
+// log closes: each value is the weighted sum of the last len(weights) log
+// closes, newest first. The warm-up bars before a full window, and windows
+// with a non-positive close, are NaN.
+func (e *MLFeatureEngine) calculateFracDiff(closes []float64, weights []float64) []float64 {
+	result := make([]float64, len(closes))
+	width := len(weights)
+
+	for i := range closes {
+		if i < width-1 {
+			result[i] = math.NaN()
+			continue
+		}
+		sum := 0.0
+		for k, w := range weights {
+			c := closes[i-k]
+			if c <= 0 {
+				sum = math.NaN()
+				break
+			}
+			sum += w * math.Log(c)
+		}
+		result[i] = sum
+	}
+	return result
+}
+
 func (e *MLFeatureEngine) calculatePriceChange(closes []float64) []float64 {
 	result := make([]float64, len(closes))
 	result[0] = 0
@@ -667,7 +898,7 @@ func (e *MLFeatureEngine) calculateFutureReturns(closes []float64, period int) [
 		}
 	}
 	// Fill remaining with NaN
-	for i := len(closes) - period; i < len(closes); i++ {
+	for i := max(len(closes)-period, 0); i < len(closes); i++ {
 		result[i] = math.NaN()
 	}
 	return result
@@ -682,7 +913,7 @@ func (e *MLFeatureEngine) calculateFutureDirection(closes []float64, period int)
 			result[i] = 0
 		}
 	}
-	for i := len(closes) - period; i < len(closes); i++ {
+	for i := max(len(closes)-period, 0); i < len(closes); i++ {
 		result[i] = math.NaN()
 	}
 	return result
@@ -724,7 +955,7 @@ func (e *MLFeatureEngine) calculateFutureVolatility(highs, lows, closes []float6
 		result[i] = sum / float64(period)
 	}
 
-	for i := len(closes) - period; i < len(closes); i++ {
+	for i := max(len(closes)-period, 0); i < len(closes); i++ {
 		result[i] = math.NaN()
 	}
 	return result
@@ -809,7 +1040,7 @@ func (e *MLFeatureEngine) calculateRSIOverbought(rsi []float64) []float64 {
 
 func (e *MLFeatureEngine) lagSeries(values []float64, lag int) []float64 {
 	result := make([]float64, len(values))
-	for i := 0; i < lag; i++ {
+	for i := 0; i < lag && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := lag; i < len(values); i++ {
@@ -820,7 +1051,7 @@ func (e *MLFeatureEngine) lagSeries(values []float64, lag int) []float64 {
 
 func (e *MLFeatureEngine) rollingMean(values []float64, window int) []float64 {
 	result := make([]float64, len(values))
-	for i := 0; i < window-1; i++ {
+	for i := 0; i < window-1 && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := window - 1; i < len(values); i++ {
@@ -845,7 +1076,7 @@ func (e *MLFeatureEngine) rollingStd(values []float64, window int) []float64 {
 	means := e.rollingMean(values, window)
 	result := make([]float64, len(values))
 
-	for i := 0; i < window-1; i++ {
+	for i := 0; i < window-1 && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := window - 1; i < len(values); i++ {
@@ -874,7 +1105,7 @@ func (e *MLFeatureEngine) rollingStd(values []float64, window int) []float64 {
 
 func (e *MLFeatureEngine) rollingMin(values []float64, window int) []float64 {
 	result := make([]float64, len(values))
-	for i := 0; i < window-1; i++ {
+	for i := 0; i < window-1 && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := window - 1; i < len(values); i++ {
@@ -895,7 +1126,7 @@ func (e *MLFeatureEngine) rollingMin(values []float64, window int) []float64 {
 
 func (e *MLFeatureEngine) rollingMax(values []float64, window int) []float64 {
 	result := make([]float64, len(values))
-	for i := 0; i < window-1; i++ {
+	for i := 0; i < window-1 && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := window - 1; i < len(values); i++ {
@@ -916,7 +1147,7 @@ func (e *MLFeatureEngine) rollingMax(values []float64, window int) []float64 {
 
 func (e *MLFeatureEngine) rollingMedian(values []float64, window int) []float64 {
 	result := make([]float64, len(values))
-	for i := 0; i < window-1; i++ {
+	for i := 0; i < window-1 && i < len(values); i++ {
 		result[i] = math.NaN()
 	}
 	for i := window - 1; i < len(values); i++ {
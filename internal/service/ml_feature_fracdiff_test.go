@@ -0,0 +1,54 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestFracDiffWeights(t *testing.T) {
+	weights := (&models.FracDiffConfig{D: 0.5, Threshold: 0.05}).Weights()
+	// 1, -0.5, -0.125, -0.0625; the next, -0.0390625, is below the threshold
+	want := []float64{1, -0.5, -0.125, -0.0625}
+	if len(weights) != len(want) {
+		t.Fatalf("weights = %v, want %v", weights, want)
+	}
+	for i := range want {
+		if math.Abs(weights[i]-want[i]) > 1e-12 {
+			t.Errorf("weight %d = %v, want %v", i, weights[i], want[i])
+		}
+	}
+
+	if err := (&models.FracDiffConfig{D: 1}).Validate(); err == nil {
+		t.Error("d = 1 should be invalid")
+	}
+	if err := (&models.FracDiffConfig{D: -0.2}).Validate(); err == nil {
+		t.Error("d < 0 should be invalid")
+	}
+}
+
+func TestCalculateFracDiff(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	closes := []float64{1, math.E, math.E * math.E, math.E * math.E * math.E}
+	weights := []float64{1, -0.5, -0.125}
+
+	values := engine.calculateFracDiff(closes, weights)
+	for i := 0; i < 2; i++ {
+		if !math.IsNaN(values[i]) {
+			t.Errorf("warm-up value %d = %v, want NaN", i, values[i])
+		}
+	}
+	// log closes are 0, 1, 2, 3
+	if want := 2 - 0.5*1 - 0.125*0; math.Abs(values[2]-want) > 1e-12 {
+		t.Errorf("value 2 = %v, want %v", values[2], want)
+	}
+	if want := 3 - 0.5*2 - 0.125*1; math.Abs(values[3]-want) > 1e-12 {
+		t.Errorf("value 3 = %v, want %v", values[3], want)
+	}
+
+	// With d = 1 and a single lag the fractional difference is the log return
+	if got := engine.calculateFracDiff(closes, []float64{1, -1}); math.Abs(got[3]-1) > 1e-12 {
+		t.Errorf("first difference = %v, want 1", got[3])
+	}
+}
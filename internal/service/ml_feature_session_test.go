@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func sessionTestCandles() []models.Candle {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	candles := make([]models.Candle, 24)
+	for i := range candles {
+		candles[i] = models.Candle{Timestamp: start.Add(time.Duration(i) * time.Hour).UnixMilli(), Open: 1, High: 1, Low: 1, Close: 1}
+	}
+	return candles
+}
+
+func TestMarketSessionFeature(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	config := models.FeatureConfig{TemporalFeatures: []string{"market_session", "hour"}}
+
+	matrix, err := engine.GenerateFeatures(context.Background(), sessionTestCandles(), config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"hour", "market_session"}; !reflect.DeepEqual(matrix.Columns, want) {
+		t.Fatalf("columns = %v, want %v", matrix.Columns, want)
+	}
+	if !reflect.DeepEqual(matrix.Schema[1].Categories, models.MarketSessionLegend) {
+		t.Errorf("legend = %v", matrix.Schema[1].Categories)
+	}
+	for _, row := range matrix.Data {
+		hour := int(row[0])
+		if want := float64(config.MarketSessions.SessionAt(hour)); row[1] != want {
+			t.Errorf("hour %d session = %v, want %v", hour, row[1], want)
+		}
+	}
+}
+
+func TestMarketSessionFeatureOneHot(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	config := models.FeatureConfig{
+		TemporalFeatures: []string{"market_session"},
+		MarketSessions:   &models.MarketSessionConfig{OneHot: true},
+	}
+
+	matrix, err := engine.GenerateFeatures(context.Background(), sessionTestCandles(), config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"market_session_off_hours", "market_session_asian", "market_session_european",
+		"market_session_us", "market_session_overlap",
+	}
+	if !reflect.DeepEqual(matrix.Columns, want) {
+		t.Fatalf("columns = %v, want %v", matrix.Columns, want)
+	}
+	for r, row := range matrix.Data {
+		sum := 0.0
+		for _, v := range row {
+			sum += v
+		}
+		if sum != 1 {
+			t.Errorf("row %d = %v, want exactly one session set", r, row)
+		}
+	}
+}
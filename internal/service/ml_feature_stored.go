@@ -0,0 +1,135 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// storedIndicatorField is an exportable indicator and its field in models.Indicators
+type storedIndicatorField struct {
+	name     string
+	category string
+	index    int
+}
+
+// storedIndicatorFields lists the exportable indicators in Indicators field
+// order, which is also the column order of addIndicatorFeatures
+var storedIndicatorFields = func() []storedIndicatorField {
+	t := reflect.TypeOf(models.Indicators{})
+	var fields []storedIndicatorField
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("bson"), ",")[0]
+		if ind, ok := indicatorWarmup[name]; ok {
+			fields = append(fields, storedIndicatorField{name: name, category: ind.category, index: i})
+		}
+	}
+	return fields
+}()
+
+// storedColumnsOnly reports whether the config only selects OHLCV and stored
+// indicator columns, so nothing has to be derived from neighbouring rows
+func storedColumnsOnly(config models.FeatureConfig) bool {
+	return len(config.PriceFeatures) == 0 &&
+		len(config.TemporalFeatures) == 0 &&
+		len(config.CrossFeatures) == 0 &&
+		len(config.DerivativesFeatures) == 0 &&
+		!config.LaggedFeatures.Enabled &&
+		!config.RollingFeatures.Enabled
+}
+
+// projectStoredColumns builds the feature matrix straight from the sorted
+// candles' stored values. It produces the same columns as the full path, but
+// fills each row once in a single allocation instead of extracting a slice per
+// feature and appending it to every row.
+func (e *MLFeatureEngine) projectStoredColumns(candles []models.Candle, config models.FeatureConfig) *models.FeatureMatrix {
+	type column struct {
+		name   string
+		source string
+		value  func(c *models.Candle) float64
+	}
+
+	var columns []column
+	if config.IncludeTimestamp {
+		columns = append(columns, column{"timestamp", "ohlcv", func(c *models.Candle) float64 { return float64(c.Timestamp) }})
+	}
+	if config.IncludeOHLCV {
+		columns = append(columns,
+			column{"open", "ohlcv", func(c *models.Candle) float64 { return c.Open }},
+			column{"high", "ohlcv", func(c *models.Candle) float64 { return c.High }},
+			column{"low", "ohlcv", func(c *models.Candle) float64 { return c.Low }},
+			column{"close", "ohlcv", func(c *models.Candle) float64 { return c.Close }},
+		)
+	}
+	if config.IncludeVolume {
+		columns = append(columns, column{"volume", "ohlcv", func(c *models.Candle) float64 { return c.Volume }})
+	}
+	baseCount := len(columns)
+
+	var indicatorFields []storedIndicatorField
+	for _, field := range storedIndicatorFields {
+		if includeIndicator(config, field.name, field.category) {
+			indicatorFields = append(indicatorFields, field)
+		}
+	}
+
+	rows := len(candles)
+	cols := baseCount + len(indicatorFields)
+
+	matrix := &models.FeatureMatrix{
+		Columns:     make([]string, 0, cols),
+		Data:        make([][]float64, rows),
+		Timestamps:  make([]int64, rows),
+		RowCount:    rows,
+		ColumnCount: cols,
+		Schema:      make([]models.FeatureSchema, 0, cols),
+	}
+
+	// Rows share one backing array; the capped capacity makes later appends
+	// (targets) copy the row instead of overwriting the next one
+	backing := make([]float64, rows*cols)
+	for i := range candles {
+		c := &candles[i]
+		row := backing[i*cols : (i+1)*cols : (i+1)*cols]
+
+		for j := 0; j < baseCount; j++ {
+			row[j] = columns[j].value(c)
+		}
+
+		ind := reflect.ValueOf(&c.Indicators).Elem()
+		for j, field := range indicatorFields {
+			if ptr := ind.Field(field.index); !ptr.IsNil() {
+				row[baseCount+j] = ptr.Elem().Float()
+			} else {
+				row[baseCount+j] = math.NaN()
+			}
+		}
+
+		matrix.Data[i] = row
+		matrix.Timestamps[i] = c.Timestamp
+	}
+
+	for _, col := range columns {
+		matrix.Columns = append(matrix.Columns, col.name)
+	}
+	for _, field := range indicatorFields {
+		matrix.Columns = append(matrix.Columns, field.name)
+	}
+
+	// Schema stats are computed per column, reusing one buffer
+	values := make([]float64, rows)
+	for j, name := range matrix.Columns {
+		source := "indicator"
+		if j < baseCount {
+			source = columns[j].source
+		}
+		for i, row := range matrix.Data {
+			values[i] = row[j]
+		}
+		matrix.Schema = append(matrix.Schema, columnSchema(name, "float64", source, values))
+	}
+
+	return matrix
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// storedFeatureCandles builds n one-minute candles with every exportable
+// indicator set except on every seventh candle, newest first
+func storedFeatureCandles(n int) []models.Candle {
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		price := 100 + float64(i%50)
+		c := models.Candle{
+			Timestamp: int64(1700000000000 + (n-1-i)*60000),
+			Open:      price,
+			High:      price + 2,
+			Low:       price - 1,
+			Close:     price + 1,
+			Volume:    float64(10 + i%7),
+		}
+		if i%7 != 0 {
+			ind := reflect.ValueOf(&c.Indicators).Elem()
+			for _, field := range storedIndicatorFields {
+				v := price + float64(field.index)
+				ind.Field(field.index).Set(reflect.ValueOf(&v))
+			}
+		}
+		candles[i] = c
+	}
+	return candles
+}
+
+func storedFeatureConfig() models.FeatureConfig {
+	return models.FeatureConfig{
+		IncludeOHLCV:         true,
+		IncludeVolume:        true,
+		IncludeTimestamp:     true,
+		IncludeAllIndicators: true,
+		ExcludeIndicators:    []string{"vwap"},
+	}
+}
+
+func TestProjectStoredColumnsMatchesFullPath(t *testing.T) {
+	engine := NewMLFeatureEngine()
+	config := storedFeatureConfig()
+
+	fast, err := engine.GenerateFeatures(context.Background(), storedFeatureCandles(100), config, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Build the full path from the same ascending candles
+	candles := storedFeatureCandles(100)
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+	full, err := engine.buildFeatures(context.Background(), candles, config, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fast.Columns, full.Columns) {
+		t.Fatalf("columns differ:\nfast: %v\nfull: %v", fast.Columns, full.Columns)
+	}
+	if !reflect.DeepEqual(fast.Timestamps, full.Timestamps) {
+		t.Fatal("timestamps differ")
+	}
+	for i := range full.Data {
+		for j := range full.Data[i] {
+			a, b := fast.Data[i][j], full.Data[i][j]
+			if a != b && !(math.IsNaN(a) && math.IsNaN(b)) {
+				t.Fatalf("row %d column %s: fast %v, full %v", i, full.Columns[j], a, b)
+			}
+		}
+	}
+	for j := range full.Schema {
+		if fast.Schema[j].Name != full.Schema[j].Name || fast.Schema[j].Source != full.Schema[j].Source ||
+			fast.Schema[j].NaNCount != full.Schema[j].NaNCount || fast.Schema[j].Mean != full.Schema[j].Mean {
+			t.Errorf("schema %d differs: fast %+v, full %+v", j, fast.Schema[j], full.Schema[j])
+		}
+	}
+
+	// Appending a target column must not overwrite the next row
+	engine.addColumn(fast, "extra", "float64", "target", make([]float64, fast.RowCount))
+	if fast.Data[1][0] != full.Data[1][0] {
+		t.Error("appending a column corrupted the next row")
+	}
+}
+
+func TestStoredColumnsOnly(t *testing.T) {
+	config := storedFeatureConfig()
+	if !storedColumnsOnly(config) {
+		t.Error("expected OHLCV and indicator config to take the stored path")
+	}
+
+	config.LaggedFeatures.Enabled = true
+	if storedColumnsOnly(config) {
+		t.Error("lagged features must take the full path")
+	}
+
+	config = storedFeatureConfig()
+	config.PriceFeatures = []string{"returns"}
+	if storedColumnsOnly(config) {
+		t.Error("price features must take the full path")
+	}
+}
+
+func BenchmarkGenerateFeaturesStored(b *testing.B) {
+	engine := NewMLFeatureEngine()
+	candles := storedFeatureCandles(100000)
+	config := storedFeatureConfig()
+
+	b.Run("stored", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			engine.projectStoredColumns(candles, config)
+		}
+	})
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			engine.buildFeatures(context.Background(), candles, config, "")
+		}
+	})
+}
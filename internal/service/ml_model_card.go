@@ -0,0 +1,225 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// BuildModelCard packages a completed export's stored metadata and config into a data card
+func BuildModelCard(exportJob *models.MLExportJob) *models.MLModelCard {
+	return &models.MLModelCard{
+		ExportJobID:   exportJob.ID.Hex(),
+		Name:          exportJob.Config.Name,
+		Description:   exportJob.Config.Description,
+		Format:        exportJob.Config.Format,
+		CreatedAt:     exportJob.CreatedAt,
+		CompletedAt:   exportJob.CompletedAt,
+		RowCount:      exportJob.RowCount,
+		FeatureCount:  exportJob.FeatureCount,
+		FileSizeBytes: exportJob.FileSizeBytes,
+		Checksum:      exportJob.Checksum,
+		Metadata:      exportJob.Metadata,
+		Config:        exportJob.Config,
+	}
+}
+
+// RenderModelCardMarkdown renders a data card as a Markdown document
+func RenderModelCardMarkdown(card *models.MLModelCard) string {
+	var b strings.Builder
+	meta := card.Metadata
+
+	title := card.Name
+	if title == "" {
+		title = "Export " + card.ExportJobID
+	}
+	fmt.Fprintf(&b, "# Data Card: %s\n\n", title)
+	if card.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", card.Description)
+	}
+
+	// Overview
+	b.WriteString("## Overview\n\n")
+	b.WriteString("| Property | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Export job | `%s` |\n", card.ExportJobID)
+	fmt.Fprintf(&b, "| Format | %s |\n", card.Format)
+	fmt.Fprintf(&b, "| Exported at | %s |\n", formatCardTime(meta.ExportedAt))
+	fmt.Fprintf(&b, "| Rows | %d |\n", card.RowCount)
+	fmt.Fprintf(&b, "| Features | %d |\n", card.FeatureCount)
+	fmt.Fprintf(&b, "| File size | %d bytes |\n", card.FileSizeBytes)
+	if card.Checksum != "" {
+		fmt.Fprintf(&b, "| SHA-256 | `%s` |\n", card.Checksum)
+	}
+	if meta.Version != "" {
+		fmt.Fprintf(&b, "| Metadata version | %s |\n", meta.Version)
+	}
+	b.WriteString("\n")
+
+	// Data range and sources
+	b.WriteString("## Data Range\n\n")
+	fmt.Fprintf(&b, "- **From:** %s\n", formatCardTime(meta.DataRange.StartTime))
+	fmt.Fprintf(&b, "- **To:** %s\n", formatCardTime(meta.DataRange.EndTime))
+	fmt.Fprintf(&b, "- **Total bars:** %d\n", meta.DataRange.TotalBars)
+	fmt.Fprintf(&b, "- **Exchanges:** %s\n", strings.Join(meta.DataRange.Exchanges, ", "))
+	fmt.Fprintf(&b, "- **Symbols:** %s\n", strings.Join(meta.DataRange.Symbols, ", "))
+	fmt.Fprintf(&b, "- **Timeframes:** %s\n\n", strings.Join(meta.DataRange.Timeframes, ", "))
+
+	b.WriteString("## Source Jobs\n\n")
+	b.WriteString("| Job | Exchange | Symbol | Timeframe | Bars | From | To |\n|---|---|---|---|---|---|---|\n")
+	for _, src := range meta.SourceJobs {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %d | %s | %s |\n",
+			src.JobID.Hex(), src.ExchangeID, src.Symbol, src.Timeframe, src.BarCount,
+			formatCardTime(src.StartTime), formatCardTime(src.EndTime))
+	}
+	b.WriteString("\n")
+
+	if len(meta.SkippedSources) > 0 {
+		b.WriteString("Skipped for having too few bars:\n\n")
+		for _, src := range meta.SkippedSources {
+			fmt.Fprintf(&b, "- `%s` %s %s: %d of %d required bars\n",
+				src.JobID.Hex(), src.Symbol, src.Timeframe, src.BarCount, src.RequiredBars)
+		}
+		b.WriteString("\n")
+	}
+
+	if meta.Coverage != nil {
+		fmt.Fprintf(&b, "Source coverage: **%s** (%.0f%% of the combined range is shared)\n\n",
+			meta.Coverage.Status, meta.Coverage.OverlapRatio*100)
+		for _, warning := range meta.Coverage.Warnings {
+			fmt.Fprintf(&b, "- %s\n", warning)
+		}
+		if len(meta.Coverage.Warnings) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	// Feature schema
+	b.WriteString("## Features\n\n")
+	b.WriteString("| Name | Source | Type | NaN % | Min | Max | Mean | Std | Description |\n|---|---|---|---|---|---|---|---|---|\n")
+	for _, f := range meta.FeatureSchema {
+		fmt.Fprintf(&b, "| %s | %s | %s | %.2f | %s | %s | %s | %s | %s |\n",
+			f.Name, f.Source, f.Type, f.NaNPercent,
+			formatCardFloat(f.Min), formatCardFloat(f.Max), formatCardFloat(f.Mean), formatCardFloat(f.Std),
+			f.Description)
+	}
+	b.WriteString("\n")
+
+	if len(meta.Targets) > 0 {
+		b.WriteString("## Targets\n\n")
+		b.WriteString("| Column | Type | Lookahead | Bin mode | Bin edges |\n|---|---|---|---|---|\n")
+		for _, t := range meta.Targets {
+			edges := make([]string, len(t.ClassificationBins))
+			for i, edge := range t.ClassificationBins {
+				edges[i] = formatCardFloat(edge)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %s | %s |\n",
+				t.Column, t.Type, t.LookaheadPeriod, valueOr(string(t.BinMode), "-"), valueOr(strings.Join(edges, ", "), "-"))
+		}
+		b.WriteString("\n")
+	}
+
+	// Preprocessing
+	b.WriteString("## Preprocessing\n\n")
+	pre := card.Config.Preprocessing
+	fmt.Fprintf(&b, "- **Normalization:** %s\n", valueOr(string(pre.Normalization), "none"))
+	fmt.Fprintf(&b, "- **NaN handling:** %s\n", valueOr(string(pre.NaNHandling), "none"))
+	fmt.Fprintf(&b, "- **Remove NaN rows:** %t\n", pre.RemoveNaNRows)
+	if pre.RemoveNaNRows && pre.MaxNaNFraction > 0 {
+		fmt.Fprintf(&b, "- **Max NaN fraction per row:** %g\n", pre.MaxNaNFraction)
+	}
+	if pre.MaxColNaNFraction > 0 {
+		fmt.Fprintf(&b, "- **Max NaN fraction per column:** %g\n", pre.MaxColNaNFraction)
+	}
+	if pre.ClipOutliers {
+		if pre.ClipMethod == models.ClipMethodPercentile {
+			lower, upper := pre.ClipPercentiles()
+			fmt.Fprintf(&b, "- **Outlier clipping:** percentiles %g to %g of the training rows\n", lower, upper)
+		} else {
+			fmt.Fprintf(&b, "- **Outlier clipping:** %g std devs\n", pre.OutlierStdDev)
+		}
+	}
+	for _, col := range meta.DroppedColumns {
+		fmt.Fprintf(&b, "- Dropped column `%s`: %s\n", col.Name, col.Reason)
+	}
+	if rows := meta.DroppedRows; rows != nil {
+		fmt.Fprintf(&b, "- Dropped %d rows (%s to %s): %s\n", rows.Count,
+			formatCardTime(time.UnixMilli(rows.FirstTimestamp)), formatCardTime(time.UnixMilli(rows.LastTimestamp)), rows.Reason)
+	}
+	b.WriteString("\n")
+
+	if len(meta.NormalizationParams) > 0 {
+		b.WriteString("### Normalization Parameters\n\n")
+		b.WriteString("| Feature | Method | Mean | Std | Min | Max | Median | IQR |\n|---|---|---|---|---|---|---|---|\n")
+		names := make([]string, 0, len(meta.NormalizationParams))
+		for name := range meta.NormalizationParams {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := meta.NormalizationParams[name]
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				name, p.Method, formatCardFloat(p.Mean), formatCardFloat(p.Std),
+				formatCardFloat(p.Min), formatCardFloat(p.Max), formatCardFloat(p.Median), formatCardFloat(p.IQR))
+		}
+		b.WriteString("\n")
+	}
+
+	// Split
+	if split := meta.SplitInfo; split != nil {
+		b.WriteString("## Split\n\n")
+		b.WriteString("| Split | Rows | From | To |\n|---|---|---|---|\n")
+		fmt.Fprintf(&b, "| train | %d | %s | %s |\n", split.TrainRows, formatCardTime(split.TrainStart), formatCardTime(split.TrainEnd))
+		fmt.Fprintf(&b, "| validation | %d | %s | %s |\n", split.ValRows, formatCardTime(split.ValStart), formatCardTime(split.ValEnd))
+		fmt.Fprintf(&b, "| test | %d | %s | %s |\n", split.TestRows, formatCardTime(split.TestStart), formatCardTime(split.TestEnd))
+		if split.ShuffleSeed != nil {
+			fmt.Fprintf(&b, "\nTraining rows carry a `shuffle_index` column (seed %d).\n", *split.ShuffleSeed)
+		}
+		b.WriteString("\n")
+	}
+
+	// Sequences
+	if seq := meta.SequenceInfo; seq != nil {
+		b.WriteString("## Sequences\n\n")
+		fmt.Fprintf(&b, "- **Length:** %d\n", seq.Length)
+		fmt.Fprintf(&b, "- **Stride:** %d\n", seq.Stride)
+		fmt.Fprintf(&b, "- **Total sequences:** %d (train %d, validation %d, test %d)\n\n",
+			seq.TotalSequences, seq.TrainSequences, seq.ValSequences, seq.TestSequences)
+	}
+
+	// Config
+	b.WriteString("## Export Configuration\n\n")
+	if configJSON, err := json.MarshalIndent(card.Config, "", "  "); err == nil {
+		fmt.Fprintf(&b, "```json\n%s\n```\n", configJSON)
+	}
+
+	return b.String()
+}
+
+// formatCardTime formats a time for the data card, or "-" if unset
+func formatCardTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatCardFloat formats a statistic compactly, keeping NaN/Inf readable
+func formatCardFloat(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("%.6g", v)
+}
+
+// valueOr returns value, or fallback if value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
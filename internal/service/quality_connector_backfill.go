@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// enqueueBackfill queues a backfill job on its connector's backfill worker,
+// starting the worker if it isn't running. Each connector has at most one
+// worker, which runs its backfills one after another, so the exchange sees a
+// single stream of requests paced by the connector's rate limiter instead of
+// one per backfilling job.
+func (s *QualityService) enqueueBackfill(exchangeID, backfillJobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, running := s.backfillQueues[exchangeID]
+	s.backfillQueues[exchangeID] = append(queue, backfillJobID)
+	if !running {
+		go s.runBackfillQueue(exchangeID)
+	}
+}
+
+// runBackfillQueue processes a connector's queued backfill jobs in order until
+// the queue is empty
+func (s *QualityService) runBackfillQueue(exchangeID string) {
+	for {
+		s.mu.Lock()
+		queue := s.backfillQueues[exchangeID]
+		if len(queue) == 0 {
+			delete(s.backfillQueues, exchangeID)
+			s.mu.Unlock()
+			return
+		}
+		backfillJobID := queue[0]
+		s.backfillQueues[exchangeID] = queue[1:]
+		s.mu.Unlock()
+
+		s.processBackfill(backfillJobID)
+	}
+}
+
+// StartConnectorBackfill queues a backfill for every OHLCV job of a connector
+// on the connector's backfill worker. Jobs that already have an active
+// backfill, or whose backfill can't be created, are reported as skipped.
+func (s *QualityService) StartConnectorBackfill(ctx context.Context, connectorID string, monthsBack int, targetDateStr string) (*models.ConnectorBackfillStatus, error) {
+	connector, err := s.connectorRepo.FindByID(ctx, connectorID)
+	if err != nil || connector == nil {
+		return nil, fmt.Errorf("connector not found")
+	}
+
+	targetStartDate, err := backfillTargetDate(monthsBack, targetDateStr)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.FindByConnector(ctx, connector.ExchangeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connector jobs: %w", err)
+	}
+
+	connectorBackfillID := primitive.NewObjectID()
+	var backfillJobs []*models.BackfillJob
+	var skipped []models.ConnectorBackfillSkip
+	skip := func(job *models.Job, reason string) {
+		skipped = append(skipped, models.ConnectorBackfillSkip{
+			JobID:     job.ID,
+			Symbol:    job.Symbol,
+			Timeframe: job.Timeframe,
+			Reason:    reason,
+		})
+	}
+
+	for _, job := range jobs {
+		if job.GetJobType() != models.JobTypeOHLCV {
+			skip(job, fmt.Sprintf("%s jobs have no history to backfill", job.GetJobType()))
+			continue
+		}
+
+		existing, err := s.qualityRepo.FindActiveBackfillJobForJob(ctx, job.ID)
+		if err != nil {
+			skip(job, err.Error())
+			continue
+		}
+		if existing != nil {
+			skip(job, fmt.Sprintf("backfill %s is already active", existing.ID.Hex()))
+			continue
+		}
+
+		backfillJob, err := s.createBackfillJob(ctx, job, targetStartDate, &connectorBackfillID)
+		if err != nil {
+			skip(job, err.Error())
+			continue
+		}
+		backfillJobs = append(backfillJobs, backfillJob)
+	}
+
+	// Queued only once all are created, so the status lists every job from the start
+	for _, backfillJob := range backfillJobs {
+		s.enqueueBackfill(connector.ExchangeID, backfillJob.ID.Hex())
+	}
+
+	logging.Printf(ctx, "[BACKFILL] Queued %d backfills for connector %s (%d jobs skipped)",
+		len(backfillJobs), connector.ExchangeID, len(skipped))
+
+	status := models.SummarizeConnectorBackfill(connectorBackfillID, connector.ExchangeID, backfillJobs)
+	status.Skipped = skipped
+	return status, nil
+}
+
+// GetConnectorBackfillStatus reports the progress of a connector's most recent
+// connector backfill, or nil if it never had one
+func (s *QualityService) GetConnectorBackfillStatus(ctx context.Context, connectorID string) (*models.ConnectorBackfillStatus, error) {
+	connector, err := s.connectorRepo.FindByID(ctx, connectorID)
+	if err != nil || connector == nil {
+		return nil, fmt.Errorf("connector not found")
+	}
+
+	connectorBackfillID, err := s.qualityRepo.FindLatestConnectorBackfillID(ctx, connector.ExchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if connectorBackfillID == nil {
+		return nil, nil
+	}
+
+	backfillJobs, err := s.qualityRepo.FindBackfillJobsForConnectorBackfill(ctx, *connectorBackfillID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.SummarizeConnectorBackfill(*connectorBackfillID, connector.ExchangeID, backfillJobs), nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// qualityRefreshTimeout bounds a single background quality analysis
+const qualityRefreshTimeout = 2 * time.Minute
+
+// QualityRefresher re-analyzes a job's cached quality shortly after new data
+// is stored for it, so the quality view doesn't wait for the hourly check.
+// Writes are debounced per job: each one restarts the job's delay, and the
+// analysis runs once the job has gone that long without a write.
+type QualityRefresher struct {
+	analyze func(ctx context.Context, job *models.Job) (*models.DataQualityResult, error)
+	delay   time.Duration
+
+	mu      sync.Mutex
+	pending map[primitive.ObjectID]*time.Timer
+	stopped bool
+}
+
+// NewQualityRefresher creates a refresher analyzing jobs with the quality
+// service after delay without writes
+func NewQualityRefresher(qualityService *QualityService, delay time.Duration) *QualityRefresher {
+	return &QualityRefresher{
+		analyze: qualityService.AnalyzeJob,
+		delay:   delay,
+		pending: make(map[primitive.ObjectID]*time.Timer),
+	}
+}
+
+// Schedule queues a quality analysis of the job, replacing one already
+// queued. It never blocks on the analysis itself.
+func (r *QualityRefresher) Schedule(job *models.Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+
+	// The job may be changed by the caller after this returns
+	snapshot := *job
+	if timer, ok := r.pending[job.ID]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(r.delay, func() {
+		r.mu.Lock()
+		if r.pending[snapshot.ID] != timer {
+			// Replaced by a later write after it fired
+			r.mu.Unlock()
+			return
+		}
+		delete(r.pending, snapshot.ID)
+		r.mu.Unlock()
+
+		r.refresh(&snapshot)
+	})
+	r.pending[job.ID] = timer
+}
+
+// refresh runs the analysis, logging rather than returning failures since
+// nobody waits on it
+func (r *QualityRefresher) refresh(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), qualityRefreshTimeout)
+	defer cancel()
+
+	result, err := r.analyze(ctx, job)
+	if err != nil {
+		log.Printf("[QUALITY_REFRESH] Failed to refresh quality for job %s: %v", job.ID.Hex(), err)
+		return
+	}
+	log.Printf("[QUALITY_REFRESH] Refreshed quality for job %s (%s %s): %s, %.1f%% complete",
+		job.ID.Hex(), job.Symbol, job.Timeframe, result.QualityStatus, result.CompletenessScore)
+}
+
+// Stop drops the queued analyses; later writes are ignored
+func (r *QualityRefresher) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	for id, timer := range r.pending {
+		timer.Stop()
+		delete(r.pending, id)
+	}
+}
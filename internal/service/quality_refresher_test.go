@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// countingRefresher returns a refresher with a short delay whose analyses are
+// counted per job instead of run
+func countingRefresher() (*QualityRefresher, func(primitive.ObjectID) int) {
+	var mu sync.Mutex
+	counts := make(map[primitive.ObjectID]int)
+	r := &QualityRefresher{
+		analyze: func(ctx context.Context, job *models.Job) (*models.DataQualityResult, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			counts[job.ID]++
+			return &models.DataQualityResult{}, nil
+		},
+		delay:   20 * time.Millisecond,
+		pending: make(map[primitive.ObjectID]*time.Timer),
+	}
+	return r, func(id primitive.ObjectID) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[id]
+	}
+}
+
+func TestQualityRefresherDebounces(t *testing.T) {
+	r, count := countingRefresher()
+	a := &models.Job{ID: primitive.NewObjectID()}
+	b := &models.Job{ID: primitive.NewObjectID()}
+
+	for i := 0; i < 5; i++ {
+		r.Schedule(a)
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.Schedule(b)
+	time.Sleep(100 * time.Millisecond)
+
+	if got := count(a.ID); got != 1 {
+		t.Errorf("job a analyzed %d times, want 1", got)
+	}
+	if got := count(b.ID); got != 1 {
+		t.Errorf("job b analyzed %d times, want 1", got)
+	}
+
+	// A write after the analysis queues another one
+	r.Schedule(a)
+	time.Sleep(100 * time.Millisecond)
+	if got := count(a.ID); got != 2 {
+		t.Errorf("job a analyzed %d times after a second write, want 2", got)
+	}
+}
+
+func TestQualityRefresherStop(t *testing.T) {
+	r, count := countingRefresher()
+	job := &models.Job{ID: primitive.NewObjectID()}
+
+	r.Schedule(job)
+	r.Stop()
+	r.Schedule(job)
+	time.Sleep(100 * time.Millisecond)
+
+	if got := count(job.ID); got != 0 {
+		t.Errorf("job analyzed %d times after Stop, want 0", got)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 )
@@ -25,6 +28,10 @@ type QualityService struct {
 	// Background processing
 	mu            sync.Mutex
 	runningChecks map[string]bool
+
+	// Backfill jobs waiting for each connector's backfill worker, keyed by
+	// exchange ID; an entry exists while the connector's worker is running
+	backfillQueues map[string][]string
 }
 
 // NewQualityService creates a new quality service
@@ -44,13 +51,15 @@ func NewQualityService(
 		connectorRepo: connectorRepo,
 		rateLimiter:   rateLimiter,
 		runningChecks: make(map[string]bool),
+
+		backfillQueues: make(map[string][]string),
 	}
 }
 
 // AnalyzeJob analyzes data quality for a single job and stores the result
 func (s *QualityService) AnalyzeJob(ctx context.Context, job *models.Job) (*models.DataQualityResult, error) {
 	// Get raw quality analysis from OHLCV repository
-	quality, err := s.ohlcvRepo.AnalyzeDataQuality(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	quality, err := s.ohlcvRepo.AnalyzeJobDataQuality(ctx, job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze job quality: %w", err)
 	}
@@ -83,7 +92,7 @@ func (s *QualityService) AnalyzeJob(ctx context.Context, job *models.Job) (*mode
 
 	// Store the result
 	if err := s.qualityRepo.UpsertResult(ctx, result); err != nil {
-		log.Printf("[QUALITY] Warning: Failed to store quality result: %v", err)
+		logging.Printf(ctx, "[QUALITY] Warning: Failed to store quality result: %v", err)
 	}
 
 	return result, nil
@@ -168,7 +177,7 @@ func (s *QualityService) StartQualityCheck(ctx context.Context, checkType models
 		filter["timeframe"] = timeframe
 	}
 
-	jobs, err := s.jobRepo.FindAll(ctx, filter)
+	jobs, err := s.jobRepo.FindAll(ctx, repository.OHLCVJobs(filter))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find jobs: %w", err)
 	}
@@ -211,7 +220,7 @@ func (s *QualityService) processQualityCheck(checkJobID string) {
 	// Get check job
 	checkJob, err := s.qualityRepo.FindCheckJob(ctx, checkJobID)
 	if err != nil || checkJob == nil {
-		log.Printf("[QUALITY] Failed to find check job %s: %v", checkJobID, err)
+		logging.Printf(ctx, "[QUALITY] Failed to find check job %s: %v", checkJobID, err)
 		return
 	}
 
@@ -220,10 +229,10 @@ func (s *QualityService) processQualityCheck(checkJobID string) {
 	checkJob.Status = models.QualityCheckRunning
 	checkJob.StartedAt = &now
 	if err := s.qualityRepo.UpdateCheckJob(ctx, checkJob); err != nil {
-		log.Printf("[QUALITY] Failed to update check job status: %v", err)
+		logging.Printf(ctx, "[QUALITY] Failed to update check job status: %v", err)
 	}
 
-	log.Printf("[QUALITY] Starting quality check %s for %d jobs", checkJobID, checkJob.TotalJobs)
+	logging.Printf(ctx, "[QUALITY] Starting quality check %s for %d jobs", checkJobID, checkJob.TotalJobs)
 
 	// Process each job
 	for i, jobID := range checkJob.JobIDs {
@@ -240,7 +249,7 @@ func (s *QualityService) processQualityCheck(checkJobID string) {
 			checkJob.FailedJobs++
 			checkJob.LastError = err.Error()
 			checkJob.Errors = append(checkJob.Errors, fmt.Sprintf("Job %s: %v", jobID.Hex(), err))
-			log.Printf("[QUALITY] Failed to analyze job %s: %v", jobID.Hex(), err)
+			logging.Printf(ctx, "[QUALITY] Failed to analyze job %s: %v", jobID.Hex(), err)
 		} else {
 			checkJob.CompletedJobs++
 
@@ -263,7 +272,7 @@ func (s *QualityService) processQualityCheck(checkJobID string) {
 		// Update check job periodically (every 10 jobs or at end)
 		if (i+1)%10 == 0 || i == len(checkJob.JobIDs)-1 {
 			if err := s.qualityRepo.UpdateCheckJob(ctx, checkJob); err != nil {
-				log.Printf("[QUALITY] Failed to update check job progress: %v", err)
+				logging.Printf(ctx, "[QUALITY] Failed to update check job progress: %v", err)
 			}
 		}
 	}
@@ -275,13 +284,13 @@ func (s *QualityService) processQualityCheck(checkJobID string) {
 	checkJob.Progress = 100
 
 	if err := s.qualityRepo.UpdateCheckJob(ctx, checkJob); err != nil {
-		log.Printf("[QUALITY] Failed to mark check job as completed: %v", err)
+		logging.Printf(ctx, "[QUALITY] Failed to mark check job as completed: %v", err)
 	}
 
 	// Update summary cache
 	s.updateSummaryCache(ctx, checkJob.ExchangeID)
 
-	log.Printf("[QUALITY] Completed quality check %s: %d/%d jobs analyzed",
+	logging.Printf(ctx, "[QUALITY] Completed quality check %s: %d/%d jobs analyzed",
 		checkJobID, checkJob.CompletedJobs, checkJob.TotalJobs)
 }
 
@@ -290,10 +299,10 @@ func (s *QualityService) updateSummaryCache(ctx context.Context, exchangeID stri
 	// Update global summary
 	globalSummary, err := s.qualityRepo.ComputeSummaryFromResults(ctx, "")
 	if err != nil {
-		log.Printf("[QUALITY] Failed to compute global summary: %v", err)
+		logging.Printf(ctx, "[QUALITY] Failed to compute global summary: %v", err)
 	} else {
 		if err := s.qualityRepo.UpsertSummary(ctx, globalSummary); err != nil {
-			log.Printf("[QUALITY] Failed to save global summary: %v", err)
+			logging.Printf(ctx, "[QUALITY] Failed to save global summary: %v", err)
 		}
 	}
 
@@ -301,13 +310,166 @@ func (s *QualityService) updateSummaryCache(ctx context.Context, exchangeID stri
 	if exchangeID != "" {
 		exchSummary, err := s.qualityRepo.ComputeSummaryFromResults(ctx, exchangeID)
 		if err != nil {
-			log.Printf("[QUALITY] Failed to compute exchange summary: %v", err)
+			logging.Printf(ctx, "[QUALITY] Failed to compute exchange summary: %v", err)
 		} else {
 			if err := s.qualityRepo.UpsertSummary(ctx, exchSummary); err != nil {
-				log.Printf("[QUALITY] Failed to save exchange summary: %v", err)
+				logging.Printf(ctx, "[QUALITY] Failed to save exchange summary: %v", err)
+			}
+		}
+	}
+}
+
+// ComputeSummary computes a live quality summary for all series, optionally
+// filtered by exchange. Without gaps the summary is built from chunk metadata
+// in a single aggregation; with gaps each series' candles are loaded for gap
+// detection, which is far slower on exchanges with many jobs.
+func (s *QualityService) ComputeSummary(ctx context.Context, exchangeID string, includeGaps bool) (*models.DataQualitySummary, error) {
+	coverage, err := s.ohlcvRepo.AnalyzeDataCoverage(ctx, exchangeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze data coverage: %w", err)
+	}
+
+	// Series collected by a job are classified with the job's own thresholds
+	filter := bson.M{}
+	if exchangeID != "" {
+		filter["connector_exchange_id"] = exchangeID
+	}
+	jobs, err := s.jobRepo.FindAll(ctx, repository.OHLCVJobs(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	jobsBySeries := make(map[string]*models.Job, len(jobs))
+	for _, job := range jobs {
+		jobsBySeries[job.ConnectorExchangeID+"|"+job.Symbol+"|"+job.Timeframe] = job
+	}
+
+	summary := &models.DataQualitySummary{
+		TotalJobs:    len(coverage),
+		GapsIncluded: includeGaps,
+	}
+
+	var totalCompleteness float64
+	for _, quality := range coverage {
+		job := jobsBySeries[quality.ExchangeID+"|"+quality.Symbol+"|"+quality.Timeframe]
+
+		if includeGaps {
+			var analyzed *models.DataQuality
+			if job != nil {
+				analyzed, err = s.ohlcvRepo.AnalyzeJobDataQuality(ctx, job)
+			} else {
+				analyzed, err = s.ohlcvRepo.AnalyzeDataQuality(ctx, quality.ExchangeID, quality.Symbol, quality.Timeframe)
+			}
+			if err != nil {
+				logging.Printf(ctx, "[QUALITY] Failed to analyze %s %s %s: %v", quality.ExchangeID, quality.Symbol, quality.Timeframe, err)
+				summary.SkippedJobs++
+				continue
+			}
+			quality = analyzed
+		} else if job != nil {
+			s.ohlcvRepo.ClassifyCoverage(quality, job)
+		}
+
+		totalCompleteness += quality.CompletenessScore
+		summary.TotalMissingCandles += quality.MissingCandles
+		summary.TotalGaps += quality.GapsDetected
+
+		switch quality.QualityStatus {
+		case "excellent":
+			summary.ExcellentQuality++
+		case "good":
+			summary.GoodQuality++
+		case "fair":
+			summary.FairQuality++
+		case "poor":
+			summary.PoorQuality++
+		}
+
+		if quality.DataFreshness == "fresh" {
+			summary.FreshDataJobs++
+		} else {
+			summary.StaleDataJobs++
+		}
+	}
+
+	// Skipped series have no score, so they don't count toward the average
+	if analyzed := summary.TotalJobs - summary.SkippedJobs; analyzed > 0 {
+		summary.AverageCompleteness = totalCompleteness / float64(analyzed)
+	}
+
+	return summary, nil
+}
+
+// FindStaleJobs returns the active OHLCV jobs, optionally of one exchange,
+// whose newest candle is older than their freshness SLA, most overdue first.
+// Ages come from the same chunk metadata aggregation as ComputeSummary, and
+// jobs without any data are always listed.
+func (s *QualityService) FindStaleJobs(ctx context.Context, exchangeID string) ([]models.StaleJob, error) {
+	coverage, err := s.ohlcvRepo.AnalyzeDataCoverage(ctx, exchangeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze data coverage: %w", err)
+	}
+	coverageBySeries := make(map[string]*models.DataQuality, len(coverage))
+	for _, quality := range coverage {
+		coverageBySeries[quality.ExchangeID+"|"+quality.Symbol+"|"+quality.Timeframe] = quality
+	}
+
+	filter := bson.M{"status": "active"}
+	if exchangeID != "" {
+		filter["connector_exchange_id"] = exchangeID
+	}
+	jobs, err := s.jobRepo.FindAll(ctx, repository.OHLCVJobs(filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	now := time.Now()
+	stale := []models.StaleJob{}
+	for _, job := range jobs {
+		entry := models.StaleJob{
+			JobID:      job.ID,
+			ExchangeID: job.ConnectorExchangeID,
+			Symbol:     job.Symbol,
+			Timeframe:  job.Timeframe,
+			SLASource:  "timeframe",
+		}
+		if job.FreshnessSLAMinutes > 0 {
+			entry.SLASource = "job"
+		}
+
+		quality := coverageBySeries[job.ConnectorExchangeID+"|"+job.Symbol+"|"+job.Timeframe]
+		if quality == nil || quality.TotalCandles == 0 {
+			entry.DataFreshness = "no_data"
+			if job.FreshnessSLAMinutes > 0 {
+				entry.SLAMinutes = int64(job.FreshnessSLAMinutes)
 			}
+			stale = append(stale, entry)
+			continue
+		}
+
+		thresholds := s.ohlcvRepo.FreshnessThresholds(job.Freshness)
+		reference := models.FreshnessReference(job.Timeframe, quality.NewestCandle, job.ExcludesUnclosedBar())
+		deadline := models.FreshnessSLADeadline(job.Timeframe, reference, job.FreshnessSLAMinutes, thresholds)
+		if !now.After(deadline) {
+			continue
 		}
+
+		newest := quality.NewestCandle
+		entry.NewestCandle = &newest
+		entry.AgeMinutes = int64(now.Sub(reference).Minutes())
+		entry.SLAMinutes = int64(deadline.Sub(reference).Minutes())
+		entry.BreachMinutes = int64(now.Sub(deadline).Minutes())
+		entry.DataFreshness = models.ClassifyFreshness(job.Timeframe, reference, now, thresholds)
+		stale = append(stale, entry)
 	}
+
+	// Jobs without data sort first, then by how far past their SLA they are
+	sort.SliceStable(stale, func(i, j int) bool {
+		if (stale[i].NewestCandle == nil) != (stale[j].NewestCandle == nil) {
+			return stale[i].NewestCandle == nil
+		}
+		return stale[i].BreachMinutes > stale[j].BreachMinutes
+	})
+	return stale, nil
 }
 
 // GetCheckJobStatus gets the status of a quality check job
@@ -401,7 +563,7 @@ func (s *QualityService) processGapFill(gapFillJobID string) {
 	// Get gap fill job
 	gapFillJob, err := s.qualityRepo.FindGapFillJob(ctx, gapFillJobID)
 	if err != nil || gapFillJob == nil {
-		log.Printf("[GAP_FILL] Failed to find gap fill job %s: %v", gapFillJobID, err)
+		logging.Printf(ctx, "[GAP_FILL] Failed to find gap fill job %s: %v", gapFillJobID, err)
 		return
 	}
 
@@ -410,10 +572,10 @@ func (s *QualityService) processGapFill(gapFillJobID string) {
 	gapFillJob.Status = models.GapFillRunning
 	gapFillJob.StartedAt = &now
 	if err := s.qualityRepo.UpdateGapFillJob(ctx, gapFillJob); err != nil {
-		log.Printf("[GAP_FILL] Failed to update gap fill job status: %v", err)
+		logging.Printf(ctx, "[GAP_FILL] Failed to update gap fill job status: %v", err)
 	}
 
-	log.Printf("[GAP_FILL] Starting gap fill %s for %s %s %s", gapFillJobID, gapFillJob.ExchangeID, gapFillJob.Symbol, gapFillJob.Timeframe)
+	logging.Printf(ctx, "[GAP_FILL] Starting gap fill %s for %s %s %s", gapFillJobID, gapFillJob.ExchangeID, gapFillJob.Symbol, gapFillJob.Timeframe)
 
 	// Get job and connector
 	job, err := s.jobRepo.FindByID(ctx, gapFillJob.JobID.Hex())
@@ -456,7 +618,7 @@ func (s *QualityService) processGapFill(gapFillJobID string) {
 		completedAt := time.Now()
 		gapFillJob.CompletedAt = &completedAt
 		s.qualityRepo.UpdateGapFillJob(ctx, gapFillJob)
-		log.Printf("[GAP_FILL] No gaps to fill for %s", gapFillJobID)
+		logging.Printf(ctx, "[GAP_FILL] No gaps to fill for %s", gapFillJobID)
 		return
 	}
 
@@ -509,7 +671,7 @@ func (s *QualityService) processGapFill(gapFillJobID string) {
 
 		// Update job periodically (every gap or at end)
 		if err := s.qualityRepo.UpdateGapFillJob(ctx, gapFillJob); err != nil {
-			log.Printf("[GAP_FILL] Failed to update gap fill job progress: %v", err)
+			logging.Printf(ctx, "[GAP_FILL] Failed to update gap fill job progress: %v", err)
 		}
 
 		// Small delay between gaps to avoid rate limiting
@@ -523,13 +685,13 @@ func (s *QualityService) processGapFill(gapFillJobID string) {
 	gapFillJob.Progress = 100
 
 	if err := s.qualityRepo.UpdateGapFillJob(ctx, gapFillJob); err != nil {
-		log.Printf("[GAP_FILL] Failed to mark gap fill job as completed: %v", err)
+		logging.Printf(ctx, "[GAP_FILL] Failed to mark gap fill job as completed: %v", err)
 	}
 
 	// Re-analyze quality after filling
 	_, _ = s.AnalyzeJob(ctx, job)
 
-	log.Printf("[GAP_FILL] Completed gap fill %s: %d/%d gaps filled, %d candles fetched",
+	logging.Printf(ctx, "[GAP_FILL] Completed gap fill %s: %d/%d gaps filled, %d candles fetched",
 		gapFillJobID, gapFillJob.GapsFilled, gapFillJob.GapsAttempted, gapFillJob.CandlesFetched)
 }
 
@@ -666,7 +828,8 @@ func (s *QualityService) RunScheduledCheck(ctx context.Context) error {
 	return nil
 }
 
-// StartBackfill starts a background backfill job to fetch historical data
+// StartBackfill starts a background backfill job to fetch historical data.
+// It is queued behind any other backfill of the same connector.
 func (s *QualityService) StartBackfill(ctx context.Context, jobID string, monthsBack int, targetDateStr string) (*models.BackfillJob, error) {
 	job, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil || job == nil {
@@ -682,6 +845,42 @@ func (s *QualityService) StartBackfill(ctx context.Context, jobID string, months
 		return existingJob, nil // Return existing job
 	}
 
+	targetStartDate, err := backfillTargetDate(monthsBack, targetDateStr)
+	if err != nil {
+		return nil, err
+	}
+
+	backfillJob, err := s.createBackfillJob(ctx, job, targetStartDate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start background processing
+	s.enqueueBackfill(job.ConnectorExchangeID, backfillJob.ID.Hex())
+
+	return backfillJob, nil
+}
+
+// backfillTargetDate returns how far back a backfill fetches: a specific
+// YYYY-MM-DD date, a number of months back, or by default 5 years
+func backfillTargetDate(monthsBack int, targetDateStr string) (time.Time, error) {
+	if targetDateStr != "" {
+		targetStartDate, err := time.Parse("2006-01-02", targetDateStr)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid target date format (use YYYY-MM-DD): %w", err)
+		}
+		return targetStartDate, nil
+	}
+	if monthsBack > 0 {
+		return time.Now().AddDate(0, -monthsBack, 0), nil
+	}
+	// Default: try to get maximum available (5 years back)
+	return time.Now().AddDate(-5, 0, 0), nil
+}
+
+// createBackfillJob saves a pending backfill job that fetches the job's data
+// from its current oldest candle back to targetStartDate
+func (s *QualityService) createBackfillJob(ctx context.Context, job *models.Job, targetStartDate time.Time, connectorBackfillID *primitive.ObjectID) (*models.BackfillJob, error) {
 	// Get current data to determine oldest data point
 	result, err := s.GetCachedResult(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
 	if err != nil || result == nil {
@@ -692,41 +891,27 @@ func (s *QualityService) StartBackfill(ctx context.Context, jobID string, months
 		}
 	}
 
-	// Determine target start date
-	var targetStartDate time.Time
-	if targetDateStr != "" {
-		// Parse specific target date
-		targetStartDate, err = time.Parse("2006-01-02", targetDateStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid target date format (use YYYY-MM-DD): %w", err)
-		}
-	} else if monthsBack > 0 {
-		// Calculate based on months back
-		targetStartDate = time.Now().AddDate(0, -monthsBack, 0)
-	} else {
-		// Default: try to get maximum available (5 years back)
-		targetStartDate = time.Now().AddDate(-5, 0, 0)
+	// A job without data yet is backfilled from now
+	currentOldest := result.DataPeriodStart
+	if currentOldest.IsZero() {
+		currentOldest = time.Now()
 	}
 
-	// Create backfill job
 	backfillJob := &models.BackfillJob{
-		JobID:           job.ID,
-		ExchangeID:      job.ConnectorExchangeID,
-		Symbol:          job.Symbol,
-		Timeframe:       job.Timeframe,
-		Status:          models.BackfillPending,
-		TargetStartDate: targetStartDate,
-		CurrentOldest:   result.DataPeriodStart,
+		JobID:               job.ID,
+		ExchangeID:          job.ConnectorExchangeID,
+		Symbol:              job.Symbol,
+		Timeframe:           job.Timeframe,
+		Status:              models.BackfillPending,
+		ConnectorBackfillID: connectorBackfillID,
+		TargetStartDate:     targetStartDate,
+		CurrentOldest:       currentOldest,
 	}
 
-	// Save the job
 	if err := s.qualityRepo.CreateBackfillJob(ctx, backfillJob); err != nil {
 		return nil, fmt.Errorf("failed to create backfill job: %w", err)
 	}
 
-	// Start background processing
-	go s.processBackfill(backfillJob.ID.Hex())
-
 	return backfillJob, nil
 }
 
@@ -752,7 +937,7 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 	// Get backfill job
 	backfillJob, err := s.qualityRepo.FindBackfillJob(ctx, backfillJobID)
 	if err != nil || backfillJob == nil {
-		log.Printf("[BACKFILL] Failed to find backfill job %s: %v", backfillJobID, err)
+		logging.Printf(ctx, "[BACKFILL] Failed to find backfill job %s: %v", backfillJobID, err)
 		return
 	}
 
@@ -761,10 +946,10 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 	backfillJob.Status = models.BackfillRunning
 	backfillJob.StartedAt = &now
 	if err := s.qualityRepo.UpdateBackfillJob(ctx, backfillJob); err != nil {
-		log.Printf("[BACKFILL] Failed to update backfill job status: %v", err)
+		logging.Printf(ctx, "[BACKFILL] Failed to update backfill job status: %v", err)
 	}
 
-	log.Printf("[BACKFILL] Starting backfill %s for %s %s %s (target: %s)",
+	logging.Printf(ctx, "[BACKFILL] Starting backfill %s for %s %s %s (target: %s)",
 		backfillJobID, backfillJob.ExchangeID, backfillJob.Symbol, backfillJob.Timeframe,
 		backfillJob.TargetStartDate.Format("2006-01-02"))
 
@@ -791,11 +976,19 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 
 	// Calculate total expected batches for progress tracking
 	totalDuration := backfillJob.CurrentOldest.Sub(backfillJob.TargetStartDate)
-	tfDurationMs := models.GetTimeframeDurationMinutes(backfillJob.Timeframe) * 60 * 1000
-	expectedCandles := int(totalDuration.Milliseconds() / int64(tfDurationMs))
+	tfDurationMs, err := models.GetTimeframeMs(backfillJob.Timeframe)
+	if err != nil {
+		backfillJob.Status = models.BackfillFailed
+		backfillJob.LastError = err.Error()
+		completedAt := time.Now()
+		backfillJob.CompletedAt = &completedAt
+		s.qualityRepo.UpdateBackfillJob(ctx, backfillJob)
+		return
+	}
+	expectedCandles := int(totalDuration.Milliseconds() / tfDurationMs)
 	expectedBatches := (expectedCandles / 500) + 1 // Assuming ~500 candles per batch
 
-	log.Printf("[BACKFILL] Expected ~%d candles, ~%d batches", expectedCandles, expectedBatches)
+	logging.Printf(ctx, "[BACKFILL] Expected ~%d candles, ~%d batches", expectedCandles, expectedBatches)
 
 	// Fetch historical data in batches, working backwards from current oldest
 	currentEndTime := backfillJob.CurrentOldest
@@ -805,7 +998,7 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 	for batch := 0; batch < maxBatches; batch++ {
 		// Check if we've reached the target date
 		if currentEndTime.Before(backfillJob.TargetStartDate) || currentEndTime.Equal(backfillJob.TargetStartDate) {
-			log.Printf("[BACKFILL] Reached target date, stopping")
+			logging.Printf(ctx, "[BACKFILL] Reached target date, stopping")
 			break
 		}
 
@@ -818,7 +1011,7 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 			currentStartTime = backfillJob.TargetStartDate
 		}
 
-		log.Printf("[BACKFILL] Fetching batch %d: %s to %s",
+		logging.Printf(ctx, "[BACKFILL] Fetching batch %d: %s to %s",
 			batch+1, currentStartTime.Format("2006-01-02"), currentEndTime.Format("2006-01-02"))
 
 		// Fetch data for this range
@@ -835,7 +1028,7 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 			errMsg := fmt.Sprintf("Batch %d: %v", batch+1, err)
 			backfillJob.LastError = errMsg
 			backfillJob.Errors = append(backfillJob.Errors, errMsg)
-			log.Printf("[BACKFILL] Error fetching batch: %v", err)
+			logging.Printf(ctx, "[BACKFILL] Error fetching batch: %v", err)
 
 			// Continue to next batch on error (some exchanges have limited history)
 			currentEndTime = currentStartTime
@@ -849,13 +1042,13 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 				errMsg := fmt.Sprintf("Failed to store candles: %v", err)
 				backfillJob.LastError = errMsg
 				backfillJob.Errors = append(backfillJob.Errors, errMsg)
-				log.Printf("[BACKFILL] Error storing candles: %v", err)
+				logging.Printf(ctx, "[BACKFILL] Error storing candles: %v", err)
 			} else {
 				backfillJob.CandlesFetched += len(candles)
-				log.Printf("[BACKFILL] Stored %d candles", len(candles))
+				logging.Printf(ctx, "[BACKFILL] Stored %d candles", len(candles))
 			}
 		} else {
-			log.Printf("[BACKFILL] No candles returned for this batch, exchange may not have data this far back")
+			logging.Printf(ctx, "[BACKFILL] No candles returned for this batch, exchange may not have data this far back")
 			// If no data returned, likely reached the exchange's history limit
 			break
 		}
@@ -872,7 +1065,7 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 
 		// Update job periodically
 		if err := s.qualityRepo.UpdateBackfillJob(ctx, backfillJob); err != nil {
-			log.Printf("[BACKFILL] Failed to update backfill job progress: %v", err)
+			logging.Printf(ctx, "[BACKFILL] Failed to update backfill job progress: %v", err)
 		}
 
 		// Move to earlier time range
@@ -889,13 +1082,13 @@ func (s *QualityService) processBackfill(backfillJobID string) {
 	backfillJob.Progress = 100
 
 	if err := s.qualityRepo.UpdateBackfillJob(ctx, backfillJob); err != nil {
-		log.Printf("[BACKFILL] Failed to mark backfill job as completed: %v", err)
+		logging.Printf(ctx, "[BACKFILL] Failed to mark backfill job as completed: %v", err)
 	}
 
 	// Re-analyze quality after backfill
 	_, _ = s.AnalyzeJob(ctx, job)
 
-	log.Printf("[BACKFILL] Completed backfill %s: %d batches, %d candles fetched",
+	logging.Printf(ctx, "[BACKFILL] Completed backfill %s: %d batches, %d candles fetched",
 		backfillJobID, backfillJob.BatchesFetched, backfillJob.CandlesFetched)
 }
 
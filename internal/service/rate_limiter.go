@@ -3,10 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 )
@@ -50,7 +50,7 @@ func (r *RateLimiter) WaitForSlot(ctx context.Context, exchangeID string) error
 
 		if elapsed < minDelay {
 			waitTime := minDelay - elapsed
-			log.Printf("[RATE_LIMIT] %s: Waiting %v before next API call (min delay: %dms)",
+			logging.Printf(ctx, "[RATE_LIMIT] %s: Waiting %v before next API call (min delay: %dms)",
 				exchangeID, waitTime.Round(time.Millisecond), minDelayMs)
 
 			// Wait with context cancellation support
@@ -69,23 +69,23 @@ func (r *RateLimiter) WaitForSlot(ctx context.Context, exchangeID string) error
 
 	if periodElapsed >= int64(connector.RateLimit.PeriodMs) {
 		// Period has elapsed, reset
-		log.Printf("[RATE_LIMIT] %s: Period elapsed, resetting usage counter", exchangeID)
+		logging.Printf(ctx, "[RATE_LIMIT] %s: Period elapsed, resetting usage counter", exchangeID)
 		if err := r.resetPeriod(ctx, exchangeID); err != nil {
-			log.Printf("[RATE_LIMIT] Warning: Failed to reset period: %v", err)
+			logging.Printf(ctx, "[RATE_LIMIT] Warning: Failed to reset period: %v", err)
 		}
 	} else if connector.RateLimit.Usage >= connector.RateLimit.Limit {
 		// Limit reached, wait for period to reset
 		remainingMs := int64(connector.RateLimit.PeriodMs) - periodElapsed
 		waitTime := time.Duration(remainingMs) * time.Millisecond
 
-		log.Printf("[RATE_LIMIT] %s: Rate limit reached (%d/%d), waiting %v for period reset",
+		logging.Printf(ctx, "[RATE_LIMIT] %s: Rate limit reached (%d/%d), waiting %v for period reset",
 			exchangeID, connector.RateLimit.Usage, connector.RateLimit.Limit, waitTime.Round(time.Millisecond))
 
 		select {
 		case <-time.After(waitTime):
 			// Reset after waiting
 			if err := r.resetPeriod(ctx, exchangeID); err != nil {
-				log.Printf("[RATE_LIMIT] Warning: Failed to reset period after wait: %v", err)
+				logging.Printf(ctx, "[RATE_LIMIT] Warning: Failed to reset period after wait: %v", err)
 			}
 		case <-ctx.Done():
 			return ctx.Err()
@@ -97,10 +97,10 @@ func (r *RateLimiter) WaitForSlot(ctx context.Context, exchangeID string) error
 
 	// Increment usage counter in database
 	if err := r.incrementUsage(ctx, exchangeID); err != nil {
-		log.Printf("[RATE_LIMIT] Warning: Failed to increment usage: %v", err)
+		logging.Printf(ctx, "[RATE_LIMIT] Warning: Failed to increment usage: %v", err)
 	}
 
-	log.Printf("[RATE_LIMIT] %s: API call slot acquired", exchangeID)
+	logging.Printf(ctx, "[RATE_LIMIT] %s: API call slot acquired", exchangeID)
 	return nil
 }
 
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 
+	"github.com/yourusername/datacollector/internal/logging"
+	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 	"github.com/yourusername/datacollector/internal/service/indicators"
 )
@@ -17,6 +19,7 @@ type RecalculatorService struct {
 	connectorRepo    *repository.ConnectorRepository
 	ohlcvRepo        *repository.OHLCVRepository
 	indicatorService *indicators.Service
+	configResolver   *IndicatorConfigResolver
 }
 
 // NewRecalculatorService creates a new recalculator service
@@ -24,19 +27,21 @@ func NewRecalculatorService(
 	jobRepo *repository.JobRepository,
 	connectorRepo *repository.ConnectorRepository,
 	ohlcvRepo *repository.OHLCVRepository,
+	indicatorConfigRepo *repository.IndicatorConfigRepository,
 ) *RecalculatorService {
 	return &RecalculatorService{
 		jobRepo:          jobRepo,
 		connectorRepo:    connectorRepo,
 		ohlcvRepo:        ohlcvRepo,
 		indicatorService: indicators.NewService(),
+		configResolver:   NewIndicatorConfigResolver(indicatorConfigRepo),
 	}
 }
 
 // RecalculateJob recalculates all indicators for a specific job
 // This fetches all existing candles, recalculates indicators, and updates the database
 func (r *RecalculatorService) RecalculateJob(ctx context.Context, jobID string) error {
-	log.Printf("[RECALC] Starting recalculation for job %s", jobID)
+	logging.Printf(ctx, "[RECALC] Starting recalculation for job %s", jobID)
 
 	// Fetch job
 	job, err := r.jobRepo.FindByID(ctx, jobID)
@@ -50,42 +55,164 @@ func (r *RecalculatorService) RecalculateJob(ctx context.Context, jobID string)
 		return fmt.Errorf("failed to find connector: %w", err)
 	}
 
-	// Fetch all candles for this job
-	ohlcvDoc, err := r.ohlcvRepo.FindByJob(ctx, connector.ExchangeID, job.Symbol, job.Timeframe)
+	config := r.configResolver.Resolve(ctx, job, connector)
+	recordsUpdated, err := r.recalculateSeries(ctx, connector.ExchangeID, job.Symbol, job.Timeframe, config)
 	if err != nil {
-		return fmt.Errorf("failed to fetch candles: %w", err)
+		return err
+	}
+
+	logging.Printf(ctx, "[RECALC] Successfully recalculated indicators for job %s (%d candles updated)", jobID, recordsUpdated)
+	return nil
+}
+
+// RecalculateSeries recalculates all indicators for a stored OHLCV series.
+// Unlike RecalculateJob it does not need a job, so it also covers series that
+// were loaded directly (e.g. imported raw OHLCV). Returns the number of candles
+// whose stored indicators were updated. The series' job and connector
+// indicator configs are honored when they exist.
+func (r *RecalculatorService) RecalculateSeries(ctx context.Context, exchangeID, symbol, timeframe string) (int, error) {
+	var job *models.Job
+	jobs, err := r.jobRepo.FindAll(ctx, repository.OHLCVJobs(bson.M{"connector_exchange_id": exchangeID, "symbol": symbol, "timeframe": timeframe}))
+	if err == nil && len(jobs) > 0 {
+		job = jobs[0]
+	}
+	connector, _ := r.connectorRepo.FindByExchangeID(ctx, exchangeID)
+
+	return r.recalculateSeries(ctx, exchangeID, symbol, timeframe, r.configResolver.Resolve(ctx, job, connector))
+}
+
+// recalculateSeries recalculates a stored series with the given indicator config
+func (r *RecalculatorService) recalculateSeries(ctx context.Context, exchangeID, symbol, timeframe string, config *models.IndicatorConfig) (int, error) {
+	ohlcvDoc, err := r.ohlcvRepo.FindByJob(ctx, exchangeID, symbol, timeframe)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch candles: %w", err)
 	}
 
 	if ohlcvDoc == nil || len(ohlcvDoc.Candles) == 0 {
-		log.Printf("[RECALC] No candles found for job %s", jobID)
-		return nil
+		logging.Printf(ctx, "[RECALC] No candles found for %s %s %s", exchangeID, symbol, timeframe)
+		return 0, nil
 	}
 
-	log.Printf("[RECALC] Found %d candles for job %s", len(ohlcvDoc.Candles), jobID)
+	logging.Printf(ctx, "[RECALC] Found %d candles for %s %s %s", len(ohlcvDoc.Candles), exchangeID, symbol, timeframe)
 
-	// Recalculate all indicators for all candles
-	candles, err := r.indicatorService.CalculateAll(ohlcvDoc.Candles)
+	// Recalculate indicators for all candles
+	candles, err := r.indicatorService.CalculateWithConfig(ohlcvDoc.Candles, config)
 	if err != nil {
-		return fmt.Errorf("failed to calculate indicators: %w", err)
+		return 0, fmt.Errorf("failed to calculate indicators: %w", err)
 	}
 
-	// Update candles in database
-	recordsUpdated, err := r.ohlcvRepo.UpsertCandles(ctx, connector.ExchangeID, job.Symbol, job.Timeframe, candles)
+	// Replace the stored indicators; UpsertCandles would skip existing candles
+	updated, err := r.ohlcvRepo.UpdateIndicatorFields(ctx, exchangeID, symbol, timeframe, candles, nil)
 	if err != nil {
-		return fmt.Errorf("failed to update candles: %w", err)
+		return updated, fmt.Errorf("failed to update candles: %w", err)
 	}
 
-	log.Printf("[RECALC] Successfully recalculated indicators for job %s (%d candles updated)", jobID, recordsUpdated)
-	return nil
+	return updated, nil
+}
+
+// IndicatorConfigForJob returns a job together with the indicator config its
+// candles are computed with
+func (r *RecalculatorService) IndicatorConfigForJob(ctx context.Context, jobID string) (*models.Job, *models.IndicatorConfig, error) {
+	job, err := r.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find job: %w", err)
+	}
+
+	connector, err := r.connectorRepo.FindByExchangeID(ctx, job.ConnectorExchangeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find connector: %w", err)
+	}
+
+	return job, r.configResolver.Resolve(ctx, job, connector), nil
+}
+
+// RecalculateJobWithConfig recalculates all indicators of a job's candles with
+// the given config, as returned by IndicatorConfigForJob. Returns the number
+// of candles updated.
+func (r *RecalculatorService) RecalculateJobWithConfig(ctx context.Context, job *models.Job, config *models.IndicatorConfig) (int, error) {
+	updated, err := r.recalculateSeries(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, config)
+	if err != nil {
+		return updated, err
+	}
+
+	logging.Printf(ctx, "[RECALC] Successfully recalculated indicators for job %s (%d candles updated)", job.ID.Hex(), updated)
+	return updated, nil
+}
+
+// RecalculateIndicator recomputes a single indicator across a job's candles
+// and stores only that indicator's fields. config should compute nothing but
+// the indicator (see models.SingleIndicatorConfig). Returns the number of
+// candles updated.
+func (r *RecalculatorService) RecalculateIndicator(ctx context.Context, job *models.Job, spec models.IndicatorSpec, config *models.IndicatorConfig) (int, error) {
+	ohlcvDoc, err := r.ohlcvRepo.FindByJob(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch candles: %w", err)
+	}
+
+	if ohlcvDoc == nil || len(ohlcvDoc.Candles) == 0 {
+		logging.Printf(ctx, "[RECALC] No candles found for job %s", job.ID.Hex())
+		return 0, nil
+	}
+
+	// Drop the old values so periods no longer configured don't linger
+	candles := ohlcvDoc.Candles
+	for i := range candles {
+		candles[i].Indicators.ClearFields(spec.Fields)
+	}
+
+	candles, err = r.indicatorService.CalculateWithConfig(candles, config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate %s: %w", spec.Name, err)
+	}
+
+	updated, err := r.ohlcvRepo.UpdateIndicatorFields(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, candles, spec.Fields)
+	if err != nil {
+		return updated, fmt.Errorf("failed to update candles: %w", err)
+	}
+
+	logging.Printf(ctx, "[RECALC] Recalculated %s for job %s (%d candles updated)", spec.Name, job.ID.Hex(), updated)
+	return updated, nil
+}
+
+// previewWarmupBars is the history loaded ahead of the previewed candles so
+// that indicators with the longest allowed periods are settled
+const previewWarmupBars = 3 * models.MaxPeriod
+
+// PreviewIndicators computes indicators with config over a job's most recent
+// candles without storing anything. Stored indicator values are dropped first,
+// so the result only reflects config. Returns the last limit candles, newest
+// first.
+func (r *RecalculatorService) PreviewIndicators(ctx context.Context, job *models.Job, config *models.IndicatorConfig, limit int) ([]models.Candle, error) {
+	candles, err := r.ohlcvRepo.GetRecentCandles(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe, limit+previewWarmupBars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch candles: %w", err)
+	}
+	if len(candles) == 0 {
+		return []models.Candle{}, nil
+	}
+
+	for i := range candles {
+		candles[i].Indicators = models.Indicators{}
+	}
+
+	candles, err = r.indicatorService.CalculateWithConfig(candles, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate indicators: %w", err)
+	}
+
+	if len(candles) > limit {
+		candles = candles[:limit]
+	}
+	return candles, nil
 }
 
 // RecalculateConnector recalculates all indicators for all jobs using a specific connector
 // This is useful when connector-level indicator configuration changes
 func (r *RecalculatorService) RecalculateConnector(ctx context.Context, connectorExchangeID string) error {
-	log.Printf("[RECALC] Starting recalculation for all jobs on connector %s", connectorExchangeID)
+	logging.Printf(ctx, "[RECALC] Starting recalculation for all jobs on connector %s", connectorExchangeID)
 
 	// Find all jobs for this connector
-	jobs, err := r.jobRepo.FindAll(ctx, bson.M{"connector_exchange_id": connectorExchangeID})
+	jobs, err := r.jobRepo.FindAll(ctx, repository.OHLCVJobs(bson.M{"connector_exchange_id": connectorExchangeID}))
 	if err != nil {
 		return fmt.Errorf("failed to find jobs: %w", err)
 	}
@@ -93,25 +220,25 @@ func (r *RecalculatorService) RecalculateConnector(ctx context.Context, connecto
 	connectorJobs := jobs
 
 	if len(connectorJobs) == 0 {
-		log.Printf("[RECALC] No jobs found for connector %s", connectorExchangeID)
+		logging.Printf(ctx, "[RECALC] No jobs found for connector %s", connectorExchangeID)
 		return nil
 	}
 
-	log.Printf("[RECALC] Found %d jobs for connector %s", len(connectorJobs), connectorExchangeID)
+	logging.Printf(ctx, "[RECALC] Found %d jobs for connector %s", len(connectorJobs), connectorExchangeID)
 
 	// Recalculate each job
 	successCount := 0
 	errorCount := 0
 	for _, job := range connectorJobs {
 		if err := r.RecalculateJob(ctx, job.ID.Hex()); err != nil {
-			log.Printf("[RECALC] Error recalculating job %s: %v", job.ID.Hex(), err)
+			logging.Printf(ctx, "[RECALC] Error recalculating job %s: %v", job.ID.Hex(), err)
 			errorCount++
 		} else {
 			successCount++
 		}
 	}
 
-	log.Printf("[RECALC] Connector recalculation complete: %d succeeded, %d failed", successCount, errorCount)
+	logging.Printf(ctx, "[RECALC] Connector recalculation complete: %d succeeded, %d failed", successCount, errorCount)
 
 	if errorCount > 0 {
 		return fmt.Errorf("recalculation completed with %d errors", errorCount)
@@ -123,29 +250,29 @@ func (r *RecalculatorService) RecalculateConnector(ctx context.Context, connecto
 // RecalculateAll recalculates indicators for all jobs in the system
 // WARNING: This can be resource-intensive for large datasets
 func (r *RecalculatorService) RecalculateAll(ctx context.Context) error {
-	log.Printf("[RECALC] Starting recalculation for ALL jobs")
+	logging.Printf(ctx, "[RECALC] Starting recalculation for ALL jobs")
 
 	// Find all jobs
-	jobs, err := r.jobRepo.FindAll(ctx, bson.M{})
+	jobs, err := r.jobRepo.FindAll(ctx, repository.OHLCVJobs(bson.M{}))
 	if err != nil {
 		return fmt.Errorf("failed to find jobs: %w", err)
 	}
 
-	log.Printf("[RECALC] Found %d total jobs", len(jobs))
+	logging.Printf(ctx, "[RECALC] Found %d total jobs", len(jobs))
 
 	// Recalculate each job
 	successCount := 0
 	errorCount := 0
 	for _, job := range jobs {
 		if err := r.RecalculateJob(ctx, job.ID.Hex()); err != nil {
-			log.Printf("[RECALC] Error recalculating job %s: %v", job.ID.Hex(), err)
+			logging.Printf(ctx, "[RECALC] Error recalculating job %s: %v", job.ID.Hex(), err)
 			errorCount++
 		} else {
 			successCount++
 		}
 	}
 
-	log.Printf("[RECALC] Full recalculation complete: %d succeeded, %d failed", successCount, errorCount)
+	logging.Printf(ctx, "[RECALC] Full recalculation complete: %d succeeded, %d failed", successCount, errorCount)
 
 	if errorCount > 0 {
 		return fmt.Errorf("recalculation completed with %d errors", errorCount)
@@ -154,6 +281,93 @@ func (r *RecalculatorService) RecalculateAll(ctx context.Context) error {
 	return nil
 }
 
+// CheckCoverage reads the latest candle of every active job and reports which of
+// the indicators the job's own config expects are missing. Jobs collected
+// before an indicator was enabled are flagged as needing recalculation. Jobs
+// that don't compute indicators on ingest are counted separately and not
+// checked.
+func (r *RecalculatorService) CheckCoverage(ctx context.Context) (*models.IndicatorCoverageReport, error) {
+	jobs, err := r.jobRepo.FindAll(ctx, repository.OHLCVJobs(bson.M{"status": "active"}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find jobs: %w", err)
+	}
+
+	report := &models.IndicatorCoverageReport{
+		TotalJobs: len(jobs),
+		Jobs:      make([]models.IndicatorCoverage, 0, len(jobs)),
+		CheckedAt: time.Now(),
+	}
+
+	// Jobs of one connector share its config unless they override it
+	connectors := make(map[string]*models.Connector)
+	for _, job := range jobs {
+		coverage := models.IndicatorCoverage{
+			JobID:      job.ID.Hex(),
+			ExchangeID: job.ConnectorExchangeID,
+			Symbol:     job.Symbol,
+			Timeframe:  job.Timeframe,
+			Populated:  []string{},
+			Missing:    []string{},
+		}
+
+		if !job.ComputesIndicatorsOnIngest() {
+			coverage.RawOHLCV = true
+			report.RawOHLCVJobs++
+			report.Jobs = append(report.Jobs, coverage)
+			continue
+		}
+
+		connector, ok := connectors[job.ConnectorExchangeID]
+		if !ok {
+			connector, err = r.connectorRepo.FindByExchangeID(ctx, job.ConnectorExchangeID)
+			if err != nil {
+				logging.Printf(ctx, "[RECALC] Connector %s of job %s not found, checking against the default config: %v", job.ConnectorExchangeID, job.ID.Hex(), err)
+				connector = nil
+			}
+			connectors[job.ConnectorExchangeID] = connector
+		}
+		config := r.configResolver.Resolve(ctx, job, connector)
+		expected := config.ExpectedIndicators()
+		coverage.ConfigName = config.Name
+		coverage.Expected = expected
+
+		latest, err := r.ohlcvRepo.GetLastCandle(ctx, job.ConnectorExchangeID, job.Symbol, job.Timeframe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest candle for job %s: %w", job.ID.Hex(), err)
+		}
+
+		if latest == nil {
+			report.NoData++
+			report.Jobs = append(report.Jobs, coverage)
+			continue
+		}
+
+		coverage.HasData = true
+		coverage.LatestTimestamp = latest.Timestamp
+		coverage.Populated = latest.Indicators.Populated()
+
+		populated := make(map[string]bool, len(coverage.Populated))
+		for _, name := range coverage.Populated {
+			populated[name] = true
+		}
+		for _, name := range expected {
+			if !populated[name] {
+				coverage.Missing = append(coverage.Missing, name)
+			}
+		}
+
+		if len(coverage.Missing) > 0 {
+			coverage.NeedsRecalculation = true
+			report.NeedsRecalculation++
+		} else {
+			report.CompleteJobs++
+		}
+		report.Jobs = append(report.Jobs, coverage)
+	}
+
+	return report, nil
+}
+
 // RecalculationProgress holds progress information for long-running recalculations
 type RecalculationProgress struct {
 	TotalJobs      int    `json:"total_jobs"`
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+// ResampleCandles aggregates candles into buckets of the target timeframe
+// Open/Close take the first/last value in the bucket, High/Low the extremes.
+// Base Volume, QuoteVolume and TradeCount are each summed independently.
+// Indicators are not carried over since they must be recomputed on the new series,
+// and VWAP-like fields are not rebuilt as that would require tick data.
+// Returns candles sorted by timestamp ascending.
+func ResampleCandles(candles []models.Candle, sourceTimeframe string, config models.ResampleConfig) ([]models.Candle, error) {
+	if config.Timeframe == "" {
+		return nil, fmt.Errorf("resample timeframe is required")
+	}
+
+	sourceMs, err := models.GetTimeframeMs(sourceTimeframe)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resample: %w", err)
+	}
+	targetMs, err := models.GetTimeframeMs(config.Timeframe)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resample: %w", err)
+	}
+	if targetMs < sourceMs {
+		return nil, fmt.Errorf("cannot resample %s candles to finer timeframe %s", sourceTimeframe, config.Timeframe)
+	}
+	// Source bars must tile target bars exactly, or buckets would cut bars in two
+	if targetMs%sourceMs != 0 {
+		return nil, fmt.Errorf("cannot resample %s candles to %s: not a whole multiple of the source timeframe", sourceTimeframe, config.Timeframe)
+	}
+
+	aggregation := config.VolumeAggregation
+	if aggregation == "" {
+		aggregation = models.VolumeAggregationBase
+	}
+	if aggregation != models.VolumeAggregationBase && aggregation != models.VolumeAggregationQuote {
+		return nil, fmt.Errorf("unsupported volume aggregation: %s", aggregation)
+	}
+
+	if len(candles) == 0 {
+		return []models.Candle{}, nil
+	}
+
+	// Work on an ascending copy so the caller's slice order is untouched
+	sorted := make([]models.Candle, len(candles))
+	copy(sorted, candles)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+
+	if aggregation == models.VolumeAggregationQuote {
+		for _, c := range sorted {
+			if c.QuoteVolume == 0 && c.Volume != 0 {
+				return nil, fmt.Errorf("quote volume aggregation requested but candle at %d has no quote volume", c.Timestamp)
+			}
+		}
+	}
+
+	result := make([]models.Candle, 0, len(sorted)/int(targetMs/sourceMs)+1)
+	var current *models.Candle
+	var baseVolume float64
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if aggregation == models.VolumeAggregationQuote {
+			current.Volume = current.QuoteVolume
+		} else {
+			current.Volume = baseVolume
+		}
+		result = append(result, *current)
+	}
+
+	for _, c := range sorted {
+		bucket := models.TimeframeBucketStart(config.Timeframe, c.Timestamp)
+
+		if current == nil || current.Timestamp != bucket {
+			flush()
+			current = &models.Candle{
+				Timestamp: bucket,
+				Open:      c.Open,
+				High:      c.High,
+				Low:       c.Low,
+				Close:     c.Close,
+			}
+			baseVolume = 0
+		}
+
+		if c.High > current.High {
+			current.High = c.High
+		}
+		if c.Low < current.Low {
+			current.Low = c.Low
+		}
+		current.Close = c.Close
+		baseVolume += c.Volume
+		current.QuoteVolume += c.QuoteVolume
+		current.TradeCount += c.TradeCount
+	}
+	flush()
+
+	return result, nil
+}
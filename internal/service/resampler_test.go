@@ -0,0 +1,138 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/datacollector/internal/models"
+)
+
+func TestResampleCandlesSumsVolumeFields(t *testing.T) {
+	// Four 15m candles spanning one hour, given newest-first like the repository returns them
+	base := int64(1700000000000) - int64(1700000000000)%(60*60*1000)
+	candles := []models.Candle{
+		{Timestamp: base + 45*60*1000, Open: 104, High: 108, Low: 103, Close: 107, Volume: 4, QuoteVolume: 420, TradeCount: 40},
+		{Timestamp: base + 30*60*1000, Open: 102, High: 105, Low: 101, Close: 104, Volume: 3, QuoteVolume: 310, TradeCount: 30},
+		{Timestamp: base + 15*60*1000, Open: 101, High: 103, Low: 99, Close: 102, Volume: 2, QuoteVolume: 205, TradeCount: 20},
+		{Timestamp: base, Open: 100, High: 102, Low: 100, Close: 101, Volume: 1, QuoteVolume: 100, TradeCount: 10},
+	}
+
+	result, err := ResampleCandles(candles, "15m", models.ResampleConfig{Enabled: true, Timeframe: "1h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 resampled candle, got %d", len(result))
+	}
+
+	c := result[0]
+	if c.Timestamp != base {
+		t.Errorf("expected timestamp %d, got %d", base, c.Timestamp)
+	}
+	if c.Open != 100 || c.Close != 107 {
+		t.Errorf("expected open 100 / close 107, got %f / %f", c.Open, c.Close)
+	}
+	if c.High != 108 || c.Low != 99 {
+		t.Errorf("expected high 108 / low 99, got %f / %f", c.High, c.Low)
+	}
+	if c.Volume != 10 {
+		t.Errorf("expected base volume 10, got %f", c.Volume)
+	}
+	if c.QuoteVolume != 1035 {
+		t.Errorf("expected quote volume 1035, got %f", c.QuoteVolume)
+	}
+	if c.TradeCount != 100 {
+		t.Errorf("expected trade count 100, got %d", c.TradeCount)
+	}
+}
+
+func TestResampleCandlesQuoteAggregation(t *testing.T) {
+	candles := []models.Candle{
+		{Timestamp: 0, Open: 1, High: 1, Low: 1, Close: 1, Volume: 5, QuoteVolume: 50},
+		{Timestamp: 60 * 1000, Open: 1, High: 1, Low: 1, Close: 1, Volume: 7, QuoteVolume: 70},
+	}
+
+	result, err := ResampleCandles(candles, "1m", models.ResampleConfig{
+		Enabled:           true,
+		Timeframe:         "5m",
+		VolumeAggregation: models.VolumeAggregationQuote,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 resampled candle, got %d", len(result))
+	}
+	if result[0].Volume != 120 {
+		t.Errorf("expected volume to be summed quote volume 120, got %f", result[0].Volume)
+	}
+}
+
+func TestResampleCandlesQuoteAggregationRequiresQuoteVolume(t *testing.T) {
+	candles := []models.Candle{
+		{Timestamp: 0, Open: 1, High: 1, Low: 1, Close: 1, Volume: 5},
+	}
+
+	_, err := ResampleCandles(candles, "1m", models.ResampleConfig{
+		Enabled:           true,
+		Timeframe:         "5m",
+		VolumeAggregation: models.VolumeAggregationQuote,
+	})
+	if err == nil {
+		t.Error("expected error when quote volume is missing")
+	}
+}
+
+func TestResampleCandlesRejectsFinerTimeframe(t *testing.T) {
+	_, err := ResampleCandles([]models.Candle{{Timestamp: 0}}, "1h", models.ResampleConfig{Enabled: true, Timeframe: "5m"})
+	if err == nil {
+		t.Error("expected error when resampling to a finer timeframe")
+	}
+}
+
+func TestResampleCandlesRejectsNonMultipleTimeframe(t *testing.T) {
+	if _, err := ResampleCandles([]models.Candle{{Timestamp: 0}}, "3m", models.ResampleConfig{Enabled: true, Timeframe: "5m"}); err == nil {
+		t.Error("expected an error resampling 3m candles to 5m")
+	}
+	if _, err := ResampleCandles([]models.Candle{{Timestamp: 0}}, "3m", models.ResampleConfig{Enabled: true, Timeframe: "15m"}); err != nil {
+		t.Errorf("3m to 15m should be accepted: %v", err)
+	}
+}
+
+func TestResampleCandlesRejectsUnknownTimeframe(t *testing.T) {
+	if _, err := ResampleCandles(nil, "1h", models.ResampleConfig{Timeframe: "7h"}); err == nil {
+		t.Error("expected an error for an unknown target timeframe")
+	}
+	if _, err := ResampleCandles(nil, "1y", models.ResampleConfig{Timeframe: "1d"}); err == nil {
+		t.Error("expected an error for an unknown source timeframe")
+	}
+}
+
+func TestResampleCandlesMonthlyUsesCalendarMonths(t *testing.T) {
+	day := func(month time.Month, d int) int64 {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC).UnixMilli()
+	}
+
+	// Daily candles on the first and last day of February (29 days) and March (31 days)
+	candles := []models.Candle{
+		{Timestamp: day(time.March, 31), Open: 40, High: 41, Low: 39, Close: 40, Volume: 4},
+		{Timestamp: day(time.March, 1), Open: 30, High: 31, Low: 29, Close: 30, Volume: 3},
+		{Timestamp: day(time.February, 29), Open: 20, High: 21, Low: 19, Close: 20, Volume: 2},
+		{Timestamp: day(time.February, 1), Open: 10, High: 11, Low: 9, Close: 10, Volume: 1},
+	}
+
+	result, err := ResampleCandles(candles, "1d", models.ResampleConfig{Enabled: true, Timeframe: "1M"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 monthly candles, got %d", len(result))
+	}
+
+	if result[0].Timestamp != day(time.February, 1) || result[0].Open != 10 || result[0].Close != 20 || result[0].Volume != 3 {
+		t.Errorf("unexpected February candle: %+v", result[0])
+	}
+	if result[1].Timestamp != day(time.March, 1) || result[1].Open != 30 || result[1].Close != 40 || result[1].Volume != 7 {
+		t.Errorf("unexpected March candle: %+v", result[1])
+	}
+}
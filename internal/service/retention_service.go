@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/yourusername/datacollector/internal/logging"
 	"github.com/yourusername/datacollector/internal/models"
 	"github.com/yourusername/datacollector/internal/repository"
 )
@@ -13,17 +14,20 @@ import (
 // RetentionService handles data retention and cleanup operations
 type RetentionService struct {
 	retentionRepo *repository.RetentionRepository
+	ohlcvRepo     *repository.OHLCVRepository
 }
 
 // NewRetentionService creates a new retention service
-func NewRetentionService(retentionRepo *repository.RetentionRepository) *RetentionService {
+func NewRetentionService(retentionRepo *repository.RetentionRepository, ohlcvRepo *repository.OHLCVRepository) *RetentionService {
 	return &RetentionService{
 		retentionRepo: retentionRepo,
+		ohlcvRepo:     ohlcvRepo,
 	}
 }
 
-// RunCleanup executes cleanup based on enabled policies
-func (s *RetentionService) RunCleanup(ctx context.Context) (*models.RetentionCleanupSummary, error) {
+// RunCleanup executes cleanup based on enabled policies.
+// With dryRun nothing is deleted; the summary lists exactly what would be.
+func (s *RetentionService) RunCleanup(ctx context.Context, dryRun bool) (*models.RetentionCleanupSummary, error) {
 	startTime := time.Now()
 
 	config, err := s.retentionRepo.GetConfig(ctx)
@@ -49,49 +53,67 @@ func (s *RetentionService) RunCleanup(ctx context.Context) (*models.RetentionCle
 	summary := &models.RetentionCleanupSummary{
 		StartedAt: startTime,
 		Results:   make([]models.RetentionCleanupResult, 0, len(policies)),
+		DryRun:    dryRun,
 	}
 
 	// Execute each policy
 	for _, policy := range policies {
-		result := s.executePolicy(ctx, policy)
+		result := s.executePolicy(ctx, policy, dryRun)
 		summary.Results = append(summary.Results, result)
 		summary.TotalChunksDeleted += result.ChunksDeleted
 		summary.TotalCandlesDeleted += result.CandlesDeleted
 		summary.TotalBytesFreed += result.BytesFreed
 		summary.TotalDuration += result.Duration
 
+		if dryRun {
+			continue
+		}
+
 		// Record policy run
 		if err := s.retentionRepo.RecordPolicyRun(ctx, policy.ID.Hex()); err != nil {
-			log.Printf("[RETENTION] Warning: Failed to record policy run: %v", err)
+			logging.Printf(ctx, "[RETENTION] Warning: Failed to record policy run: %v", err)
 		}
 	}
 
 	// Also delete empty chunks if configured
-	if config.DeleteEmptyChunks {
+	if config.DeleteEmptyChunks && dryRun {
+		emptyCount, err := s.retentionRepo.CountEmptyChunks(ctx)
+		if err != nil {
+			logging.Printf(ctx, "[RETENTION] Warning: Failed to count empty chunks: %v", err)
+		} else {
+			summary.TotalChunksDeleted += emptyCount
+		}
+	} else if config.DeleteEmptyChunks {
 		emptyDeleted, err := s.retentionRepo.DeleteEmptyChunks(ctx)
 		if err != nil {
-			log.Printf("[RETENTION] Warning: Failed to delete empty chunks: %v", err)
+			logging.Printf(ctx, "[RETENTION] Warning: Failed to delete empty chunks: %v", err)
 		} else if emptyDeleted > 0 {
-			log.Printf("[RETENTION] Deleted %d empty chunks", emptyDeleted)
+			logging.Printf(ctx, "[RETENTION] Deleted %d empty chunks", emptyDeleted)
 			summary.TotalChunksDeleted += emptyDeleted
 		}
 	}
 
 	summary.CompletedAt = time.Now()
-	log.Printf("[RETENTION] Cleanup completed: %d chunks, %d candles deleted in %dms",
+	if dryRun {
+		logging.Printf(ctx, "[RETENTION] Dry run completed: %d chunks, %d candles would be deleted",
+			summary.TotalChunksDeleted, summary.TotalCandlesDeleted)
+		return summary, nil
+	}
+	logging.Printf(ctx, "[RETENTION] Cleanup completed: %d chunks, %d candles deleted in %dms",
 		summary.TotalChunksDeleted, summary.TotalCandlesDeleted, summary.TotalDuration)
 
 	return summary, nil
 }
 
 // executePolicy executes a single retention policy
-func (s *RetentionService) executePolicy(ctx context.Context, policy *models.RetentionPolicy) models.RetentionCleanupResult {
+func (s *RetentionService) executePolicy(ctx context.Context, policy *models.RetentionPolicy, dryRun bool) models.RetentionCleanupResult {
 	startTime := time.Now()
 
 	result := models.RetentionCleanupResult{
 		PolicyID:   policy.ID.Hex(),
 		PolicyName: policy.Name,
 		StartedAt:  startTime,
+		DryRun:     dryRun,
 	}
 
 	// Calculate cutoff time
@@ -110,80 +132,277 @@ func (s *RetentionService) executePolicy(ctx context.Context, policy *models.Ret
 		result.Timeframe = timeframe
 	}
 
-	log.Printf("[RETENTION] Executing policy '%s': delete data older than %s (exchange=%s, timeframe=%s)",
-		policy.Name, cutoffTime.Format("2006-01-02"), exchangeID, timeframe)
+	if policy.Action == models.RetentionPolicyActionDownsample {
+		s.executeDownsample(ctx, policy, exchangeID, dryRun, &result)
+		result.CompletedAt = time.Now()
+		result.Duration = result.CompletedAt.Sub(startTime).Milliseconds()
+		return result
+	}
+
+	logging.Printf(ctx, "[RETENTION] Executing policy '%s': delete data older than %s (exchange=%s, timeframe=%s, dry_run=%t)",
+		policy.Name, cutoffTime.Format("2006-01-02"), exchangeID, timeframe, dryRun)
+
+	if dryRun {
+		s.previewPolicy(ctx, policy, cutoffTime, exchangeID, timeframe, &result)
+		result.CompletedAt = time.Now()
+		result.Duration = result.CompletedAt.Sub(startTime).Milliseconds()
+		return result
+	}
 
-	// Delete old chunks
-	chunksDeleted, err := s.retentionRepo.DeleteChunksOlderThan(ctx, cutoffTime, exchangeID, timeframe)
+	// Delete old chunks, previewing them first so the result counts the
+	// candles actually removed, as a dry run would
+	var chunksDeleted int64
+	preview, err := s.retentionRepo.PreviewChunksOlderThan(ctx, cutoffTime, exchangeID, timeframe)
+	if err == nil {
+		chunksDeleted, err = s.retentionRepo.DeleteChunksOlderThan(ctx, cutoffTime, exchangeID, timeframe)
+	}
 	if err != nil {
 		result.Error = err.Error()
-		log.Printf("[RETENTION] Policy '%s' error: %v", policy.Name, err)
+		logging.Printf(ctx, "[RETENTION] Policy '%s' error: %v", policy.Name, err)
 	} else {
+		_, candlesDeleted := previewTotals(preview)
 		result.ChunksDeleted = chunksDeleted
-		// Estimate candles (average ~1000 per chunk)
-		result.CandlesDeleted = chunksDeleted * 1000
-		// Estimate bytes (average ~100KB per chunk)
-		result.BytesFreed = chunksDeleted * 100 * 1024
-		log.Printf("[RETENTION] Policy '%s' completed: %d chunks deleted", policy.Name, chunksDeleted)
+		result.CandlesDeleted = candlesDeleted
+		logging.Printf(ctx, "[RETENTION] Policy '%s' completed: %d chunks deleted", policy.Name, chunksDeleted)
 	}
 
+	// Cap each series at the most recent N candles regardless of age
+	if result.Error == "" && policy.MaxCandles != nil && *policy.MaxCandles > 0 {
+		chunksTrimmed, candlesTrimmed, err := s.retentionRepo.TrimChunksToMaxCandles(ctx, *policy.MaxCandles, exchangeID, timeframe, cutoffTime.Format("2006-01"))
+		result.ChunksDeleted += chunksTrimmed
+		result.CandlesDeleted += candlesTrimmed
+		if err != nil {
+			result.Error = err.Error()
+			logging.Printf(ctx, "[RETENTION] Policy '%s' max candles error: %v", policy.Name, err)
+		} else {
+			logging.Printf(ctx, "[RETENTION] Policy '%s' max candles (%d): %d chunks deleted, %d candles removed",
+				policy.Name, *policy.MaxCandles, chunksTrimmed, candlesTrimmed)
+		}
+	}
+	result.BytesFreed = estimateBytesFreed(result.CandlesDeleted)
+
 	result.CompletedAt = time.Now()
 	result.Duration = result.CompletedAt.Sub(startTime).Milliseconds()
 
 	return result
 }
 
-// RunDefaultCleanup executes cleanup based on default config only
-func (s *RetentionService) RunDefaultCleanup(ctx context.Context, retentionDays int) (*models.RetentionCleanupResult, error) {
-	startTime := time.Now()
+// executeDownsample rolls candles older than the policy's AfterDays up into its
+// TargetTimeframe and then deletes the originals. Only complete target buckets
+// are rolled up, and existing target candles are never overwritten, so running
+// it repeatedly is safe.
+func (s *RetentionService) executeDownsample(ctx context.Context, policy *models.RetentionPolicy, exchangeID string, dryRun bool, result *models.RetentionCleanupResult) {
+	if policy.Timeframe == nil || policy.TargetTimeframe == nil || policy.AfterDays < 1 {
+		result.Error = "downsample policy requires timeframe, target_timeframe and after_days"
+		return
+	}
+	sourceTimeframe := *policy.Timeframe
+	targetTimeframe := *policy.TargetTimeframe
 
-	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
+	// Align the cutoff to a target bucket boundary so no partial bucket is rolled up
+	cutoffMs := models.TimeframeBucketStart(targetTimeframe, time.Now().AddDate(0, 0, -policy.AfterDays).UnixMilli())
 
-	log.Printf("[RETENTION] Running default cleanup: delete data older than %s", cutoffTime.Format("2006-01-02"))
+	logging.Printf(ctx, "[RETENTION] Executing policy '%s': downsample %s to %s before %s (exchange=%s, dry_run=%t)",
+		policy.Name, sourceTimeframe, targetTimeframe, time.UnixMilli(cutoffMs).Format("2006-01-02 15:04"), exchangeID, dryRun)
 
-	chunksDeleted, err := s.retentionRepo.DeleteChunksOlderThan(ctx, cutoffTime, "", "")
+	series, err := s.retentionRepo.GetDataUsageStats(ctx, exchangeID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete old chunks: %w", err)
+		result.Error = err.Error()
+		return
 	}
 
-	result := &models.RetentionCleanupResult{
-		ChunksDeleted:  chunksDeleted,
-		CandlesDeleted: chunksDeleted * 1000,
-		BytesFreed:     chunksDeleted * 100 * 1024,
-		StartedAt:      startTime,
-		CompletedAt:    time.Now(),
-		Duration:       time.Since(startTime).Milliseconds(),
+	for _, stat := range series {
+		if stat.Timeframe != sourceTimeframe {
+			continue
+		}
+
+		rollup := s.downsampleSeries(ctx, stat.ExchangeID, stat.Symbol, sourceTimeframe, targetTimeframe, cutoffMs, dryRun)
+		if rollup == nil {
+			continue
+		}
+		result.Rollups = append(result.Rollups, *rollup)
+		result.CandlesDeleted += rollup.CandlesDeleted
+		if rollup.Error != "" {
+			logging.Printf(ctx, "[RETENTION] Policy '%s' downsample error for %s %s: %s", policy.Name, stat.ExchangeID, stat.Symbol, rollup.Error)
+		}
 	}
+}
 
-	log.Printf("[RETENTION] Default cleanup completed: %d chunks deleted", chunksDeleted)
+// downsampleSeries rolls up one series. Returns nil if there was nothing to roll up.
+func (s *RetentionService) downsampleSeries(ctx context.Context, exchangeID, symbol, sourceTimeframe, targetTimeframe string, cutoffMs int64, dryRun bool) *models.RetentionRollup {
+	doc, source, err := s.ohlcvRepo.FindByJobWithSource(ctx, exchangeID, symbol, sourceTimeframe)
+	if err != nil || doc == nil || source.Backend != models.StorageBackendChunked {
+		// Retention only manages chunked storage
+		return nil
+	}
 
-	return result, nil
+	var old []models.Candle
+	for _, c := range doc.Candles {
+		if c.Timestamp < cutoffMs {
+			old = append(old, c)
+		}
+	}
+	if len(old) == 0 {
+		return nil
+	}
+
+	rollup := &models.RetentionRollup{
+		ExchangeID:      exchangeID,
+		Symbol:          symbol,
+		SourceTimeframe: sourceTimeframe,
+		TargetTimeframe: targetTimeframe,
+		Before:          time.UnixMilli(cutoffMs),
+		CandlesRead:     int64(len(old)),
+	}
+
+	resampled, err := ResampleCandles(old, sourceTimeframe, models.ResampleConfig{Enabled: true, Timeframe: targetTimeframe})
+	if err != nil {
+		rollup.Error = err.Error()
+		return rollup
+	}
+	rollup.CandlesWritten = int64(len(resampled))
+
+	if dryRun {
+		rollup.CandlesDeleted = int64(len(old))
+		return rollup
+	}
+
+	if _, err := s.ohlcvRepo.UpsertCandles(ctx, exchangeID, symbol, targetTimeframe, resampled); err != nil {
+		rollup.Error = err.Error()
+		return rollup
+	}
+
+	// UpsertCandles skips chunks it fails to write, so make sure every bucket
+	// landed before the originals are removed
+	written, err := s.ohlcvRepo.FindByJob(ctx, exchangeID, symbol, targetTimeframe)
+	if err != nil {
+		rollup.Error = fmt.Sprintf("failed to verify rolled up candles: %v", err)
+		return rollup
+	}
+	stored := make(map[int64]bool)
+	if written != nil {
+		for _, c := range written.Candles {
+			stored[c.Timestamp] = true
+		}
+	}
+	for _, c := range resampled {
+		if !stored[c.Timestamp] {
+			rollup.Error = "rolled up candles were not all written, originals kept"
+			return rollup
+		}
+	}
+
+	if err := s.retentionRepo.DeleteCandlesBefore(ctx, exchangeID, symbol, sourceTimeframe, cutoffMs); err != nil {
+		rollup.Error = err.Error()
+		return rollup
+	}
+	rollup.CandlesDeleted = int64(len(old))
+
+	return rollup
+}
+
+// previewPolicy fills result with what executePolicy would delete, using the
+// same chunk matching as the real run
+func (s *RetentionService) previewPolicy(ctx context.Context, policy *models.RetentionPolicy, cutoffTime time.Time, exchangeID, timeframe string, result *models.RetentionCleanupResult) {
+	chunks, err := s.retentionRepo.PreviewChunksOlderThan(ctx, cutoffTime, exchangeID, timeframe)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	// The real run trims only what survives the age-based delete
+	if policy.MaxCandles != nil && *policy.MaxCandles > 0 {
+		plan, err := s.retentionRepo.PlanMaxCandlesTrim(ctx, *policy.MaxCandles, exchangeID, timeframe, cutoffTime.Format("2006-01"))
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		chunks = append(chunks, plan...)
+	}
+
+	applyPreview(result, chunks)
+}
+
+// applyPreview records previewed chunks and their totals on a dry-run result
+func applyPreview(result *models.RetentionCleanupResult, chunks []models.RetentionChunkPreview) {
+	result.Chunks = chunks
+	result.ChunksDeleted, result.CandlesDeleted = previewTotals(chunks)
+	result.BytesFreed = estimateBytesFreed(result.CandlesDeleted)
+}
+
+// previewTotals returns how many chunks previewed chunks delete outright and
+// how many candles they remove in total
+func previewTotals(chunks []models.RetentionChunkPreview) (int64, int64) {
+	var chunksDeleted, candlesDeleted int64
+	for _, chunk := range chunks {
+		if chunk.Action == models.RetentionActionDelete {
+			chunksDeleted++
+		}
+		candlesDeleted += chunk.CandlesToDelete
+	}
+	return chunksDeleted, candlesDeleted
+}
+
+// estimateBytesFreed estimates ~100 bytes per candle, as in the usage stats
+func estimateBytesFreed(candles int64) int64 {
+	return candles * 100
+}
+
+// RunDefaultCleanup executes cleanup based on default config only
+func (s *RetentionService) RunDefaultCleanup(ctx context.Context, retentionDays int, dryRun bool) (*models.RetentionCleanupResult, error) {
+	return s.cleanupOlderThan(ctx, "", retentionDays, dryRun)
 }
 
 // CleanupByExchange deletes old data for a specific exchange
-func (s *RetentionService) CleanupByExchange(ctx context.Context, exchangeID string, retentionDays int) (*models.RetentionCleanupResult, error) {
+func (s *RetentionService) CleanupByExchange(ctx context.Context, exchangeID string, retentionDays int, dryRun bool) (*models.RetentionCleanupResult, error) {
+	return s.cleanupOlderThan(ctx, exchangeID, retentionDays, dryRun)
+}
+
+// cleanupOlderThan deletes (or previews deleting) chunks older than retentionDays,
+// optionally restricted to one exchange
+func (s *RetentionService) cleanupOlderThan(ctx context.Context, exchangeID string, retentionDays int, dryRun bool) (*models.RetentionCleanupResult, error) {
 	startTime := time.Now()
 
 	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
 
-	log.Printf("[RETENTION] Cleaning up exchange %s: delete data older than %s", exchangeID, cutoffTime.Format("2006-01-02"))
-
-	chunksDeleted, err := s.retentionRepo.DeleteChunksOlderThan(ctx, cutoffTime, exchangeID, "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete old chunks: %w", err)
+	scope := "all exchanges"
+	if exchangeID != "" {
+		scope = "exchange " + exchangeID
 	}
+	logging.Printf(ctx, "[RETENTION] Cleaning up %s: delete data older than %s (dry_run=%t)", scope, cutoffTime.Format("2006-01-02"), dryRun)
 
 	result := &models.RetentionCleanupResult{
-		ExchangeID:     exchangeID,
-		ChunksDeleted:  chunksDeleted,
-		CandlesDeleted: chunksDeleted * 1000,
-		BytesFreed:     chunksDeleted * 100 * 1024,
-		StartedAt:      startTime,
-		CompletedAt:    time.Now(),
-		Duration:       time.Since(startTime).Milliseconds(),
+		ExchangeID: exchangeID,
+		StartedAt:  startTime,
+		DryRun:     dryRun,
+	}
+
+	if dryRun {
+		chunks, err := s.retentionRepo.PreviewChunksOlderThan(ctx, cutoffTime, exchangeID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview old chunks: %w", err)
+		}
+		applyPreview(result, chunks)
+	} else {
+		// Previewed first so the result counts the candles actually removed
+		chunks, err := s.retentionRepo.PreviewChunksOlderThan(ctx, cutoffTime, exchangeID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview old chunks: %w", err)
+		}
+		chunksDeleted, err := s.retentionRepo.DeleteChunksOlderThan(ctx, cutoffTime, exchangeID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete old chunks: %w", err)
+		}
+		_, candlesDeleted := previewTotals(chunks)
+		result.ChunksDeleted = chunksDeleted
+		result.CandlesDeleted = candlesDeleted
+		result.BytesFreed = estimateBytesFreed(candlesDeleted)
 	}
 
-	log.Printf("[RETENTION] Exchange cleanup completed: %d chunks deleted", chunksDeleted)
+	result.CompletedAt = time.Now()
+	result.Duration = time.Since(startTime).Milliseconds()
+
+	logging.Printf(ctx, "[RETENTION] Cleanup of %s completed: %d chunks deleted (dry_run=%t)", scope, result.ChunksDeleted, dryRun)
 
 	return result, nil
 }
@@ -207,3 +426,9 @@ func (s *RetentionService) GetTotalUsage(ctx context.Context) (chunks int64, can
 func (s *RetentionService) DeleteEmptyChunks(ctx context.Context) (int64, error) {
 	return s.retentionRepo.DeleteEmptyChunks(ctx)
 }
+
+// RepairChunkBoundaries recomputes the candle count and time range of each
+// chunk, of one exchange or all of them, and fixes those that don't match
+func (s *RetentionService) RepairChunkBoundaries(ctx context.Context, exchangeID string, dryRun bool) (*models.ChunkConsistencyResult, error) {
+	return s.ohlcvRepo.RepairChunkBoundaries(ctx, exchangeID, dryRun)
+}